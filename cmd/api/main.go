@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
+	"time"
+
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	
 	// Import dari proyek Anda
 	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/database"
 	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/router"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/scheduler"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
 	_ "github.com/akhmadzaqiriyadi/stmadb-portal-go/docs" // Import kosong untuk Swagger docs
 )
 
@@ -31,6 +36,9 @@ func init() {
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+// @securityDefinitions.apikey DeviceKey
+// @in header
+// @name X-Device-Key
 func main() {
 	// Ambil port dari file .env, gunakan "3000" sebagai default
 	port := viper.GetString("PORT")
@@ -48,6 +56,25 @@ func main() {
 	}()
 	logrus.Info("🗄️ Database connected successfully")
 
+	// Tugas harian: pengingat jurnal KBM yang belum diisi
+	jobs := scheduler.New(service.AppLocation())
+	complianceService := service.NewJournalComplianceService(dbClient)
+	reminderTime := viper.GetString("JOURNAL_REMINDER_TIME")
+	if reminderTime == "" {
+		reminderTime = "15:00"
+	}
+	if err := jobs.Daily("journal-reminders", reminderTime, func(now time.Time) error {
+		count, err := complianceService.GenerateReminders(now)
+		if err != nil {
+			return err
+		}
+		logrus.Infof("Created %d journal reminders", count)
+		return nil
+	}); err != nil {
+		logrus.Fatalf("Failed to schedule journal reminders: %v", err)
+	}
+	jobs.Start(context.Background())
+
 	// Setup router yang berisi semua endpoint API
 	r := router.SetupRouter(dbClient)
 
@@ -15,60 +15,52 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/change-password": {
-            "put": {
+        "/academic-years": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows an authenticated user to change their password.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists academic years with their semesters, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
-                ],
-                "summary": "Change user password",
-                "parameters": [
-                    {
-                        "description": "Current and new passwords",
-                        "name": "passwords",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.ChangePasswordRequest"
-                        }
-                    }
+                    "Calendar"
                 ],
+                "summary": "Get academic years",
                 "responses": {
                     "200": {
-                        "description": "Password changed successfully",
-                        "schema": {
-                            "$ref": "#/definitions/handler.GenericResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request body",
-                        "schema": {
-                            "$ref": "#/definitions/handler.GenericResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized or incorrect password",
+                        "description": "List of academic years",
                         "schema": {
-                            "$ref": "#/definitions/handler.GenericResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.AcademicYearData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            }
-        },
-        "/auth/login": {
+            },
             "post": {
-                "description": "Authenticate a user and return a JWT token pair.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates an academic year with its semesters. The name must match the academic_year of classes (e.g. 2025/2026). Without dates, 1 July to 30 June is used. Dates between semesters are treated as the semester break.",
                 "consumes": [
                     "application/json"
                 ],
@@ -76,23 +68,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Calendar"
                 ],
-                "summary": "User login",
+                "summary": "Create an academic year",
                 "parameters": [
                     {
-                        "description": "Login Credentials",
-                        "name": "credentials",
+                        "description": "Academic year",
+                        "name": "academicYear",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.LoginRequest"
+                            "$ref": "#/definitions/handler.AcademicYearRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Login Successful",
+                    "201": {
+                        "description": "Academic year created",
                         "schema": {
                             "allOf": [
                                 {
@@ -102,7 +94,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handler.TokenResponse"
+                                            "$ref": "#/definitions/handler.AcademicYearData"
                                         }
                                     }
                                 }
@@ -110,13 +102,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Request",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Academic year already exists",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
@@ -124,40 +116,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/profile": {
-            "get": {
+        "/academic-years/rollover/apply": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the profile of the currently authenticated user.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Get user profile",
-                "responses": {
-                    "200": {
-                        "description": "Profile Retrieved",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ProfileResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handler.GenericResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/refresh": {
-            "post": {
-                "description": "Get a new pair of access and refresh tokens.",
+                "description": "Creates the next-year classes, moves students and marks final-grade students as LULUS in a single transaction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -165,23 +131,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Academic Year"
                 ],
-                "summary": "Refresh token",
+                "summary": "Apply academic year rollover",
                 "parameters": [
                     {
-                        "description": "Refresh Token",
-                        "name": "token",
+                        "description": "Rollover parameters",
+                        "name": "rollover",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.RefreshTokenRequest"
+                            "$ref": "#/definitions/handler.RolloverRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Tokens refreshed",
+                        "description": "Rollover applied",
                         "schema": {
                             "allOf": [
                                 {
@@ -191,115 +157,36 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handler.TokenResponse"
+                                            "$ref": "#/definitions/service.RolloverPlan"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handler.GenericResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "get the status of server",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Health Check"
-                ],
-                "summary": "Show the status of server",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            }
-        },
-        "/users": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieves a list of all users. Only accessible by admins.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Get all users with filters and pagination",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by username",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by role (admin, teacher, student, staff)",
-                        "name": "role",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by active status (true/false)",
-                        "name": "is_active",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "List of users",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Rollover already applied",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/academic-years/rollover/preview": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new user account. Only accessible by admins.",
+                "description": "Proposes next-year classes and maps every active student (promote, repeat, graduate) without changing any data.",
                 "consumes": [
                     "application/json"
                 ],
@@ -307,23 +194,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Academic Year"
                 ],
-                "summary": "Create a new user",
+                "summary": "Preview academic year rollover",
                 "parameters": [
                     {
-                        "description": "New User Data",
-                        "name": "user",
+                        "description": "Rollover parameters",
+                        "name": "rollover",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateUserRequest"
+                            "$ref": "#/definitions/handler.RolloverRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User created successfully",
+                    "200": {
+                        "description": "Rollover preview",
                         "schema": {
                             "allOf": [
                                 {
@@ -333,7 +220,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handler.ProfileData"
+                                            "$ref": "#/definitions/service.RolloverPlan"
                                         }
                                     }
                                 }
@@ -341,7 +228,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or username exists",
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No classes in source academic year",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Rollover already applied",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
@@ -349,25 +248,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/academic-years/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves details of a single user.",
+                "description": "Retrieves an academic year with its semesters.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Calendar"
                 ],
-                "summary": "Get a single user by ID",
+                "summary": "Get an academic year",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Academic year ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -375,7 +274,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User details",
+                        "description": "Academic year",
                         "schema": {
                             "allOf": [
                                 {
@@ -385,7 +284,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handler.ProfileData"
+                                            "$ref": "#/definitions/handler.AcademicYearData"
                                         }
                                     }
                                 }
@@ -393,7 +292,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Academic year not found",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
@@ -406,7 +305,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates a user's role or active status.",
+                "description": "Replaces the date range and all semesters of an academic year. The name cannot be changed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -414,30 +313,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Calendar"
                 ],
-                "summary": "Update a user",
+                "summary": "Update an academic year",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Academic year ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User Update Data",
-                        "name": "user",
+                        "description": "Academic year",
+                        "name": "academicYear",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateUserRequest"
+                            "$ref": "#/definitions/handler.UpdateAcademicYearRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
+                        "description": "Academic year updated",
                         "schema": {
                             "allOf": [
                                 {
@@ -447,15 +346,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handler.ProfileData"
+                                            "$ref": "#/definitions/handler.AcademicYearData"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
                     "404": {
-                        "description": "User not found",
+                        "description": "Academic year not found",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
@@ -468,18 +373,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a user account.",
+                "description": "Deletes an academic year that is not used by any class. Its semesters are deleted too.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Calendar"
                 ],
-                "summary": "Delete a user",
+                "summary": "Delete an academic year",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
+                        "description": "Academic year ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -487,183 +392,11935 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Academic year deleted",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Academic year not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Academic year still used by classes",
                         "schema": {
                             "$ref": "#/definitions/handler.GenericResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.ChangePasswordRequest": {
-            "type": "object",
-            "required": [
-                "currentPassword",
-                "newPassword"
-            ],
+        },
+        "/attendance-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the working hours per role and day of week used to classify daily attendance. A day without a rule is not a working day for that role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Rules"
+                ],
+                "summary": "Get attendance rules",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by role (admin, teacher, student, staff)",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of attendance rules",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.AttendanceRuleData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid role",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the expected start and end time and the grace period for a role on one day of week, for example shorter hours on Friday. Only one rule per role and day is allowed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Rules"
+                ],
+                "summary": "Create an attendance rule",
+                "parameters": [
+                    {
+                        "description": "Attendance rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AttendanceRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Attendance rule created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.AttendanceRuleData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Rule for the role and day already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attendance-rules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces an attendance rule. Daily attendance is computed on the fly, so the change also applies to past dates.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Rules"
+                ],
+                "summary": "Update an attendance rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attendance rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Attendance rule",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AttendanceRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attendance rule updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.AttendanceRuleData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Attendance rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Rule for the role and day already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes an attendance rule; the day is no longer a working day for the role.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance Rules"
+                ],
+                "summary": "Delete an attendance rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attendance rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attendance rule deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Attendance rule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves daily check-ins and check-outs of teachers, staff and students with pagination, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Get daily attendances",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user role (admin, teacher, student, staff)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (Masuk or Pulang)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of attendances",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.AttendanceData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/check-in": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records the logged-in user's daily arrival. Only one check-in per day is allowed. When SCHOOL_COORDINATES is configured the coordinates are required and must be within SCHOOL_GEOFENCE_RADIUS_METERS (default 100) of the school. A photo (JPEG/PNG, max 5 MB) is optional.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Check in at school",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current location as lat,long",
+                        "name": "coordinates",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo taken at the location",
+                        "name": "photo",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Checked in successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.AttendanceData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coordinates or photo, or outside the school geofence",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already checked in today",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/check-out": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records the logged-in user's daily departure. The user must have checked in on the same day. The geofence rules of check-in apply.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Check out from school",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current location as lat,long",
+                        "name": "coordinates",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo taken at the location",
+                        "name": "photo",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Checked out successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.AttendanceData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coordinates or photo, or outside the school geofence",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not checked in or already checked out today",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/daily": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Classifies each active user's day from the raw check-ins, the attendance rules, approved leave requests and the school calendar: Tepat Waktu, Terlambat (check-in after start time plus grace period), Tidak Hadir, Izin (approved leave), Libur (no rule, holiday or no school day) or Belum Presensi (today before the end time). Early leave and minutes late are reported separately. Defaults to today; the range is limited to 31 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Get daily attendance records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (admin, teacher, student, staff)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status, e.g. Terlambat",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daily attendance records",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.DailyAttendance"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/attendances/{id}/photo": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a signed, expiring download URL for the photo of a daily attendance record. Users can view their own photos; admin and staff can view all.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Get attendance photo link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Attendance ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Download link",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.FileLinkData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not your attendance",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Attendance or photo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/change-password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows an authenticated user to change their password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "description": "Current and new passwords",
+                        "name": "passwords",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized or incorrect password",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate a user and return a JWT token pair.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login Credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login Successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the profile of the currently authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile Retrieved",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Get a new pair of access and refresh tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tokens refreshed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists calendar events overlapping the date range (max 400 days). Filtering by grade level also includes events for all grades.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Get calendar events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type (LiburNasional, LiburSekolah, KegiatanSekolah, Ujian)",
+                        "name": "event_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Grade level",
+                        "name": "grade_level",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of calendar events",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.CalendarEventData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a holiday, school event or exam period. Events with a grade level only apply to classes of that grade and override the general calendar for them (e.g. grade XII still attends during the semester break).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Create a calendar event",
+                "parameters": [
+                    {
+                        "description": "Calendar event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CalendarEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Calendar event created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CalendarEventData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/events/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single calendar event.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Get a calendar event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Calendar event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Calendar event",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CalendarEventData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Calendar event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces a calendar event.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Update a calendar event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Calendar event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Calendar event",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CalendarEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Calendar event updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CalendarEventData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Calendar event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a calendar event.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Delete a calendar event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Calendar event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Calendar event deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Calendar event not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Imports the events of an .ics file (max 2 MB), e.g. the national holiday calendar. Events are matched by UID, so importing the same file again updates the existing events. Recurring events are skipped.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Import calendar events from an iCalendar file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "iCalendar (.ics) file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type for the imported events (default LiburNasional)",
+                        "name": "event_type",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether the imported events cancel lessons (default depends on the event type)",
+                        "name": "non_teaching",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only apply the imported events to this grade level",
+                        "name": "grade_level",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Import result",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.CalendarImportResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid file",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/school-days": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tells whether lessons take place on a date, for the whole school or for one class (taking grade-specific calendar exceptions into account).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Check whether a date is a school day",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "class_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "School day check",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.SchoolDayData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/classes/{id}/curriculum-report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compares a class's schedule with its curriculum and reports unscheduled or under-scheduled subjects.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Curriculum"
+                ],
+                "summary": "Curriculum coverage of a class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Curriculum report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.CurriculumReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/classes/{id}/schedules": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saves the whole weekly timetable of a class in one transaction: entries with an id are updated, entries without an id are created and missing slots are removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Bulk upsert a class timetable",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Timetable entries",
+                        "name": "timetable",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ClassTimetableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timetable saved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.ScheduleData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or curriculum violation",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/companies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves partner companies (DU/DI) with pagination and search by name, address or contact person, including how many students are currently placed there.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Get PKL partner companies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name, address or contact person",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of companies",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.CompanyData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers a partner company. Coordinates must be \"lat,long\" in decimal degrees; capacity is the maximum number of students with an active placement at the same time.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Create a PKL partner company",
+                "parameters": [
+                    {
+                        "description": "Company",
+                        "name": "company",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CompanyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Company created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CompanyData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/companies/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a company with its current (Aktif) and past internship placements.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Get a PKL partner company",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Company details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CompanyData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces all data of a partner company.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Update a PKL partner company",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Company",
+                        "name": "company",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CompanyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Company updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CompanyData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a company that has never had internship placements.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Companies"
+                ],
+                "summary": "Delete a PKL partner company",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Company ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Company deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Company not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Company still has internship placements",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/curriculum": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists subjects taught per grade level and major with their weekly hour quota. Filtering by major also returns rows that apply to all majors.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Curriculum"
+                ],
+                "summary": "Get curriculum mapping",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Grade level (10/X, 11/XI, 12/XII)",
+                        "name": "grade_level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Major",
+                        "name": "major",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Curriculum mapping",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.CurriculumItemData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or updates the weekly hour quota of a subject for a grade level and major.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Curriculum"
+                ],
+                "summary": "Set a curriculum quota",
+                "parameters": [
+                    {
+                        "description": "Curriculum item",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpsertCurriculumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Curriculum item saved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CurriculumItemData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subject not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/curriculum/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a subject from the curriculum of a grade level and major.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Curriculum"
+                ],
+                "summary": "Delete a curriculum item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Curriculum item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Curriculum item deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Curriculum item not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/attendance-sync": {
+            "post": {
+                "security": [
+                    {
+                        "DeviceKey": []
+                    }
+                ],
+                "description": "Device endpoint for kiosks that record RFID taps while offline and upload them later. Each event carries a client-generated ID and the original tap time; events are processed in time order with the same rules as live taps. Re-sending an event with a client_id already received from the same device returns result=duplicate instead of recording it twice, so a kiosk can safely retry a batch after a lost response. The whole batch is rejected when device_time differs from the server clock by more than KIOSK_MAX_CLOCK_SKEW_SECONDS (default 120); single events later than the device time or older than KIOSK_SYNC_MAX_AGE_DAYS (default 7) are rejected individually. At most 500 events per request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Sync queued attendance events from a kiosk",
+                "parameters": [
+                    {
+                        "description": "Queued events",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AttendanceSyncRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Per-event results: created, duplicate, ignored or rejected",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.AttendanceSyncResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or device clock skew too large",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid device key",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/devices/rfid-taps": {
+            "post": {
+                "security": [
+                    {
+                        "DeviceKey": []
+                    }
+                ],
+                "description": "Device endpoint for gate readers, authenticated with a key from ATTENDANCE_DEVICE_KEYS. The first tap of the day is recorded as Masuk and a later tap from RFID_CHECKOUT_FROM (default 12:00) as Pulang. Repeated taps within RFID_DEBOUNCE_SECONDS (default 30), a second tap before check-out time and taps after Pulang are not recorded; recorded=false tells the reader nothing was saved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Record an RFID tap from a gate reader",
+                "parameters": [
+                    {
+                        "description": "Card UID",
+                        "name": "tap",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RFIDTapRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tap processed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.RFIDTapResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid device key",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Student is not active",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Card is not enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Attendance already recorded for the day by another reader",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{token}": {
+            "get": {
+                "description": "Serves an uploaded file (attendance photo, leave attachment, signature) from a signed download URL. The URL is only issued to users allowed to see the file and expires after FILE_URL_TTL_MINUTES (default 15), so it can be used directly in an img tag without an Authorization header.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Download an uploaded file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed download token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Download link has expired",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "File not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "get the status of server",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health Check"
+                ],
+                "summary": "Show the status of server",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-assessments/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the rubric items of the student's major with the scores given so far. The weighted final score and predicate are included once every item has been scored. Students can only view their own assessment and teachers only those they supervise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Assessments"
+                ],
+                "summary": "Get the PKL assessment of a placement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Internship placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assessment",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.InternshipAssessment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to view this placement",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Placement or rubric not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-assessments/{id}/certificate": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the PKL certificate of a placement as PDF with the final score and a score list per competency. Available once every rubric item has been scored.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Internship Assessments"
+                ],
+                "summary": "Download PKL certificate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Internship placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF certificate",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to view this placement",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Assessment not complete or placement cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-assessments/{id}/mentor-links": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a one-time link, valid for 14 days, that the company mentor opens without an account to score the Mentor items. Creating a new link invalidates unused older links of the placement.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Assessments"
+                ],
+                "summary": "Create a company mentor assessment link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Internship placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mentor",
+                        "name": "mentor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MentorLinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Mentor link created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.MentorLinkData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Rubric has no mentor items",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to manage this placement",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-assessments/{id}/supervisor-scores": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Saves scores (0-100) for the rubric items assessed by the supervisor teacher. Scores can be entered in several steps and changed later.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Assessments"
+                ],
+                "summary": "Score a placement as supervisor teacher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Internship placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scores",
+                        "name": "scores",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternshipScoresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scores saved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.InternshipAssessment"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid scores",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the supervisor teacher",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Placement has been cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-attendances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves internship check-ins and check-outs with pagination, newest first. Use out_of_range=true to review flagged attendances. Teachers only see the students they supervise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Attendances"
+                ],
+                "summary": "Get internship attendances",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by placement",
+                        "name": "placement_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by supervisor teacher",
+                        "name": "supervisor_teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by out-of-range flag",
+                        "name": "out_of_range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of internship attendances",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InternshipAttendanceData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-attendances/check-in": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records the logged-in student's arrival at their active placement with GPS coordinates and a photo (JPEG/PNG, max 5 MB). The distance to the company coordinates is calculated; check-ins outside INTERNSHIP_CHECKIN_RADIUS_METERS are saved but flagged for the supervisor.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Attendances"
+                ],
+                "summary": "Check in at the internship company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current location as lat,long",
+                        "name": "coordinates",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo taken at the location",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Checked in successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipAttendanceData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coordinates or photo, or outside the placement period",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No active internship placement",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already checked in today",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-attendances/check-out": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records the logged-in student's departure. The student must have checked in on the same day. Out-of-range check-outs are flagged like check-ins.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Attendances"
+                ],
+                "summary": "Check out from the internship company",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Current location as lat,long",
+                        "name": "coordinates",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo taken at the location",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Checked out successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipAttendanceData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid coordinates or photo",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not checked in or already checked out today",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-attendances/placements/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports presence per day for a placement: Hadir when the student checked in, Libur for days outside SCHOOL_WEEK_DAYS without a check-in, otherwise Tidak Hadir. Defaults to the placement start until today or the placement end; the range may span at most 400 days. Students can only view their own placement and teachers only those they supervise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Attendances"
+                ],
+                "summary": "Get daily presence of an internship placement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Internship placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Presence report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.InternshipPresenceReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to view this placement",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Internship placement not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-journals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves students' daily internship journals with pagination, newest first. Teachers only see the students they supervise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Journals"
+                ],
+                "summary": "Get internship journals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by placement",
+                        "name": "placement_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by student",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by supervisor teacher",
+                        "name": "supervisor_teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (Pending, Approved, Rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Activity date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Activity date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of internship journals",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InternshipJournalData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submits the logged-in student's daily activity for their active placement. Only one journal per date is allowed, within the placement period and not in the future.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Journals"
+                ],
+                "summary": "Submit an internship journal",
+                "parameters": [
+                    {
+                        "description": "Internship journal",
+                        "name": "journal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternshipJournalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Internship journal submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipJournalData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or date outside the placement period",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No active internship placement",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Journal for the date already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-journals/pending": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves journals waiting for review, oldest first. Teachers get the queue of the students they supervise; admins and staff can filter by supervisor_teacher_id.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Journals"
+                ],
+                "summary": "Get pending internship journals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by placement",
+                        "name": "placement_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by student",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by supervisor teacher (admin/staff)",
+                        "name": "supervisor_teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Activity date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Activity date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pending internship journals",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InternshipJournalData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-journals/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approves or rejects several pending journals at once. Only the supervisor teacher can review and notes are required when rejecting; if any journal is not eligible nothing is changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Journals"
+                ],
+                "summary": "Review internship journals",
+                "parameters": [
+                    {
+                        "description": "Review decision",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternshipJournalReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Internship journals reviewed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InternshipJournalData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Journal of a student not supervised by the teacher",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Journal already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-journals/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single internship journal. Students can only view their own journals and teachers only those of students they supervise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Journals"
+                ],
+                "summary": "Get an internship journal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Internship journal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Internship journal details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipJournalData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to view this journal",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Internship journal not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revises the description of the student's own journal that has not been approved. A rejected journal goes back to Pending.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Journals"
+                ],
+                "summary": "Update an internship journal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Internship journal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Internship journal",
+                        "name": "journal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateInternshipJournalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Internship journal updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipJournalData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner of the journal",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Journal already approved or placement no longer active",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-monitoring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aggregates every active placement: working days elapsed vs. total, journals submitted vs. expected, pending approvals, last check-in and an at-risk flag when no journal was written for INTERNSHIP_AT_RISK_DAYS working days (default 3). Supervisor teachers see their own students; admin and staff (PKL coordinator) see all placements with a recap per supervisor. Working days follow SCHOOL_WEEK_DAYS.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Monitoring"
+                ],
+                "summary": "PKL monitoring dashboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Filter by supervisor teacher (admin/staff)",
+                        "name": "supervisor_teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by company",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by the student's current class",
+                        "name": "class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only list at-risk students (summary counts still cover all placements)",
+                        "name": "at_risk_only",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Monitoring dashboard",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.InternshipMonitoring"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-placements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves internship placements with pagination, newest first. Teachers only see the students they supervise.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Placements"
+                ],
+                "summary": "Get internship placements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by company",
+                        "name": "company_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by student",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by the student's current class",
+                        "name": "class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by supervisor teacher",
+                        "name": "supervisor_teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (Aktif, Selesai, Batal)",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of placements",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InternshipPlacementData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates an active placement. A student can only have one active placement and the company's capacity must not be exceeded; conflicts are returned in data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Placements"
+                ],
+                "summary": "Place a student at a company",
+                "parameters": [
+                    {
+                        "description": "Placement",
+                        "name": "placement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternshipPlacementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Placement created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipPlacementData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student, company or teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Active placement or capacity conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.PlacementConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-placements/bulk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Places students of a class at one or more companies for the same period in a single transaction. If student_ids is empty in every group, active students without an active placement are distributed round-robin over the companies within their remaining capacity. Any conflict (student outside the class, active placement, capacity) cancels the whole assignment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Placements"
+                ],
+                "summary": "Place a class at companies",
+                "parameters": [
+                    {
+                        "description": "Bulk assignment",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.BulkPlacementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Placements created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InternshipPlacementData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or not enough capacity",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class, student, company or teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Placement conflicts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.PlacementConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-placements/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single internship placement.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Placements"
+                ],
+                "summary": "Get an internship placement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Placement details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipPlacementData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not your supervised student",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Placement not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the company, supervisor teacher and period of an active placement. Moving to another company checks its capacity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Placements"
+                ],
+                "summary": "Update an internship placement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Placement",
+                        "name": "placement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateInternshipPlacementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Placement updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipPlacementData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Placement, company or teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Placement is no longer active or company is full",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a placement created by mistake. Placements with internship journals cannot be deleted; cancel them instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Placements"
+                ],
+                "summary": "Delete an internship placement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Placement deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Placement not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Placement has internship journals",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-placements/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves an active placement to Selesai or Batal. A reason is required to cancel. The end date defaults to today (or the planned end date when finishing).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Placements"
+                ],
+                "summary": "Finish or cancel an internship placement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Placement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PlacementStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Placement status changed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipPlacementData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or missing reason",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Placement not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Placement is no longer active",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-rubrics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the PKL assessment rubric of every major with its competency items.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Rubrics"
+                ],
+                "summary": "Get internship assessment rubrics",
+                "responses": {
+                    "200": {
+                        "description": "List of rubrics",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InternshipRubricData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates the PKL rubric for a major (must match the class major). Each item is scored 0-100 by either the supervisor teacher (Pembimbing) or the company mentor (Mentor); the final score is the weighted average.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Rubrics"
+                ],
+                "summary": "Create an internship assessment rubric",
+                "parameters": [
+                    {
+                        "description": "Rubric",
+                        "name": "rubric",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternshipRubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Rubric created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipRubricData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Rubric for the major already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/internship-rubrics/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single rubric with its competency items.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Rubrics"
+                ],
+                "summary": "Get an internship assessment rubric",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipRubricData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces a rubric. Items with an id are updated, items without id are added and omitted items are removed. Items that have been scored cannot be removed or moved to another assessor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Rubrics"
+                ],
+                "summary": "Update an internship assessment rubric",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rubric",
+                        "name": "rubric",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternshipRubricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InternshipRubricData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Scored items cannot be changed this way",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a rubric that has never been used for scoring.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Rubrics"
+                ],
+                "summary": "Delete an internship assessment rubric",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rubric ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rubric deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Rubric not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Rubric has been used for scoring",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves leave requests for verification with pagination, latest start date first. Admins and staff see all requests; teachers only see requests of students in the classes they are homeroom teacher of. The from/to range matches requests overlapping it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave Requests"
+                ],
+                "summary": "Get leave requests",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by requester",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by requester role (teacher, staff, student)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by student's class",
+                        "name": "class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (Pending, Approved, Rejected, Cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by type (Sakit, Izin, Cuti, DinasLuar)",
+                        "name": "request_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of leave requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.LeaveRequestData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Submits a leave request for the logged-in user with status Pending. Students can only request Sakit or Izin. The end date must not be before the start date, and the dates must not overlap another pending or approved request of the same user. A supporting document can be attached afterwards via PUT /leave-requests/{id}/attachment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave Requests"
+                ],
+                "summary": "Submit a leave request",
+                "parameters": [
+                    {
+                        "description": "Leave request",
+                        "name": "leave",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.LeaveRequestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Leave request submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.LeaveRequestData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or date range",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Overlaps another leave request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single leave request. Available to the requester and to those who can verify it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave Requests"
+                ],
+                "summary": "Get a leave request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Leave request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leave request details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.LeaveRequestData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to view this leave request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leave request not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests/{id}/attachment": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a signed, expiring download URL for a leave request attachment. Available to the requester and to those who can verify the request (admin, staff, and the homeroom teacher for students).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Get leave request attachment link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Leave request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Download link",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.FileLinkData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not your leave request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leave request or attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attaches a supporting document (doctor's note, assignment letter) to the logged-in user's pending leave request. Accepts JPEG, PNG or PDF up to UPLOAD_MAX_SIZE_MB; the type is detected from the content. A previous attachment is replaced.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Upload a leave request attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Leave request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Attachment",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.FileLinkData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing, too large or unsupported file",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your leave request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leave request not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Leave request is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancels the logged-in user's own pending leave request. The request is kept with status Cancelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave Requests"
+                ],
+                "summary": "Cancel a leave request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Leave request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leave request cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.LeaveRequestData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not your leave request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leave request not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Leave request is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/leave-requests/{id}/review": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approves or rejects a pending leave request and records the verifier and verification time. Admins and staff can verify any request; homeroom teachers can verify requests of students in their class. Nobody can verify their own request. A rejection reason is required when rejecting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave Requests"
+                ],
+                "summary": "Review a leave request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Leave request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review decision",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.LeaveRequestReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leave request reviewed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.LeaveRequestData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or missing rejection reason",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to verify this leave request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Leave request not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Leave request is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lesson-attendances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists student attendance records of teaching journals, newest lesson first, e.g. to find who was absent (Alpa) from which lesson.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teaching Journals"
+                ],
+                "summary": "Get per-lesson student attendance",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attendance status (Hadir, Sakit, Izin, Alpa)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lesson attendance records",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.LessonAttendanceData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/attendance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the logged-in user's check-in and check-out of today and whether checking in or out is still possible.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Get my attendance today",
+                "responses": {
+                    "200": {
+                        "description": "Today's attendance",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.AttendanceToday"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/attendance/daily": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the logged-in user's classified daily attendance. Defaults to the start of this month until today.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendances"
+                ],
+                "summary": "Get my daily attendance records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daily attendance records",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.DailyAttendance"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/internship-journals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the logged-in student's internship journals from all placements, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get my internship journals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by placement",
+                        "name": "placement_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (Pending, Approved, Rejected)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Activity date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Activity date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of internship journals",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InternshipJournalData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "User is not a student",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/journal-reminders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the lessons for which the logged-in teacher has not written a teaching journal yet. Reminders are created by a daily job (JOURNAL_REMINDER_TIME) and removed once the journal is written.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get my journal reminders",
+                "responses": {
+                    "200": {
+                        "description": "Journal reminders",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.JournalReminderData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "User is not a teacher",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/leave-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the logged-in user's leave requests, latest start date first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Me"
+                ],
+                "summary": "Get my leave requests",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status (Pending, Approved, Rejected, Cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by type (Sakit, Izin, Cuti, DinasLuar)",
+                        "name": "request_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date to (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of leave requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.LeaveRequestData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/lesson-attendances": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the logged-in student's attendance per lesson, newest lesson first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teaching Journals"
+                ],
+                "summary": "Get my per-lesson attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Attendance status (Hadir, Sakit, Izin, Alpa)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lesson attendance records",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.LessonAttendanceData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "User is not a student",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/signature": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a signed, expiring download URL for the logged-in teacher's signature image.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Get my signature link",
+                "responses": {
+                    "200": {
+                        "description": "Download link",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.FileLinkData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "No signature uploaded yet",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Uploads the logged-in teacher's signature image (JPEG/PNG). The previous signature is replaced.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Upload my signature",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Signature image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Signature uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.FileLinkData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing, too large or unsupported file",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Only teachers have a signature",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/substitutions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the lessons the logged-in teacher has to cover as a substitute.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Substitutions"
+                ],
+                "summary": "Get my substitution duties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of substitutions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.SubstitutionData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "User is not a teacher",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/timetable": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the weekly grid of the authenticated teacher (teaching schedule) or student (current class).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetables"
+                ],
+                "summary": "My timetable",
+                "responses": {
+                    "200": {
+                        "description": "My timetable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Timetable"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "No teacher/student profile or class",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/timetable/feed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a signed URL that calendar apps can subscribe to without an Authorization header. The URL stays the same until it is regenerated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetables"
+                ],
+                "summary": "Get my calendar subscription URL",
+                "responses": {
+                    "200": {
+                        "description": "Calendar feed URL",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CalendarFeedData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/timetable/feed/regenerate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a new calendar feed URL; the previous URL stops working, e.g. after it was shared by accident. Calendar apps must subscribe again with the new URL.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetables"
+                ],
+                "summary": "Regenerate my calendar subscription URL",
+                "responses": {
+                    "200": {
+                        "description": "New calendar feed URL",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.CalendarFeedData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/me/timetable/ical": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the authenticated user's timetable as weekly recurring events within the academic year.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Timetables"
+                ],
+                "summary": "Download my timetable as iCalendar",
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "No teacher/student profile or class",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/mentor-assessments/{token}": {
+            "get": {
+                "description": "Public endpoint for the company mentor, identified by the one-time token from the assessment link. Returns the student, the company and the items the mentor has to score.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Assessments"
+                ],
+                "summary": "Open a company mentor assessment form",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Mentor link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Assessment form",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.MentorAssessmentData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Link already used or expired",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Public endpoint for the company mentor to score every Mentor item of the rubric. The link can only be used once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Internship Assessments"
+                ],
+                "summary": "Submit company mentor scores",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Mentor link token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scores",
+                        "name": "scores",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InternshipScoresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scores submitted successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.MentorAssessmentData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or incomplete scores",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Link not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Link already used or expired",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/attendance-recap": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Builds a monthly attendance grid (one code per user and day: H on time, T late, A absent, S/I/C/DL approved leave by type, L holiday, blank for days not yet passed) with per-user summaries of working days, days present, late count, minutes late, early leaves, leave days per type and absences. Pass class_id for the students of one class, otherwise role selects teachers (default) or staff. format=xlsx or format=pdf downloads the recap with the school letterhead (SCHOOL_NAME, SCHOOL_ADDRESS, SCHOOL_LOGO_PATH); the PDF is signed by the principal and, for a class, the homeroom teacher.",
+                "produces": [
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get monthly attendance recap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "teacher (default) or staff; ignored when class_id is set",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Class ID for a student recap",
+                        "name": "class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default), xlsx or pdf",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Monthly attendance recap",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.AttendanceRecap"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid month, role or format",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/journal-compliance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Expands every schedule into its lesson dates within the range (skipping school holidays and dates after today) and compares them with the teaching journals written. Returns per-teacher compliance percentages, lowest first, with the list of missing journals. Lessons covered by a substitute count for the substitute; lessons of a teacher on approved leave without a substitute are excused. The range may span at most 184 days.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get the teaching journal compliance report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "class_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Compliance report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.JournalComplianceReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists weekly schedule slots filtered by class, teacher, subject, day, room or academic year.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get schedules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Subject ID",
+                        "name": "subject_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day of week (Senin..Minggu)",
+                        "name": "day_of_week",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Room",
+                        "name": "room",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Academic year, e.g. 2024/2025",
+                        "name": "academic_year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of schedules",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.ScheduleData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a weekly schedule slot. Rejects slots overlapping the same teacher, class or room on the same day (conflicting entries are returned in data) and slots outside the class curriculum.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Create a schedule slot",
+                "parameters": [
+                    {
+                        "description": "Schedule slot",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Schedule created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.ScheduleData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or curriculum violation",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single schedule slot.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Get a schedule slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.ScheduleData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces a schedule slot with the same conflict and curriculum checks as creation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Update a schedule slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule slot",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.ScheduleData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule conflict",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a schedule slot that has no teaching journals.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedules"
+                ],
+                "summary": "Delete a schedule slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Schedule has teaching journals",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/class": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the student's current class and records the change in the class history.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Move a student to another class",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target class",
+                        "name": "class",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChangeStudentClassRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student class changed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.StudentData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student or class not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/class-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every class the student has been enrolled in, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get a student's class history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Class history",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.ClassHistoryEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/rfid": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers or replaces the RFID card used at the gate readers. The UID is stored in upper case without spaces and can only belong to one student.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Enroll a student's RFID card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "RFID card",
+                        "name": "card",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.EnrollCardRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Card enrolled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.StudentData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Card already enrolled to another student",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unlinks the RFID card from the student, for example when the card is lost.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Remove a student's RFID card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Card removed",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found or no card enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subjects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves subjects with pagination and search by code or name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subjects"
+                ],
+                "summary": "Get all subjects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by subject code or name",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of subjects",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.SubjectData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a subject with a unique subject code. Only accessible by admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subjects"
+                ],
+                "summary": "Create a new subject",
+                "parameters": [
+                    {
+                        "description": "New Subject Data",
+                        "name": "subject",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateSubjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subject created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.SubjectData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Subject code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subjects/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a subject together with its curriculum mapping.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subjects"
+                ],
+                "summary": "Get a single subject by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subject ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subject details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.SubjectData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Subject not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a subject's code or name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subjects"
+                ],
+                "summary": "Update a subject",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subject ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Subject Update Data",
+                        "name": "subject",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateSubjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subject updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.SubjectData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Subject not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Subject code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a subject that is not used by any schedule.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subjects"
+                ],
+                "summary": "Delete a subject",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subject ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subject deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Subject not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Subject still used by schedules",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/substitutions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists recorded substitutions, optionally filtered by date range and teacher (either the substitute or the replaced teacher).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Substitutions"
+                ],
+                "summary": "Get substitutions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "teacher_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of substitutions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.SubstitutionData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records a substitute teacher for one lesson on one date. The approved leave request of the original teacher covering that date is linked automatically.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Substitutions"
+                ],
+                "summary": "Assign a substitute teacher",
+                "parameters": [
+                    {
+                        "description": "Substitution",
+                        "name": "substitution",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateSubstitutionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Substitution created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.SubstitutionData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule or teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Lesson already covered or substitute not available",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/substitutions/affected-lessons": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Expands approved leave requests of teachers into the lessons they miss within a date range (max 62 days), including the substitute if one is already recorded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Substitutions"
+                ],
+                "summary": "Get lessons left by absent teachers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only lessons without a substitute",
+                        "name": "uncovered_only",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Affected lessons",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.AffectedLesson"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date range",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/substitutions/suggestions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists teachers who are free during the lesson on the given date (no teaching clash, no other substitution, not on leave, not unavailable). Teachers of the same subject come first, then teachers of the same class, then teachers with the fewest lessons that day.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Substitutions"
+                ],
+                "summary": "Suggest substitute teachers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lesson date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Substitute candidates",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.SubstituteCandidate"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Date does not match the schedule day",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/substitutions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a recorded substitute teacher.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Substitutions"
+                ],
+                "summary": "Cancel a substitution",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Substitution ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Substitution deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Substitution not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/substitutions/{id}/journal": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lets the substitute teacher write the teaching journal (Jurnal KBM) for the lesson they covered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Substitutions"
+                ],
+                "summary": "Write the journal of a substituted lesson",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Substitution ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Journal",
+                        "name": "journal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TeachingJournalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Teaching journal created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TeachingJournalData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not the substitute teacher",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Substitution not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Journal already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/{id}/signature": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a signed, expiring download URL for a teacher's signature image.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Get a teacher's signature link",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Download link",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.FileLinkData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Teacher or signature not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Uploads the signature image (JPEG/PNG, max UPLOAD_MAX_SIZE_MB, default 5 MB) printed on journal recaps, internship certificates and attendance recaps. PNG with a transparent background is recommended. Large images are downscaled to IMAGE_MAX_DIMENSION; the previous signature is deleted.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Upload a teacher's signature",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Signature image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Signature uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.FileLinkData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Missing, too large or unsupported file",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teachers/{id}/unavailability": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the weekly slots in which a teacher cannot teach.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Get teacher unavailability",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unavailable slots",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.TeacherUnavailabilitySlot"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces all weekly slots in which a teacher cannot teach. The timetable generator never places lessons in these slots.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Replace teacher unavailability",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Unavailable slots",
+                        "name": "slots",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TeacherUnavailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unavailable slots saved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.TeacherUnavailabilitySlot"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teaching-assignments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists which teacher teaches which subject in each class. Used as input for the timetable generator.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Get teaching assignments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Academic year, e.g. 2025/2026",
+                        "name": "academic_year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of teaching assignments",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.TeachingAssignmentData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Assigns the teacher of a subject in a class, replacing the previous teacher if any.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Set a teaching assignment",
+                "parameters": [
+                    {
+                        "description": "Teaching assignment",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TeachingAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Teaching assignment saved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TeachingAssignmentData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Class, subject or teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teaching-assignments/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the teacher assignment of a subject in a class.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Delete a teaching assignment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teaching assignment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Teaching assignment deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Teaching assignment not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teaching-journals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists teaching journals (Jurnal KBM), newest first. Teachers only see journals of their own schedules or journals they wrote as a substitute.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teaching Journals"
+                ],
+                "summary": "Get teaching journals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "class_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID (scheduled teacher or author)",
+                        "name": "teacher_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of teaching journals",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.TeachingJournalData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates the teaching journal of one of the logged-in teacher's schedule slots (or a slot they cover as a substitute) for a teaching date. The date must fall on the schedule's day of week and not be in the future; only one journal per schedule and date is allowed. Attendance is recorded for every student of the class: students with an approved leave default to Sakit/Izin, everyone else to Hadir, and ` + "`" + `attendances` + "`" + ` overrides individual students. The attendance summary is derived from these records.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teaching Journals"
+                ],
+                "summary": "Write a teaching journal",
+                "parameters": [
+                    {
+                        "description": "Journal",
+                        "name": "journal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateTeachingJournalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Teaching journal created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TeachingJournalData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or date does not match the schedule day",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your lesson",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Journal already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teaching-journals/recap": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a PDF recap of a teacher's teaching journals for one month, grouped by class and subject, with signature blocks for the teacher (using the teacher's signature image) and the principal (PRINCIPAL_NAME, PRINCIPAL_NIP, PRINCIPAL_SIGNATURE_PATH). Teachers download their own recap; admin and staff must pass teacher_id.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Teaching Journals"
+                ],
+                "summary": "Download monthly teaching journal recap",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Month (YYYY-MM)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID (required for admin and staff)",
+                        "name": "teacher_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF recap",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid month or missing teacher",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your recap",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teaching-journals/roster": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the students of the class on the teaching date with their prefilled attendance status (Sakit/Izin from approved leave requests, otherwise Hadir). If the journal already exists, the saved statuses are returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teaching Journals"
+                ],
+                "summary": "Get the attendance roster of a lesson",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Teaching date (YYYY-MM-DD)",
+                        "name": "teaching_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attendance roster",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.RosterEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Date does not match the schedule day",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your lesson",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teaching-journals/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single teaching journal.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teaching Journals"
+                ],
+                "summary": "Get a teaching journal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teaching journal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Teaching journal",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TeachingJournalData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Not your teaching journal",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Teaching journal not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the topic, notes and student attendance of a teaching journal; the attendance summary is derived again. Only the author can edit, and journals are locked JOURNAL_EDIT_LOCK_DAYS days (default 7) after the teaching date.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teaching Journals"
+                ],
+                "summary": "Edit a teaching journal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teaching journal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Journal",
+                        "name": "journal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TeachingJournalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Teaching journal updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TeachingJournalData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the author or journal locked",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Teaching journal not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timetable-drafts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists generated timetable drafts, newest first, without their entries.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Get timetable drafts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Academic year, e.g. 2025/2026",
+                        "name": "academic_year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of timetable drafts",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.TimetableDraftData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Builds a conflict-free weekly timetable for the given classes from curriculum hour quotas, teaching assignments, teacher unavailability and room availability. Existing schedules of other classes in the same academic year are respected. The result is saved as a draft with a report of lessons that could not be placed; nothing is written to the schedules until the draft is committed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Generate a timetable draft",
+                "parameters": [
+                    {
+                        "description": "Generator input",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenerateTimetableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Timetable draft created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TimetableDraftData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timetable-drafts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a timetable draft with all generated entries and the unplaced lesson report for review.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Get a timetable draft",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Timetable draft ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timetable draft",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TimetableDraftData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Timetable draft not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a timetable draft. Schedules created by committing the draft are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Delete a timetable draft",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Timetable draft ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timetable draft deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Timetable draft not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timetable-drafts/{id}/commit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the schedules of every class in the draft with the draft entries in a single transaction. Conflicts are re-checked against the current schedules, and classes whose current schedules already have teaching journals cannot be replaced.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetable Generator"
+                ],
+                "summary": "Commit a timetable draft",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Timetable draft ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Timetable draft committed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TimetableDraftData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Curriculum violation",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Timetable draft not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Already committed, schedule conflict, or existing teaching journals",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/service.ScheduleConflict"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/timetable-feeds/{token}": {
+            "get": {
+                "description": "Public iCalendar feed identified by a signed token from /me/timetable/feed.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Timetables"
+                ],
+                "summary": "Calendar subscription feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signed feed token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timetables/classes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the day-by-day weekly grid of a class.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetables"
+                ],
+                "summary": "Class timetable",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Class ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Class timetable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Timetable"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Class not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/timetables/rooms/{room}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the day-by-day weekly usage grid of a room for an academic year (default: current).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetables"
+                ],
+                "summary": "Room timetable",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room name",
+                        "name": "room",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Academic year, e.g. 2024/2025",
+                        "name": "academic_year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Room timetable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Timetable"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/timetables/teachers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the day-by-day weekly teaching grid of a teacher for an academic year (default: current).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Timetables"
+                ],
+                "summary": "Teacher timetable",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Teacher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Academic year, e.g. 2024/2025",
+                        "name": "academic_year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Teacher timetable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/service.Timetable"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Teacher not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of all users. Only accessible by admins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get all users with filters and pagination",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by username",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by role (admin, teacher, student, staff)",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active status (true/false)",
+                        "name": "is_active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a new user account. Only accessible by admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "New User Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.ProfileData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or username exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves details of a single user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get a single user by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.ProfileData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a user's role or active status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User Update Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handler.GenericResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.ProfileData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a user account.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.GenericResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.AcademicYearData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-06-26"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "2025/2026"
+                },
+                "semesters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SemesterData"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                }
+            }
+        },
+        "handler.AcademicYearRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-06-26"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "2025/2026"
+                },
+                "semesters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SemesterRequest"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                }
+            }
+        },
+        "handler.AttendanceData": {
+            "type": "object",
+            "properties": {
+                "device_name": {
+                    "description": "Reader/kiosk yang mencatat tap RFID",
+                    "type": "string",
+                    "example": "gerbang-utama"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "location_coordinates": {
+                    "type": "string",
+                    "example": "-7.7956,110.3695"
+                },
+                "photo_path": {
+                    "type": "string",
+                    "example": "attendances/12/2025-09-22-masuk.jpg"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "teacher"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Masuk"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "username": {
+                    "type": "string",
+                    "example": "siti.aminah"
+                }
+            }
+        },
+        "handler.AttendanceRuleData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Jumat"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "11:30"
+                },
+                "grace_minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "role": {
+                    "type": "string",
+                    "example": "teacher"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AttendanceRuleRequest": {
+            "type": "object",
+            "required": [
+                "day_of_week",
+                "end_time",
+                "role",
+                "start_time"
+            ],
+            "properties": {
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Jumat"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "11:30"
+                },
+                "grace_minutes": {
+                    "description": "Toleransi keterlambatan dalam menit",
+                    "type": "integer",
+                    "example": 10
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "teacher",
+                        "student",
+                        "staff"
+                    ],
+                    "example": "teacher"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                }
+            }
+        },
+        "handler.AttendanceSyncEventRequest": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "description": "ID unik buatan kiosk",
+                    "type": "string",
+                    "example": "5f0c6a7e-2b1d-4c4e-9a57-0b8f3f1d2c11"
+                },
+                "rfid_uid": {
+                    "type": "string",
+                    "example": "04A1B2C3"
+                },
+                "timestamp": {
+                    "description": "Waktu tap menurut jam kiosk",
+                    "type": "string",
+                    "example": "2025-09-22T06:58:12+07:00"
+                }
+            }
+        },
+        "handler.AttendanceSyncRequest": {
+            "type": "object",
+            "required": [
+                "device_time",
+                "events"
+            ],
+            "properties": {
+                "device_time": {
+                    "description": "Jam kiosk saat mengirim",
+                    "type": "string",
+                    "example": "2025-09-22T07:45:00+07:00"
+                },
+                "events": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "items": {
+                        "$ref": "#/definitions/handler.AttendanceSyncEventRequest"
+                    }
+                }
+            }
+        },
+        "handler.BulkPlacementGroupRequest": {
+            "type": "object",
+            "required": [
+                "company_id"
+            ],
+            "properties": {
+                "company_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "student_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        21,
+                        22,
+                        23
+                    ]
+                },
+                "supervisor_teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "handler.BulkPlacementRequest": {
+            "type": "object",
+            "required": [
+                "class_id",
+                "groups",
+                "start_date"
+            ],
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-19"
+                },
+                "groups": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/handler.BulkPlacementGroupRequest"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                }
+            }
+        },
+        "handler.CalendarEventData": {
+            "type": "object",
+            "properties": {
+                "academic_year_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Libur nasional"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-09-05"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "LiburNasional"
+                },
+                "grade_level": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "ical_uid": {
+                    "type": "string",
+                    "example": "20250905_maulid@google.com"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "non_teaching": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-09-05"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Libur Maulid Nabi"
+                }
+            }
+        },
+        "handler.CalendarEventRequest": {
+            "type": "object",
+            "required": [
+                "event_type",
+                "start_date",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Libur nasional"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-09-05"
+                },
+                "event_type": {
+                    "type": "string",
+                    "enum": [
+                        "LiburNasional",
+                        "LiburSekolah",
+                        "KegiatanSekolah",
+                        "Ujian"
+                    ],
+                    "example": "LiburNasional"
+                },
+                "grade_level": {
+                    "description": "Kosong berarti berlaku untuk semua kelas",
+                    "type": "string",
+                    "example": "XII"
+                },
+                "non_teaching": {
+                    "description": "Default: true kecuali KegiatanSekolah",
+                    "type": "boolean",
+                    "example": true
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-09-05"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Libur Maulid Nabi"
+                }
+            }
+        },
+        "handler.CalendarFeedData": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:3000/api/v1/timetable-feeds/5.Xyz.ics"
+                }
+            }
+        },
+        "handler.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "currentPassword",
+                "newPassword"
+            ],
+            "properties": {
+                "currentPassword": {
+                    "type": "string",
+                    "example": "old_password123"
+                },
+                "newPassword": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "new_secure_password"
+                }
+            }
+        },
+        "handler.ChangeStudentClassRequest": {
+            "type": "object",
+            "required": [
+                "class_id"
+            ],
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "handler.ClassTimetableEntry": {
+            "type": "object",
+            "required": [
+                "day_of_week",
+                "end_time",
+                "start_time",
+                "subject_id",
+                "teacher_id"
+            ],
+            "properties": {
+                "day_of_week": {
+                    "type": "string",
+                    "enum": [
+                        "Senin",
+                        "Selasa",
+                        "Rabu",
+                        "Kamis",
+                        "Jumat",
+                        "Sabtu",
+                        "Minggu"
+                    ],
+                    "example": "Senin"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "id": {
+                    "description": "Kosong untuk slot baru",
+                    "type": "integer",
+                    "example": 12
+                },
+                "room": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Lab RPL 1"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handler.ClassTimetableRequest": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ClassTimetableEntry"
+                    }
+                }
+            }
+        },
+        "handler.CompanyData": {
+            "type": "object",
+            "properties": {
+                "active_placements": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Yos Sudarso No. 9, Yogyakarta"
+                },
+                "capacity": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "contact_email": {
+                    "type": "string",
+                    "example": "hrd@example.co.id"
+                },
+                "contact_person": {
+                    "type": "string",
+                    "example": "Bambang Riyanto"
+                },
+                "contact_phone": {
+                    "type": "string",
+                    "example": "081234567890"
+                },
+                "coordinates": {
+                    "type": "string",
+                    "example": "-7.7956,110.3695"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "current_placements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.InternshipPlacementData"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "PT Telkom Indonesia Witel Yogyakarta"
+                },
+                "past_placements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.InternshipPlacementData"
+                    }
+                },
+                "remaining_capacity": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.CompanyRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "Jl. Yos Sudarso No. 9, Yogyakarta"
+                },
+                "capacity": {
+                    "description": "Kosong berarti tidak dibatasi",
+                    "type": "integer",
+                    "example": 4
+                },
+                "contact_email": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "hrd@example.co.id"
+                },
+                "contact_person": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Bambang Riyanto"
+                },
+                "contact_phone": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "081234567890"
+                },
+                "coordinates": {
+                    "description": "lat,long",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "-7.7956,110.3695"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "PT Telkom Indonesia Witel Yogyakarta"
+                }
+            }
+        },
+        "handler.CreateSubjectRequest": {
+            "type": "object",
+            "required": [
+                "subject_code",
+                "subject_name"
+            ],
+            "properties": {
+                "subject_code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "MTK"
+                },
+                "subject_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Matematika"
+                }
+            }
+        },
+        "handler.CreateSubstitutionRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "schedule_id",
+                "substitute_teacher_id"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Tugas halaman 45"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "substitute_teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "handler.CreateTeachingJournalRequest": {
+            "type": "object",
+            "required": [
+                "schedule_id",
+                "teaching_date",
+                "topic"
+            ],
+            "properties": {
+                "attendances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.StudentAttendanceRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Latihan soal dilanjutkan minggu depan"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "teaching_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Persamaan kuadrat"
+                }
+            }
+        },
+        "handler.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "minLength": 6,
+                    "example": "newpassword123"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "teacher",
+                        "student",
+                        "staff"
+                    ],
+                    "example": "student"
+                },
+                "username": {
+                    "type": "string",
+                    "minLength": 3,
+                    "example": "newuser"
+                }
+            }
+        },
+        "handler.CurriculumItemData": {
+            "type": "object",
+            "properties": {
+                "grade_level": {
+                    "type": "string",
+                    "example": "10"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "major": {
+                    "type": "string",
+                    "example": "RPL"
+                },
+                "subject_code": {
+                    "type": "string",
+                    "example": "MTK"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                },
+                "weekly_hours": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "handler.EnrollCardRequest": {
+            "type": "object",
+            "required": [
+                "rfid_uid"
+            ],
+            "properties": {
+                "rfid_uid": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "04A1B2C3"
+                }
+            }
+        },
+        "handler.FileLinkData": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "signatures/4-1758520800.png"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:3000/api/v1/files/1758521700.c2lnbmF0dXJlcy80LTE3NTg1MjA4MDAucG5n.Xk2...9w"
+                }
+            }
+        },
+        "handler.GenerateTimetableRequest": {
+            "type": "object",
+            "required": [
+                "academic_year",
+                "periods"
+            ],
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2025/2026"
+                },
+                "attempts": {
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": 0,
+                    "example": 20
+                },
+                "class_ids": {
+                    "description": "Kosong berarti semua kelas pada tahun ajaran",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "max_periods_per_day": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 0,
+                    "example": 2
+                },
+                "periods": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/service.TimetablePeriod"
+                    }
+                },
+                "rooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TimetableRoom"
+                    }
+                },
+                "seed": {
+                    "description": "Isi untuk mengulang hasil draft sebelumnya",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "handler.GenericResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "message": {
+                    "type": "string",
+                    "example": "Operation successful"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handler.InternshipAttendanceData": {
+            "type": "object",
+            "properties": {
+                "attendance_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "XII RPL 1"
+                },
+                "company_name": {
+                    "type": "string",
+                    "example": "PT Teknologi Nusantara"
+                },
+                "distance_meters": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "location_coordinates": {
+                    "type": "string",
+                    "example": "-7.7956,110.3695"
+                },
+                "out_of_range": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "photo_path": {
+                    "type": "string",
+                    "example": "internship-attendances/3/2025-09-22-masuk.jpg"
+                },
+                "placement_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Masuk"
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.InternshipJournalData": {
+            "type": "object",
+            "properties": {
+                "activity_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "activity_description": {
+                    "type": "string",
+                    "example": "Membuat halaman login aplikasi inventaris"
+                },
+                "approved_at": {
+                    "type": "string"
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "XII RPL 1"
+                },
+                "company_name": {
+                    "type": "string",
+                    "example": "PT Teknologi Nusantara"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "placement_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Pending"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "student_nis": {
+                    "type": "string",
+                    "example": "232410001"
+                },
+                "supervisor_notes": {
+                    "type": "string",
+                    "example": "Lengkapi dengan hasil pekerjaan"
+                },
+                "supervisor_teacher_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.InternshipJournalRequest": {
+            "type": "object",
+            "required": [
+                "activity_date",
+                "activity_description"
+            ],
+            "properties": {
+                "activity_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "activity_description": {
+                    "type": "string",
+                    "example": "Membuat halaman login aplikasi inventaris"
+                }
+            }
+        },
+        "handler.InternshipJournalReviewRequest": {
+            "type": "object",
+            "required": [
+                "journal_ids",
+                "status"
+            ],
+            "properties": {
+                "journal_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "notes": {
+                    "description": "Wajib untuk Rejected",
+                    "type": "string",
+                    "example": "Kegiatan sudah sesuai"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "Approved",
+                        "Rejected"
+                    ],
+                    "example": "Approved"
+                }
+            }
+        },
+        "handler.InternshipPlacementData": {
+            "type": "object",
+            "properties": {
+                "class_name": {
+                    "type": "string",
+                    "example": "XI TKJ 1"
+                },
+                "company_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "company_name": {
+                    "type": "string",
+                    "example": "PT Telkom Indonesia Witel Yogyakarta"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-19"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Aktif"
+                },
+                "status_changed_at": {
+                    "type": "string"
+                },
+                "status_reason": {
+                    "type": "string",
+                    "example": "Siswa pindah sekolah"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Siti Nurhaliza"
+                },
+                "student_nis": {
+                    "type": "string",
+                    "example": "2024001"
+                },
+                "supervisor_teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "supervisor_teacher_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                }
+            }
+        },
+        "handler.InternshipPlacementRequest": {
+            "type": "object",
+            "required": [
+                "company_id",
+                "start_date",
+                "student_id"
+            ],
+            "properties": {
+                "company_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-19"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "supervisor_teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "handler.InternshipRubricData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.InternshipRubricItemData"
+                    }
+                },
+                "major": {
+                    "type": "string",
+                    "example": "Rekayasa Perangkat Lunak"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Penilaian PKL RPL"
+                },
+                "total_weight": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.InternshipRubricItemData": {
+            "type": "object",
+            "properties": {
+                "assessor": {
+                    "type": "string",
+                    "example": "Mentor"
+                },
+                "competency": {
+                    "type": "string",
+                    "example": "Menerapkan K3 di tempat kerja"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "sort_order": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "handler.InternshipRubricItemRequest": {
+            "type": "object",
+            "required": [
+                "assessor",
+                "competency",
+                "weight"
+            ],
+            "properties": {
+                "assessor": {
+                    "type": "string",
+                    "enum": [
+                        "Pembimbing",
+                        "Mentor"
+                    ],
+                    "example": "Mentor"
+                },
+                "competency": {
+                    "type": "string",
+                    "example": "Menerapkan K3 di tempat kerja"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "weight": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 20
+                }
+            }
+        },
+        "handler.InternshipRubricRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "major",
+                "name"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/handler.InternshipRubricItemRequest"
+                    }
+                },
+                "major": {
+                    "type": "string",
+                    "example": "Rekayasa Perangkat Lunak"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Penilaian PKL RPL"
+                }
+            }
+        },
+        "handler.InternshipScoreRequest": {
+            "type": "object",
+            "required": [
+                "rubric_item_id"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "example": "Selalu memakai APD"
+                },
+                "rubric_item_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "score": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 85
+                }
+            }
+        },
+        "handler.InternshipScoresRequest": {
+            "type": "object",
+            "required": [
+                "scores"
+            ],
+            "properties": {
+                "scores": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/handler.InternshipScoreRequest"
+                    }
+                }
+            }
+        },
+        "handler.JournalReminderData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "lesson": {
+                    "$ref": "#/definitions/handler.ScheduleData"
+                },
+                "teaching_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                }
+            }
+        },
+        "handler.LeaveRequestData": {
+            "type": "object",
+            "properties": {
+                "attachment_path": {
+                    "type": "string",
+                    "example": "leave-requests/1/lampiran-1758520800.pdf"
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "XI RPL 1"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-09-23"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Demam, surat dokter terlampir"
+                },
+                "rejection_reason": {
+                    "type": "string",
+                    "example": "Tanggal bertepatan dengan ujian"
+                },
+                "request_type": {
+                    "type": "string",
+                    "example": "Sakit"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "student"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Pending"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verifier_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "verifier_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                }
+            }
+        },
+        "handler.LeaveRequestRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "reason",
+                "request_type",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-09-23"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Demam, surat dokter terlampir"
+                },
+                "request_type": {
+                    "type": "string",
+                    "enum": [
+                        "Sakit",
+                        "Izin",
+                        "Cuti",
+                        "DinasLuar"
+                    ],
+                    "example": "Sakit"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                }
+            }
+        },
+        "handler.LeaveRequestReviewRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "rejection_reason": {
+                    "description": "Wajib untuk Rejected",
+                    "type": "string",
+                    "example": "Tanggal bertepatan dengan ujian"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "Approved",
+                        "Rejected"
+                    ],
+                    "example": "Rejected"
+                }
+            }
+        },
+        "handler.LessonAttendanceData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 301
+                },
+                "journal_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "leave_request_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "lesson": {
+                    "$ref": "#/definitions/handler.ScheduleData"
+                },
+                "nis": {
+                    "type": "string",
+                    "example": "2324001"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Tidak ada keterangan"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Alpa"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Ahmad Fauzi"
+                },
+                "teaching_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                }
+            }
+        },
+        "handler.LoginRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "admin123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "handler.MentorAssessmentData": {
+            "type": "object",
+            "properties": {
+                "assessment": {
+                    "$ref": "#/definitions/service.InternshipAssessment"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "mentor_name": {
+                    "type": "string",
+                    "example": "Andi Wijaya"
+                }
+            }
+        },
+        "handler.MentorLinkData": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "mentor_name": {
+                    "type": "string",
+                    "example": "Andi Wijaya"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "http://localhost:3000/api/v1/mentor-assessments/Q2x...9w"
+                }
+            }
+        },
+        "handler.MentorLinkRequest": {
+            "type": "object",
+            "required": [
+                "mentor_name"
+            ],
+            "properties": {
+                "mentor_name": {
+                    "type": "string",
+                    "example": "Andi Wijaya"
+                }
+            }
+        },
+        "handler.PlacementStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-10-03"
+                },
+                "reason": {
+                    "description": "Wajib untuk Batal",
+                    "type": "string",
+                    "example": "Siswa pindah sekolah"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "Selesai",
+                        "Batal"
+                    ],
+                    "example": "Batal"
+                }
+            }
+        },
+        "handler.ProfileData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-09-13T12:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "role": {
+                    "type": "string",
+                    "example": "admin"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
+        "handler.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/handler.ProfileData"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Profile retrieved successfully"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handler.RFIDTapRequest": {
+            "type": "object",
+            "required": [
+                "rfid_uid"
+            ],
+            "properties": {
+                "rfid_uid": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "04A1B2C3"
+                }
+            }
+        },
+        "handler.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refreshToken"
+            ],
+            "properties": {
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "handler.RolloverClassOverride": {
+            "type": "object",
+            "required": [
+                "source_class_id",
+                "target_class_name"
+            ],
+            "properties": {
+                "source_class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "target_class_name": {
+                    "type": "string",
+                    "example": "XI RPL 1"
+                }
+            }
+        },
+        "handler.RolloverExceptionRequest": {
+            "type": "object",
+            "required": [
+                "result",
+                "student_id"
+            ],
+            "properties": {
+                "result": {
+                    "type": "string",
+                    "enum": [
+                        "NAIK",
+                        "TINGGAL",
+                        "LULUS"
+                    ],
+                    "example": "TINGGAL"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "target_class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                }
+            }
+        },
+        "handler.RolloverRequest": {
+            "type": "object",
+            "required": [
+                "source_academic_year"
+            ],
+            "properties": {
+                "class_overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RolloverClassOverride"
+                    }
+                },
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.RolloverExceptionRequest"
+                    }
+                },
+                "source_academic_year": {
+                    "type": "string",
+                    "example": "2024/2025"
+                },
+                "target_academic_year": {
+                    "type": "string",
+                    "example": "2025/2026"
+                }
+            }
+        },
+        "handler.ScheduleData": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Senin"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "room": {
+                    "type": "string",
+                    "example": "Lab RPL 1"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "subject_code": {
+                    "type": "string",
+                    "example": "MTK"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "teacher_name": {
+                    "type": "string",
+                    "example": "Budi Santoso, S.Pd"
+                }
+            }
+        },
+        "handler.ScheduleRequest": {
+            "type": "object",
+            "required": [
+                "class_id",
+                "day_of_week",
+                "end_time",
+                "start_time",
+                "subject_id",
+                "teacher_id"
+            ],
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "enum": [
+                        "Senin",
+                        "Selasa",
+                        "Rabu",
+                        "Kamis",
+                        "Jumat",
+                        "Sabtu",
+                        "Minggu"
+                    ],
+                    "example": "Senin"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "room": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Lab RPL 1"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handler.SchoolDayData": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-05"
+                },
+                "school_day": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "handler.SemesterData": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-19"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                },
+                "term": {
+                    "type": "string",
+                    "example": "Ganjil"
+                }
+            }
+        },
+        "handler.SemesterRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date",
+                "term"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-19"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                },
+                "term": {
+                    "type": "string",
+                    "enum": [
+                        "Ganjil",
+                        "Genap"
+                    ],
+                    "example": "Ganjil"
+                }
+            }
+        },
+        "handler.StudentAttendanceRequest": {
+            "type": "object",
+            "required": [
+                "status",
+                "student_id"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Tidak ada keterangan"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "Hadir",
+                        "Sakit",
+                        "Izin",
+                        "Alpa"
+                    ],
+                    "example": "Alpa"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
+                }
+            }
+        },
+        "handler.StudentData": {
+            "type": "object",
+            "properties": {
+                "current_class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "current_class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Siti Nurhaliza"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "nis": {
+                    "type": "string",
+                    "example": "2024001"
+                },
+                "rfid_uid": {
+                    "type": "string",
+                    "example": "04A1B2C3"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "AKTIF"
+                }
+            }
+        },
+        "handler.SubjectData": {
+            "type": "object",
+            "properties": {
+                "curriculum": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.CurriculumItemData"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_code": {
+                    "type": "string",
+                    "example": "MTK"
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                }
+            }
+        },
+        "handler.SubstitutionData": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "leave_request_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "lesson": {
+                    "$ref": "#/definitions/handler.ScheduleData"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Tugas halaman 45"
+                },
+                "substitute_teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "substitute_teacher_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                }
+            }
+        },
+        "handler.TeacherUnavailabilityRequest": {
+            "type": "object",
+            "properties": {
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.TeacherUnavailabilitySlot"
+                    }
+                }
+            }
+        },
+        "handler.TeacherUnavailabilitySlot": {
+            "type": "object",
+            "required": [
+                "day_of_week",
+                "end_time",
+                "start_time"
+            ],
+            "properties": {
+                "day_of_week": {
+                    "type": "string",
+                    "enum": [
+                        "Senin",
+                        "Selasa",
+                        "Rabu",
+                        "Kamis",
+                        "Jumat",
+                        "Sabtu",
+                        "Minggu"
+                    ],
+                    "example": "Rabu"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "10:00"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Mengajar di sekolah lain"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                }
+            }
+        },
+        "handler.TeachingAssignmentData": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_code": {
+                    "type": "string",
+                    "example": "MTK"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "teacher_name": {
+                    "type": "string",
+                    "example": "Budi Santoso, S.Pd"
+                }
+            }
+        },
+        "handler.TeachingAssignmentRequest": {
+            "type": "object",
+            "required": [
+                "class_id",
+                "subject_id",
+                "teacher_id"
+            ],
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handler.TeachingJournalData": {
+            "type": "object",
+            "properties": {
+                "attendances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.LessonAttendanceData"
+                    }
+                },
+                "author_teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "author_teacher_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lesson": {
+                    "$ref": "#/definitions/handler.ScheduleData"
+                },
+                "locked": {
+                    "description": "Sudah melewati batas waktu edit",
+                    "type": "boolean",
+                    "example": false
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Latihan soal dilanjutkan minggu depan"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "student_attendance_summary": {
+                    "type": "string",
+                    "example": "Hadir 30, Sakit 1, Izin 1"
+                },
+                "teaching_date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Persamaan kuadrat"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TeachingJournalRequest": {
+            "type": "object",
+            "required": [
+                "topic"
+            ],
+            "properties": {
+                "attendances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.StudentAttendanceRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Latihan soal dilanjutkan minggu depan"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Persamaan kuadrat"
+                }
+            }
+        },
+        "handler.TimetableDraftData": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2025/2026"
+                },
+                "class_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "committed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "entries": {
+                    "description": "ID berisi ID slot draft",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ScheduleData"
+                    }
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TimetablePeriod"
+                    }
+                },
+                "seed": {
+                    "type": "integer",
+                    "example": 1726646400000000000
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Draft"
+                },
+                "unplaced": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.UnplacedLesson"
+                    }
+                },
+                "unplaced_hours": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handler.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "handler.UpdateAcademicYearRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-06-26"
+                },
+                "semesters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SemesterRequest"
+                    }
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                }
+            }
+        },
+        "handler.UpdateInternshipJournalRequest": {
+            "type": "object",
+            "required": [
+                "activity_description"
+            ],
+            "properties": {
+                "activity_description": {
+                    "type": "string",
+                    "example": "Membuat halaman login dan validasi form"
+                }
+            }
+        },
+        "handler.UpdateInternshipPlacementRequest": {
+            "type": "object",
+            "required": [
+                "company_id",
+                "start_date"
+            ],
+            "properties": {
+                "company_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-19"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                },
+                "supervisor_teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "handler.UpdateSubjectRequest": {
+            "type": "object",
+            "properties": {
+                "subject_code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "MTK"
+                },
+                "subject_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Matematika Wajib"
+                }
+            }
+        },
+        "handler.UpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "description": "Pointer agar bisa membedakan antara nilai ` + "`" + `false` + "`" + ` dan tidak diisi sama sekali.",
+                    "type": "boolean"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "teacher",
+                        "student",
+                        "staff"
+                    ],
+                    "example": "teacher"
+                }
+            }
+        },
+        "handler.UpsertCurriculumRequest": {
+            "type": "object",
+            "required": [
+                "grade_level",
+                "subject_id",
+                "weekly_hours"
+            ],
+            "properties": {
+                "grade_level": {
+                    "type": "string",
+                    "example": "X"
+                },
+                "major": {
+                    "description": "Kosong berarti semua jurusan",
+                    "type": "string",
+                    "example": "RPL"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "weekly_hours": {
+                    "type": "integer",
+                    "maximum": 40,
+                    "minimum": 1,
+                    "example": 4
+                }
+            }
+        },
+        "service.AffectedLesson": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Senin"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "leave_request_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "leave_type": {
+                    "type": "string",
+                    "example": "Sakit"
+                },
+                "room": {
+                    "type": "string",
+                    "example": "Lab RPL 1"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                },
+                "substitute_teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "substitute_teacher_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                },
+                "substitution_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "teacher_name": {
+                    "type": "string",
+                    "example": "Budi Santoso, S.Pd"
+                }
+            }
+        },
+        "service.AttendanceRecap": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AttendanceRecapDay"
+                    }
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2025-09"
+                },
+                "month_name": {
+                    "type": "string",
+                    "example": "September 2025"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AttendanceRecapRow"
+                    }
+                },
+                "scope": {
+                    "description": "Guru, Staf, atau Kelas \u003cnama kelas\u003e",
+                    "type": "string",
+                    "example": "Guru"
+                },
+                "totals": {
+                    "$ref": "#/definitions/service.AttendanceRecapSummary"
+                }
+            }
+        },
+        "service.AttendanceRecapDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "day": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Senin"
+                }
+            }
+        },
+        "service.AttendanceRecapRow": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "Kode per tanggal: H, T, A, S, I, C, DL, L, atau kosong untuk hari yang belum lewat",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "identifier": {
+                    "description": "NIP guru atau NIS siswa",
+                    "type": "string",
+                    "example": "198703122010012003"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                },
+                "summary": {
+                    "$ref": "#/definitions/service.AttendanceRecapSummary"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "service.AttendanceRecapSummary": {
+            "type": "object",
+            "properties": {
+                "absent": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "early_leave": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "late": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "leave": {
+                    "description": "Jumlah hari izin per jenis (Sakit, Izin, Cuti, DinasLuar)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "minutes_late": {
+                    "type": "integer",
+                    "example": 41
+                },
+                "present": {
+                    "description": "Termasuk yang terlambat",
+                    "type": "integer",
+                    "example": 20
+                },
+                "work_days": {
+                    "type": "integer",
+                    "example": 22
+                }
+            }
+        },
+        "service.AttendanceSyncEventResult": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "type": "integer",
+                    "example": 981
+                },
+                "client_id": {
+                    "type": "string",
+                    "example": "5f0c6a7e-2b1d-4c4e-9a57-0b8f3f1d2c11"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Selamat datang, Budi Santoso"
+                },
+                "result": {
+                    "description": "created, duplicate, ignored atau rejected",
+                    "type": "string",
+                    "example": "created"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Masuk"
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.AttendanceSyncResult": {
+            "type": "object",
+            "properties": {
+                "clock_skew_seconds": {
+                    "description": "Jam server dikurangi jam kiosk",
+                    "type": "integer",
+                    "example": -3
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "duplicates": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "ignored": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "rejected": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AttendanceSyncEventResult"
+                    }
+                },
+                "server_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "service.AttendanceToday": {
+            "type": "object",
+            "properties": {
+                "can_check_in": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "can_check_out": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "check_in": {
+                    "type": "string"
+                },
+                "check_out": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "status": {
+                    "description": "Belum Presensi, Sudah Masuk atau Sudah Pulang",
+                    "type": "string",
+                    "example": "Sudah Masuk"
+                }
+            }
+        },
+        "service.CalendarImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 15
+                },
+                "skipped": {
+                    "description": "Event yang tidak diimpor beserta alasannya",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "service.ClassHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2024/2025"
+                },
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-06-30"
+                },
+                "grade_level": {
+                    "type": "string",
+                    "example": "X"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2024-07-15"
+                }
+            }
+        },
+        "service.CurriculumReport": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "off_curriculum": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.CurriculumReportItem"
+                    }
+                },
+                "scheduled": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.CurriculumReportItem"
+                    }
+                },
+                "unscheduled": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.CurriculumReportItem"
+                    }
+                }
+            }
+        },
+        "service.CurriculumReportItem": {
+            "type": "object",
+            "properties": {
+                "missing_hours": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "required_hours": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "scheduled_hours": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "subject_code": {
+                    "type": "string",
+                    "example": "MTK"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                }
+            }
+        },
+        "service.DailyAttendance": {
+            "type": "object",
+            "properties": {
+                "check_in": {
+                    "type": "string",
+                    "example": "07:18"
+                },
+                "check_out": {
+                    "type": "string",
+                    "example": "15:05"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Senin"
+                },
+                "early_leave": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "expected_end": {
+                    "type": "string",
+                    "example": "15:30"
+                },
+                "expected_start": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "full_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                },
+                "leave_type": {
+                    "type": "string",
+                    "example": "Sakit"
+                },
+                "minutes_early": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "minutes_late": {
+                    "description": "Dihitung dari jam masuk, bukan dari akhir toleransi",
+                    "type": "integer",
+                    "example": 18
+                },
+                "missing_check_out": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "role": {
+                    "type": "string",
+                    "example": "teacher"
+                },
+                "status": {
+                    "description": "Tepat Waktu, Terlambat, Tidak Hadir, Izin, Libur atau Belum Presensi",
+                    "type": "string",
+                    "example": "Terlambat"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "username": {
+                    "type": "string",
+                    "example": "siti.aminah"
+                },
+                "work_minutes": {
+                    "type": "integer",
+                    "example": 467
+                }
+            }
+        },
+        "service.InternshipAssessment": {
+            "type": "object",
             "properties": {
-                "currentPassword": {
+                "class_name": {
+                    "type": "string",
+                    "example": "XII RPL 1"
+                },
+                "company_name": {
+                    "type": "string",
+                    "example": "PT Teknologi Nusantara"
+                },
+                "complete": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-19"
+                },
+                "final_score": {
+                    "type": "number",
+                    "example": 86.5
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.InternshipAssessmentItem"
+                    }
+                },
+                "major": {
+                    "type": "string",
+                    "example": "Rekayasa Perangkat Lunak"
+                },
+                "placement_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "predicate": {
+                    "type": "string",
+                    "example": "Baik"
+                },
+                "rubric_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rubric_name": {
+                    "type": "string",
+                    "example": "Penilaian PKL RPL"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "student_nis": {
+                    "type": "string",
+                    "example": "232410001"
+                },
+                "supervisor_teacher_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                }
+            }
+        },
+        "service.InternshipAssessmentItem": {
+            "type": "object",
+            "properties": {
+                "assessed_at": {
+                    "type": "string"
+                },
+                "assessed_by": {
+                    "type": "string",
+                    "example": "Andi Wijaya"
+                },
+                "assessor": {
+                    "type": "string",
+                    "example": "Mentor"
+                },
+                "competency": {
+                    "type": "string",
+                    "example": "Menerapkan K3 di tempat kerja"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Selalu memakai APD"
+                },
+                "rubric_item_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 85
+                },
+                "weight": {
+                    "type": "integer",
+                    "example": 20
+                }
+            }
+        },
+        "service.InternshipMonitoring": {
+            "type": "object",
+            "properties": {
+                "at_risk": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "at_risk_days": {
+                    "description": "Batas hari kerja tanpa jurnal sebelum ditandai berisiko",
+                    "type": "integer",
+                    "example": 3
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "pending_approvals": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "placements": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "students": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.PlacementMonitoring"
+                    }
+                },
+                "supervisors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.SupervisorMonitoring"
+                    }
+                }
+            }
+        },
+        "service.InternshipPresenceDay": {
+            "type": "object",
+            "properties": {
+                "check_in": {
+                    "type": "string",
+                    "example": "07:55"
+                },
+                "check_in_out_of_range": {
+                    "type": "boolean"
+                },
+                "check_out": {
+                    "type": "string",
+                    "example": "16:02"
+                },
+                "check_out_out_of_range": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Senin"
+                },
+                "status": {
+                    "description": "Hadir, Tidak Hadir atau Libur",
+                    "type": "string",
+                    "example": "Hadir"
+                }
+            }
+        },
+        "service.InternshipPresenceReport": {
+            "type": "object",
+            "properties": {
+                "absent": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "company_name": {
+                    "type": "string",
+                    "example": "PT Teknologi Nusantara"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.InternshipPresenceDay"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "out_of_range": {
+                    "description": "Jumlah presensi di luar radius",
+                    "type": "integer",
+                    "example": 1
+                },
+                "placement_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "present": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-09-30"
+                },
+                "work_days": {
+                    "type": "integer",
+                    "example": 22
+                }
+            }
+        },
+        "service.JournalComplianceReport": {
+            "type": "object",
+            "properties": {
+                "expected": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "filled": {
+                    "type": "integer",
+                    "example": 1130
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "percentage": {
+                    "type": "number",
+                    "example": 94.2
+                },
+                "teachers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TeacherJournalCompliance"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-09-30"
+                }
+            }
+        },
+        "service.MissingJournal": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-09-22"
+                },
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Senin"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                },
+                "substitution_id": {
+                    "description": "Diisi jika guru yang bertanggung jawab adalah guru pengganti",
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "service.PlacementConflict": {
+            "type": "object",
+            "properties": {
+                "company_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "company_name": {
+                    "type": "string",
+                    "example": "PT Telkom Indonesia Witel Yogyakarta"
+                },
+                "placement_id": {
+                    "description": "Penempatan aktif yang sudah ada",
+                    "type": "integer",
+                    "example": 10
+                },
+                "reason": {
+                    "description": "active_placement, capacity, not_in_class, inactive_student atau duplicate",
+                    "type": "string",
+                    "example": "active_placement"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Siti Nurhaliza"
+                }
+            }
+        },
+        "service.PlacementMonitoring": {
+            "type": "object",
+            "properties": {
+                "at_risk": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "XII RPL 1"
+                },
+                "company_name": {
+                    "type": "string",
+                    "example": "PT Teknologi Nusantara"
+                },
+                "days_elapsed": {
+                    "description": "Hari kerja yang sudah dilalui, termasuk hari ini",
+                    "type": "integer",
+                    "example": 45
+                },
+                "days_without_journal": {
+                    "description": "Hari kerja sejak jurnal terakhir",
+                    "type": "integer",
+                    "example": 1
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-12-19"
+                },
+                "journals_approved": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "journals_expected": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "journals_submitted": {
+                    "description": "Jurnal pada hari kerja yang sudah dilalui",
+                    "type": "integer",
+                    "example": 40
+                },
+                "last_check_in": {
+                    "type": "string"
+                },
+                "last_check_in_out_of_range": {
+                    "type": "boolean"
+                },
+                "last_journal_date": {
+                    "type": "string",
+                    "example": "2025-09-19"
+                },
+                "pending_approvals": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "placement_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-07-14"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "student_nis": {
+                    "type": "string",
+                    "example": "232410001"
+                },
+                "supervisor_teacher_name": {
+                    "type": "string",
+                    "example": "Siti Aminah, S.Kom"
+                },
+                "total_days": {
+                    "description": "Hari kerja sepanjang penempatan, kosong jika belum ada tanggal selesai",
+                    "type": "integer",
+                    "example": 115
+                }
+            }
+        },
+        "service.RFIDTapResult": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "description": "Presensi yang tercatat, kosong jika tidak dicatat",
+                    "type": "integer",
+                    "example": 981
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "XI RPL 1"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Selamat datang, Budi Santoso"
+                },
+                "recorded": {
+                    "description": "false untuk tap berulang atau presensi hari ini yang sudah lengkap",
+                    "type": "boolean",
+                    "example": true
+                },
+                "status": {
+                    "description": "Status presensi yang tercatat, atau status terakhir jika tidak dicatat",
+                    "type": "string",
+                    "example": "Masuk"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "student_name": {
+                    "type": "string",
+                    "example": "Budi Santoso"
+                },
+                "student_nis": {
+                    "type": "string",
+                    "example": "232410001"
+                },
+                "timestamp": {
+                    "description": "Waktu presensi yang tercatat atau presensi terakhir",
+                    "type": "string"
+                }
+            }
+        },
+        "service.RolloverClassPlan": {
+            "type": "object",
+            "properties": {
+                "class_name": {
+                    "type": "string",
+                    "example": "XI RPL 1"
+                },
+                "existing_class_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "grade_level": {
                     "type": "string",
-                    "example": "old_password123"
+                    "example": "XI"
                 },
-                "newPassword": {
+                "major": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "new_secure_password"
+                    "example": "RPL"
+                },
+                "source_class_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "student_count": {
+                    "type": "integer",
+                    "example": 32
                 }
             }
         },
-        "handler.CreateUserRequest": {
+        "service.RolloverPlan": {
             "type": "object",
-            "required": [
-                "password",
-                "role",
-                "username"
-            ],
             "properties": {
-                "password": {
-                    "type": "string",
-                    "minLength": 6,
-                    "example": "newpassword123"
+                "classes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.RolloverClassPlan"
+                    }
                 },
-                "role": {
+                "source_academic_year": {
                     "type": "string",
-                    "enum": [
-                        "admin",
-                        "teacher",
-                        "student",
-                        "staff"
-                    ],
-                    "example": "student"
+                    "example": "2024/2025"
                 },
-                "username": {
+                "students": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.RolloverStudentPlan"
+                    }
+                },
+                "summary": {
+                    "$ref": "#/definitions/service.RolloverSummary"
+                },
+                "target_academic_year": {
                     "type": "string",
-                    "minLength": 3,
-                    "example": "newuser"
+                    "example": "2025/2026"
                 }
             }
         },
-        "handler.GenericResponse": {
+        "service.RolloverStudentPlan": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "full_name": {
                     "type": "string",
-                    "example": "Operation successful"
+                    "example": "Siti Nurhaliza"
                 },
-                "success": {
+                "is_exception": {
                     "type": "boolean",
-                    "example": true
+                    "example": false
+                },
+                "nis": {
+                    "type": "string",
+                    "example": "2024001"
+                },
+                "result": {
+                    "type": "string",
+                    "example": "NAIK"
+                },
+                "source_class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "source_class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "target_class_name": {
+                    "type": "string",
+                    "example": "XI RPL 1"
                 }
             }
         },
-        "handler.LoginRequest": {
+        "service.RolloverSummary": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "graduating": {
+                    "type": "integer",
+                    "example": 95
+                },
+                "new_classes": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "promoted": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "repeating": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "service.RosterEntry": {
+            "type": "object",
+            "properties": {
+                "full_name": {
                     "type": "string",
-                    "example": "admin123"
+                    "example": "Ahmad Fauzi"
                 },
-                "username": {
+                "leave_request_id": {
+                    "description": "Izin disetujui yang menjadi dasar status",
+                    "type": "integer",
+                    "example": 7
+                },
+                "nis": {
                     "type": "string",
-                    "example": "admin"
+                    "example": "2324001"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Demam"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Sakit"
+                },
+                "student_id": {
+                    "type": "integer",
+                    "example": 21
                 }
             }
         },
-        "handler.ProfileData": {
+        "service.ScheduleConflict": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
                     "type": "string",
-                    "example": "2025-09-13T12:00:00Z"
+                    "example": "X RPL 1"
                 },
-                "id": {
+                "day_of_week": {
+                    "type": "string",
+                    "example": "Senin"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "entry_index": {
+                    "description": "Indeks slot pada request yang bentrok",
+                    "type": "integer",
+                    "example": 0
+                },
+                "other_entry_index": {
+                    "description": "Diisi jika bentrok dengan slot lain di request yang sama",
+                    "type": "integer",
+                    "example": 3
+                },
+                "reason": {
+                    "description": "teacher, class, atau room",
+                    "type": "string",
+                    "example": "teacher"
+                },
+                "room": {
+                    "type": "string",
+                    "example": "Lab RPL 1"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "subject_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "is_active": {
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "teacher_name": {
+                    "type": "string",
+                    "example": "Budi Santoso, S.Pd"
+                }
+            }
+        },
+        "service.SubstituteCandidate": {
+            "type": "object",
+            "properties": {
+                "lessons_that_day": {
+                    "description": "Jumlah jam mengajar pada hari itu",
+                    "type": "integer",
+                    "example": 3
+                },
+                "same_subject": {
+                    "description": "Mengajar mapel yang sama",
                     "type": "boolean",
                     "example": true
                 },
-                "role": {
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "teacher_name": {
                     "type": "string",
-                    "example": "admin"
+                    "example": "Siti Aminah, S.Kom"
                 },
-                "username": {
+                "teaches_class": {
+                    "description": "Sudah mengajar di kelas tersebut",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "service.SupervisorMonitoring": {
+            "type": "object",
+            "properties": {
+                "at_risk": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pending_approvals": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "placements": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "supervisor_teacher_id": {
+                    "description": "Kosong untuk siswa tanpa pembimbing",
+                    "type": "integer",
+                    "example": 5
+                },
+                "supervisor_teacher_name": {
                     "type": "string",
-                    "example": "admin"
+                    "example": "Siti Aminah, S.Kom"
                 }
             }
         },
-        "handler.ProfileResponse": {
+        "service.TeacherJournalCompliance": {
             "type": "object",
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/handler.ProfileData"
+                "excused": {
+                    "description": "Tidak dihitung karena guru izin dan tidak ada pengganti",
+                    "type": "integer",
+                    "example": 2
                 },
-                "message": {
+                "expected": {
+                    "description": "Jam pelajaran yang wajib berjurnal",
+                    "type": "integer",
+                    "example": 40
+                },
+                "filled": {
+                    "description": "Jam pelajaran yang sudah berjurnal",
+                    "type": "integer",
+                    "example": 37
+                },
+                "missing_journals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.MissingJournal"
+                    }
+                },
+                "percentage": {
+                    "type": "number",
+                    "example": 92.5
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "teacher_name": {
                     "type": "string",
-                    "example": "Profile retrieved successfully"
+                    "example": "Budi Santoso, S.Pd"
+                }
+            }
+        },
+        "service.Timetable": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string",
+                    "example": "2024/2025"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TimetableDay"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "type": {
+                    "description": "class, teacher, atau room",
+                    "type": "string",
+                    "example": "class"
                 }
             }
         },
-        "handler.RefreshTokenRequest": {
+        "service.TimetableDay": {
             "type": "object",
-            "required": [
-                "refreshToken"
-            ],
             "properties": {
-                "refreshToken": {
+                "day_of_week": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "Senin"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TimetableSlot"
+                    }
                 }
             }
         },
-        "handler.TokenResponse": {
+        "service.TimetablePeriod": {
             "type": "object",
             "properties": {
-                "accessToken": {
+                "end_time": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "07:45"
                 },
-                "refreshToken": {
+                "start_time": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "07:00"
                 }
             }
         },
-        "handler.UpdateUserRequest": {
+        "service.TimetableRoom": {
             "type": "object",
             "properties": {
-                "is_active": {
-                    "description": "Pointer agar bisa membedakan antara nilai ` + "`" + `false` + "`" + ` dan tidak diisi sama sekali.",
-                    "type": "boolean"
+                "name": {
+                    "type": "string",
+                    "example": "Lab RPL 1"
                 },
-                "role": {
+                "unavailable": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TimetableRoomBlock"
+                    }
+                }
+            }
+        },
+        "service.TimetableRoomBlock": {
+            "type": "object",
+            "properties": {
+                "day_of_week": {
                     "type": "string",
-                    "enum": [
-                        "admin",
-                        "teacher",
-                        "student",
-                        "staff"
-                    ],
-                    "example": "teacher"
+                    "example": "Jumat"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "13:00"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "11:00"
+                }
+            }
+        },
+        "service.TimetableSlot": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "08:30"
+                },
+                "room": {
+                    "type": "string",
+                    "example": "Lab RPL 1"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "07:00"
+                },
+                "subject_code": {
+                    "type": "string",
+                    "example": "MTK"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "teacher_name": {
+                    "type": "string",
+                    "example": "Budi Santoso, S.Pd"
+                }
+            }
+        },
+        "service.UnplacedLesson": {
+            "type": "object",
+            "properties": {
+                "class_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "class_name": {
+                    "type": "string",
+                    "example": "X RPL 1"
+                },
+                "hours": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "no teacher assigned"
+                },
+                "subject_code": {
+                    "type": "string",
+                    "example": "MTK"
+                },
+                "subject_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "subject_name": {
+                    "type": "string",
+                    "example": "Matematika"
+                },
+                "teacher_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "teacher_name": {
+                    "type": "string",
+                    "example": "Budi Santoso, S.Pd"
                 }
             }
         }
@@ -673,6 +12330,11 @@ const docTemplate = `{
             "type": "apiKey",
             "name": "Authorization",
             "in": "header"
+        },
+        "DeviceKey": {
+            "type": "apiKey",
+            "name": "X-Device-Key",
+            "in": "header"
         }
     }
 }`
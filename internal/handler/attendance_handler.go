@@ -0,0 +1,279 @@
+// internal/handler/attendance_handler.go
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type AttendanceHandler struct {
+	service *service.AttendanceService
+}
+
+func NewAttendanceHandler(service *service.AttendanceService) *AttendanceHandler {
+	return &AttendanceHandler{service: service}
+}
+
+// ToAttendanceDTO mengubah model presensi harian menjadi data response.
+func ToAttendanceDTO(attendance db.AttendanceModel) AttendanceData {
+	data := AttendanceData{
+		ID:        int64(attendance.ID),
+		UserID:    int64(attendance.UserID),
+		Status:    string(attendance.Status),
+		Timestamp: attendance.Timestamp,
+	}
+	if coordinates, ok := attendance.LocationCoordinates(); ok {
+		data.LocationCoordinates = &coordinates
+	}
+	if photoPath, ok := attendance.PhotoPath(); ok {
+		data.PhotoPath = &photoPath
+	}
+	if deviceName, ok := attendance.DeviceName(); ok {
+		data.DeviceName = &deviceName
+	}
+	if attendance.RelationsAttendance.User != nil {
+		user := attendance.User()
+		data.Username = user.Username
+		data.Role = string(user.Role)
+		if teacher, ok := user.Teacher(); ok {
+			data.FullName = teacher.FullName
+		} else if student, ok := user.Student(); ok {
+			data.FullName = student.FullName
+		}
+	}
+	return data
+}
+
+// readDailyAttendanceInput membaca koordinat dan foto opsional dari form presensi harian.
+func readDailyAttendanceInput(c *gin.Context) (service.AttendanceInput, bool) {
+	photo, ok := readAttendancePhoto(c)
+	if !ok {
+		return service.AttendanceInput{}, false
+	}
+	return service.AttendanceInput{Coordinates: c.PostForm("coordinates"), Photo: photo}, true
+}
+
+// CheckIn godoc
+// @Summary      Check in at school
+// @Description  Records the logged-in user's daily arrival. Only one check-in per day is allowed. When SCHOOL_COORDINATES is configured the coordinates are required and must be within SCHOOL_GEOFENCE_RADIUS_METERS (default 100) of the school. A photo (JPEG/PNG, max 5 MB) is optional.
+// @Tags         Attendances
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        coordinates formData string false "Current location as lat,long"
+// @Param        photo formData file false "Photo taken at the location"
+// @Success      201 {object} GenericResponse{data=AttendanceData} "Checked in successfully"
+// @Failure      400 {object} GenericResponse "Invalid coordinates or photo, or outside the school geofence"
+// @Failure      409 {object} GenericResponse "Already checked in today"
+// @Router       /attendances/check-in [post]
+func (h *AttendanceHandler) CheckIn(c *gin.Context) {
+	input, ok := readDailyAttendanceInput(c)
+	if !ok {
+		return
+	}
+
+	attendance, err := h.service.CheckIn(int(currentUser(c).ID), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Checked in successfully",
+		Data:    ToAttendanceDTO(*attendance),
+	})
+}
+
+// CheckOut godoc
+// @Summary      Check out from school
+// @Description  Records the logged-in user's daily departure. The user must have checked in on the same day. The geofence rules of check-in apply.
+// @Tags         Attendances
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        coordinates formData string false "Current location as lat,long"
+// @Param        photo formData file false "Photo taken at the location"
+// @Success      201 {object} GenericResponse{data=AttendanceData} "Checked out successfully"
+// @Failure      400 {object} GenericResponse "Invalid coordinates or photo, or outside the school geofence"
+// @Failure      409 {object} GenericResponse "Not checked in or already checked out today"
+// @Router       /attendances/check-out [post]
+func (h *AttendanceHandler) CheckOut(c *gin.Context) {
+	input, ok := readDailyAttendanceInput(c)
+	if !ok {
+		return
+	}
+
+	attendance, err := h.service.CheckOut(int(currentUser(c).ID), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Checked out successfully",
+		Data:    ToAttendanceDTO(*attendance),
+	})
+}
+
+// GetMyToday godoc
+// @Summary      Get my attendance today
+// @Description  Returns the logged-in user's check-in and check-out of today and whether checking in or out is still possible.
+// @Tags         Attendances
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} GenericResponse{data=service.AttendanceToday} "Today's attendance"
+// @Router       /me/attendance [get]
+func (h *AttendanceHandler) GetMyToday(c *gin.Context) {
+	today, err := h.service.GetToday(int(currentUser(c).ID))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Today's attendance retrieved successfully",
+		Data:    today,
+	})
+}
+
+// GetAttendances godoc
+// @Summary      Get daily attendances
+// @Description  Retrieves daily check-ins and check-outs of teachers, staff and students with pagination, newest first.
+// @Tags         Attendances
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        user_id query int false "Filter by user"
+// @Param        role query string false "Filter by user role (admin, teacher, student, staff)"
+// @Param        status query string false "Filter by status (Masuk or Pulang)"
+// @Param        from query string false "Date from (YYYY-MM-DD)"
+// @Param        to query string false "Date to (YYYY-MM-DD)"
+// @Success      200 {object}  GenericResponse{data=[]AttendanceData} "List of attendances"
+// @Failure      400 {object}  GenericResponse "Invalid filter"
+// @Router       /attendances [get]
+func (h *AttendanceHandler) GetAttendances(c *gin.Context) {
+	var filters AttendanceQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+
+	attendances, total, err := h.service.GetAttendances(service.AttendanceFilters{
+		UserID: filters.UserID,
+		Role:   filters.Role,
+		Status: filters.Status,
+		From:   filters.From,
+		To:     filters.To,
+		Page:   filters.Page,
+		Limit:  filters.Limit,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]AttendanceData, 0, len(attendances))
+	for _, attendance := range attendances {
+		data = append(data, ToAttendanceDTO(attendance))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Attendances retrieved successfully",
+		"data":    data,
+		"meta": gin.H{
+			"page":       filters.Page,
+			"limit":      filters.Limit,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(filters.Limit))),
+		},
+	})
+}
+
+// TapRFID godoc
+// @Summary      Record an RFID tap from a gate reader
+// @Description  Device endpoint for gate readers, authenticated with a key from ATTENDANCE_DEVICE_KEYS. The first tap of the day is recorded as Masuk and a later tap from RFID_CHECKOUT_FROM (default 12:00) as Pulang. Repeated taps within RFID_DEBOUNCE_SECONDS (default 30), a second tap before check-out time and taps after Pulang are not recorded; recorded=false tells the reader nothing was saved.
+// @Tags         Attendances
+// @Security     DeviceKey
+// @Accept       json
+// @Produce      json
+// @Param        tap body RFIDTapRequest true "Card UID"
+// @Success      200 {object} GenericResponse{data=service.RFIDTapResult} "Tap processed"
+// @Failure      401 {object} GenericResponse "Invalid device key"
+// @Failure      403 {object} GenericResponse "Student is not active"
+// @Failure      404 {object} GenericResponse "Card is not enrolled"
+// @Failure      409 {object} GenericResponse "Attendance already recorded for the day by another reader"
+// @Router       /devices/rfid-taps [post]
+func (h *AttendanceHandler) TapRFID(c *gin.Context) {
+	var req RFIDTapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	result, err := h.service.TapRFID(req.RfidUID, c.GetString("device"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: result.Message,
+		Data:    result,
+	})
+}
+
+// SyncDeviceAttendances godoc
+// @Summary      Sync queued attendance events from a kiosk
+// @Description  Device endpoint for kiosks that record RFID taps while offline and upload them later. Each event carries a client-generated ID and the original tap time; events are processed in time order with the same rules as live taps. Re-sending an event with a client_id already received from the same device returns result=duplicate instead of recording it twice, so a kiosk can safely retry a batch after a lost response. The whole batch is rejected when device_time differs from the server clock by more than KIOSK_MAX_CLOCK_SKEW_SECONDS (default 120); single events later than the device time or older than KIOSK_SYNC_MAX_AGE_DAYS (default 7) are rejected individually. At most 500 events per request.
+// @Tags         Attendances
+// @Security     DeviceKey
+// @Accept       json
+// @Produce      json
+// @Param        batch body AttendanceSyncRequest true "Queued events"
+// @Success      200 {object} GenericResponse{data=service.AttendanceSyncResult} "Per-event results: created, duplicate, ignored or rejected"
+// @Failure      400 {object} GenericResponse "Invalid body or device clock skew too large"
+// @Failure      401 {object} GenericResponse "Invalid device key"
+// @Router       /devices/attendance-sync [post]
+func (h *AttendanceHandler) SyncDeviceAttendances(c *gin.Context) {
+	var req AttendanceSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	events := make([]service.AttendanceSyncEvent, 0, len(req.Events))
+	for _, event := range req.Events {
+		events = append(events, service.AttendanceSyncEvent{
+			ClientID:  event.ClientID,
+			RfidUID:   event.RfidUID,
+			Timestamp: event.Timestamp,
+		})
+	}
+	result, err := h.service.SyncDeviceAttendances(c.GetString("device"), req.DeviceTime, events)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Attendance events synced",
+		Data:    result,
+	})
+}
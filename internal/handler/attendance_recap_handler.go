@@ -0,0 +1,68 @@
+// internal/handler/attendance_recap_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
+type AttendanceRecapHandler struct {
+	service *service.AttendanceRecapService
+}
+
+func NewAttendanceRecapHandler(service *service.AttendanceRecapService) *AttendanceRecapHandler {
+	return &AttendanceRecapHandler{service: service}
+}
+
+// GetMonthlyRecap godoc
+// @Summary      Get monthly attendance recap
+// @Description  Builds a monthly attendance grid (one code per user and day: H on time, T late, A absent, S/I/C/DL approved leave by type, L holiday, blank for days not yet passed) with per-user summaries of working days, days present, late count, minutes late, early leaves, leave days per type and absences. Pass class_id for the students of one class, otherwise role selects teachers (default) or staff. format=xlsx or format=pdf downloads the recap with the school letterhead (SCHOOL_NAME, SCHOOL_ADDRESS, SCHOOL_LOGO_PATH); the PDF is signed by the principal and, for a class, the homeroom teacher.
+// @Tags         Reports
+// @Security     BearerAuth
+// @Produce      json,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Param        month query string true "Month (YYYY-MM)"
+// @Param        role query string false "teacher (default) or staff; ignored when class_id is set"
+// @Param        class_id query int false "Class ID for a student recap"
+// @Param        format query string false "json (default), xlsx or pdf"
+// @Success      200 {object} GenericResponse{data=service.AttendanceRecap} "Monthly attendance recap"
+// @Failure      400 {object} GenericResponse "Invalid month, role or format"
+// @Failure      404 {object} GenericResponse "Class not found"
+// @Router       /reports/attendance-recap [get]
+func (h *AttendanceRecapHandler) GetMonthlyRecap(c *gin.Context) {
+	var query AttendanceRecapQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+	filters := service.AttendanceRecapFilters{
+		Month:   query.Month,
+		Role:    query.Role,
+		ClassID: query.ClassID,
+	}
+
+	if query.Format == "" || query.Format == "json" {
+		recap, err := h.service.GetRecap(filters)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, GenericResponse{
+			Success: true,
+			Message: "Attendance recap retrieved successfully",
+			Data:    recap,
+		})
+		return
+	}
+
+	file, err := h.service.ExportRecap(filters, query.Format)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
@@ -0,0 +1,168 @@
+// internal/handler/attendance_rule_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type AttendanceRuleHandler struct {
+	service *service.AttendanceRuleService
+}
+
+func NewAttendanceRuleHandler(service *service.AttendanceRuleService) *AttendanceRuleHandler {
+	return &AttendanceRuleHandler{service: service}
+}
+
+// ToAttendanceRuleDTO mengubah model aturan jam kerja menjadi data response.
+func ToAttendanceRuleDTO(rule db.AttendanceRuleModel) AttendanceRuleData {
+	return AttendanceRuleData{
+		ID:           int64(rule.ID),
+		Role:         string(rule.Role),
+		DayOfWeek:    string(rule.DayOfWeek),
+		StartTime:    rule.StartTime.UTC().Format("15:04"),
+		EndTime:      rule.EndTime.UTC().Format("15:04"),
+		GraceMinutes: rule.GraceMinutes,
+		CreatedAt:    rule.CreatedAt,
+		UpdatedAt:    rule.UpdatedAt,
+	}
+}
+
+func toAttendanceRuleInput(req AttendanceRuleRequest) service.AttendanceRuleInput {
+	return service.AttendanceRuleInput{
+		Role:         req.Role,
+		DayOfWeek:    req.DayOfWeek,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		GraceMinutes: req.GraceMinutes,
+	}
+}
+
+// GetRules godoc
+// @Summary      Get attendance rules
+// @Description  Retrieves the working hours per role and day of week used to classify daily attendance. A day without a rule is not a working day for that role.
+// @Tags         Attendance Rules
+// @Security     BearerAuth
+// @Produce      json
+// @Param        role query string false "Filter by role (admin, teacher, student, staff)"
+// @Success      200 {object}  GenericResponse{data=[]AttendanceRuleData} "List of attendance rules"
+// @Failure      400 {object}  GenericResponse "Invalid role"
+// @Router       /attendance-rules [get]
+func (h *AttendanceRuleHandler) GetRules(c *gin.Context) {
+	rules, err := h.service.GetRules(c.Query("role"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]AttendanceRuleData, 0, len(rules))
+	for _, rule := range rules {
+		data = append(data, ToAttendanceRuleDTO(rule))
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Attendance rules retrieved successfully",
+		Data:    data,
+	})
+}
+
+// CreateRule godoc
+// @Summary      Create an attendance rule
+// @Description  Sets the expected start and end time and the grace period for a role on one day of week, for example shorter hours on Friday. Only one rule per role and day is allowed.
+// @Tags         Attendance Rules
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        rule body AttendanceRuleRequest true "Attendance rule"
+// @Success      201 {object} GenericResponse{data=AttendanceRuleData} "Attendance rule created successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      409 {object} GenericResponse "Rule for the role and day already exists"
+// @Router       /attendance-rules [post]
+func (h *AttendanceRuleHandler) CreateRule(c *gin.Context) {
+	var req AttendanceRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	rule, err := h.service.CreateRule(toAttendanceRuleInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Attendance rule created successfully",
+		Data:    ToAttendanceRuleDTO(*rule),
+	})
+}
+
+// UpdateRule godoc
+// @Summary      Update an attendance rule
+// @Description  Replaces an attendance rule. Daily attendance is computed on the fly, so the change also applies to past dates.
+// @Tags         Attendance Rules
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Attendance rule ID"
+// @Param        rule body AttendanceRuleRequest true "Attendance rule"
+// @Success      200 {object} GenericResponse{data=AttendanceRuleData} "Attendance rule updated successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      404 {object} GenericResponse "Attendance rule not found"
+// @Failure      409 {object} GenericResponse "Rule for the role and day already exists"
+// @Router       /attendance-rules/{id} [put]
+func (h *AttendanceRuleHandler) UpdateRule(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "attendance rule")
+	if !ok {
+		return
+	}
+	var req AttendanceRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	rule, err := h.service.UpdateRule(id, toAttendanceRuleInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Attendance rule updated successfully",
+		Data:    ToAttendanceRuleDTO(*rule),
+	})
+}
+
+// DeleteRule godoc
+// @Summary      Delete an attendance rule
+// @Description  Deletes an attendance rule; the day is no longer a working day for the role.
+// @Tags         Attendance Rules
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Attendance rule ID"
+// @Success      200 {object} GenericResponse "Attendance rule deleted successfully"
+// @Failure      404 {object} GenericResponse "Attendance rule not found"
+// @Router       /attendance-rules/{id} [delete]
+func (h *AttendanceRuleHandler) DeleteRule(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "attendance rule")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRule(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Attendance rule deleted successfully",
+	})
+}
@@ -0,0 +1,508 @@
+// internal/handler/calendar_handler.go
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// maxICalFileSize membatasi ukuran file .ics yang diimpor.
+const maxICalFileSize = 2 << 20
+
+type CalendarHandler struct {
+	service *service.CalendarService
+}
+
+func NewCalendarHandler(service *service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{service: service}
+}
+
+// ToAcademicYearDTO mengubah model tahun ajaran menjadi data response.
+func ToAcademicYearDTO(year db.AcademicYearModel) AcademicYearData {
+	data := AcademicYearData{
+		ID:        int64(year.ID),
+		Name:      year.Name,
+		StartDate: year.StartDate.UTC().Format("2006-01-02"),
+		EndDate:   year.EndDate.UTC().Format("2006-01-02"),
+		Semesters: []SemesterData{},
+		CreatedAt: year.CreatedAt,
+	}
+	if year.RelationsAcademicYear.Semesters != nil {
+		for _, semester := range year.Semesters() {
+			data.Semesters = append(data.Semesters, SemesterData{
+				ID:        int64(semester.ID),
+				Term:      string(semester.Term),
+				StartDate: semester.StartDate.UTC().Format("2006-01-02"),
+				EndDate:   semester.EndDate.UTC().Format("2006-01-02"),
+			})
+		}
+	}
+	return data
+}
+
+// ToCalendarEventDTO mengubah model agenda kalender menjadi data response.
+func ToCalendarEventDTO(event db.CalendarEventModel) CalendarEventData {
+	data := CalendarEventData{
+		ID:          int64(event.ID),
+		Title:       event.Title,
+		EventType:   string(event.EventType),
+		StartDate:   event.StartDate.UTC().Format("2006-01-02"),
+		EndDate:     event.EndDate.UTC().Format("2006-01-02"),
+		NonTeaching: event.NonTeaching,
+		CreatedAt:   event.CreatedAt,
+	}
+	if yearID, ok := event.AcademicYearID(); ok {
+		id := int64(yearID)
+		data.AcademicYearID = &id
+	}
+	if description, ok := event.Description(); ok {
+		data.Description = description
+	}
+	if grade, ok := event.GradeLevel(); ok {
+		data.GradeLevel = grade
+	}
+	if uid, ok := event.IcalUID(); ok {
+		data.ICalUID = uid
+	}
+	return data
+}
+
+func toSemesterInputs(requests []SemesterRequest) []service.SemesterInput {
+	inputs := make([]service.SemesterInput, 0, len(requests))
+	for _, req := range requests {
+		inputs = append(inputs, service.SemesterInput{Term: req.Term, StartDate: req.StartDate, EndDate: req.EndDate})
+	}
+	return inputs
+}
+
+func toCalendarEventInput(req CalendarEventRequest) service.CalendarEventInput {
+	return service.CalendarEventInput{
+		Title:       req.Title,
+		Description: req.Description,
+		EventType:   req.EventType,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		NonTeaching: req.NonTeaching,
+		GradeLevel:  req.GradeLevel,
+	}
+}
+
+// GetAcademicYears godoc
+// @Summary      Get academic years
+// @Description  Lists academic years with their semesters, newest first.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} GenericResponse{data=[]AcademicYearData} "List of academic years"
+// @Router       /academic-years [get]
+func (h *CalendarHandler) GetAcademicYears(c *gin.Context) {
+	years, err := h.service.GetAcademicYears()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]AcademicYearData, 0, len(years))
+	for _, year := range years {
+		data = append(data, ToAcademicYearDTO(year))
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Academic years retrieved successfully",
+		Data:    data,
+	})
+}
+
+// GetAcademicYearByID godoc
+// @Summary      Get an academic year
+// @Description  Retrieves an academic year with its semesters.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Academic year ID"
+// @Success      200 {object} GenericResponse{data=AcademicYearData} "Academic year"
+// @Failure      404 {object} GenericResponse "Academic year not found"
+// @Router       /academic-years/{id} [get]
+func (h *CalendarHandler) GetAcademicYearByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "academic year")
+	if !ok {
+		return
+	}
+
+	year, err := h.service.GetAcademicYearByID(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Academic year retrieved successfully",
+		Data:    ToAcademicYearDTO(*year),
+	})
+}
+
+// CreateAcademicYear godoc
+// @Summary      Create an academic year
+// @Description  Creates an academic year with its semesters. The name must match the academic_year of classes (e.g. 2025/2026). Without dates, 1 July to 30 June is used. Dates between semesters are treated as the semester break.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        academicYear body AcademicYearRequest true "Academic year"
+// @Success      201 {object} GenericResponse{data=AcademicYearData} "Academic year created"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      409 {object} GenericResponse "Academic year already exists"
+// @Router       /academic-years [post]
+func (h *CalendarHandler) CreateAcademicYear(c *gin.Context) {
+	var req AcademicYearRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	year, err := h.service.CreateAcademicYear(service.AcademicYearInput{
+		Name:      req.Name,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Semesters: toSemesterInputs(req.Semesters),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Academic year created successfully",
+		Data:    ToAcademicYearDTO(*year),
+	})
+}
+
+// UpdateAcademicYear godoc
+// @Summary      Update an academic year
+// @Description  Replaces the date range and all semesters of an academic year. The name cannot be changed.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Academic year ID"
+// @Param        academicYear body UpdateAcademicYearRequest true "Academic year"
+// @Success      200 {object} GenericResponse{data=AcademicYearData} "Academic year updated"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "Academic year not found"
+// @Router       /academic-years/{id} [put]
+func (h *CalendarHandler) UpdateAcademicYear(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "academic year")
+	if !ok {
+		return
+	}
+	var req UpdateAcademicYearRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	year, err := h.service.UpdateAcademicYear(id, service.AcademicYearInput{
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Semesters: toSemesterInputs(req.Semesters),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Academic year updated successfully",
+		Data:    ToAcademicYearDTO(*year),
+	})
+}
+
+// DeleteAcademicYear godoc
+// @Summary      Delete an academic year
+// @Description  Deletes an academic year that is not used by any class. Its semesters are deleted too.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Academic year ID"
+// @Success      200 {object} GenericResponse "Academic year deleted"
+// @Failure      404 {object} GenericResponse "Academic year not found"
+// @Failure      409 {object} GenericResponse "Academic year still used by classes"
+// @Router       /academic-years/{id} [delete]
+func (h *CalendarHandler) DeleteAcademicYear(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "academic year")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAcademicYear(id); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Academic year deleted successfully",
+	})
+}
+
+// GetEvents godoc
+// @Summary      Get calendar events
+// @Description  Lists calendar events overlapping the date range (max 400 days). Filtering by grade level also includes events for all grades.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string true "Start date (YYYY-MM-DD)"
+// @Param        to query string true "End date (YYYY-MM-DD)"
+// @Param        event_type query string false "Event type (LiburNasional, LiburSekolah, KegiatanSekolah, Ujian)"
+// @Param        grade_level query string false "Grade level"
+// @Success      200 {object} GenericResponse{data=[]CalendarEventData} "List of calendar events"
+// @Failure      400 {object} GenericResponse "Invalid date range"
+// @Router       /calendar/events [get]
+func (h *CalendarHandler) GetEvents(c *gin.Context) {
+	var filters CalendarEventQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	events, err := h.service.GetEvents(service.CalendarEventFilters{
+		From:       filters.From,
+		To:         filters.To,
+		EventType:  filters.EventType,
+		GradeLevel: filters.GradeLevel,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]CalendarEventData, 0, len(events))
+	for _, event := range events {
+		data = append(data, ToCalendarEventDTO(event))
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Calendar events retrieved successfully",
+		Data:    data,
+	})
+}
+
+// GetEventByID godoc
+// @Summary      Get a calendar event
+// @Description  Retrieves a single calendar event.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Calendar event ID"
+// @Success      200 {object} GenericResponse{data=CalendarEventData} "Calendar event"
+// @Failure      404 {object} GenericResponse "Calendar event not found"
+// @Router       /calendar/events/{id} [get]
+func (h *CalendarHandler) GetEventByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "calendar event")
+	if !ok {
+		return
+	}
+
+	event, err := h.service.GetEventByID(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Calendar event retrieved successfully",
+		Data:    ToCalendarEventDTO(*event),
+	})
+}
+
+// CreateEvent godoc
+// @Summary      Create a calendar event
+// @Description  Creates a holiday, school event or exam period. Events with a grade level only apply to classes of that grade and override the general calendar for them (e.g. grade XII still attends during the semester break).
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        event body CalendarEventRequest true "Calendar event"
+// @Success      201 {object} GenericResponse{data=CalendarEventData} "Calendar event created"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Router       /calendar/events [post]
+func (h *CalendarHandler) CreateEvent(c *gin.Context) {
+	var req CalendarEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	event, err := h.service.CreateEvent(toCalendarEventInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Calendar event created successfully",
+		Data:    ToCalendarEventDTO(*event),
+	})
+}
+
+// UpdateEvent godoc
+// @Summary      Update a calendar event
+// @Description  Replaces a calendar event.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Calendar event ID"
+// @Param        event body CalendarEventRequest true "Calendar event"
+// @Success      200 {object} GenericResponse{data=CalendarEventData} "Calendar event updated"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "Calendar event not found"
+// @Router       /calendar/events/{id} [put]
+func (h *CalendarHandler) UpdateEvent(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "calendar event")
+	if !ok {
+		return
+	}
+	var req CalendarEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	event, err := h.service.UpdateEvent(id, toCalendarEventInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Calendar event updated successfully",
+		Data:    ToCalendarEventDTO(*event),
+	})
+}
+
+// DeleteEvent godoc
+// @Summary      Delete a calendar event
+// @Description  Deletes a calendar event.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Calendar event ID"
+// @Success      200 {object} GenericResponse "Calendar event deleted"
+// @Failure      404 {object} GenericResponse "Calendar event not found"
+// @Router       /calendar/events/{id} [delete]
+func (h *CalendarHandler) DeleteEvent(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "calendar event")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteEvent(id); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Calendar event deleted successfully",
+	})
+}
+
+// ImportICal godoc
+// @Summary      Import calendar events from an iCalendar file
+// @Description  Imports the events of an .ics file (max 2 MB), e.g. the national holiday calendar. Events are matched by UID, so importing the same file again updates the existing events. Recurring events are skipped.
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "iCalendar (.ics) file"
+// @Param        event_type formData string false "Event type for the imported events (default LiburNasional)"
+// @Param        non_teaching formData bool false "Whether the imported events cancel lessons (default depends on the event type)"
+// @Param        grade_level formData string false "Only apply the imported events to this grade level"
+// @Success      200 {object} GenericResponse{data=service.CalendarImportResult} "Import result"
+// @Failure      400 {object} GenericResponse "Invalid file"
+// @Router       /calendar/import [post]
+func (h *CalendarHandler) ImportICal(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "File is required"})
+		return
+	}
+	if header.Size > maxICalFileSize {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "File must not exceed 2 MB"})
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Failed to read file"})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxICalFileSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Failed to read file"})
+		return
+	}
+
+	options := service.CalendarImportOptions{
+		EventType:  c.PostForm("event_type"),
+		GradeLevel: c.PostForm("grade_level"),
+	}
+	if value := c.PostForm("non_teaching"); value != "" {
+		nonTeaching, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "non_teaching must be a boolean"})
+			return
+		}
+		options.NonTeaching = &nonTeaching
+	}
+
+	result, err := h.service.ImportICal(data, options)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Calendar imported successfully",
+		Data:    result,
+	})
+}
+
+// CheckSchoolDay godoc
+// @Summary      Check whether a date is a school day
+// @Description  Tells whether lessons take place on a date, for the whole school or for one class (taking grade-specific calendar exceptions into account).
+// @Tags         Calendar
+// @Security     BearerAuth
+// @Produce      json
+// @Param        date query string true "Date (YYYY-MM-DD)"
+// @Param        class_id query int false "Class ID"
+// @Success      200 {object} GenericResponse{data=SchoolDayData} "School day check"
+// @Failure      400 {object} GenericResponse "Invalid date"
+// @Failure      404 {object} GenericResponse "Class not found"
+// @Router       /calendar/school-days [get]
+func (h *CalendarHandler) CheckSchoolDay(c *gin.Context) {
+	var query SchoolDayQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+	schoolDay, err := h.service.CheckSchoolDay(query.Date, query.ClassID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "School day checked successfully",
+		Data: SchoolDayData{
+			Date:      query.Date,
+			ClassID:   query.ClassID,
+			SchoolDay: schoolDay,
+		},
+	})
+}
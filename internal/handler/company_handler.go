@@ -0,0 +1,258 @@
+// internal/handler/company_handler.go
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type CompanyHandler struct {
+	service *service.CompanyService
+}
+
+func NewCompanyHandler(service *service.CompanyService) *CompanyHandler {
+	return &CompanyHandler{service: service}
+}
+
+// ToCompanyDTO mengubah model DU/DI menjadi data response. Jika penempatan ikut
+// diambil, kuota terpakai dihitung dari penempatan berstatus Aktif dan
+// penempatan dipisah menjadi yang sedang berjalan dan riwayat.
+func ToCompanyDTO(company db.CompanyModel, withPlacements bool) CompanyData {
+	data := CompanyData{
+		ID:        int64(company.ID),
+		Name:      company.Name,
+		CreatedAt: company.CreatedAt,
+	}
+	if address, ok := company.Address(); ok {
+		data.Address = address
+	}
+	if coordinates, ok := company.Coordinates(); ok {
+		data.Coordinates = coordinates
+	}
+	if person, ok := company.ContactPerson(); ok {
+		data.ContactPerson = person
+	}
+	if phone, ok := company.ContactPhone(); ok {
+		data.ContactPhone = phone
+	}
+	if email, ok := company.ContactEmail(); ok {
+		data.ContactEmail = email
+	}
+	if capacity, ok := company.Capacity(); ok {
+		data.Capacity = &capacity
+	}
+
+	if company.RelationsCompany.InternshipPlacements != nil {
+		active := 0
+		for _, placement := range company.InternshipPlacements() {
+			if placement.Status == db.InternshipStatusAktif {
+				active++
+			}
+			if !withPlacements {
+				continue
+			}
+			if placement.Status == db.InternshipStatusAktif {
+				data.CurrentPlacements = append(data.CurrentPlacements, ToInternshipPlacementDTO(placement))
+			} else {
+				data.PastPlacements = append(data.PastPlacements, ToInternshipPlacementDTO(placement))
+			}
+		}
+		data.ActivePlacements = &active
+		if data.Capacity != nil {
+			remaining := max(*data.Capacity-active, 0)
+			data.RemainingCapacity = &remaining
+		}
+	}
+	return data
+}
+
+func toCompanyInput(req CompanyRequest) service.CompanyInput {
+	return service.CompanyInput{
+		Name:          req.Name,
+		Address:       req.Address,
+		Coordinates:   req.Coordinates,
+		ContactPerson: req.ContactPerson,
+		ContactPhone:  req.ContactPhone,
+		ContactEmail:  req.ContactEmail,
+		Capacity:      req.Capacity,
+	}
+}
+
+// GetCompanies godoc
+// @Summary      Get PKL partner companies
+// @Description  Retrieves partner companies (DU/DI) with pagination and search by name, address or contact person, including how many students are currently placed there.
+// @Tags         Companies
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        search query string false "Search by name, address or contact person"
+// @Success      200 {object}  GenericResponse{data=[]CompanyData} "List of companies"
+// @Failure      500 {object}  GenericResponse "Internal Server Error"
+// @Router       /companies [get]
+func (h *CompanyHandler) GetCompanies(c *gin.Context) {
+	var filters CompanyQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+
+	companies, total, err := h.service.GetCompanies(filters.Page, filters.Limit, filters.Search)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	data := make([]CompanyData, 0, len(companies))
+	for _, company := range companies {
+		data = append(data, ToCompanyDTO(company, false))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Companies retrieved successfully",
+		"data":    data,
+		"meta": gin.H{
+			"page":       filters.Page,
+			"limit":      filters.Limit,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(filters.Limit))),
+		},
+	})
+}
+
+// GetCompanyByID godoc
+// @Summary      Get a PKL partner company
+// @Description  Retrieves a company with its current (Aktif) and past internship placements.
+// @Tags         Companies
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Company ID"
+// @Success      200 {object} GenericResponse{data=CompanyData} "Company details"
+// @Failure      404 {object} GenericResponse "Company not found"
+// @Router       /companies/{id} [get]
+func (h *CompanyHandler) GetCompanyByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "company")
+	if !ok {
+		return
+	}
+
+	company, err := h.service.GetCompanyByID(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Company retrieved successfully",
+		Data:    ToCompanyDTO(*company, true),
+	})
+}
+
+// CreateCompany godoc
+// @Summary      Create a PKL partner company
+// @Description  Registers a partner company. Coordinates must be "lat,long" in decimal degrees; capacity is the maximum number of students with an active placement at the same time.
+// @Tags         Companies
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        company body CompanyRequest true "Company"
+// @Success      201 {object} GenericResponse{data=CompanyData} "Company created successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Router       /companies [post]
+func (h *CompanyHandler) CreateCompany(c *gin.Context) {
+	var req CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	company, err := h.service.CreateCompany(toCompanyInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Company created successfully",
+		Data:    ToCompanyDTO(*company, false),
+	})
+}
+
+// UpdateCompany godoc
+// @Summary      Update a PKL partner company
+// @Description  Replaces all data of a partner company.
+// @Tags         Companies
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Company ID"
+// @Param        company body CompanyRequest true "Company"
+// @Success      200 {object} GenericResponse{data=CompanyData} "Company updated successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      404 {object} GenericResponse "Company not found"
+// @Router       /companies/{id} [put]
+func (h *CompanyHandler) UpdateCompany(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "company")
+	if !ok {
+		return
+	}
+	var req CompanyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	company, err := h.service.UpdateCompany(id, toCompanyInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Company updated successfully",
+		Data:    ToCompanyDTO(*company, false),
+	})
+}
+
+// DeleteCompany godoc
+// @Summary      Delete a PKL partner company
+// @Description  Deletes a company that has never had internship placements.
+// @Tags         Companies
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Company ID"
+// @Success      200 {object} GenericResponse "Company deleted successfully"
+// @Failure      404 {object} GenericResponse "Company not found"
+// @Failure      409 {object} GenericResponse "Company still has internship placements"
+// @Router       /companies/{id} [delete]
+func (h *CompanyHandler) DeleteCompany(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "company")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCompany(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Company deleted successfully",
+	})
+}
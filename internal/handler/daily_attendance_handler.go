@@ -0,0 +1,89 @@
+// internal/handler/daily_attendance_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
+type DailyAttendanceHandler struct {
+	service *service.DailyAttendanceService
+}
+
+func NewDailyAttendanceHandler(service *service.DailyAttendanceService) *DailyAttendanceHandler {
+	return &DailyAttendanceHandler{service: service}
+}
+
+// GetDaily godoc
+// @Summary      Get daily attendance records
+// @Description  Classifies each active user's day from the raw check-ins, the attendance rules, approved leave requests and the school calendar: Tepat Waktu, Terlambat (check-in after start time plus grace period), Tidak Hadir, Izin (approved leave), Libur (no rule, holiday or no school day) or Belum Presensi (today before the end time). Early leave and minutes late are reported separately. Defaults to today; the range is limited to 31 days.
+// @Tags         Attendances
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string false "Date from (YYYY-MM-DD)"
+// @Param        to query string false "Date to (YYYY-MM-DD)"
+// @Param        role query string false "Filter by role (admin, teacher, student, staff)"
+// @Param        user_id query int false "Filter by user"
+// @Param        status query string false "Filter by status, e.g. Terlambat"
+// @Success      200 {object}  GenericResponse{data=[]service.DailyAttendance} "Daily attendance records"
+// @Failure      400 {object}  GenericResponse "Invalid filter"
+// @Router       /attendances/daily [get]
+func (h *DailyAttendanceHandler) GetDaily(c *gin.Context) {
+	var query DailyAttendanceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	records, err := h.service.GetDaily(service.DailyAttendanceFilters{
+		From:   query.From,
+		To:     query.To,
+		Role:   query.Role,
+		UserID: query.UserID,
+		Status: query.Status,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Daily attendance retrieved successfully",
+		Data:    records,
+	})
+}
+
+// GetMyDaily godoc
+// @Summary      Get my daily attendance records
+// @Description  Returns the logged-in user's classified daily attendance. Defaults to the start of this month until today.
+// @Tags         Attendances
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string false "Date from (YYYY-MM-DD)"
+// @Param        to query string false "Date to (YYYY-MM-DD)"
+// @Success      200 {object}  GenericResponse{data=[]service.DailyAttendance} "Daily attendance records"
+// @Failure      400 {object}  GenericResponse "Invalid date range"
+// @Router       /me/attendance/daily [get]
+func (h *DailyAttendanceHandler) GetMyDaily(c *gin.Context) {
+	var query DailyAttendanceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	records, err := h.service.GetMyDaily(int(currentUser(c).ID), query.From, query.To)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Daily attendance retrieved successfully",
+		Data:    records,
+	})
+}
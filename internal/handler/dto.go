@@ -1,6 +1,12 @@
 // internal/handler/dto.go
 package handler
 
+import (
+	"time"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
 // GenericResponse is the base structure for all JSON responses.
 type GenericResponse struct {
 	Success bool        `json:"success" example:"true"`
@@ -68,4 +74,867 @@ type UserQueryFilters struct {
 	Search   string `form:"search"`
 	Role     string `form:"role"`
 	IsActive string `form:"is_active"` // String agar bisa handle 'true'/'false' dari query
-}
\ No newline at end of file
+}
+
+// RolloverClassOverride menentukan nama kelas tujuan untuk satu kelas asal.
+type RolloverClassOverride struct {
+	SourceClassID   int64  `json:"source_class_id" binding:"required" example:"3"`
+	TargetClassName string `json:"target_class_name" binding:"required" example:"XI RPL 1"`
+}
+
+// RolloverExceptionRequest mengecualikan siswa dari aturan kenaikan default, misalnya tinggal kelas.
+type RolloverExceptionRequest struct {
+	StudentID       int64  `json:"student_id" binding:"required" example:"15"`
+	Result          string `json:"result" binding:"required,oneof=NAIK TINGGAL LULUS" example:"TINGGAL"`
+	TargetClassName string `json:"target_class_name" example:"X RPL 1"`
+}
+
+// RolloverRequest adalah struktur untuk preview dan penerapan kenaikan kelas.
+type RolloverRequest struct {
+	SourceAcademicYear string                     `json:"source_academic_year" binding:"required" example:"2024/2025"`
+	TargetAcademicYear string                     `json:"target_academic_year" example:"2025/2026"`
+	ClassOverrides     []RolloverClassOverride    `json:"class_overrides" binding:"omitempty,dive"`
+	Exceptions         []RolloverExceptionRequest `json:"exceptions" binding:"omitempty,dive"`
+}
+
+// ChangeStudentClassRequest adalah struktur untuk memindahkan siswa ke kelas lain.
+type ChangeStudentClassRequest struct {
+	ClassID int64 `json:"class_id" binding:"required" example:"4"`
+}
+
+// StudentData adalah data ringkas siswa yang dikirim ke client.
+type StudentData struct {
+	ID               int64  `json:"id" example:"1"`
+	Nis              string `json:"nis" example:"2024001"`
+	FullName         string `json:"full_name" example:"Siti Nurhaliza"`
+	Status           string `json:"status" example:"AKTIF"`
+	CurrentClassID   *int64 `json:"current_class_id,omitempty" example:"3"`
+	CurrentClassName string `json:"current_class_name,omitempty" example:"X RPL 1"`
+	RfidUID          string `json:"rfid_uid,omitempty" example:"04A1B2C3"`
+}
+
+// CreateSubjectRequest adalah struktur untuk membuat mata pelajaran baru.
+type CreateSubjectRequest struct {
+	SubjectCode string `json:"subject_code" binding:"required,max=20" example:"MTK"`
+	SubjectName string `json:"subject_name" binding:"required,max=255" example:"Matematika"`
+}
+
+// UpdateSubjectRequest adalah struktur untuk memperbarui mata pelajaran.
+type UpdateSubjectRequest struct {
+	SubjectCode string `json:"subject_code" binding:"omitempty,max=20" example:"MTK"`
+	SubjectName string `json:"subject_name" binding:"omitempty,max=255" example:"Matematika Wajib"`
+}
+
+// SubjectQueryFilters adalah parameter query untuk daftar mata pelajaran.
+type SubjectQueryFilters struct {
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+	Search string `form:"search"`
+}
+
+// CurriculumItemData adalah satu baris pemetaan kurikulum.
+type CurriculumItemData struct {
+	ID          int64  `json:"id" example:"1"`
+	SubjectID   int64  `json:"subject_id" example:"1"`
+	SubjectCode string `json:"subject_code,omitempty" example:"MTK"`
+	SubjectName string `json:"subject_name,omitempty" example:"Matematika"`
+	GradeLevel  string `json:"grade_level" example:"10"`
+	Major       string `json:"major" example:"RPL"`
+	WeeklyHours int    `json:"weekly_hours" example:"4"`
+}
+
+// SubjectData adalah data mata pelajaran yang dikirim ke client.
+type SubjectData struct {
+	ID          int64                `json:"id" example:"1"`
+	SubjectCode string               `json:"subject_code" example:"MTK"`
+	SubjectName string               `json:"subject_name" example:"Matematika"`
+	Curriculum  []CurriculumItemData `json:"curriculum,omitempty"`
+}
+
+// UpsertCurriculumRequest menetapkan kuota jam mapel untuk tingkat dan jurusan tertentu.
+type UpsertCurriculumRequest struct {
+	SubjectID   int64  `json:"subject_id" binding:"required" example:"1"`
+	GradeLevel  string `json:"grade_level" binding:"required" example:"X"`
+	Major       string `json:"major" example:"RPL"` // Kosong berarti semua jurusan
+	WeeklyHours int    `json:"weekly_hours" binding:"required,min=1,max=40" example:"4"`
+}
+
+// CurriculumQueryFilters adalah parameter query untuk daftar kurikulum.
+type CurriculumQueryFilters struct {
+	GradeLevel string `form:"grade_level"`
+	Major      string `form:"major"`
+}
+
+// ScheduleRequest adalah struktur untuk membuat atau memperbarui satu slot jadwal.
+type ScheduleRequest struct {
+	ClassID   int64  `json:"class_id" binding:"required" example:"3"`
+	SubjectID int64  `json:"subject_id" binding:"required" example:"1"`
+	TeacherID int64  `json:"teacher_id" binding:"required" example:"2"`
+	DayOfWeek string `json:"day_of_week" binding:"required,oneof=Senin Selasa Rabu Kamis Jumat Sabtu Minggu" example:"Senin"`
+	StartTime string `json:"start_time" binding:"required" example:"07:00"`
+	EndTime   string `json:"end_time" binding:"required" example:"08:30"`
+	Room      string `json:"room" binding:"max=50" example:"Lab RPL 1"`
+}
+
+// ClassTimetableEntry adalah satu slot pada bulk upsert jadwal kelas.
+type ClassTimetableEntry struct {
+	ID        int64  `json:"id" example:"12"` // Kosong untuk slot baru
+	SubjectID int64  `json:"subject_id" binding:"required" example:"1"`
+	TeacherID int64  `json:"teacher_id" binding:"required" example:"2"`
+	DayOfWeek string `json:"day_of_week" binding:"required,oneof=Senin Selasa Rabu Kamis Jumat Sabtu Minggu" example:"Senin"`
+	StartTime string `json:"start_time" binding:"required" example:"07:00"`
+	EndTime   string `json:"end_time" binding:"required" example:"08:30"`
+	Room      string `json:"room" binding:"max=50" example:"Lab RPL 1"`
+}
+
+// ClassTimetableRequest berisi seluruh jadwal mingguan satu kelas.
+type ClassTimetableRequest struct {
+	Entries []ClassTimetableEntry `json:"entries" binding:"dive"`
+}
+
+// ScheduleQueryFilters adalah parameter query untuk daftar jadwal.
+type ScheduleQueryFilters struct {
+	ClassID      int64  `form:"class_id"`
+	TeacherID    int64  `form:"teacher_id"`
+	SubjectID    int64  `form:"subject_id"`
+	DayOfWeek    string `form:"day_of_week"`
+	Room         string `form:"room"`
+	AcademicYear string `form:"academic_year"`
+}
+
+// ScheduleData adalah data satu slot jadwal yang dikirim ke client.
+type ScheduleData struct {
+	ID          int64  `json:"id" example:"12"`
+	ClassID     int64  `json:"class_id" example:"3"`
+	ClassName   string `json:"class_name,omitempty" example:"X RPL 1"`
+	SubjectID   int64  `json:"subject_id" example:"1"`
+	SubjectCode string `json:"subject_code,omitempty" example:"MTK"`
+	SubjectName string `json:"subject_name,omitempty" example:"Matematika"`
+	TeacherID   int64  `json:"teacher_id" example:"2"`
+	TeacherName string `json:"teacher_name,omitempty" example:"Budi Santoso, S.Pd"`
+	DayOfWeek   string `json:"day_of_week" example:"Senin"`
+	StartTime   string `json:"start_time" example:"07:00"`
+	EndTime     string `json:"end_time" example:"08:30"`
+	Room        string `json:"room,omitempty" example:"Lab RPL 1"`
+}
+
+// TimetableQueryFilters adalah parameter query untuk jadwal guru dan ruangan.
+type TimetableQueryFilters struct {
+	AcademicYear string `form:"academic_year"`
+}
+
+// CalendarFeedData berisi URL langganan kalender (.ics) milik user.
+type CalendarFeedData struct {
+	URL string `json:"url" example:"http://localhost:3000/api/v1/timetable-feeds/5.Xyz.ics"`
+}
+
+// TeachingAssignmentRequest menetapkan guru pengampu sebuah mapel di satu kelas.
+type TeachingAssignmentRequest struct {
+	ClassID   int64 `json:"class_id" binding:"required" example:"3"`
+	SubjectID int64 `json:"subject_id" binding:"required" example:"1"`
+	TeacherID int64 `json:"teacher_id" binding:"required" example:"2"`
+}
+
+// TeachingAssignmentQueryFilters adalah parameter query untuk daftar guru pengampu.
+type TeachingAssignmentQueryFilters struct {
+	ClassID      int64  `form:"class_id"`
+	TeacherID    int64  `form:"teacher_id"`
+	AcademicYear string `form:"academic_year"`
+}
+
+// TeachingAssignmentData adalah data guru pengampu yang dikirim ke client.
+type TeachingAssignmentData struct {
+	ID          int64  `json:"id" example:"1"`
+	ClassID     int64  `json:"class_id" example:"3"`
+	ClassName   string `json:"class_name,omitempty" example:"X RPL 1"`
+	SubjectID   int64  `json:"subject_id" example:"1"`
+	SubjectCode string `json:"subject_code,omitempty" example:"MTK"`
+	SubjectName string `json:"subject_name,omitempty" example:"Matematika"`
+	TeacherID   int64  `json:"teacher_id" example:"2"`
+	TeacherName string `json:"teacher_name,omitempty" example:"Budi Santoso, S.Pd"`
+}
+
+// TeacherUnavailabilitySlot adalah satu slot ketika guru tidak bisa mengajar.
+type TeacherUnavailabilitySlot struct {
+	ID        int64  `json:"id,omitempty" example:"1"`
+	DayOfWeek string `json:"day_of_week" binding:"required,oneof=Senin Selasa Rabu Kamis Jumat Sabtu Minggu" example:"Rabu"`
+	StartTime string `json:"start_time" binding:"required" example:"07:00"`
+	EndTime   string `json:"end_time" binding:"required" example:"10:00"`
+	Reason    string `json:"reason,omitempty" binding:"max=255" example:"Mengajar di sekolah lain"`
+}
+
+// TeacherUnavailabilityRequest berisi seluruh slot tidak tersedia milik seorang guru.
+type TeacherUnavailabilityRequest struct {
+	Slots []TeacherUnavailabilitySlot `json:"slots" binding:"dive"`
+}
+
+// GenerateTimetableRequest adalah input penyusun jadwal otomatis.
+type GenerateTimetableRequest struct {
+	AcademicYear     string                    `json:"academic_year" binding:"required" example:"2025/2026"`
+	ClassIDs         []int64                   `json:"class_ids"` // Kosong berarti semua kelas pada tahun ajaran
+	Days             []string                  `json:"days" binding:"dive,oneof=Senin Selasa Rabu Kamis Jumat Sabtu Minggu"`
+	Periods          []service.TimetablePeriod `json:"periods" binding:"required,min=1"`
+	Rooms            []service.TimetableRoom   `json:"rooms"`
+	MaxPeriodsPerDay int                       `json:"max_periods_per_day" binding:"min=0,max=12" example:"2"`
+	Attempts         int                       `json:"attempts" binding:"min=0,max=200" example:"20"`
+	Seed             int64                     `json:"seed" example:"0"` // Isi untuk mengulang hasil draft sebelumnya
+}
+
+// TimetableDraftQueryFilters adalah parameter query untuk daftar draft jadwal.
+type TimetableDraftQueryFilters struct {
+	AcademicYear string `form:"academic_year"`
+}
+
+// TimetableDraftData adalah draft jadwal hasil penyusun otomatis.
+type TimetableDraftData struct {
+	ID            int64                     `json:"id" example:"1"`
+	AcademicYear  string                    `json:"academic_year" example:"2025/2026"`
+	Status        string                    `json:"status" example:"Draft"`
+	ClassIDs      []int64                   `json:"class_ids"`
+	Days          []string                  `json:"days"`
+	Periods       []service.TimetablePeriod `json:"periods"`
+	Seed          int64                     `json:"seed" example:"1726646400000000000"`
+	UnplacedHours int                       `json:"unplaced_hours" example:"2"`
+	Unplaced      []service.UnplacedLesson  `json:"unplaced"`
+	Entries       []ScheduleData            `json:"entries,omitempty"` // ID berisi ID slot draft
+	CreatedAt     time.Time                 `json:"created_at"`
+	CommittedAt   *time.Time                `json:"committed_at,omitempty"`
+}
+
+// AffectedLessonQueryFilters adalah parameter query untuk daftar pelajaran yang ditinggal guru izin.
+type AffectedLessonQueryFilters struct {
+	From          string `form:"from" binding:"required"`
+	To            string `form:"to" binding:"required"`
+	UncoveredOnly bool   `form:"uncovered_only"`
+}
+
+// SubstituteSuggestionQuery adalah parameter query untuk saran guru pengganti.
+type SubstituteSuggestionQuery struct {
+	ScheduleID int64  `form:"schedule_id" binding:"required"`
+	Date       string `form:"date" binding:"required"`
+}
+
+// CreateSubstitutionRequest adalah struktur untuk mencatat guru pengganti.
+type CreateSubstitutionRequest struct {
+	ScheduleID          int64  `json:"schedule_id" binding:"required" example:"12"`
+	Date                string `json:"date" binding:"required" example:"2025-09-22"`
+	SubstituteTeacherID int64  `json:"substitute_teacher_id" binding:"required" example:"5"`
+	Notes               string `json:"notes" example:"Tugas halaman 45"`
+}
+
+// SubstitutionQueryFilters adalah parameter query untuk daftar guru pengganti.
+type SubstitutionQueryFilters struct {
+	From      string `form:"from"`
+	To        string `form:"to"`
+	TeacherID int64  `form:"teacher_id"`
+}
+
+// SubstitutionData adalah data guru pengganti yang dikirim ke client.
+type SubstitutionData struct {
+	ID                    int64        `json:"id" example:"4"`
+	Date                  string       `json:"date" example:"2025-09-22"`
+	Lesson                ScheduleData `json:"lesson"`
+	SubstituteTeacherID   int64        `json:"substitute_teacher_id" example:"5"`
+	SubstituteTeacherName string       `json:"substitute_teacher_name,omitempty" example:"Siti Aminah, S.Kom"`
+	LeaveRequestID        *int64       `json:"leave_request_id,omitempty" example:"7"`
+	Notes                 string       `json:"notes,omitempty" example:"Tugas halaman 45"`
+	CreatedAt             time.Time    `json:"created_at"`
+}
+
+// TeachingJournalRequest adalah isi jurnal mengajar. Attendances cukup berisi
+// siswa yang statusnya berbeda dari isian otomatis (Hadir, atau Sakit/Izin dari izin yang disetujui).
+type TeachingJournalRequest struct {
+	Topic       string                     `json:"topic" binding:"required" example:"Persamaan kuadrat"`
+	Notes       string                     `json:"notes" example:"Latihan soal dilanjutkan minggu depan"`
+	Attendances []StudentAttendanceRequest `json:"attendances" binding:"dive"`
+}
+
+// StudentAttendanceRequest adalah status kehadiran seorang siswa pada jam pelajaran.
+type StudentAttendanceRequest struct {
+	StudentID int64  `json:"student_id" binding:"required" example:"21"`
+	Status    string `json:"status" binding:"required,oneof=Hadir Sakit Izin Alpa" example:"Alpa"`
+	Notes     string `json:"notes" binding:"max=255" example:"Tidak ada keterangan"`
+}
+
+// TeachingJournalData adalah data jurnal mengajar yang dikirim ke client.
+type TeachingJournalData struct {
+	ID                       int64                  `json:"id" example:"1"`
+	ScheduleID               int64                  `json:"schedule_id" example:"12"`
+	TeachingDate             string                 `json:"teaching_date" example:"2025-09-22"`
+	Topic                    string                 `json:"topic" example:"Persamaan kuadrat"`
+	StudentAttendanceSummary string                 `json:"student_attendance_summary,omitempty" example:"Hadir 30, Sakit 1, Izin 1"`
+	Notes                    string                 `json:"notes,omitempty" example:"Latihan soal dilanjutkan minggu depan"`
+	AuthorTeacherID          *int64                 `json:"author_teacher_id,omitempty" example:"5"`
+	AuthorTeacherName        string                 `json:"author_teacher_name,omitempty" example:"Siti Aminah, S.Kom"`
+	Lesson                   *ScheduleData          `json:"lesson,omitempty"`
+	Locked                   bool                   `json:"locked" example:"false"` // Sudah melewati batas waktu edit
+	Attendances              []LessonAttendanceData `json:"attendances,omitempty"`
+	CreatedAt                time.Time              `json:"created_at"`
+	UpdatedAt                time.Time              `json:"updated_at"`
+}
+
+// CreateTeachingJournalRequest adalah struktur untuk mengisi jurnal mengajar.
+type CreateTeachingJournalRequest struct {
+	ScheduleID   int64                      `json:"schedule_id" binding:"required" example:"12"`
+	TeachingDate string                     `json:"teaching_date" binding:"required" example:"2025-09-22"`
+	Topic        string                     `json:"topic" binding:"required" example:"Persamaan kuadrat"`
+	Notes        string                     `json:"notes" example:"Latihan soal dilanjutkan minggu depan"`
+	Attendances  []StudentAttendanceRequest `json:"attendances" binding:"dive"`
+}
+
+// TeachingJournalQueryFilters adalah parameter query untuk daftar jurnal mengajar.
+type TeachingJournalQueryFilters struct {
+	Page       int    `form:"page"`
+	Limit      int    `form:"limit"`
+	ScheduleID int64  `form:"schedule_id"`
+	ClassID    int64  `form:"class_id"`
+	TeacherID  int64  `form:"teacher_id"`
+	From       string `form:"from"`
+	To         string `form:"to"`
+}
+
+// JournalRosterQuery adalah parameter query untuk daftar hadir sebuah jam pelajaran.
+type JournalRosterQuery struct {
+	ScheduleID   int64  `form:"schedule_id" binding:"required"`
+	TeachingDate string `form:"teaching_date" binding:"required"`
+}
+
+// LessonAttendanceQueryFilters adalah parameter query untuk riwayat kehadiran per jam pelajaran.
+type LessonAttendanceQueryFilters struct {
+	StudentID int64  `form:"student_id"`
+	ClassID   int64  `form:"class_id"`
+	Status    string `form:"status"`
+	From      string `form:"from"`
+	To        string `form:"to"`
+}
+
+// LessonAttendanceData adalah kehadiran seorang siswa pada satu jam pelajaran.
+type LessonAttendanceData struct {
+	ID             int64         `json:"id" example:"301"`
+	JournalID      int64         `json:"journal_id" example:"1"`
+	StudentID      int64         `json:"student_id" example:"21"`
+	StudentName    string        `json:"student_name,omitempty" example:"Ahmad Fauzi"`
+	NIS            string        `json:"nis,omitempty" example:"2324001"`
+	Status         string        `json:"status" example:"Alpa"`
+	Notes          string        `json:"notes,omitempty" example:"Tidak ada keterangan"`
+	LeaveRequestID *int64        `json:"leave_request_id,omitempty" example:"7"`
+	TeachingDate   string        `json:"teaching_date,omitempty" example:"2025-09-22"`
+	Lesson         *ScheduleData `json:"lesson,omitempty"`
+}
+
+// JournalComplianceQueryFilters adalah parameter query untuk laporan kepatuhan jurnal.
+type JournalComplianceQueryFilters struct {
+	From      string `form:"from" binding:"required"`
+	To        string `form:"to" binding:"required"`
+	TeacherID int64  `form:"teacher_id"`
+	ClassID   int64  `form:"class_id"`
+}
+
+// JournalReminderData adalah pengingat jurnal yang belum diisi.
+type JournalReminderData struct {
+	ID           int64        `json:"id" example:"15"`
+	TeachingDate string       `json:"teaching_date" example:"2025-09-22"`
+	Lesson       ScheduleData `json:"lesson"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// SemesterRequest adalah rentang tanggal satu semester.
+type SemesterRequest struct {
+	Term      string `json:"term" binding:"required,oneof=Ganjil Genap" example:"Ganjil"`
+	StartDate string `json:"start_date" binding:"required" example:"2025-07-14"`
+	EndDate   string `json:"end_date" binding:"required" example:"2025-12-19"`
+}
+
+// AcademicYearRequest adalah struktur untuk membuat tahun ajaran. Tanpa tanggal,
+// rentang standar 1 Juli sampai 30 Juni dipakai.
+type AcademicYearRequest struct {
+	Name      string            `json:"name" binding:"required" example:"2025/2026"`
+	StartDate string            `json:"start_date" example:"2025-07-14"`
+	EndDate   string            `json:"end_date" example:"2026-06-26"`
+	Semesters []SemesterRequest `json:"semesters" binding:"dive"`
+}
+
+// UpdateAcademicYearRequest mengganti rentang tanggal dan seluruh semester tahun ajaran.
+type UpdateAcademicYearRequest struct {
+	StartDate string            `json:"start_date" example:"2025-07-14"`
+	EndDate   string            `json:"end_date" example:"2026-06-26"`
+	Semesters []SemesterRequest `json:"semesters" binding:"dive"`
+}
+
+// SemesterData adalah data semester yang dikirim ke client.
+type SemesterData struct {
+	ID        int64  `json:"id" example:"1"`
+	Term      string `json:"term" example:"Ganjil"`
+	StartDate string `json:"start_date" example:"2025-07-14"`
+	EndDate   string `json:"end_date" example:"2025-12-19"`
+}
+
+// AcademicYearData adalah data tahun ajaran yang dikirim ke client.
+type AcademicYearData struct {
+	ID        int64          `json:"id" example:"1"`
+	Name      string         `json:"name" example:"2025/2026"`
+	StartDate string         `json:"start_date" example:"2025-07-14"`
+	EndDate   string         `json:"end_date" example:"2026-06-26"`
+	Semesters []SemesterData `json:"semesters"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// CalendarEventRequest adalah struktur untuk membuat atau mengubah agenda kalender.
+type CalendarEventRequest struct {
+	Title       string `json:"title" binding:"required,max=255" example:"Libur Maulid Nabi"`
+	Description string `json:"description" example:"Libur nasional"`
+	EventType   string `json:"event_type" binding:"required,oneof=LiburNasional LiburSekolah KegiatanSekolah Ujian" example:"LiburNasional"`
+	StartDate   string `json:"start_date" binding:"required" example:"2025-09-05"`
+	EndDate     string `json:"end_date" example:"2025-09-05"`
+	NonTeaching *bool  `json:"non_teaching" example:"true"` // Default: true kecuali KegiatanSekolah
+	GradeLevel  string `json:"grade_level" example:"XII"`   // Kosong berarti berlaku untuk semua kelas
+}
+
+// CalendarEventQueryFilters adalah parameter query untuk daftar agenda kalender.
+type CalendarEventQueryFilters struct {
+	From       string `form:"from" binding:"required"`
+	To         string `form:"to" binding:"required"`
+	EventType  string `form:"event_type"`
+	GradeLevel string `form:"grade_level"`
+}
+
+// CalendarEventData adalah data agenda kalender yang dikirim ke client.
+type CalendarEventData struct {
+	ID             int64     `json:"id" example:"1"`
+	AcademicYearID *int64    `json:"academic_year_id,omitempty" example:"1"`
+	Title          string    `json:"title" example:"Libur Maulid Nabi"`
+	Description    string    `json:"description,omitempty" example:"Libur nasional"`
+	EventType      string    `json:"event_type" example:"LiburNasional"`
+	StartDate      string    `json:"start_date" example:"2025-09-05"`
+	EndDate        string    `json:"end_date" example:"2025-09-05"`
+	NonTeaching    bool      `json:"non_teaching" example:"true"`
+	GradeLevel     string    `json:"grade_level,omitempty" example:"12"`
+	ICalUID        string    `json:"ical_uid,omitempty" example:"20250905_maulid@google.com"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// SchoolDayQuery adalah parameter query untuk pengecekan hari sekolah.
+type SchoolDayQuery struct {
+	Date    string `form:"date" binding:"required"`
+	ClassID int64  `form:"class_id"`
+}
+
+// SchoolDayData adalah hasil pengecekan hari sekolah.
+type SchoolDayData struct {
+	Date      string `json:"date" example:"2025-09-05"`
+	ClassID   int64  `json:"class_id,omitempty" example:"3"`
+	SchoolDay bool   `json:"school_day" example:"false"`
+}
+
+// JournalRecapQuery adalah parameter query untuk unduhan rekap jurnal bulanan.
+type JournalRecapQuery struct {
+	Month     string `form:"month" binding:"required"` // YYYY-MM
+	TeacherID int64  `form:"teacher_id"`               // Wajib untuk admin dan staf
+}
+
+// AttendanceRecapQuery adalah parameter query untuk rekap presensi bulanan.
+type AttendanceRecapQuery struct {
+	Month   string `form:"month" binding:"required"` // YYYY-MM
+	Role    string `form:"role"`                     // teacher atau staff
+	ClassID int64  `form:"class_id"`                 // Rekap siswa satu kelas
+	Format  string `form:"format"`                   // json, xlsx atau pdf
+}
+
+// CompanyRequest adalah struktur untuk membuat atau mengubah DU/DI mitra PKL.
+type CompanyRequest struct {
+	Name          string `json:"name" binding:"required,max=255" example:"PT Telkom Indonesia Witel Yogyakarta"`
+	Address       string `json:"address" example:"Jl. Yos Sudarso No. 9, Yogyakarta"`
+	Coordinates   string `json:"coordinates" binding:"max=100" example:"-7.7956,110.3695"` // lat,long
+	ContactPerson string `json:"contact_person" binding:"max=255" example:"Bambang Riyanto"`
+	ContactPhone  string `json:"contact_phone" binding:"max=20" example:"081234567890"`
+	ContactEmail  string `json:"contact_email" binding:"max=255" example:"hrd@example.co.id"`
+	Capacity      *int   `json:"capacity" example:"4"` // Kosong berarti tidak dibatasi
+}
+
+// CompanyQueryFilters adalah parameter query untuk daftar DU/DI.
+type CompanyQueryFilters struct {
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+	Search string `form:"search"`
+}
+
+// CompanyData adalah data DU/DI yang dikirim ke client. Placements hanya diisi
+// pada detail DU/DI.
+type CompanyData struct {
+	ID                int64                     `json:"id" example:"1"`
+	Name              string                    `json:"name" example:"PT Telkom Indonesia Witel Yogyakarta"`
+	Address           string                    `json:"address,omitempty" example:"Jl. Yos Sudarso No. 9, Yogyakarta"`
+	Coordinates       string                    `json:"coordinates,omitempty" example:"-7.7956,110.3695"`
+	ContactPerson     string                    `json:"contact_person,omitempty" example:"Bambang Riyanto"`
+	ContactPhone      string                    `json:"contact_phone,omitempty" example:"081234567890"`
+	ContactEmail      string                    `json:"contact_email,omitempty" example:"hrd@example.co.id"`
+	Capacity          *int                      `json:"capacity,omitempty" example:"4"`
+	ActivePlacements  *int                      `json:"active_placements,omitempty" example:"3"`
+	RemainingCapacity *int                      `json:"remaining_capacity,omitempty" example:"1"`
+	CurrentPlacements []InternshipPlacementData `json:"current_placements,omitempty"`
+	PastPlacements    []InternshipPlacementData `json:"past_placements,omitempty"`
+	CreatedAt         time.Time                 `json:"created_at"`
+}
+
+// InternshipPlacementData adalah data penempatan PKL yang dikirim ke client.
+type InternshipPlacementData struct {
+	ID                    int64      `json:"id" example:"10"`
+	StudentID             int64      `json:"student_id" example:"21"`
+	StudentName           string     `json:"student_name,omitempty" example:"Siti Nurhaliza"`
+	StudentNis            string     `json:"student_nis,omitempty" example:"2024001"`
+	ClassName             string     `json:"class_name,omitempty" example:"XI TKJ 1"`
+	CompanyID             int64      `json:"company_id" example:"1"`
+	CompanyName           string     `json:"company_name,omitempty" example:"PT Telkom Indonesia Witel Yogyakarta"`
+	SupervisorTeacherID   *int64     `json:"supervisor_teacher_id,omitempty" example:"5"`
+	SupervisorTeacherName string     `json:"supervisor_teacher_name,omitempty" example:"Siti Aminah, S.Kom"`
+	StartDate             string     `json:"start_date" example:"2025-07-14"`
+	EndDate               string     `json:"end_date,omitempty" example:"2025-12-19"`
+	Status                string     `json:"status" example:"Aktif"`
+	StatusReason          string     `json:"status_reason,omitempty" example:"Siswa pindah sekolah"`
+	StatusChangedAt       *time.Time `json:"status_changed_at,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+// InternshipPlacementRequest adalah struktur untuk menempatkan satu siswa PKL.
+type InternshipPlacementRequest struct {
+	StudentID           int64  `json:"student_id" binding:"required" example:"21"`
+	CompanyID           int64  `json:"company_id" binding:"required" example:"1"`
+	SupervisorTeacherID int64  `json:"supervisor_teacher_id" example:"5"`
+	StartDate           string `json:"start_date" binding:"required" example:"2025-07-14"`
+	EndDate             string `json:"end_date" example:"2025-12-19"`
+}
+
+// UpdateInternshipPlacementRequest adalah struktur untuk mengubah penempatan
+// yang masih Aktif. Supervisor kosong melepas guru pembimbing.
+type UpdateInternshipPlacementRequest struct {
+	CompanyID           int64  `json:"company_id" binding:"required" example:"1"`
+	SupervisorTeacherID int64  `json:"supervisor_teacher_id" example:"5"`
+	StartDate           string `json:"start_date" binding:"required" example:"2025-07-14"`
+	EndDate             string `json:"end_date" example:"2025-12-19"`
+}
+
+// BulkPlacementGroupRequest adalah siswa-siswa yang ditempatkan di satu DU/DI.
+type BulkPlacementGroupRequest struct {
+	CompanyID           int64   `json:"company_id" binding:"required" example:"1"`
+	SupervisorTeacherID int64   `json:"supervisor_teacher_id" example:"5"`
+	StudentIDs          []int64 `json:"student_ids" example:"21,22,23"`
+}
+
+// BulkPlacementRequest adalah struktur untuk menempatkan satu kelas ke beberapa
+// DU/DI sekaligus. Jika student_ids kosong di semua grup, siswa dibagi otomatis.
+type BulkPlacementRequest struct {
+	ClassID   int64                       `json:"class_id" binding:"required" example:"7"`
+	StartDate string                      `json:"start_date" binding:"required" example:"2025-07-14"`
+	EndDate   string                      `json:"end_date" example:"2025-12-19"`
+	Groups    []BulkPlacementGroupRequest `json:"groups" binding:"required,min=1,dive"`
+}
+
+// PlacementStatusRequest adalah struktur untuk menutup penempatan yang Aktif.
+type PlacementStatusRequest struct {
+	Status  string `json:"status" binding:"required,oneof=Selesai Batal" example:"Batal"`
+	Reason  string `json:"reason" example:"Siswa pindah sekolah"` // Wajib untuk Batal
+	EndDate string `json:"end_date" example:"2025-10-03"`
+}
+
+// PlacementQueryFilters adalah parameter query untuk daftar penempatan PKL.
+type PlacementQueryFilters struct {
+	Page                int    `form:"page"`
+	Limit               int    `form:"limit"`
+	CompanyID           int64  `form:"company_id"`
+	StudentID           int64  `form:"student_id"`
+	ClassID             int64  `form:"class_id"`
+	SupervisorTeacherID int64  `form:"supervisor_teacher_id"`
+	Status              string `form:"status"`
+}
+
+// InternshipJournalData adalah struktur data jurnal kegiatan harian PKL.
+type InternshipJournalData struct {
+	ID                    int64      `json:"id" example:"1"`
+	PlacementID           int64      `json:"placement_id" example:"3"`
+	StudentID             int64      `json:"student_id,omitempty" example:"21"`
+	StudentName           string     `json:"student_name,omitempty" example:"Budi Santoso"`
+	StudentNis            string     `json:"student_nis,omitempty" example:"232410001"`
+	ClassName             string     `json:"class_name,omitempty" example:"XII RPL 1"`
+	CompanyName           string     `json:"company_name,omitempty" example:"PT Teknologi Nusantara"`
+	SupervisorTeacherName string     `json:"supervisor_teacher_name,omitempty" example:"Siti Aminah, S.Kom"`
+	ActivityDate          string     `json:"activity_date" example:"2025-09-22"`
+	ActivityDescription   string     `json:"activity_description" example:"Membuat halaman login aplikasi inventaris"`
+	Status                string     `json:"status" example:"Pending"`
+	SupervisorNotes       string     `json:"supervisor_notes,omitempty" example:"Lengkapi dengan hasil pekerjaan"`
+	ApprovedAt            *time.Time `json:"approved_at,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+}
+
+// InternshipJournalRequest adalah struktur untuk mengisi jurnal PKL harian.
+type InternshipJournalRequest struct {
+	ActivityDate        string `json:"activity_date" binding:"required" example:"2025-09-22"`
+	ActivityDescription string `json:"activity_description" binding:"required" example:"Membuat halaman login aplikasi inventaris"`
+}
+
+// UpdateInternshipJournalRequest adalah struktur untuk memperbaiki isi jurnal PKL.
+type UpdateInternshipJournalRequest struct {
+	ActivityDescription string `json:"activity_description" binding:"required" example:"Membuat halaman login dan validasi form"`
+}
+
+// InternshipJournalReviewRequest adalah struktur untuk menyetujui atau menolak
+// beberapa jurnal PKL sekaligus.
+type InternshipJournalReviewRequest struct {
+	JournalIDs []int64 `json:"journal_ids" binding:"required,min=1" example:"1,2,3"`
+	Status     string  `json:"status" binding:"required,oneof=Approved Rejected" example:"Approved"`
+	Notes      string  `json:"notes" example:"Kegiatan sudah sesuai"` // Wajib untuk Rejected
+}
+
+// InternshipJournalQueryFilters adalah parameter query untuk daftar jurnal PKL.
+type InternshipJournalQueryFilters struct {
+	Page                int    `form:"page"`
+	Limit               int    `form:"limit"`
+	PlacementID         int64  `form:"placement_id"`
+	StudentID           int64  `form:"student_id"`
+	SupervisorTeacherID int64  `form:"supervisor_teacher_id"`
+	Status              string `form:"status"`
+	From                string `form:"from"`
+	To                  string `form:"to"`
+}
+
+// InternshipAttendanceData adalah struktur data presensi masuk/pulang siswa PKL.
+type InternshipAttendanceData struct {
+	ID                  int64     `json:"id" example:"1"`
+	PlacementID         int64     `json:"placement_id" example:"3"`
+	StudentName         string    `json:"student_name,omitempty" example:"Budi Santoso"`
+	ClassName           string    `json:"class_name,omitempty" example:"XII RPL 1"`
+	CompanyName         string    `json:"company_name,omitempty" example:"PT Teknologi Nusantara"`
+	AttendanceDate      string    `json:"attendance_date" example:"2025-09-22"`
+	Status              string    `json:"status" example:"Masuk"`
+	Timestamp           time.Time `json:"timestamp"`
+	LocationCoordinates string    `json:"location_coordinates" example:"-7.7956,110.3695"`
+	PhotoPath           string    `json:"photo_path" example:"internship-attendances/3/2025-09-22-masuk.jpg"`
+	DistanceMeters      *int      `json:"distance_meters,omitempty" example:"35"`
+	OutOfRange          bool      `json:"out_of_range" example:"false"`
+}
+
+// InternshipAttendanceQueryFilters adalah parameter query untuk daftar presensi PKL.
+type InternshipAttendanceQueryFilters struct {
+	Page                int    `form:"page"`
+	Limit               int    `form:"limit"`
+	PlacementID         int64  `form:"placement_id"`
+	SupervisorTeacherID int64  `form:"supervisor_teacher_id"`
+	OutOfRange          *bool  `form:"out_of_range"`
+	From                string `form:"from"`
+	To                  string `form:"to"`
+}
+
+// InternshipPresenceQuery adalah parameter query rekap kehadiran PKL.
+type InternshipPresenceQuery struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+// InternshipRubricItemData adalah struktur data satu kompetensi dalam rubrik PKL.
+type InternshipRubricItemData struct {
+	ID         int64  `json:"id" example:"4"`
+	Competency string `json:"competency" example:"Menerapkan K3 di tempat kerja"`
+	Assessor   string `json:"assessor" example:"Mentor"`
+	Weight     int    `json:"weight" example:"20"`
+	SortOrder  int    `json:"sort_order" example:"0"`
+}
+
+// InternshipRubricData adalah struktur data rubrik penilaian PKL satu jurusan.
+type InternshipRubricData struct {
+	ID          int64                      `json:"id" example:"1"`
+	Major       string                     `json:"major" example:"Rekayasa Perangkat Lunak"`
+	Name        string                     `json:"name" example:"Penilaian PKL RPL"`
+	TotalWeight int                        `json:"total_weight" example:"100"`
+	Items       []InternshipRubricItemData `json:"items"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+}
+
+// InternshipRubricItemRequest adalah satu kompetensi pada request rubrik. ID
+// diisi untuk mengubah item lama dan dikosongkan untuk item baru.
+type InternshipRubricItemRequest struct {
+	ID         int64  `json:"id" example:"4"`
+	Competency string `json:"competency" binding:"required" example:"Menerapkan K3 di tempat kerja"`
+	Assessor   string `json:"assessor" binding:"required,oneof=Pembimbing Mentor" example:"Mentor"`
+	Weight     int    `json:"weight" binding:"required,min=1" example:"20"`
+}
+
+// InternshipRubricRequest adalah struktur untuk membuat atau mengganti rubrik PKL.
+type InternshipRubricRequest struct {
+	Major string                        `json:"major" binding:"required" example:"Rekayasa Perangkat Lunak"`
+	Name  string                        `json:"name" binding:"required" example:"Penilaian PKL RPL"`
+	Items []InternshipRubricItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// InternshipScoreRequest adalah nilai untuk satu kompetensi.
+type InternshipScoreRequest struct {
+	RubricItemID int64  `json:"rubric_item_id" binding:"required" example:"4"`
+	Score        int    `json:"score" binding:"min=0,max=100" example:"85"`
+	Notes        string `json:"notes" example:"Selalu memakai APD"`
+}
+
+// InternshipScoresRequest adalah struktur untuk mengirim nilai beberapa kompetensi.
+type InternshipScoresRequest struct {
+	Scores []InternshipScoreRequest `json:"scores" binding:"required,min=1,dive"`
+}
+
+// FileLinkData adalah tautan unduhan file unggahan yang berlaku sementara.
+type FileLinkData struct {
+	Path      string    `json:"path" example:"signatures/4-1758520800.png"`
+	URL       string    `json:"url" example:"http://localhost:3000/api/v1/files/1758521700.c2lnbmF0dXJlcy80LTE3NTg1MjA4MDAucG5n.Xk2...9w"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LeaveRequestData adalah struktur data pengajuan izin.
+type LeaveRequestData struct {
+	ID              int64      `json:"id" example:"1"`
+	UserID          int64      `json:"user_id" example:"12"`
+	FullName        string     `json:"full_name,omitempty" example:"Budi Santoso"`
+	Role            string     `json:"role,omitempty" example:"student"`
+	ClassName       string     `json:"class_name,omitempty" example:"XI RPL 1"`
+	RequestType     string     `json:"request_type" example:"Sakit"`
+	StartDate       string     `json:"start_date" example:"2025-09-22"`
+	EndDate         string     `json:"end_date" example:"2025-09-23"`
+	Reason          string     `json:"reason" example:"Demam, surat dokter terlampir"`
+	AttachmentPath  *string    `json:"attachment_path,omitempty" example:"leave-requests/1/lampiran-1758520800.pdf"`
+	Status          string     `json:"status" example:"Pending"`
+	VerifierID      *int64     `json:"verifier_id,omitempty" example:"3"`
+	VerifierName    string     `json:"verifier_name,omitempty" example:"Siti Aminah, S.Kom"`
+	VerifiedAt      *time.Time `json:"verified_at,omitempty"`
+	RejectionReason string     `json:"rejection_reason,omitempty" example:"Tanggal bertepatan dengan ujian"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// LeaveRequestRequest adalah struktur untuk mengajukan izin.
+type LeaveRequestRequest struct {
+	RequestType string `json:"request_type" binding:"required,oneof=Sakit Izin Cuti DinasLuar" example:"Sakit"`
+	StartDate   string `json:"start_date" binding:"required" example:"2025-09-22"`
+	EndDate     string `json:"end_date" binding:"required" example:"2025-09-23"`
+	Reason      string `json:"reason" binding:"required" example:"Demam, surat dokter terlampir"`
+}
+
+// LeaveRequestReviewRequest adalah struktur untuk menyetujui atau menolak pengajuan izin.
+type LeaveRequestReviewRequest struct {
+	Status          string `json:"status" binding:"required,oneof=Approved Rejected" example:"Rejected"`
+	RejectionReason string `json:"rejection_reason" example:"Tanggal bertepatan dengan ujian"` // Wajib untuk Rejected
+}
+
+// LeaveRequestQueryFilters adalah parameter query untuk daftar pengajuan izin.
+type LeaveRequestQueryFilters struct {
+	Page        int    `form:"page"`
+	Limit       int    `form:"limit"`
+	UserID      int64  `form:"user_id"`
+	Role        string `form:"role"`
+	ClassID     int64  `form:"class_id"`
+	Status      string `form:"status"`
+	RequestType string `form:"request_type"`
+	From        string `form:"from"`
+	To          string `form:"to"`
+}
+
+// MentorLinkRequest adalah struktur untuk membuat tautan penilaian mentor DU/DI.
+type MentorLinkRequest struct {
+	MentorName string `json:"mentor_name" binding:"required" example:"Andi Wijaya"`
+}
+
+// MentorLinkData adalah tautan penilaian sekali pakai untuk mentor DU/DI.
+type MentorLinkData struct {
+	URL        string    `json:"url" example:"http://localhost:3000/api/v1/mentor-assessments/Q2x...9w"`
+	MentorName string    `json:"mentor_name" example:"Andi Wijaya"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// MentorAssessmentData adalah formulir penilaian yang dibuka mentor DU/DI.
+type MentorAssessmentData struct {
+	MentorName string                        `json:"mentor_name" example:"Andi Wijaya"`
+	ExpiresAt  time.Time                     `json:"expires_at"`
+	Assessment *service.InternshipAssessment `json:"assessment"`
+}
+
+// InternshipMonitoringQuery adalah parameter query dashboard pemantauan PKL.
+type InternshipMonitoringQuery struct {
+	SupervisorTeacherID int64 `form:"supervisor_teacher_id"`
+	CompanyID           int64 `form:"company_id"`
+	ClassID             int64 `form:"class_id"`
+	AtRiskOnly          bool  `form:"at_risk_only"`
+}
+
+// AttendanceData adalah struktur data presensi harian masuk/pulang di sekolah.
+type AttendanceData struct {
+	ID                  int64     `json:"id" example:"1"`
+	UserID              int64     `json:"user_id" example:"12"`
+	Username            string    `json:"username,omitempty" example:"siti.aminah"`
+	FullName            string    `json:"full_name,omitempty" example:"Siti Aminah, S.Kom"`
+	Role                string    `json:"role,omitempty" example:"teacher"`
+	Status              string    `json:"status" example:"Masuk"`
+	Timestamp           time.Time `json:"timestamp"`
+	LocationCoordinates *string   `json:"location_coordinates,omitempty" example:"-7.7956,110.3695"`
+	PhotoPath           *string   `json:"photo_path,omitempty" example:"attendances/12/2025-09-22-masuk.jpg"`
+	DeviceName          *string   `json:"device_name,omitempty" example:"gerbang-utama"` // Reader/kiosk yang mencatat tap RFID
+}
+
+// AttendanceQueryFilters adalah parameter query untuk daftar presensi harian.
+type AttendanceQueryFilters struct {
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+	UserID int64  `form:"user_id"`
+	Role   string `form:"role"`
+	Status string `form:"status"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+}
+
+// EnrollCardRequest adalah struktur untuk mendaftarkan kartu RFID siswa.
+type EnrollCardRequest struct {
+	RfidUID string `json:"rfid_uid" binding:"required,max=100" example:"04A1B2C3"`
+}
+
+// RFIDTapRequest adalah data yang dikirim reader RFID saat kartu ditempel.
+type RFIDTapRequest struct {
+	RfidUID string `json:"rfid_uid" binding:"required,max=100" example:"04A1B2C3"`
+}
+
+// AttendanceSyncRequest adalah antrean tap kartu yang dikirim kiosk setelah
+// kembali online. Event divalidasi satu per satu agar satu event rusak tidak
+// menahan seluruh antrean.
+type AttendanceSyncRequest struct {
+	DeviceTime time.Time                    `json:"device_time" binding:"required" example:"2025-09-22T07:45:00+07:00"` // Jam kiosk saat mengirim
+	Events     []AttendanceSyncEventRequest `json:"events" binding:"required,max=500"`
+}
+
+// AttendanceSyncEventRequest adalah satu tap kartu yang dicatat kiosk saat offline.
+type AttendanceSyncEventRequest struct {
+	ClientID  string    `json:"client_id" example:"5f0c6a7e-2b1d-4c4e-9a57-0b8f3f1d2c11"` // ID unik buatan kiosk
+	RfidUID   string    `json:"rfid_uid" example:"04A1B2C3"`
+	Timestamp time.Time `json:"timestamp" example:"2025-09-22T06:58:12+07:00"` // Waktu tap menurut jam kiosk
+}
+
+// AttendanceRuleRequest adalah struktur untuk membuat atau mengubah aturan jam kerja presensi.
+type AttendanceRuleRequest struct {
+	Role         string `json:"role" binding:"required,oneof=admin teacher student staff" example:"teacher"`
+	DayOfWeek    string `json:"day_of_week" binding:"required" example:"Jumat"`
+	StartTime    string `json:"start_time" binding:"required" example:"07:00"`
+	EndTime      string `json:"end_time" binding:"required" example:"11:30"`
+	GraceMinutes int    `json:"grace_minutes" example:"10"` // Toleransi keterlambatan dalam menit
+}
+
+// AttendanceRuleData adalah struktur data aturan jam kerja presensi.
+type AttendanceRuleData struct {
+	ID           int64     `json:"id" example:"5"`
+	Role         string    `json:"role" example:"teacher"`
+	DayOfWeek    string    `json:"day_of_week" example:"Jumat"`
+	StartTime    string    `json:"start_time" example:"07:00"`
+	EndTime      string    `json:"end_time" example:"11:30"`
+	GraceMinutes int       `json:"grace_minutes" example:"10"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// DailyAttendanceQuery adalah parameter query rekap presensi harian.
+type DailyAttendanceQuery struct {
+	From   string `form:"from"`
+	To     string `form:"to"`
+	Role   string `form:"role"`
+	UserID int64  `form:"user_id"`
+	Status string `form:"status"`
+}
@@ -0,0 +1,292 @@
+// internal/handler/file_handler.go
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
+type FileHandler struct {
+	service *service.FileService
+}
+
+func NewFileHandler(service *service.FileService) *FileHandler {
+	return &FileHandler{service: service}
+}
+
+// readUploadFile membaca satu file dari form multipart. Hasilnya nil jika
+// field tidak dikirim; false berarti response error sudah dikirim.
+func readUploadFile(c *gin.Context, field string) ([]byte, bool) {
+	header, err := c.FormFile(field)
+	if err != nil {
+		return nil, true
+	}
+	limit := service.MaxUploadSize()
+	if header.Size > limit {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: fmt.Sprintf("File must not exceed %d MB", limit>>20)})
+		return nil, false
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Failed to read file"})
+		return nil, false
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, limit+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Failed to read file"})
+		return nil, false
+	}
+	return data, true
+}
+
+// readRequiredUpload membaca field "file" yang wajib diisi.
+func readRequiredUpload(c *gin.Context) ([]byte, bool) {
+	data, ok := readUploadFile(c, "file")
+	if ok && data == nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "File is required"})
+		return nil, false
+	}
+	return data, ok
+}
+
+// ToFileLinkDTO mengubah tautan unduhan menjadi data response dengan URL lengkap.
+func ToFileLinkDTO(c *gin.Context, link service.FileLink) FileLinkData {
+	return FileLinkData{
+		Path:      link.Path,
+		URL:       fmt.Sprintf("%s/api/v1/files/%s", baseURL(c), link.Token),
+		ExpiresAt: link.ExpiresAt,
+	}
+}
+
+// Download godoc
+// @Summary      Download an uploaded file
+// @Description  Serves an uploaded file (attendance photo, leave attachment, signature) from a signed download URL. The URL is only issued to users allowed to see the file and expires after FILE_URL_TTL_MINUTES (default 15), so it can be used directly in an img tag without an Authorization header.
+// @Tags         Files
+// @Produce      image/jpeg,image/png,application/pdf
+// @Param        token path string true "Signed download token"
+// @Success      200 {file} file "File content"
+// @Failure      403 {object} GenericResponse "Download link has expired"
+// @Failure      404 {object} GenericResponse "File not found"
+// @Router       /files/{token} [get]
+func (h *FileHandler) Download(c *gin.Context) {
+	file, err := h.service.OpenFile(c.Param("token"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	maxAge := int(time.Until(file.ExpiresAt).Seconds())
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", max(maxAge, 0)))
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s"`, file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+// GetAttendancePhoto godoc
+// @Summary      Get attendance photo link
+// @Description  Returns a signed, expiring download URL for the photo of a daily attendance record. Users can view their own photos; admin and staff can view all.
+// @Tags         Files
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Attendance ID"
+// @Success      200 {object} GenericResponse{data=FileLinkData} "Download link"
+// @Failure      403 {object} GenericResponse "Not your attendance"
+// @Failure      404 {object} GenericResponse "Attendance or photo not found"
+// @Router       /attendances/{id}/photo [get]
+func (h *FileHandler) GetAttendancePhoto(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "attendance")
+	if !ok {
+		return
+	}
+	link, err := h.service.AttendancePhoto(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Download link created successfully",
+		Data:    ToFileLinkDTO(c, *link),
+	})
+}
+
+// UploadSignature godoc
+// @Summary      Upload a teacher's signature
+// @Description  Uploads the signature image (JPEG/PNG, max UPLOAD_MAX_SIZE_MB, default 5 MB) printed on journal recaps, internship certificates and attendance recaps. PNG with a transparent background is recommended. Large images are downscaled to IMAGE_MAX_DIMENSION; the previous signature is deleted.
+// @Tags         Files
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id path int true "Teacher ID"
+// @Param        file formData file true "Signature image"
+// @Success      200 {object} GenericResponse{data=FileLinkData} "Signature uploaded successfully"
+// @Failure      400 {object} GenericResponse "Missing, too large or unsupported file"
+// @Failure      404 {object} GenericResponse "Teacher not found"
+// @Router       /teachers/{id}/signature [put]
+func (h *FileHandler) UploadSignature(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "teacher")
+	if !ok {
+		return
+	}
+	data, ok := readRequiredUpload(c)
+	if !ok {
+		return
+	}
+	link, err := h.service.UploadSignature(id, data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Signature uploaded successfully",
+		Data:    ToFileLinkDTO(c, *link),
+	})
+}
+
+// GetSignature godoc
+// @Summary      Get a teacher's signature link
+// @Description  Returns a signed, expiring download URL for a teacher's signature image.
+// @Tags         Files
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Teacher ID"
+// @Success      200 {object} GenericResponse{data=FileLinkData} "Download link"
+// @Failure      404 {object} GenericResponse "Teacher or signature not found"
+// @Router       /teachers/{id}/signature [get]
+func (h *FileHandler) GetSignature(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "teacher")
+	if !ok {
+		return
+	}
+	link, err := h.service.Signature(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Download link created successfully",
+		Data:    ToFileLinkDTO(c, *link),
+	})
+}
+
+// UploadMySignature godoc
+// @Summary      Upload my signature
+// @Description  Uploads the logged-in teacher's signature image (JPEG/PNG). The previous signature is replaced.
+// @Tags         Files
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file formData file true "Signature image"
+// @Success      200 {object} GenericResponse{data=FileLinkData} "Signature uploaded successfully"
+// @Failure      400 {object} GenericResponse "Missing, too large or unsupported file"
+// @Failure      403 {object} GenericResponse "Only teachers have a signature"
+// @Router       /me/signature [put]
+func (h *FileHandler) UploadMySignature(c *gin.Context) {
+	data, ok := readRequiredUpload(c)
+	if !ok {
+		return
+	}
+	link, err := h.service.UploadMySignature(currentUser(c), data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Signature uploaded successfully",
+		Data:    ToFileLinkDTO(c, *link),
+	})
+}
+
+// GetMySignature godoc
+// @Summary      Get my signature link
+// @Description  Returns a signed, expiring download URL for the logged-in teacher's signature image.
+// @Tags         Files
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} GenericResponse{data=FileLinkData} "Download link"
+// @Failure      404 {object} GenericResponse "No signature uploaded yet"
+// @Router       /me/signature [get]
+func (h *FileHandler) GetMySignature(c *gin.Context) {
+	link, err := h.service.MySignature(currentUser(c))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Download link created successfully",
+		Data:    ToFileLinkDTO(c, *link),
+	})
+}
+
+// UploadLeaveAttachment godoc
+// @Summary      Upload a leave request attachment
+// @Description  Attaches a supporting document (doctor's note, assignment letter) to the logged-in user's pending leave request. Accepts JPEG, PNG or PDF up to UPLOAD_MAX_SIZE_MB; the type is detected from the content. A previous attachment is replaced.
+// @Tags         Files
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id path int true "Leave request ID"
+// @Param        file formData file true "Attachment"
+// @Success      200 {object} GenericResponse{data=FileLinkData} "Attachment uploaded successfully"
+// @Failure      400 {object} GenericResponse "Missing, too large or unsupported file"
+// @Failure      403 {object} GenericResponse "Not your leave request"
+// @Failure      404 {object} GenericResponse "Leave request not found"
+// @Failure      409 {object} GenericResponse "Leave request is no longer pending"
+// @Router       /leave-requests/{id}/attachment [put]
+func (h *FileHandler) UploadLeaveAttachment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "leave request")
+	if !ok {
+		return
+	}
+	data, ok := readRequiredUpload(c)
+	if !ok {
+		return
+	}
+	link, err := h.service.UploadLeaveAttachment(currentUser(c), id, data)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Attachment uploaded successfully",
+		Data:    ToFileLinkDTO(c, *link),
+	})
+}
+
+// GetLeaveAttachment godoc
+// @Summary      Get leave request attachment link
+// @Description  Returns a signed, expiring download URL for a leave request attachment. Available to the requester and to those who can verify the request (admin, staff, and the homeroom teacher for students).
+// @Tags         Files
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "Leave request ID"
+// @Success      200 {object} GenericResponse{data=FileLinkData} "Download link"
+// @Failure      403 {object} GenericResponse "Not your leave request"
+// @Failure      404 {object} GenericResponse "Leave request or attachment not found"
+// @Router       /leave-requests/{id}/attachment [get]
+func (h *FileHandler) GetLeaveAttachment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "leave request")
+	if !ok {
+		return
+	}
+	link, err := h.service.LeaveAttachment(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Download link created successfully",
+		Data:    ToFileLinkDTO(c, *link),
+	})
+}
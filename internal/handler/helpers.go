@@ -0,0 +1,50 @@
+// internal/handler/helpers.go
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// respondError memetakan error dari service ke HTTP status code yang sesuai.
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrValidation):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrForbidden):
+		status = http.StatusForbidden
+	}
+	var detailed service.DetailedError
+	if errors.As(err, &detailed) {
+		c.JSON(status, GenericResponse{Success: false, Message: err.Error(), Data: detailed.Details()})
+		return
+	}
+	c.JSON(status, GenericResponse{Success: false, Message: err.Error()})
+}
+
+// currentUser mengambil user yang sudah diset oleh middleware Authenticate.
+func currentUser(c *gin.Context) *db.UserModel {
+	userCtx, _ := c.Get("user")
+	return userCtx.(*db.UserModel)
+}
+
+// parseIDParam membaca path parameter numerik dan langsung mengirim 400 jika tidak valid.
+func parseIDParam(c *gin.Context, name, label string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid " + label + " ID"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,231 @@
+// internal/handler/internship_assessment_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
+type InternshipAssessmentHandler struct {
+	service *service.InternshipAssessmentService
+}
+
+func NewInternshipAssessmentHandler(service *service.InternshipAssessmentService) *InternshipAssessmentHandler {
+	return &InternshipAssessmentHandler{service: service}
+}
+
+func toScoreInputs(req InternshipScoresRequest) []service.ScoreInput {
+	inputs := make([]service.ScoreInput, 0, len(req.Scores))
+	for _, score := range req.Scores {
+		inputs = append(inputs, service.ScoreInput{
+			RubricItemID: score.RubricItemID,
+			Score:        score.Score,
+			Notes:        score.Notes,
+		})
+	}
+	return inputs
+}
+
+func toMentorAssessmentDTO(form *service.MentorAssessmentForm) MentorAssessmentData {
+	return MentorAssessmentData{
+		MentorName: form.MentorName,
+		ExpiresAt:  form.ExpiresAt,
+		Assessment: form.Assessment,
+	}
+}
+
+// GetAssessment godoc
+// @Summary      Get the PKL assessment of a placement
+// @Description  Retrieves the rubric items of the student's major with the scores given so far. The weighted final score and predicate are included once every item has been scored. Students can only view their own assessment and teachers only those they supervise.
+// @Tags         Internship Assessments
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Internship placement ID"
+// @Success      200 {object} GenericResponse{data=service.InternshipAssessment} "Assessment"
+// @Failure      403 {object} GenericResponse "Not allowed to view this placement"
+// @Failure      404 {object} GenericResponse "Placement or rubric not found"
+// @Router       /internship-assessments/{id} [get]
+func (h *InternshipAssessmentHandler) GetAssessment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "internship placement")
+	if !ok {
+		return
+	}
+
+	assessment, err := h.service.GetAssessment(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship assessment retrieved successfully",
+		Data:    assessment,
+	})
+}
+
+// SaveSupervisorScores godoc
+// @Summary      Score a placement as supervisor teacher
+// @Description  Saves scores (0-100) for the rubric items assessed by the supervisor teacher. Scores can be entered in several steps and changed later.
+// @Tags         Internship Assessments
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Internship placement ID"
+// @Param        scores body InternshipScoresRequest true "Scores"
+// @Success      200 {object} GenericResponse{data=service.InternshipAssessment} "Scores saved successfully"
+// @Failure      400 {object} GenericResponse "Invalid scores"
+// @Failure      403 {object} GenericResponse "Not the supervisor teacher"
+// @Failure      409 {object} GenericResponse "Placement has been cancelled"
+// @Router       /internship-assessments/{id}/supervisor-scores [put]
+func (h *InternshipAssessmentHandler) SaveSupervisorScores(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "internship placement")
+	if !ok {
+		return
+	}
+	var req InternshipScoresRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	assessment, err := h.service.SaveSupervisorScores(int(currentUser(c).ID), id, toScoreInputs(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship scores saved successfully",
+		Data:    assessment,
+	})
+}
+
+// CreateMentorLink godoc
+// @Summary      Create a company mentor assessment link
+// @Description  Creates a one-time link, valid for 14 days, that the company mentor opens without an account to score the Mentor items. Creating a new link invalidates unused older links of the placement.
+// @Tags         Internship Assessments
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Internship placement ID"
+// @Param        mentor body MentorLinkRequest true "Mentor"
+// @Success      201 {object} GenericResponse{data=MentorLinkData} "Mentor link created successfully"
+// @Failure      400 {object} GenericResponse "Rubric has no mentor items"
+// @Failure      403 {object} GenericResponse "Not allowed to manage this placement"
+// @Router       /internship-assessments/{id}/mentor-links [post]
+func (h *InternshipAssessmentHandler) CreateMentorLink(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "internship placement")
+	if !ok {
+		return
+	}
+	var req MentorLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	link, err := h.service.CreateMentorLink(currentUser(c), id, req.MentorName)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Mentor link created successfully",
+		Data: MentorLinkData{
+			URL:        fmt.Sprintf("%s/api/v1/mentor-assessments/%s", baseURL(c), link.Token),
+			MentorName: link.MentorName,
+			ExpiresAt:  link.ExpiresAt,
+		},
+	})
+}
+
+// DownloadCertificate godoc
+// @Summary      Download PKL certificate
+// @Description  Returns the PKL certificate of a placement as PDF with the final score and a score list per competency. Available once every rubric item has been scored.
+// @Tags         Internship Assessments
+// @Security     BearerAuth
+// @Produce      application/pdf
+// @Param        id   path      int  true  "Internship placement ID"
+// @Success      200 {file} file "PDF certificate"
+// @Failure      403 {object} GenericResponse "Not allowed to view this placement"
+// @Failure      409 {object} GenericResponse "Assessment not complete or placement cancelled"
+// @Router       /internship-assessments/{id}/certificate [get]
+func (h *InternshipAssessmentHandler) DownloadCertificate(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "internship placement")
+	if !ok {
+		return
+	}
+
+	certificate, err := h.service.Certificate(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, certificate.FileName))
+	c.Data(http.StatusOK, "application/pdf", certificate.Content)
+}
+
+// GetMentorForm godoc
+// @Summary      Open a company mentor assessment form
+// @Description  Public endpoint for the company mentor, identified by the one-time token from the assessment link. Returns the student, the company and the items the mentor has to score.
+// @Tags         Internship Assessments
+// @Produce      json
+// @Param        token path string true "Mentor link token"
+// @Success      200 {object} GenericResponse{data=MentorAssessmentData} "Assessment form"
+// @Failure      404 {object} GenericResponse "Link not found"
+// @Failure      409 {object} GenericResponse "Link already used or expired"
+// @Router       /mentor-assessments/{token} [get]
+func (h *InternshipAssessmentHandler) GetMentorForm(c *gin.Context) {
+	form, err := h.service.GetMentorForm(c.Param("token"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Mentor assessment form retrieved successfully",
+		Data:    toMentorAssessmentDTO(form),
+	})
+}
+
+// SubmitMentorScores godoc
+// @Summary      Submit company mentor scores
+// @Description  Public endpoint for the company mentor to score every Mentor item of the rubric. The link can only be used once.
+// @Tags         Internship Assessments
+// @Accept       json
+// @Produce      json
+// @Param        token path string true "Mentor link token"
+// @Param        scores body InternshipScoresRequest true "Scores"
+// @Success      200 {object} GenericResponse{data=MentorAssessmentData} "Scores submitted successfully"
+// @Failure      400 {object} GenericResponse "Invalid or incomplete scores"
+// @Failure      404 {object} GenericResponse "Link not found"
+// @Failure      409 {object} GenericResponse "Link already used or expired"
+// @Router       /mentor-assessments/{token} [post]
+func (h *InternshipAssessmentHandler) SubmitMentorScores(c *gin.Context) {
+	var req InternshipScoresRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	form, err := h.service.SubmitMentorScores(c.Param("token"), toScoreInputs(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Mentor scores submitted successfully",
+		Data:    toMentorAssessmentDTO(form),
+	})
+}
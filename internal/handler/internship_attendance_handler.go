@@ -0,0 +1,231 @@
+// internal/handler/internship_attendance_handler.go
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type InternshipAttendanceHandler struct {
+	service *service.InternshipAttendanceService
+}
+
+func NewInternshipAttendanceHandler(service *service.InternshipAttendanceService) *InternshipAttendanceHandler {
+	return &InternshipAttendanceHandler{service: service}
+}
+
+// ToInternshipAttendanceDTO mengubah model presensi PKL menjadi data response.
+func ToInternshipAttendanceDTO(attendance db.InternshipAttendanceModel) InternshipAttendanceData {
+	data := InternshipAttendanceData{
+		ID:                  int64(attendance.ID),
+		PlacementID:         int64(attendance.PlacementID),
+		AttendanceDate:      attendance.AttendanceDate.UTC().Format("2006-01-02"),
+		Status:              string(attendance.Status),
+		Timestamp:           attendance.Timestamp,
+		LocationCoordinates: attendance.LocationCoordinates,
+		PhotoPath:           attendance.PhotoPath,
+		OutOfRange:          attendance.OutOfRange,
+	}
+	if distance, ok := attendance.DistanceMeters(); ok {
+		data.DistanceMeters = &distance
+	}
+	if attendance.RelationsInternshipAttendance.Placement != nil {
+		placement := ToInternshipPlacementDTO(*attendance.Placement())
+		data.StudentName = placement.StudentName
+		data.ClassName = placement.ClassName
+		data.CompanyName = placement.CompanyName
+	}
+	return data
+}
+
+// readAttendanceInput membaca koordinat dan foto dari form presensi.
+func readAttendanceInput(c *gin.Context) (service.InternshipAttendanceInput, bool) {
+	coordinates := c.PostForm("coordinates")
+	if coordinates == "" {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Coordinates are required"})
+		return service.InternshipAttendanceInput{}, false
+	}
+	if _, err := c.FormFile("photo"); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Photo is required"})
+		return service.InternshipAttendanceInput{}, false
+	}
+	photo, ok := readAttendancePhoto(c)
+	if !ok {
+		return service.InternshipAttendanceInput{}, false
+	}
+	return service.InternshipAttendanceInput{Coordinates: coordinates, Photo: photo}, true
+}
+
+// readAttendancePhoto membaca field foto dari form presensi. Hasilnya nil jika
+// foto tidak dikirim.
+func readAttendancePhoto(c *gin.Context) ([]byte, bool) {
+	return readUploadFile(c, "photo")
+}
+
+// CheckIn godoc
+// @Summary      Check in at the internship company
+// @Description  Records the logged-in student's arrival at their active placement with GPS coordinates and a photo (JPEG/PNG, max 5 MB). The distance to the company coordinates is calculated; check-ins outside INTERNSHIP_CHECKIN_RADIUS_METERS are saved but flagged for the supervisor.
+// @Tags         Internship Attendances
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        coordinates formData string true "Current location as lat,long"
+// @Param        photo formData file true "Photo taken at the location"
+// @Success      201 {object} GenericResponse{data=InternshipAttendanceData} "Checked in successfully"
+// @Failure      400 {object} GenericResponse "Invalid coordinates or photo, or outside the placement period"
+// @Failure      404 {object} GenericResponse "No active internship placement"
+// @Failure      409 {object} GenericResponse "Already checked in today"
+// @Router       /internship-attendances/check-in [post]
+func (h *InternshipAttendanceHandler) CheckIn(c *gin.Context) {
+	input, ok := readAttendanceInput(c)
+	if !ok {
+		return
+	}
+
+	attendance, err := h.service.CheckIn(int(currentUser(c).ID), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Checked in successfully",
+		Data:    ToInternshipAttendanceDTO(*attendance),
+	})
+}
+
+// CheckOut godoc
+// @Summary      Check out from the internship company
+// @Description  Records the logged-in student's departure. The student must have checked in on the same day. Out-of-range check-outs are flagged like check-ins.
+// @Tags         Internship Attendances
+// @Security     BearerAuth
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        coordinates formData string true "Current location as lat,long"
+// @Param        photo formData file true "Photo taken at the location"
+// @Success      201 {object} GenericResponse{data=InternshipAttendanceData} "Checked out successfully"
+// @Failure      400 {object} GenericResponse "Invalid coordinates or photo"
+// @Failure      409 {object} GenericResponse "Not checked in or already checked out today"
+// @Router       /internship-attendances/check-out [post]
+func (h *InternshipAttendanceHandler) CheckOut(c *gin.Context) {
+	input, ok := readAttendanceInput(c)
+	if !ok {
+		return
+	}
+
+	attendance, err := h.service.CheckOut(int(currentUser(c).ID), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Checked out successfully",
+		Data:    ToInternshipAttendanceDTO(*attendance),
+	})
+}
+
+// GetAttendances godoc
+// @Summary      Get internship attendances
+// @Description  Retrieves internship check-ins and check-outs with pagination, newest first. Use out_of_range=true to review flagged attendances. Teachers only see the students they supervise.
+// @Tags         Internship Attendances
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        placement_id query int false "Filter by placement"
+// @Param        supervisor_teacher_id query int false "Filter by supervisor teacher"
+// @Param        out_of_range query bool false "Filter by out-of-range flag"
+// @Param        from query string false "Date from (YYYY-MM-DD)"
+// @Param        to query string false "Date to (YYYY-MM-DD)"
+// @Success      200 {object}  GenericResponse{data=[]InternshipAttendanceData} "List of internship attendances"
+// @Failure      400 {object}  GenericResponse "Invalid filter"
+// @Router       /internship-attendances [get]
+func (h *InternshipAttendanceHandler) GetAttendances(c *gin.Context) {
+	var filters InternshipAttendanceQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+
+	attendances, total, err := h.service.GetAttendances(currentUser(c), service.InternshipAttendanceFilters{
+		PlacementID:         filters.PlacementID,
+		SupervisorTeacherID: filters.SupervisorTeacherID,
+		OutOfRange:          filters.OutOfRange,
+		From:                filters.From,
+		To:                  filters.To,
+		Page:                filters.Page,
+		Limit:               filters.Limit,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]InternshipAttendanceData, 0, len(attendances))
+	for _, attendance := range attendances {
+		data = append(data, ToInternshipAttendanceDTO(attendance))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Internship attendances retrieved successfully",
+		"data":    data,
+		"meta": gin.H{
+			"page":       filters.Page,
+			"limit":      filters.Limit,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(filters.Limit))),
+		},
+	})
+}
+
+// GetPresence godoc
+// @Summary      Get daily presence of an internship placement
+// @Description  Reports presence per day for a placement: Hadir when the student checked in, Libur for days outside SCHOOL_WEEK_DAYS without a check-in, otherwise Tidak Hadir. Defaults to the placement start until today or the placement end; the range may span at most 400 days. Students can only view their own placement and teachers only those they supervise.
+// @Tags         Internship Attendances
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Internship placement ID"
+// @Param        from query string false "Start date (YYYY-MM-DD)"
+// @Param        to query string false "End date (YYYY-MM-DD)"
+// @Success      200 {object} GenericResponse{data=service.InternshipPresenceReport} "Presence report"
+// @Failure      400 {object} GenericResponse "Invalid date range"
+// @Failure      403 {object} GenericResponse "Not allowed to view this placement"
+// @Failure      404 {object} GenericResponse "Internship placement not found"
+// @Router       /internship-attendances/placements/{id} [get]
+func (h *InternshipAttendanceHandler) GetPresence(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "internship placement")
+	if !ok {
+		return
+	}
+	var query InternshipPresenceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	report, err := h.service.GetPresence(currentUser(c), id, query.From, query.To)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship presence retrieved successfully",
+		Data:    report,
+	})
+}
@@ -0,0 +1,326 @@
+// internal/handler/internship_journal_handler.go
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type InternshipJournalHandler struct {
+	service *service.InternshipJournalService
+}
+
+func NewInternshipJournalHandler(service *service.InternshipJournalService) *InternshipJournalHandler {
+	return &InternshipJournalHandler{service: service}
+}
+
+// ToInternshipJournalDTO mengubah model jurnal PKL menjadi data response.
+func ToInternshipJournalDTO(journal db.InternshipJournalModel) InternshipJournalData {
+	data := InternshipJournalData{
+		ID:                  int64(journal.ID),
+		PlacementID:         int64(journal.PlacementID),
+		ActivityDate:        journal.ActivityDate.UTC().Format("2006-01-02"),
+		ActivityDescription: journal.ActivityDescription,
+		Status:              string(journal.Status),
+		CreatedAt:           journal.CreatedAt,
+		UpdatedAt:           journal.UpdatedAt,
+	}
+	if notes, ok := journal.SupervisorNotes(); ok {
+		data.SupervisorNotes = notes
+	}
+	if approvedAt, ok := journal.ApprovedAt(); ok {
+		data.ApprovedAt = &approvedAt
+	}
+	if journal.RelationsInternshipJournal.Placement != nil {
+		placement := ToInternshipPlacementDTO(*journal.Placement())
+		data.StudentID = placement.StudentID
+		data.StudentName = placement.StudentName
+		data.StudentNis = placement.StudentNis
+		data.ClassName = placement.ClassName
+		data.CompanyName = placement.CompanyName
+		data.SupervisorTeacherName = placement.SupervisorTeacherName
+	}
+	return data
+}
+
+func toInternshipJournalDTOs(journals []db.InternshipJournalModel) []InternshipJournalData {
+	data := make([]InternshipJournalData, 0, len(journals))
+	for _, journal := range journals {
+		data = append(data, ToInternshipJournalDTO(journal))
+	}
+	return data
+}
+
+// bindJournalFilters membaca parameter query daftar jurnal PKL beserta paginasinya.
+func bindJournalFilters(c *gin.Context) (service.InternshipJournalFilters, bool) {
+	var query InternshipJournalQueryFilters
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return service.InternshipJournalFilters{}, false
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+	return service.InternshipJournalFilters{
+		PlacementID:         query.PlacementID,
+		StudentID:           query.StudentID,
+		SupervisorTeacherID: query.SupervisorTeacherID,
+		Status:              query.Status,
+		From:                query.From,
+		To:                  query.To,
+		Page:                query.Page,
+		Limit:               query.Limit,
+	}, true
+}
+
+func respondJournalList(c *gin.Context, message string, journals []db.InternshipJournalModel, total int, filters service.InternshipJournalFilters) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"data":    toInternshipJournalDTOs(journals),
+		"meta": gin.H{
+			"page":       filters.Page,
+			"limit":      filters.Limit,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(filters.Limit))),
+		},
+	})
+}
+
+// GetJournals godoc
+// @Summary      Get internship journals
+// @Description  Retrieves students' daily internship journals with pagination, newest first. Teachers only see the students they supervise.
+// @Tags         Internship Journals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        placement_id query int false "Filter by placement"
+// @Param        student_id query int false "Filter by student"
+// @Param        supervisor_teacher_id query int false "Filter by supervisor teacher"
+// @Param        status query string false "Filter by status (Pending, Approved, Rejected)"
+// @Param        from query string false "Activity date from (YYYY-MM-DD)"
+// @Param        to query string false "Activity date to (YYYY-MM-DD)"
+// @Success      200 {object}  GenericResponse{data=[]InternshipJournalData} "List of internship journals"
+// @Failure      400 {object}  GenericResponse "Invalid filter"
+// @Router       /internship-journals [get]
+func (h *InternshipJournalHandler) GetJournals(c *gin.Context) {
+	filters, ok := bindJournalFilters(c)
+	if !ok {
+		return
+	}
+
+	journals, total, err := h.service.GetJournals(currentUser(c), filters)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJournalList(c, "Internship journals retrieved successfully", journals, total, filters)
+}
+
+// GetPendingQueue godoc
+// @Summary      Get pending internship journals
+// @Description  Retrieves journals waiting for review, oldest first. Teachers get the queue of the students they supervise; admins and staff can filter by supervisor_teacher_id.
+// @Tags         Internship Journals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        placement_id query int false "Filter by placement"
+// @Param        student_id query int false "Filter by student"
+// @Param        supervisor_teacher_id query int false "Filter by supervisor teacher (admin/staff)"
+// @Param        from query string false "Activity date from (YYYY-MM-DD)"
+// @Param        to query string false "Activity date to (YYYY-MM-DD)"
+// @Success      200 {object}  GenericResponse{data=[]InternshipJournalData} "Pending internship journals"
+// @Failure      400 {object}  GenericResponse "Invalid filter"
+// @Router       /internship-journals/pending [get]
+func (h *InternshipJournalHandler) GetPendingQueue(c *gin.Context) {
+	filters, ok := bindJournalFilters(c)
+	if !ok {
+		return
+	}
+
+	journals, total, err := h.service.GetPendingQueue(currentUser(c), filters)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJournalList(c, "Pending internship journals retrieved successfully", journals, total, filters)
+}
+
+// GetMyJournals godoc
+// @Summary      Get my internship journals
+// @Description  Retrieves the logged-in student's internship journals from all placements, newest first.
+// @Tags         Me
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        placement_id query int false "Filter by placement"
+// @Param        status query string false "Filter by status (Pending, Approved, Rejected)"
+// @Param        from query string false "Activity date from (YYYY-MM-DD)"
+// @Param        to query string false "Activity date to (YYYY-MM-DD)"
+// @Success      200 {object}  GenericResponse{data=[]InternshipJournalData} "List of internship journals"
+// @Failure      403 {object}  GenericResponse "User is not a student"
+// @Router       /me/internship-journals [get]
+func (h *InternshipJournalHandler) GetMyJournals(c *gin.Context) {
+	filters, ok := bindJournalFilters(c)
+	if !ok {
+		return
+	}
+
+	journals, total, err := h.service.GetMyJournals(int(currentUser(c).ID), filters)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondJournalList(c, "Internship journals retrieved successfully", journals, total, filters)
+}
+
+// GetJournalByID godoc
+// @Summary      Get an internship journal
+// @Description  Retrieves a single internship journal. Students can only view their own journals and teachers only those of students they supervise.
+// @Tags         Internship Journals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Internship journal ID"
+// @Success      200 {object} GenericResponse{data=InternshipJournalData} "Internship journal details"
+// @Failure      403 {object} GenericResponse "Not allowed to view this journal"
+// @Failure      404 {object} GenericResponse "Internship journal not found"
+// @Router       /internship-journals/{id} [get]
+func (h *InternshipJournalHandler) GetJournalByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "internship journal")
+	if !ok {
+		return
+	}
+
+	journal, err := h.service.GetJournalByID(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship journal retrieved successfully",
+		Data:    ToInternshipJournalDTO(*journal),
+	})
+}
+
+// SubmitJournal godoc
+// @Summary      Submit an internship journal
+// @Description  Submits the logged-in student's daily activity for their active placement. Only one journal per date is allowed, within the placement period and not in the future.
+// @Tags         Internship Journals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        journal body InternshipJournalRequest true "Internship journal"
+// @Success      201 {object} GenericResponse{data=InternshipJournalData} "Internship journal submitted successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body or date outside the placement period"
+// @Failure      404 {object} GenericResponse "No active internship placement"
+// @Failure      409 {object} GenericResponse "Journal for the date already exists"
+// @Router       /internship-journals [post]
+func (h *InternshipJournalHandler) SubmitJournal(c *gin.Context) {
+	var req InternshipJournalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	journal, err := h.service.SubmitJournal(int(currentUser(c).ID), service.InternshipJournalInput{
+		ActivityDate:        req.ActivityDate,
+		ActivityDescription: req.ActivityDescription,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Internship journal submitted successfully",
+		Data:    ToInternshipJournalDTO(*journal),
+	})
+}
+
+// UpdateJournal godoc
+// @Summary      Update an internship journal
+// @Description  Revises the description of the student's own journal that has not been approved. A rejected journal goes back to Pending.
+// @Tags         Internship Journals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Internship journal ID"
+// @Param        journal body UpdateInternshipJournalRequest true "Internship journal"
+// @Success      200 {object} GenericResponse{data=InternshipJournalData} "Internship journal updated successfully"
+// @Failure      403 {object} GenericResponse "Not the owner of the journal"
+// @Failure      409 {object} GenericResponse "Journal already approved or placement no longer active"
+// @Router       /internship-journals/{id} [put]
+func (h *InternshipJournalHandler) UpdateJournal(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "internship journal")
+	if !ok {
+		return
+	}
+	var req UpdateInternshipJournalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	journal, err := h.service.UpdateJournal(int(currentUser(c).ID), id, req.ActivityDescription)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship journal updated successfully",
+		Data:    ToInternshipJournalDTO(*journal),
+	})
+}
+
+// ReviewJournals godoc
+// @Summary      Review internship journals
+// @Description  Approves or rejects several pending journals at once. Only the supervisor teacher can review and notes are required when rejecting; if any journal is not eligible nothing is changed.
+// @Tags         Internship Journals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        review body InternshipJournalReviewRequest true "Review decision"
+// @Success      200 {object} GenericResponse{data=[]InternshipJournalData} "Internship journals reviewed successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      403 {object} GenericResponse "Journal of a student not supervised by the teacher"
+// @Failure      409 {object} GenericResponse "Journal already reviewed"
+// @Router       /internship-journals/review [post]
+func (h *InternshipJournalHandler) ReviewJournals(c *gin.Context) {
+	var req InternshipJournalReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	journals, err := h.service.ReviewJournals(int(currentUser(c).ID), service.InternshipJournalReview{
+		JournalIDs: req.JournalIDs,
+		Status:     req.Status,
+		Notes:      req.Notes,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship journals reviewed successfully",
+		Data:    toInternshipJournalDTOs(journals),
+	})
+}
@@ -0,0 +1,56 @@
+// internal/handler/internship_monitoring_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
+type InternshipMonitoringHandler struct {
+	service *service.InternshipMonitoringService
+}
+
+func NewInternshipMonitoringHandler(service *service.InternshipMonitoringService) *InternshipMonitoringHandler {
+	return &InternshipMonitoringHandler{service: service}
+}
+
+// GetMonitoring godoc
+// @Summary      PKL monitoring dashboard
+// @Description  Aggregates every active placement: working days elapsed vs. total, journals submitted vs. expected, pending approvals, last check-in and an at-risk flag when no journal was written for INTERNSHIP_AT_RISK_DAYS working days (default 3). Supervisor teachers see their own students; admin and staff (PKL coordinator) see all placements with a recap per supervisor. Working days follow SCHOOL_WEEK_DAYS.
+// @Tags         Internship Monitoring
+// @Security     BearerAuth
+// @Produce      json
+// @Param        supervisor_teacher_id query int false "Filter by supervisor teacher (admin/staff)"
+// @Param        company_id query int false "Filter by company"
+// @Param        class_id query int false "Filter by the student's current class"
+// @Param        at_risk_only query bool false "Only list at-risk students (summary counts still cover all placements)"
+// @Success      200 {object} GenericResponse{data=service.InternshipMonitoring} "Monitoring dashboard"
+// @Failure      400 {object} GenericResponse "Invalid query parameters"
+// @Router       /internship-monitoring [get]
+func (h *InternshipMonitoringHandler) GetMonitoring(c *gin.Context) {
+	var query InternshipMonitoringQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	monitoring, err := h.service.GetMonitoring(currentUser(c), service.InternshipMonitoringFilters{
+		SupervisorTeacherID: query.SupervisorTeacherID,
+		CompanyID:           query.CompanyID,
+		ClassID:             query.ClassID,
+		AtRiskOnly:          query.AtRiskOnly,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship monitoring retrieved successfully",
+		Data:    monitoring,
+	})
+}
@@ -0,0 +1,352 @@
+// internal/handler/internship_placement_handler.go
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type InternshipPlacementHandler struct {
+	service *service.InternshipPlacementService
+}
+
+func NewInternshipPlacementHandler(service *service.InternshipPlacementService) *InternshipPlacementHandler {
+	return &InternshipPlacementHandler{service: service}
+}
+
+// ToInternshipPlacementDTO mengubah model penempatan PKL menjadi data response.
+func ToInternshipPlacementDTO(placement db.InternshipPlacementModel) InternshipPlacementData {
+	data := InternshipPlacementData{
+		ID:        int64(placement.ID),
+		StudentID: int64(placement.StudentID),
+		CompanyID: int64(placement.CompanyID),
+		StartDate: placement.StartDate.UTC().Format("2006-01-02"),
+		Status:    string(placement.Status),
+		CreatedAt: placement.CreatedAt,
+	}
+	if reason, ok := placement.StatusReason(); ok {
+		data.StatusReason = reason
+	}
+	if changedAt, ok := placement.StatusChangedAt(); ok {
+		data.StatusChangedAt = &changedAt
+	}
+	if endDate, ok := placement.EndDate(); ok {
+		data.EndDate = endDate.UTC().Format("2006-01-02")
+	}
+	if teacherID, ok := placement.SupervisorTeacherID(); ok {
+		id := int64(teacherID)
+		data.SupervisorTeacherID = &id
+	}
+	if placement.RelationsInternshipPlacement.Student != nil {
+		student := placement.Student()
+		data.StudentName = student.FullName
+		data.StudentNis = student.Nis
+		if class, ok := student.CurrentClass(); ok {
+			data.ClassName = class.ClassName
+		}
+	}
+	if placement.RelationsInternshipPlacement.Company != nil {
+		data.CompanyName = placement.Company().Name
+	}
+	if teacher, ok := placement.SupervisorTeacher(); ok {
+		data.SupervisorTeacherName = teacher.FullName
+	}
+	return data
+}
+
+func toInternshipPlacementDTOs(placements []db.InternshipPlacementModel) []InternshipPlacementData {
+	data := make([]InternshipPlacementData, 0, len(placements))
+	for _, placement := range placements {
+		data = append(data, ToInternshipPlacementDTO(placement))
+	}
+	return data
+}
+
+// GetPlacements godoc
+// @Summary      Get internship placements
+// @Description  Retrieves internship placements with pagination, newest first. Teachers only see the students they supervise.
+// @Tags         Internship Placements
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        company_id query int false "Filter by company"
+// @Param        student_id query int false "Filter by student"
+// @Param        class_id query int false "Filter by the student's current class"
+// @Param        supervisor_teacher_id query int false "Filter by supervisor teacher"
+// @Param        status query string false "Filter by status (Aktif, Selesai, Batal)"
+// @Success      200 {object}  GenericResponse{data=[]InternshipPlacementData} "List of placements"
+// @Failure      400 {object}  GenericResponse "Invalid filter"
+// @Router       /internship-placements [get]
+func (h *InternshipPlacementHandler) GetPlacements(c *gin.Context) {
+	var filters PlacementQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+
+	placements, total, err := h.service.GetPlacements(currentUser(c), service.PlacementFilters{
+		CompanyID:           filters.CompanyID,
+		StudentID:           filters.StudentID,
+		ClassID:             filters.ClassID,
+		SupervisorTeacherID: filters.SupervisorTeacherID,
+		Status:              filters.Status,
+		Page:                filters.Page,
+		Limit:               filters.Limit,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Internship placements retrieved successfully",
+		"data":    toInternshipPlacementDTOs(placements),
+		"meta": gin.H{
+			"page":       filters.Page,
+			"limit":      filters.Limit,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(filters.Limit))),
+		},
+	})
+}
+
+// GetPlacementByID godoc
+// @Summary      Get an internship placement
+// @Description  Retrieves a single internship placement.
+// @Tags         Internship Placements
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Placement ID"
+// @Success      200 {object} GenericResponse{data=InternshipPlacementData} "Placement details"
+// @Failure      403 {object} GenericResponse "Not your supervised student"
+// @Failure      404 {object} GenericResponse "Placement not found"
+// @Router       /internship-placements/{id} [get]
+func (h *InternshipPlacementHandler) GetPlacementByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "placement")
+	if !ok {
+		return
+	}
+
+	placement, err := h.service.GetPlacementByID(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship placement retrieved successfully",
+		Data:    ToInternshipPlacementDTO(*placement),
+	})
+}
+
+// CreatePlacement godoc
+// @Summary      Place a student at a company
+// @Description  Creates an active placement. A student can only have one active placement and the company's capacity must not be exceeded; conflicts are returned in data.
+// @Tags         Internship Placements
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        placement body InternshipPlacementRequest true "Placement"
+// @Success      201 {object} GenericResponse{data=InternshipPlacementData} "Placement created"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      404 {object} GenericResponse "Student, company or teacher not found"
+// @Failure      409 {object} GenericResponse{data=[]service.PlacementConflict} "Active placement or capacity conflict"
+// @Router       /internship-placements [post]
+func (h *InternshipPlacementHandler) CreatePlacement(c *gin.Context) {
+	var req InternshipPlacementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	placement, err := h.service.CreatePlacement(service.PlacementInput{
+		StudentID:           req.StudentID,
+		CompanyID:           req.CompanyID,
+		SupervisorTeacherID: req.SupervisorTeacherID,
+		StartDate:           req.StartDate,
+		EndDate:             req.EndDate,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Internship placement created successfully",
+		Data:    ToInternshipPlacementDTO(*placement),
+	})
+}
+
+// BulkAssign godoc
+// @Summary      Place a class at companies
+// @Description  Places students of a class at one or more companies for the same period in a single transaction. If student_ids is empty in every group, active students without an active placement are distributed round-robin over the companies within their remaining capacity. Any conflict (student outside the class, active placement, capacity) cancels the whole assignment.
+// @Tags         Internship Placements
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        assignment body BulkPlacementRequest true "Bulk assignment"
+// @Success      201 {object} GenericResponse{data=[]InternshipPlacementData} "Placements created"
+// @Failure      400 {object} GenericResponse "Invalid request body or not enough capacity"
+// @Failure      404 {object} GenericResponse "Class, student, company or teacher not found"
+// @Failure      409 {object} GenericResponse{data=[]service.PlacementConflict} "Placement conflicts"
+// @Router       /internship-placements/bulk [post]
+func (h *InternshipPlacementHandler) BulkAssign(c *gin.Context) {
+	var req BulkPlacementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	groups := make([]service.BulkPlacementGroup, 0, len(req.Groups))
+	for _, group := range req.Groups {
+		groups = append(groups, service.BulkPlacementGroup{
+			CompanyID:           group.CompanyID,
+			SupervisorTeacherID: group.SupervisorTeacherID,
+			StudentIDs:          group.StudentIDs,
+		})
+	}
+	placements, err := h.service.BulkAssign(service.BulkPlacementInput{
+		ClassID:   req.ClassID,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Groups:    groups,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Internship placements created successfully",
+		Data:    toInternshipPlacementDTOs(placements),
+	})
+}
+
+// UpdatePlacement godoc
+// @Summary      Update an internship placement
+// @Description  Changes the company, supervisor teacher and period of an active placement. Moving to another company checks its capacity.
+// @Tags         Internship Placements
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Placement ID"
+// @Param        placement body UpdateInternshipPlacementRequest true "Placement"
+// @Success      200 {object} GenericResponse{data=InternshipPlacementData} "Placement updated"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      404 {object} GenericResponse "Placement, company or teacher not found"
+// @Failure      409 {object} GenericResponse "Placement is no longer active or company is full"
+// @Router       /internship-placements/{id} [put]
+func (h *InternshipPlacementHandler) UpdatePlacement(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "placement")
+	if !ok {
+		return
+	}
+	var req UpdateInternshipPlacementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	placement, err := h.service.UpdatePlacement(id, service.PlacementInput{
+		CompanyID:           req.CompanyID,
+		SupervisorTeacherID: req.SupervisorTeacherID,
+		StartDate:           req.StartDate,
+		EndDate:             req.EndDate,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship placement updated successfully",
+		Data:    ToInternshipPlacementDTO(*placement),
+	})
+}
+
+// ChangeStatus godoc
+// @Summary      Finish or cancel an internship placement
+// @Description  Moves an active placement to Selesai or Batal. A reason is required to cancel. The end date defaults to today (or the planned end date when finishing).
+// @Tags         Internship Placements
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Placement ID"
+// @Param        status body PlacementStatusRequest true "New status"
+// @Success      200 {object} GenericResponse{data=InternshipPlacementData} "Placement status changed"
+// @Failure      400 {object} GenericResponse "Invalid request body or missing reason"
+// @Failure      404 {object} GenericResponse "Placement not found"
+// @Failure      409 {object} GenericResponse "Placement is no longer active"
+// @Router       /internship-placements/{id}/status [put]
+func (h *InternshipPlacementHandler) ChangeStatus(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "placement")
+	if !ok {
+		return
+	}
+	var req PlacementStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	placement, err := h.service.ChangeStatus(id, service.PlacementStatusInput{
+		Status:  req.Status,
+		Reason:  req.Reason,
+		EndDate: req.EndDate,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship placement status changed successfully",
+		Data:    ToInternshipPlacementDTO(*placement),
+	})
+}
+
+// DeletePlacement godoc
+// @Summary      Delete an internship placement
+// @Description  Deletes a placement created by mistake. Placements with internship journals cannot be deleted; cancel them instead.
+// @Tags         Internship Placements
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Placement ID"
+// @Success      200 {object} GenericResponse "Placement deleted"
+// @Failure      404 {object} GenericResponse "Placement not found"
+// @Failure      409 {object} GenericResponse "Placement has internship journals"
+// @Router       /internship-placements/{id} [delete]
+func (h *InternshipPlacementHandler) DeletePlacement(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "placement")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePlacement(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship placement deleted successfully",
+	})
+}
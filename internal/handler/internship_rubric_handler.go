@@ -0,0 +1,211 @@
+// internal/handler/internship_rubric_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type InternshipRubricHandler struct {
+	service *service.InternshipRubricService
+}
+
+func NewInternshipRubricHandler(service *service.InternshipRubricService) *InternshipRubricHandler {
+	return &InternshipRubricHandler{service: service}
+}
+
+// ToInternshipRubricDTO mengubah model rubrik PKL menjadi data response.
+func ToInternshipRubricDTO(rubric db.InternshipRubricModel) InternshipRubricData {
+	data := InternshipRubricData{
+		ID:        int64(rubric.ID),
+		Major:     rubric.Major,
+		Name:      rubric.Name,
+		Items:     []InternshipRubricItemData{},
+		CreatedAt: rubric.CreatedAt,
+		UpdatedAt: rubric.UpdatedAt,
+	}
+	if rubric.RelationsInternshipRubric.Items != nil {
+		for _, item := range rubric.Items() {
+			data.TotalWeight += item.Weight
+			data.Items = append(data.Items, InternshipRubricItemData{
+				ID:         int64(item.ID),
+				Competency: item.Competency,
+				Assessor:   string(item.Assessor),
+				Weight:     item.Weight,
+				SortOrder:  item.SortOrder,
+			})
+		}
+	}
+	return data
+}
+
+func toRubricInput(req InternshipRubricRequest) service.RubricInput {
+	input := service.RubricInput{Major: req.Major, Name: req.Name}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, service.RubricItemInput{
+			ID:         item.ID,
+			Competency: item.Competency,
+			Assessor:   item.Assessor,
+			Weight:     item.Weight,
+		})
+	}
+	return input
+}
+
+// GetRubrics godoc
+// @Summary      Get internship assessment rubrics
+// @Description  Retrieves the PKL assessment rubric of every major with its competency items.
+// @Tags         Internship Rubrics
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object}  GenericResponse{data=[]InternshipRubricData} "List of rubrics"
+// @Failure      500 {object}  GenericResponse "Internal Server Error"
+// @Router       /internship-rubrics [get]
+func (h *InternshipRubricHandler) GetRubrics(c *gin.Context) {
+	rubrics, err := h.service.GetRubrics()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]InternshipRubricData, 0, len(rubrics))
+	for _, rubric := range rubrics {
+		data = append(data, ToInternshipRubricDTO(rubric))
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship rubrics retrieved successfully",
+		Data:    data,
+	})
+}
+
+// GetRubricByID godoc
+// @Summary      Get an internship assessment rubric
+// @Description  Retrieves a single rubric with its competency items.
+// @Tags         Internship Rubrics
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Rubric ID"
+// @Success      200 {object} GenericResponse{data=InternshipRubricData} "Rubric details"
+// @Failure      404 {object} GenericResponse "Rubric not found"
+// @Router       /internship-rubrics/{id} [get]
+func (h *InternshipRubricHandler) GetRubricByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "rubric")
+	if !ok {
+		return
+	}
+
+	rubric, err := h.service.GetRubricByID(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship rubric retrieved successfully",
+		Data:    ToInternshipRubricDTO(*rubric),
+	})
+}
+
+// CreateRubric godoc
+// @Summary      Create an internship assessment rubric
+// @Description  Creates the PKL rubric for a major (must match the class major). Each item is scored 0-100 by either the supervisor teacher (Pembimbing) or the company mentor (Mentor); the final score is the weighted average.
+// @Tags         Internship Rubrics
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        rubric body InternshipRubricRequest true "Rubric"
+// @Success      201 {object} GenericResponse{data=InternshipRubricData} "Rubric created successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      409 {object} GenericResponse "Rubric for the major already exists"
+// @Router       /internship-rubrics [post]
+func (h *InternshipRubricHandler) CreateRubric(c *gin.Context) {
+	var req InternshipRubricRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	rubric, err := h.service.CreateRubric(toRubricInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Internship rubric created successfully",
+		Data:    ToInternshipRubricDTO(*rubric),
+	})
+}
+
+// UpdateRubric godoc
+// @Summary      Update an internship assessment rubric
+// @Description  Replaces a rubric. Items with an id are updated, items without id are added and omitted items are removed. Items that have been scored cannot be removed or moved to another assessor.
+// @Tags         Internship Rubrics
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Rubric ID"
+// @Param        rubric body InternshipRubricRequest true "Rubric"
+// @Success      200 {object} GenericResponse{data=InternshipRubricData} "Rubric updated successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      404 {object} GenericResponse "Rubric not found"
+// @Failure      409 {object} GenericResponse "Scored items cannot be changed this way"
+// @Router       /internship-rubrics/{id} [put]
+func (h *InternshipRubricHandler) UpdateRubric(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "rubric")
+	if !ok {
+		return
+	}
+	var req InternshipRubricRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	rubric, err := h.service.UpdateRubric(id, toRubricInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship rubric updated successfully",
+		Data:    ToInternshipRubricDTO(*rubric),
+	})
+}
+
+// DeleteRubric godoc
+// @Summary      Delete an internship assessment rubric
+// @Description  Deletes a rubric that has never been used for scoring.
+// @Tags         Internship Rubrics
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Rubric ID"
+// @Success      200 {object} GenericResponse "Rubric deleted successfully"
+// @Failure      404 {object} GenericResponse "Rubric not found"
+// @Failure      409 {object} GenericResponse "Rubric has been used for scoring"
+// @Router       /internship-rubrics/{id} [delete]
+func (h *InternshipRubricHandler) DeleteRubric(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "rubric")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRubric(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Internship rubric deleted successfully",
+	})
+}
@@ -0,0 +1,99 @@
+// internal/handler/journal_compliance_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type JournalComplianceHandler struct {
+	service *service.JournalComplianceService
+}
+
+func NewJournalComplianceHandler(service *service.JournalComplianceService) *JournalComplianceHandler {
+	return &JournalComplianceHandler{service: service}
+}
+
+// ToJournalReminderDTO mengubah model pengingat jurnal menjadi data response.
+func ToJournalReminderDTO(reminder db.JournalReminderModel) JournalReminderData {
+	data := JournalReminderData{
+		ID:           int64(reminder.ID),
+		TeachingDate: reminder.TeachingDate.UTC().Format("2006-01-02"),
+		Lesson:       ScheduleData{ID: int64(reminder.ScheduleID)},
+		CreatedAt:    reminder.CreatedAt,
+	}
+	if reminder.RelationsJournalReminder.Schedule != nil {
+		data.Lesson = ToScheduleDTO(*reminder.Schedule())
+	}
+	return data
+}
+
+// GetReport godoc
+// @Summary      Get the teaching journal compliance report
+// @Description  Expands every schedule into its lesson dates within the range (skipping school holidays and dates after today) and compares them with the teaching journals written. Returns per-teacher compliance percentages, lowest first, with the list of missing journals. Lessons covered by a substitute count for the substitute; lessons of a teacher on approved leave without a substitute are excused. The range may span at most 184 days.
+// @Tags         Reports
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string true "Start date (YYYY-MM-DD)"
+// @Param        to query string true "End date (YYYY-MM-DD)"
+// @Param        teacher_id query int false "Teacher ID"
+// @Param        class_id query int false "Class ID"
+// @Success      200 {object} GenericResponse{data=service.JournalComplianceReport} "Compliance report"
+// @Failure      400 {object} GenericResponse "Invalid date range"
+// @Router       /reports/journal-compliance [get]
+func (h *JournalComplianceHandler) GetReport(c *gin.Context) {
+	var filters JournalComplianceQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	report, err := h.service.GetReport(service.JournalComplianceFilters{
+		From:      filters.From,
+		To:        filters.To,
+		TeacherID: filters.TeacherID,
+		ClassID:   filters.ClassID,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Journal compliance report generated successfully",
+		Data:    report,
+	})
+}
+
+// GetMyReminders godoc
+// @Summary      Get my journal reminders
+// @Description  Lists the lessons for which the logged-in teacher has not written a teaching journal yet. Reminders are created by a daily job (JOURNAL_REMINDER_TIME) and removed once the journal is written.
+// @Tags         Reports
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} GenericResponse{data=[]JournalReminderData} "Journal reminders"
+// @Failure      403 {object} GenericResponse "User is not a teacher"
+// @Router       /me/journal-reminders [get]
+func (h *JournalComplianceHandler) GetMyReminders(c *gin.Context) {
+	reminders, err := h.service.GetMyReminders(int(currentUser(c).ID))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]JournalReminderData, 0, len(reminders))
+	for _, reminder := range reminders {
+		data = append(data, ToJournalReminderDTO(reminder))
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Journal reminders retrieved successfully",
+		Data:    data,
+	})
+}
@@ -0,0 +1,48 @@
+// internal/handler/journal_recap_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
+type JournalRecapHandler struct {
+	service *service.JournalRecapService
+}
+
+func NewJournalRecapHandler(service *service.JournalRecapService) *JournalRecapHandler {
+	return &JournalRecapHandler{service: service}
+}
+
+// DownloadMonthlyRecap godoc
+// @Summary      Download monthly teaching journal recap
+// @Description  Returns a PDF recap of a teacher's teaching journals for one month, grouped by class and subject, with signature blocks for the teacher (using the teacher's signature image) and the principal (PRINCIPAL_NAME, PRINCIPAL_NIP, PRINCIPAL_SIGNATURE_PATH). Teachers download their own recap; admin and staff must pass teacher_id.
+// @Tags         Teaching Journals
+// @Security     BearerAuth
+// @Produce      application/pdf
+// @Param        month query string true "Month (YYYY-MM)"
+// @Param        teacher_id query int false "Teacher ID (required for admin and staff)"
+// @Success      200 {file} file "PDF recap"
+// @Failure      400 {object} GenericResponse "Invalid month or missing teacher"
+// @Failure      403 {object} GenericResponse "Not your recap"
+// @Failure      404 {object} GenericResponse "Teacher not found"
+// @Router       /teaching-journals/recap [get]
+func (h *JournalRecapHandler) DownloadMonthlyRecap(c *gin.Context) {
+	var query JournalRecapQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	recap, err := h.service.MonthlyRecap(currentUser(c), query.TeacherID, query.Month)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, recap.FileName))
+	c.Data(http.StatusOK, "application/pdf", recap.Content)
+}
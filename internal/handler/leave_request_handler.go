@@ -0,0 +1,316 @@
+// internal/handler/leave_request_handler.go
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type LeaveRequestHandler struct {
+	service *service.LeaveRequestService
+}
+
+func NewLeaveRequestHandler(service *service.LeaveRequestService) *LeaveRequestHandler {
+	return &LeaveRequestHandler{service: service}
+}
+
+// ToLeaveRequestDTO mengubah model pengajuan izin menjadi data response.
+func ToLeaveRequestDTO(leave db.LeaveRequestModel) LeaveRequestData {
+	data := LeaveRequestData{
+		ID:          int64(leave.ID),
+		UserID:      int64(leave.UserID),
+		RequestType: string(leave.RequestType),
+		StartDate:   leave.StartDate.UTC().Format("2006-01-02"),
+		EndDate:     leave.EndDate.UTC().Format("2006-01-02"),
+		Reason:      leave.Reason,
+		Status:      string(leave.Status),
+		CreatedAt:   leave.CreatedAt,
+	}
+	if attachmentPath, ok := leave.AttachmentPath(); ok {
+		data.AttachmentPath = &attachmentPath
+	}
+	if verifierID, ok := leave.VerifierID(); ok {
+		id := int64(verifierID)
+		data.VerifierID = &id
+	}
+	if verifiedAt, ok := leave.VerifiedAt(); ok {
+		data.VerifiedAt = &verifiedAt
+	}
+	if reason, ok := leave.RejectionReason(); ok {
+		data.RejectionReason = reason
+	}
+	if leave.RelationsLeaveRequest.Requestor != nil {
+		user := leave.Requestor()
+		data.FullName = user.Username
+		data.Role = string(user.Role)
+		if teacher, ok := user.Teacher(); ok {
+			data.FullName = teacher.FullName
+		} else if student, ok := user.Student(); ok {
+			data.FullName = student.FullName
+			if class, ok := student.CurrentClass(); ok {
+				data.ClassName = class.ClassName
+			}
+		}
+	}
+	if verifier, ok := leave.Verifier(); ok {
+		data.VerifierName = verifier.Username
+		if teacher, ok := verifier.Teacher(); ok {
+			data.VerifierName = teacher.FullName
+		}
+	}
+	return data
+}
+
+func toLeaveRequestDTOs(leaves []db.LeaveRequestModel) []LeaveRequestData {
+	data := make([]LeaveRequestData, 0, len(leaves))
+	for _, leave := range leaves {
+		data = append(data, ToLeaveRequestDTO(leave))
+	}
+	return data
+}
+
+// bindLeaveRequestFilters membaca parameter query daftar pengajuan izin beserta paginasinya.
+func bindLeaveRequestFilters(c *gin.Context) (service.LeaveRequestFilters, bool) {
+	var query LeaveRequestQueryFilters
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return service.LeaveRequestFilters{}, false
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = 10
+	}
+	return service.LeaveRequestFilters{
+		UserID:      query.UserID,
+		Role:        query.Role,
+		ClassID:     query.ClassID,
+		Status:      query.Status,
+		RequestType: query.RequestType,
+		From:        query.From,
+		To:          query.To,
+		Page:        query.Page,
+		Limit:       query.Limit,
+	}, true
+}
+
+func respondLeaveRequestList(c *gin.Context, leaves []db.LeaveRequestModel, total int, filters service.LeaveRequestFilters) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Leave requests retrieved successfully",
+		"data":    toLeaveRequestDTOs(leaves),
+		"meta": gin.H{
+			"page":       filters.Page,
+			"limit":      filters.Limit,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(filters.Limit))),
+		},
+	})
+}
+
+// GetLeaveRequests godoc
+// @Summary      Get leave requests
+// @Description  Retrieves leave requests for verification with pagination, latest start date first. Admins and staff see all requests; teachers only see requests of students in the classes they are homeroom teacher of. The from/to range matches requests overlapping it.
+// @Tags         Leave Requests
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        user_id query int false "Filter by requester"
+// @Param        role query string false "Filter by requester role (teacher, staff, student)"
+// @Param        class_id query int false "Filter by student's class"
+// @Param        status query string false "Filter by status (Pending, Approved, Rejected, Cancelled)"
+// @Param        request_type query string false "Filter by type (Sakit, Izin, Cuti, DinasLuar)"
+// @Param        from query string false "Date from (YYYY-MM-DD)"
+// @Param        to query string false "Date to (YYYY-MM-DD)"
+// @Success      200 {object}  GenericResponse{data=[]LeaveRequestData} "List of leave requests"
+// @Failure      400 {object}  GenericResponse "Invalid filter"
+// @Router       /leave-requests [get]
+func (h *LeaveRequestHandler) GetLeaveRequests(c *gin.Context) {
+	filters, ok := bindLeaveRequestFilters(c)
+	if !ok {
+		return
+	}
+
+	leaves, total, err := h.service.GetLeaveRequests(currentUser(c), filters)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondLeaveRequestList(c, leaves, total, filters)
+}
+
+// GetMyLeaveRequests godoc
+// @Summary      Get my leave requests
+// @Description  Retrieves the logged-in user's leave requests, latest start date first.
+// @Tags         Me
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        status query string false "Filter by status (Pending, Approved, Rejected, Cancelled)"
+// @Param        request_type query string false "Filter by type (Sakit, Izin, Cuti, DinasLuar)"
+// @Param        from query string false "Date from (YYYY-MM-DD)"
+// @Param        to query string false "Date to (YYYY-MM-DD)"
+// @Success      200 {object}  GenericResponse{data=[]LeaveRequestData} "List of leave requests"
+// @Failure      400 {object}  GenericResponse "Invalid filter"
+// @Router       /me/leave-requests [get]
+func (h *LeaveRequestHandler) GetMyLeaveRequests(c *gin.Context) {
+	filters, ok := bindLeaveRequestFilters(c)
+	if !ok {
+		return
+	}
+
+	leaves, total, err := h.service.GetMyLeaveRequests(int(currentUser(c).ID), filters)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	respondLeaveRequestList(c, leaves, total, filters)
+}
+
+// GetLeaveRequestByID godoc
+// @Summary      Get a leave request
+// @Description  Retrieves a single leave request. Available to the requester and to those who can verify it.
+// @Tags         Leave Requests
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Leave request ID"
+// @Success      200 {object} GenericResponse{data=LeaveRequestData} "Leave request details"
+// @Failure      403 {object} GenericResponse "Not allowed to view this leave request"
+// @Failure      404 {object} GenericResponse "Leave request not found"
+// @Router       /leave-requests/{id} [get]
+func (h *LeaveRequestHandler) GetLeaveRequestByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "leave request")
+	if !ok {
+		return
+	}
+
+	leave, err := h.service.GetLeaveRequestByID(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Leave request retrieved successfully",
+		Data:    ToLeaveRequestDTO(*leave),
+	})
+}
+
+// SubmitLeaveRequest godoc
+// @Summary      Submit a leave request
+// @Description  Submits a leave request for the logged-in user with status Pending. Students can only request Sakit or Izin. The end date must not be before the start date, and the dates must not overlap another pending or approved request of the same user. A supporting document can be attached afterwards via PUT /leave-requests/{id}/attachment.
+// @Tags         Leave Requests
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        leave body LeaveRequestRequest true "Leave request"
+// @Success      201 {object} GenericResponse{data=LeaveRequestData} "Leave request submitted successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body or date range"
+// @Failure      409 {object} GenericResponse "Overlaps another leave request"
+// @Router       /leave-requests [post]
+func (h *LeaveRequestHandler) SubmitLeaveRequest(c *gin.Context) {
+	var req LeaveRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	leave, err := h.service.SubmitLeaveRequest(currentUser(c), service.LeaveRequestInput{
+		RequestType: req.RequestType,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Leave request submitted successfully",
+		Data:    ToLeaveRequestDTO(*leave),
+	})
+}
+
+// CancelLeaveRequest godoc
+// @Summary      Cancel a leave request
+// @Description  Cancels the logged-in user's own pending leave request. The request is kept with status Cancelled.
+// @Tags         Leave Requests
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Leave request ID"
+// @Success      200 {object} GenericResponse{data=LeaveRequestData} "Leave request cancelled successfully"
+// @Failure      403 {object} GenericResponse "Not your leave request"
+// @Failure      404 {object} GenericResponse "Leave request not found"
+// @Failure      409 {object} GenericResponse "Leave request is no longer pending"
+// @Router       /leave-requests/{id}/cancel [post]
+func (h *LeaveRequestHandler) CancelLeaveRequest(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "leave request")
+	if !ok {
+		return
+	}
+
+	leave, err := h.service.CancelLeaveRequest(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Leave request cancelled successfully",
+		Data:    ToLeaveRequestDTO(*leave),
+	})
+}
+
+// ReviewLeaveRequest godoc
+// @Summary      Review a leave request
+// @Description  Approves or rejects a pending leave request and records the verifier and verification time. Admins and staff can verify any request; homeroom teachers can verify requests of students in their class. Nobody can verify their own request. A rejection reason is required when rejecting.
+// @Tags         Leave Requests
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Leave request ID"
+// @Param        review body LeaveRequestReviewRequest true "Review decision"
+// @Success      200 {object} GenericResponse{data=LeaveRequestData} "Leave request reviewed successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body or missing rejection reason"
+// @Failure      403 {object} GenericResponse "Not allowed to verify this leave request"
+// @Failure      404 {object} GenericResponse "Leave request not found"
+// @Failure      409 {object} GenericResponse "Leave request is no longer pending"
+// @Router       /leave-requests/{id}/review [post]
+func (h *LeaveRequestHandler) ReviewLeaveRequest(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "leave request")
+	if !ok {
+		return
+	}
+	var req LeaveRequestReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	leave, err := h.service.ReviewLeaveRequest(currentUser(c), id, service.LeaveRequestReview{
+		Status:          req.Status,
+		RejectionReason: req.RejectionReason,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Leave request reviewed successfully",
+		Data:    ToLeaveRequestDTO(*leave),
+	})
+}
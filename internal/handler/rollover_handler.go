@@ -0,0 +1,102 @@
+// internal/handler/rollover_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
+type RolloverHandler struct {
+	service *service.RolloverService
+}
+
+func NewRolloverHandler(service *service.RolloverService) *RolloverHandler {
+	return &RolloverHandler{service: service}
+}
+
+func toRolloverParams(req RolloverRequest) service.RolloverParams {
+	params := service.RolloverParams{
+		SourceAcademicYear: req.SourceAcademicYear,
+		TargetAcademicYear: req.TargetAcademicYear,
+		ClassOverrides:     make(map[int64]string, len(req.ClassOverrides)),
+	}
+	for _, o := range req.ClassOverrides {
+		params.ClassOverrides[o.SourceClassID] = o.TargetClassName
+	}
+	for _, e := range req.Exceptions {
+		params.Exceptions = append(params.Exceptions, service.RolloverException{
+			StudentID:       e.StudentID,
+			Result:          e.Result,
+			TargetClassName: e.TargetClassName,
+		})
+	}
+	return params
+}
+
+// PreviewRollover godoc
+// @Summary      Preview academic year rollover
+// @Description  Proposes next-year classes and maps every active student (promote, repeat, graduate) without changing any data.
+// @Tags         Academic Year
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        rollover body RolloverRequest true "Rollover parameters"
+// @Success      200 {object} GenericResponse{data=service.RolloverPlan} "Rollover preview"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "No classes in source academic year"
+// @Failure      409 {object} GenericResponse "Rollover already applied"
+// @Router       /academic-years/rollover/preview [post]
+func (h *RolloverHandler) PreviewRollover(c *gin.Context) {
+	var req RolloverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	plan, err := h.service.Preview(toRolloverParams(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Rollover preview generated successfully",
+		Data:    plan,
+	})
+}
+
+// ApplyRollover godoc
+// @Summary      Apply academic year rollover
+// @Description  Creates the next-year classes, moves students and marks final-grade students as LULUS in a single transaction.
+// @Tags         Academic Year
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        rollover body RolloverRequest true "Rollover parameters"
+// @Success      200 {object} GenericResponse{data=service.RolloverPlan} "Rollover applied"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      409 {object} GenericResponse "Rollover already applied"
+// @Router       /academic-years/rollover/apply [post]
+func (h *RolloverHandler) ApplyRollover(c *gin.Context) {
+	var req RolloverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	plan, err := h.service.Apply(toRolloverParams(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Academic year rollover applied successfully",
+		Data:    plan,
+	})
+}
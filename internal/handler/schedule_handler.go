@@ -0,0 +1,286 @@
+// internal/handler/schedule_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type ScheduleHandler struct {
+	service *service.ScheduleService
+}
+
+func NewScheduleHandler(service *service.ScheduleService) *ScheduleHandler {
+	return &ScheduleHandler{service: service}
+}
+
+// ToScheduleDTO mengubah model jadwal menjadi data response.
+func ToScheduleDTO(schedule db.ScheduleModel) ScheduleData {
+	data := ScheduleData{
+		ID:        int64(schedule.ID),
+		ClassID:   int64(schedule.ClassID),
+		SubjectID: int64(schedule.SubjectID),
+		TeacherID: int64(schedule.TeacherID),
+		DayOfWeek: string(schedule.DayOfWeek),
+		StartTime: schedule.StartTime.UTC().Format("15:04"),
+		EndTime:   schedule.EndTime.UTC().Format("15:04"),
+	}
+	if room, ok := schedule.Room(); ok {
+		data.Room = room
+	}
+	if schedule.RelationsSchedule.Class != nil {
+		data.ClassName = schedule.Class().ClassName
+	}
+	if schedule.RelationsSchedule.Subject != nil {
+		data.SubjectCode = schedule.Subject().SubjectCode
+		data.SubjectName = schedule.Subject().SubjectName
+	}
+	if schedule.RelationsSchedule.Teacher != nil {
+		data.TeacherName = schedule.Teacher().FullName
+	}
+	return data
+}
+
+func toScheduleDTOs(schedules []db.ScheduleModel) []ScheduleData {
+	data := make([]ScheduleData, 0, len(schedules))
+	for _, schedule := range schedules {
+		data = append(data, ToScheduleDTO(schedule))
+	}
+	return data
+}
+
+func toScheduleInput(req ScheduleRequest) service.ScheduleInput {
+	return service.ScheduleInput{
+		ClassID:   req.ClassID,
+		SubjectID: req.SubjectID,
+		TeacherID: req.TeacherID,
+		DayOfWeek: req.DayOfWeek,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Room:      req.Room,
+	}
+}
+
+// GetSchedules godoc
+// @Summary      Get schedules
+// @Description  Lists weekly schedule slots filtered by class, teacher, subject, day, room or academic year.
+// @Tags         Schedules
+// @Security     BearerAuth
+// @Produce      json
+// @Param        class_id query int false "Class ID"
+// @Param        teacher_id query int false "Teacher ID"
+// @Param        subject_id query int false "Subject ID"
+// @Param        day_of_week query string false "Day of week (Senin..Minggu)"
+// @Param        room query string false "Room"
+// @Param        academic_year query string false "Academic year, e.g. 2024/2025"
+// @Success      200 {object} GenericResponse{data=[]ScheduleData} "List of schedules"
+// @Router       /schedules [get]
+func (h *ScheduleHandler) GetSchedules(c *gin.Context) {
+	var filters ScheduleQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	schedules, err := h.service.GetSchedules(service.ScheduleFilters{
+		ClassID:      filters.ClassID,
+		TeacherID:    filters.TeacherID,
+		SubjectID:    filters.SubjectID,
+		DayOfWeek:    filters.DayOfWeek,
+		Room:         filters.Room,
+		AcademicYear: filters.AcademicYear,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Schedules retrieved successfully",
+		Data:    toScheduleDTOs(schedules),
+	})
+}
+
+// GetScheduleByID godoc
+// @Summary      Get a schedule slot
+// @Description  Retrieves a single schedule slot.
+// @Tags         Schedules
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Schedule ID"
+// @Success      200 {object} GenericResponse{data=ScheduleData} "Schedule details"
+// @Failure      404 {object} GenericResponse "Schedule not found"
+// @Router       /schedules/{id} [get]
+func (h *ScheduleHandler) GetScheduleByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "schedule")
+	if !ok {
+		return
+	}
+
+	schedule, err := h.service.GetScheduleByID(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Schedule retrieved successfully",
+		Data:    ToScheduleDTO(*schedule),
+	})
+}
+
+// CreateSchedule godoc
+// @Summary      Create a schedule slot
+// @Description  Creates a weekly schedule slot. Rejects slots overlapping the same teacher, class or room on the same day (conflicting entries are returned in data) and slots outside the class curriculum.
+// @Tags         Schedules
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        schedule body ScheduleRequest true "Schedule slot"
+// @Success      201 {object} GenericResponse{data=ScheduleData} "Schedule created"
+// @Failure      400 {object} GenericResponse "Invalid request or curriculum violation"
+// @Failure      409 {object} GenericResponse{data=[]service.ScheduleConflict} "Schedule conflict"
+// @Router       /schedules [post]
+func (h *ScheduleHandler) CreateSchedule(c *gin.Context) {
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	schedule, err := h.service.CreateSchedule(toScheduleInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Schedule created successfully",
+		Data:    ToScheduleDTO(*schedule),
+	})
+}
+
+// UpdateSchedule godoc
+// @Summary      Update a schedule slot
+// @Description  Replaces a schedule slot with the same conflict and curriculum checks as creation.
+// @Tags         Schedules
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id       path int             true "Schedule ID"
+// @Param        schedule body ScheduleRequest true "Schedule slot"
+// @Success      200 {object} GenericResponse{data=ScheduleData} "Schedule updated"
+// @Failure      404 {object} GenericResponse "Schedule not found"
+// @Failure      409 {object} GenericResponse{data=[]service.ScheduleConflict} "Schedule conflict"
+// @Router       /schedules/{id} [put]
+func (h *ScheduleHandler) UpdateSchedule(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "schedule")
+	if !ok {
+		return
+	}
+
+	var req ScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	schedule, err := h.service.UpdateSchedule(id, toScheduleInput(req))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Schedule updated successfully",
+		Data:    ToScheduleDTO(*schedule),
+	})
+}
+
+// DeleteSchedule godoc
+// @Summary      Delete a schedule slot
+// @Description  Deletes a schedule slot that has no teaching journals.
+// @Tags         Schedules
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Schedule ID"
+// @Success      200 {object} GenericResponse "Schedule deleted"
+// @Failure      404 {object} GenericResponse "Schedule not found"
+// @Failure      409 {object} GenericResponse "Schedule has teaching journals"
+// @Router       /schedules/{id} [delete]
+func (h *ScheduleHandler) DeleteSchedule(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "schedule")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSchedule(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Schedule deleted successfully",
+	})
+}
+
+// ReplaceClassTimetable godoc
+// @Summary      Bulk upsert a class timetable
+// @Description  Saves the whole weekly timetable of a class in one transaction: entries with an id are updated, entries without an id are created and missing slots are removed.
+// @Tags         Schedules
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id        path int                   true "Class ID"
+// @Param        timetable body ClassTimetableRequest true "Timetable entries"
+// @Success      200 {object} GenericResponse{data=[]ScheduleData} "Timetable saved"
+// @Failure      400 {object} GenericResponse "Invalid request or curriculum violation"
+// @Failure      404 {object} GenericResponse "Class not found"
+// @Failure      409 {object} GenericResponse{data=[]service.ScheduleConflict} "Schedule conflict"
+// @Router       /classes/{id}/schedules [put]
+func (h *ScheduleHandler) ReplaceClassTimetable(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "class")
+	if !ok {
+		return
+	}
+
+	var req ClassTimetableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	entries := make([]service.ScheduleInput, 0, len(req.Entries))
+	for _, e := range req.Entries {
+		entries = append(entries, service.ScheduleInput{
+			ID:        e.ID,
+			SubjectID: e.SubjectID,
+			TeacherID: e.TeacherID,
+			DayOfWeek: e.DayOfWeek,
+			StartTime: e.StartTime,
+			EndTime:   e.EndTime,
+			Room:      e.Room,
+		})
+	}
+
+	schedules, err := h.service.ReplaceClassTimetable(id, entries)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Class timetable saved successfully",
+		Data:    toScheduleDTOs(schedules),
+	})
+}
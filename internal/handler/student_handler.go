@@ -0,0 +1,173 @@
+// internal/handler/student_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type StudentHandler struct {
+	service *service.StudentService
+}
+
+func NewStudentHandler(service *service.StudentService) *StudentHandler {
+	return &StudentHandler{service: service}
+}
+
+// ToStudentDTO mengubah model siswa menjadi data ringkas untuk response.
+func ToStudentDTO(student db.StudentModel) StudentData {
+	data := StudentData{
+		ID:       int64(student.ID),
+		Nis:      student.Nis,
+		FullName: student.FullName,
+		Status:   string(student.Status),
+	}
+	if classID, ok := student.CurrentClassID(); ok {
+		id := int64(classID)
+		data.CurrentClassID = &id
+	}
+	if class, ok := student.CurrentClass(); ok {
+		data.CurrentClassName = class.ClassName
+	}
+	if uid, ok := student.RfidUID(); ok {
+		data.RfidUID = uid
+	}
+	return data
+}
+
+// GetClassHistory godoc
+// @Summary      Get a student's class history
+// @Description  Lists every class the student has been enrolled in, newest first.
+// @Tags         Students
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Student ID"
+// @Success      200 {object} GenericResponse{data=[]service.ClassHistoryEntry} "Class history"
+// @Failure      404 {object} GenericResponse "Student not found"
+// @Router       /students/{id}/class-history [get]
+func (h *StudentHandler) GetClassHistory(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "student")
+	if !ok {
+		return
+	}
+
+	history, err := h.service.GetClassHistory(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Class history retrieved successfully",
+		Data:    history,
+	})
+}
+
+// ChangeClass godoc
+// @Summary      Move a student to another class
+// @Description  Updates the student's current class and records the change in the class history.
+// @Tags         Students
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id    path  int                        true  "Student ID"
+// @Param        class body  ChangeStudentClassRequest  true  "Target class"
+// @Success      200 {object} GenericResponse{data=StudentData} "Student class changed"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "Student or class not found"
+// @Router       /students/{id}/class [put]
+func (h *StudentHandler) ChangeClass(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "student")
+	if !ok {
+		return
+	}
+
+	var req ChangeStudentClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	student, err := h.service.ChangeClass(id, int(req.ClassID))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Student class changed successfully",
+		Data:    ToStudentDTO(*student),
+	})
+}
+
+// EnrollCard godoc
+// @Summary      Enroll a student's RFID card
+// @Description  Registers or replaces the RFID card used at the gate readers. The UID is stored in upper case without spaces and can only belong to one student.
+// @Tags         Students
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path  int                true  "Student ID"
+// @Param        card body  EnrollCardRequest  true  "RFID card"
+// @Success      200 {object} GenericResponse{data=StudentData} "Card enrolled"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "Student not found"
+// @Failure      409 {object} GenericResponse "Card already enrolled to another student"
+// @Router       /students/{id}/rfid [put]
+func (h *StudentHandler) EnrollCard(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "student")
+	if !ok {
+		return
+	}
+
+	var req EnrollCardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	student, err := h.service.EnrollCard(id, req.RfidUID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "RFID card enrolled successfully",
+		Data:    ToStudentDTO(*student),
+	})
+}
+
+// RemoveCard godoc
+// @Summary      Remove a student's RFID card
+// @Description  Unlinks the RFID card from the student, for example when the card is lost.
+// @Tags         Students
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path  int  true  "Student ID"
+// @Success      200 {object} GenericResponse "Card removed"
+// @Failure      404 {object} GenericResponse "Student not found or no card enrolled"
+// @Router       /students/{id}/rfid [delete]
+func (h *StudentHandler) RemoveCard(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "student")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveCard(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "RFID card removed successfully",
+	})
+}
@@ -0,0 +1,350 @@
+// internal/handler/subject_handler.go
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type SubjectHandler struct {
+	service *service.SubjectService
+}
+
+func NewSubjectHandler(service *service.SubjectService) *SubjectHandler {
+	return &SubjectHandler{service: service}
+}
+
+// ToSubjectDTO mengubah model mata pelajaran menjadi data response.
+func ToSubjectDTO(subject db.SubjectModel) SubjectData {
+	data := SubjectData{
+		ID:          int64(subject.ID),
+		SubjectCode: subject.SubjectCode,
+		SubjectName: subject.SubjectName,
+	}
+	if subject.RelationsSubject.Curriculum != nil {
+		for _, item := range subject.Curriculum() {
+			data.Curriculum = append(data.Curriculum, ToCurriculumItemDTO(item))
+		}
+	}
+	return data
+}
+
+// ToCurriculumItemDTO mengubah model kurikulum menjadi data response.
+func ToCurriculumItemDTO(item db.CurriculumSubjectModel) CurriculumItemData {
+	data := CurriculumItemData{
+		ID:          int64(item.ID),
+		SubjectID:   int64(item.SubjectID),
+		GradeLevel:  item.GradeLevel,
+		Major:       item.Major,
+		WeeklyHours: item.WeeklyHours,
+	}
+	if item.RelationsCurriculumSubject.Subject != nil {
+		data.SubjectCode = item.Subject().SubjectCode
+		data.SubjectName = item.Subject().SubjectName
+	}
+	return data
+}
+
+// GetSubjects godoc
+// @Summary      Get all subjects
+// @Description  Retrieves subjects with pagination and search by code or name.
+// @Tags         Subjects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        search query string false "Search by subject code or name"
+// @Success      200 {object}  GenericResponse{data=[]SubjectData} "List of subjects"
+// @Failure      500 {object}  GenericResponse "Internal Server Error"
+// @Router       /subjects [get]
+func (h *SubjectHandler) GetSubjects(c *gin.Context) {
+	var filters SubjectQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+
+	subjects, total, err := h.service.GetSubjects(filters.Page, filters.Limit, filters.Search)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	data := make([]SubjectData, 0, len(subjects))
+	for _, subject := range subjects {
+		data = append(data, ToSubjectDTO(subject))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Subjects retrieved successfully",
+		"data":    data,
+		"meta": gin.H{
+			"page":       filters.Page,
+			"limit":      filters.Limit,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(filters.Limit))),
+		},
+	})
+}
+
+// GetSubjectByID godoc
+// @Summary      Get a single subject by ID
+// @Description  Retrieves a subject together with its curriculum mapping.
+// @Tags         Subjects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Subject ID"
+// @Success      200 {object} GenericResponse{data=SubjectData} "Subject details"
+// @Failure      404 {object} GenericResponse "Subject not found"
+// @Router       /subjects/{id} [get]
+func (h *SubjectHandler) GetSubjectByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "subject")
+	if !ok {
+		return
+	}
+
+	subject, err := h.service.GetSubjectByID(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Subject retrieved successfully",
+		Data:    ToSubjectDTO(*subject),
+	})
+}
+
+// CreateSubject godoc
+// @Summary      Create a new subject
+// @Description  Creates a subject with a unique subject code. Only accessible by admins.
+// @Tags         Subjects
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        subject body CreateSubjectRequest true "New Subject Data"
+// @Success      201 {object} GenericResponse{data=SubjectData} "Subject created successfully"
+// @Failure      400 {object} GenericResponse "Invalid request body"
+// @Failure      409 {object} GenericResponse "Subject code already exists"
+// @Router       /subjects [post]
+func (h *SubjectHandler) CreateSubject(c *gin.Context) {
+	var req CreateSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	subject, err := h.service.CreateSubject(req.SubjectCode, req.SubjectName)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Subject created successfully",
+		Data:    ToSubjectDTO(*subject),
+	})
+}
+
+// UpdateSubject godoc
+// @Summary      Update a subject
+// @Description  Updates a subject's code or name.
+// @Tags         Subjects
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id      path int                  true "Subject ID"
+// @Param        subject body UpdateSubjectRequest true "Subject Update Data"
+// @Success      200 {object} GenericResponse{data=SubjectData} "Subject updated successfully"
+// @Failure      404 {object} GenericResponse "Subject not found"
+// @Failure      409 {object} GenericResponse "Subject code already exists"
+// @Router       /subjects/{id} [put]
+func (h *SubjectHandler) UpdateSubject(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "subject")
+	if !ok {
+		return
+	}
+
+	var req UpdateSubjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	subject, err := h.service.UpdateSubject(id, &req.SubjectCode, &req.SubjectName)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Subject updated successfully",
+		Data:    ToSubjectDTO(*subject),
+	})
+}
+
+// DeleteSubject godoc
+// @Summary      Delete a subject
+// @Description  Deletes a subject that is not used by any schedule.
+// @Tags         Subjects
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Subject ID"
+// @Success      200 {object} GenericResponse "Subject deleted successfully"
+// @Failure      404 {object} GenericResponse "Subject not found"
+// @Failure      409 {object} GenericResponse "Subject still used by schedules"
+// @Router       /subjects/{id} [delete]
+func (h *SubjectHandler) DeleteSubject(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "subject")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSubject(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Subject deleted successfully",
+	})
+}
+
+// GetCurriculum godoc
+// @Summary      Get curriculum mapping
+// @Description  Lists subjects taught per grade level and major with their weekly hour quota. Filtering by major also returns rows that apply to all majors.
+// @Tags         Curriculum
+// @Security     BearerAuth
+// @Produce      json
+// @Param        grade_level query string false "Grade level (10/X, 11/XI, 12/XII)"
+// @Param        major query string false "Major"
+// @Success      200 {object} GenericResponse{data=[]CurriculumItemData} "Curriculum mapping"
+// @Router       /curriculum [get]
+func (h *SubjectHandler) GetCurriculum(c *gin.Context) {
+	var filters CurriculumQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	items, err := h.service.GetCurriculum(filters.GradeLevel, filters.Major)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]CurriculumItemData, 0, len(items))
+	for _, item := range items {
+		data = append(data, ToCurriculumItemDTO(item))
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Curriculum retrieved successfully",
+		Data:    data,
+	})
+}
+
+// UpsertCurriculumItem godoc
+// @Summary      Set a curriculum quota
+// @Description  Creates or updates the weekly hour quota of a subject for a grade level and major.
+// @Tags         Curriculum
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        item body UpsertCurriculumRequest true "Curriculum item"
+// @Success      200 {object} GenericResponse{data=CurriculumItemData} "Curriculum item saved"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "Subject not found"
+// @Router       /curriculum [put]
+func (h *SubjectHandler) UpsertCurriculumItem(c *gin.Context) {
+	var req UpsertCurriculumRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	item, err := h.service.UpsertCurriculumItem(int(req.SubjectID), req.GradeLevel, req.Major, req.WeeklyHours)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Curriculum item saved successfully",
+		Data:    ToCurriculumItemDTO(*item),
+	})
+}
+
+// DeleteCurriculumItem godoc
+// @Summary      Delete a curriculum item
+// @Description  Removes a subject from the curriculum of a grade level and major.
+// @Tags         Curriculum
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Curriculum item ID"
+// @Success      200 {object} GenericResponse "Curriculum item deleted"
+// @Failure      404 {object} GenericResponse "Curriculum item not found"
+// @Router       /curriculum/{id} [delete]
+func (h *SubjectHandler) DeleteCurriculumItem(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "curriculum item")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCurriculumItem(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Curriculum item deleted successfully",
+	})
+}
+
+// GetClassCurriculumReport godoc
+// @Summary      Curriculum coverage of a class
+// @Description  Compares a class's schedule with its curriculum and reports unscheduled or under-scheduled subjects.
+// @Tags         Curriculum
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Class ID"
+// @Success      200 {object} GenericResponse{data=service.CurriculumReport} "Curriculum report"
+// @Failure      404 {object} GenericResponse "Class not found"
+// @Router       /classes/{id}/curriculum-report [get]
+func (h *SubjectHandler) GetClassCurriculumReport(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "class")
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetClassCurriculumReport(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Curriculum report generated successfully",
+		Data:    report,
+	})
+}
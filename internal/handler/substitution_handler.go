@@ -0,0 +1,290 @@
+// internal/handler/substitution_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type SubstitutionHandler struct {
+	service *service.SubstitutionService
+}
+
+func NewSubstitutionHandler(service *service.SubstitutionService) *SubstitutionHandler {
+	return &SubstitutionHandler{service: service}
+}
+
+// ToSubstitutionDTO mengubah model guru pengganti menjadi data response.
+func ToSubstitutionDTO(substitution db.SubstitutionModel) SubstitutionData {
+	data := SubstitutionData{
+		ID:                  int64(substitution.ID),
+		Date:                substitution.Date.UTC().Format("2006-01-02"),
+		Lesson:              ScheduleData{ID: int64(substitution.ScheduleID)},
+		SubstituteTeacherID: int64(substitution.SubstituteTeacherID),
+		CreatedAt:           substitution.CreatedAt,
+	}
+	if substitution.RelationsSubstitution.Schedule != nil {
+		data.Lesson = ToScheduleDTO(*substitution.Schedule())
+	}
+	if substitution.RelationsSubstitution.SubstituteTeacher != nil {
+		data.SubstituteTeacherName = substitution.SubstituteTeacher().FullName
+	}
+	if leaveID, ok := substitution.LeaveRequestID(); ok {
+		id := int64(leaveID)
+		data.LeaveRequestID = &id
+	}
+	if notes, ok := substitution.Notes(); ok {
+		data.Notes = notes
+	}
+	return data
+}
+
+func toSubstitutionDTOs(substitutions []db.SubstitutionModel) []SubstitutionData {
+	data := make([]SubstitutionData, 0, len(substitutions))
+	for _, substitution := range substitutions {
+		data = append(data, ToSubstitutionDTO(substitution))
+	}
+	return data
+}
+
+// GetAffectedLessons godoc
+// @Summary      Get lessons left by absent teachers
+// @Description  Expands approved leave requests of teachers into the lessons they miss within a date range (max 62 days), including the substitute if one is already recorded.
+// @Tags         Substitutions
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string true "Start date (YYYY-MM-DD)"
+// @Param        to query string true "End date (YYYY-MM-DD)"
+// @Param        uncovered_only query bool false "Only lessons without a substitute"
+// @Success      200 {object} GenericResponse{data=[]service.AffectedLesson} "Affected lessons"
+// @Failure      400 {object} GenericResponse "Invalid date range"
+// @Router       /substitutions/affected-lessons [get]
+func (h *SubstitutionHandler) GetAffectedLessons(c *gin.Context) {
+	var filters AffectedLessonQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	lessons, err := h.service.GetAffectedLessons(filters.From, filters.To, filters.UncoveredOnly)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Affected lessons retrieved successfully",
+		Data:    lessons,
+	})
+}
+
+// SuggestSubstitutes godoc
+// @Summary      Suggest substitute teachers
+// @Description  Lists teachers who are free during the lesson on the given date (no teaching clash, no other substitution, not on leave, not unavailable). Teachers of the same subject come first, then teachers of the same class, then teachers with the fewest lessons that day.
+// @Tags         Substitutions
+// @Security     BearerAuth
+// @Produce      json
+// @Param        schedule_id query int true "Schedule ID"
+// @Param        date query string true "Lesson date (YYYY-MM-DD)"
+// @Success      200 {object} GenericResponse{data=[]service.SubstituteCandidate} "Substitute candidates"
+// @Failure      400 {object} GenericResponse "Date does not match the schedule day"
+// @Failure      404 {object} GenericResponse "Schedule not found"
+// @Router       /substitutions/suggestions [get]
+func (h *SubstitutionHandler) SuggestSubstitutes(c *gin.Context) {
+	var query SubstituteSuggestionQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	candidates, err := h.service.SuggestSubstitutes(int(query.ScheduleID), query.Date)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Substitute candidates retrieved successfully",
+		Data:    candidates,
+	})
+}
+
+// GetSubstitutions godoc
+// @Summary      Get substitutions
+// @Description  Lists recorded substitutions, optionally filtered by date range and teacher (either the substitute or the replaced teacher).
+// @Tags         Substitutions
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string false "Start date (YYYY-MM-DD)"
+// @Param        to query string false "End date (YYYY-MM-DD)"
+// @Param        teacher_id query int false "Teacher ID"
+// @Success      200 {object} GenericResponse{data=[]SubstitutionData} "List of substitutions"
+// @Router       /substitutions [get]
+func (h *SubstitutionHandler) GetSubstitutions(c *gin.Context) {
+	var filters SubstitutionQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	substitutions, err := h.service.GetSubstitutions(service.SubstitutionFilters{
+		From:      filters.From,
+		To:        filters.To,
+		TeacherID: filters.TeacherID,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Substitutions retrieved successfully",
+		Data:    toSubstitutionDTOs(substitutions),
+	})
+}
+
+// CreateSubstitution godoc
+// @Summary      Assign a substitute teacher
+// @Description  Records a substitute teacher for one lesson on one date. The approved leave request of the original teacher covering that date is linked automatically.
+// @Tags         Substitutions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        substitution body CreateSubstitutionRequest true "Substitution"
+// @Success      201 {object} GenericResponse{data=SubstitutionData} "Substitution created"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "Schedule or teacher not found"
+// @Failure      409 {object} GenericResponse "Lesson already covered or substitute not available"
+// @Router       /substitutions [post]
+func (h *SubstitutionHandler) CreateSubstitution(c *gin.Context) {
+	var req CreateSubstitutionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	substitution, err := h.service.CreateSubstitution(service.SubstitutionInput{
+		ScheduleID:          req.ScheduleID,
+		Date:                req.Date,
+		SubstituteTeacherID: req.SubstituteTeacherID,
+		Notes:               req.Notes,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Substitution created successfully",
+		Data:    ToSubstitutionDTO(*substitution),
+	})
+}
+
+// DeleteSubstitution godoc
+// @Summary      Cancel a substitution
+// @Description  Removes a recorded substitute teacher.
+// @Tags         Substitutions
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Substitution ID"
+// @Success      200 {object} GenericResponse "Substitution deleted"
+// @Failure      404 {object} GenericResponse "Substitution not found"
+// @Router       /substitutions/{id} [delete]
+func (h *SubstitutionHandler) DeleteSubstitution(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "substitution")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSubstitution(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Substitution deleted successfully",
+	})
+}
+
+// GetMySubstitutions godoc
+// @Summary      Get my substitution duties
+// @Description  Lists the lessons the logged-in teacher has to cover as a substitute.
+// @Tags         Substitutions
+// @Security     BearerAuth
+// @Produce      json
+// @Param        from query string false "Start date (YYYY-MM-DD)"
+// @Param        to query string false "End date (YYYY-MM-DD)"
+// @Success      200 {object} GenericResponse{data=[]SubstitutionData} "List of substitutions"
+// @Failure      403 {object} GenericResponse "User is not a teacher"
+// @Router       /me/substitutions [get]
+func (h *SubstitutionHandler) GetMySubstitutions(c *gin.Context) {
+	var filters SubstitutionQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	substitutions, err := h.service.GetUserSubstitutions(int(currentUser(c).ID), filters.From, filters.To)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Substitutions retrieved successfully",
+		Data:    toSubstitutionDTOs(substitutions),
+	})
+}
+
+// WriteJournal godoc
+// @Summary      Write the journal of a substituted lesson
+// @Description  Lets the substitute teacher write the teaching journal (Jurnal KBM) for the lesson they covered.
+// @Tags         Substitutions
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Substitution ID"
+// @Param        journal body TeachingJournalRequest true "Journal"
+// @Success      201 {object} GenericResponse{data=TeachingJournalData} "Teaching journal created"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      403 {object} GenericResponse "User is not the substitute teacher"
+// @Failure      404 {object} GenericResponse "Substitution not found"
+// @Failure      409 {object} GenericResponse "Journal already exists"
+// @Router       /substitutions/{id}/journal [post]
+func (h *SubstitutionHandler) WriteJournal(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "substitution")
+	if !ok {
+		return
+	}
+	var req TeachingJournalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	journal, err := h.service.WriteJournal(int(currentUser(c).ID), id, service.JournalInput{
+		Topic:       req.Topic,
+		Notes:       req.Notes,
+		Attendances: toAttendanceInputs(req.Attendances),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Teaching journal created successfully",
+		Data:    ToTeachingJournalDTO(*journal),
+	})
+}
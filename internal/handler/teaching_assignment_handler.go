@@ -0,0 +1,231 @@
+// internal/handler/teaching_assignment_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type TeachingAssignmentHandler struct {
+	service *service.TeachingAssignmentService
+}
+
+func NewTeachingAssignmentHandler(service *service.TeachingAssignmentService) *TeachingAssignmentHandler {
+	return &TeachingAssignmentHandler{service: service}
+}
+
+// ToTeachingAssignmentDTO mengubah model guru pengampu menjadi data response.
+func ToTeachingAssignmentDTO(assignment db.TeachingAssignmentModel) TeachingAssignmentData {
+	data := TeachingAssignmentData{
+		ID:        int64(assignment.ID),
+		ClassID:   int64(assignment.ClassID),
+		SubjectID: int64(assignment.SubjectID),
+		TeacherID: int64(assignment.TeacherID),
+	}
+	if assignment.RelationsTeachingAssignment.Class != nil {
+		data.ClassName = assignment.Class().ClassName
+	}
+	if assignment.RelationsTeachingAssignment.Subject != nil {
+		data.SubjectCode = assignment.Subject().SubjectCode
+		data.SubjectName = assignment.Subject().SubjectName
+	}
+	if assignment.RelationsTeachingAssignment.Teacher != nil {
+		data.TeacherName = assignment.Teacher().FullName
+	}
+	return data
+}
+
+// ToTeacherUnavailabilityDTO mengubah model slot tidak tersedia menjadi data response.
+func ToTeacherUnavailabilityDTO(slot db.TeacherUnavailabilityModel) TeacherUnavailabilitySlot {
+	data := TeacherUnavailabilitySlot{
+		ID:        int64(slot.ID),
+		DayOfWeek: string(slot.DayOfWeek),
+		StartTime: slot.StartTime.UTC().Format("15:04"),
+		EndTime:   slot.EndTime.UTC().Format("15:04"),
+	}
+	if reason, ok := slot.Reason(); ok {
+		data.Reason = reason
+	}
+	return data
+}
+
+// GetAssignments godoc
+// @Summary      Get teaching assignments
+// @Description  Lists which teacher teaches which subject in each class. Used as input for the timetable generator.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Produce      json
+// @Param        class_id query int false "Class ID"
+// @Param        teacher_id query int false "Teacher ID"
+// @Param        academic_year query string false "Academic year, e.g. 2025/2026"
+// @Success      200 {object} GenericResponse{data=[]TeachingAssignmentData} "List of teaching assignments"
+// @Router       /teaching-assignments [get]
+func (h *TeachingAssignmentHandler) GetAssignments(c *gin.Context) {
+	var filters TeachingAssignmentQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	assignments, err := h.service.GetAssignments(filters.ClassID, filters.TeacherID, filters.AcademicYear)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]TeachingAssignmentData, 0, len(assignments))
+	for _, assignment := range assignments {
+		data = append(data, ToTeachingAssignmentDTO(assignment))
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Teaching assignments retrieved successfully",
+		Data:    data,
+	})
+}
+
+// UpsertAssignment godoc
+// @Summary      Set a teaching assignment
+// @Description  Assigns the teacher of a subject in a class, replacing the previous teacher if any.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        assignment body TeachingAssignmentRequest true "Teaching assignment"
+// @Success      200 {object} GenericResponse{data=TeachingAssignmentData} "Teaching assignment saved"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "Class, subject or teacher not found"
+// @Router       /teaching-assignments [put]
+func (h *TeachingAssignmentHandler) UpsertAssignment(c *gin.Context) {
+	var req TeachingAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	assignment, err := h.service.UpsertAssignment(req.ClassID, req.SubjectID, req.TeacherID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Teaching assignment saved successfully",
+		Data:    ToTeachingAssignmentDTO(*assignment),
+	})
+}
+
+// DeleteAssignment godoc
+// @Summary      Delete a teaching assignment
+// @Description  Removes the teacher assignment of a subject in a class.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Teaching assignment ID"
+// @Success      200 {object} GenericResponse "Teaching assignment deleted"
+// @Failure      404 {object} GenericResponse "Teaching assignment not found"
+// @Router       /teaching-assignments/{id} [delete]
+func (h *TeachingAssignmentHandler) DeleteAssignment(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "teaching assignment")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAssignment(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Teaching assignment deleted successfully",
+	})
+}
+
+// GetUnavailability godoc
+// @Summary      Get teacher unavailability
+// @Description  Lists the weekly slots in which a teacher cannot teach.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Teacher ID"
+// @Success      200 {object} GenericResponse{data=[]TeacherUnavailabilitySlot} "Unavailable slots"
+// @Failure      404 {object} GenericResponse "Teacher not found"
+// @Router       /teachers/{id}/unavailability [get]
+func (h *TeachingAssignmentHandler) GetUnavailability(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "teacher")
+	if !ok {
+		return
+	}
+
+	slots, err := h.service.GetUnavailability(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Teacher unavailability retrieved successfully",
+		Data:    toTeacherUnavailabilityDTOs(slots),
+	})
+}
+
+// ReplaceUnavailability godoc
+// @Summary      Replace teacher unavailability
+// @Description  Replaces all weekly slots in which a teacher cannot teach. The timetable generator never places lessons in these slots.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Teacher ID"
+// @Param        slots body TeacherUnavailabilityRequest true "Unavailable slots"
+// @Success      200 {object} GenericResponse{data=[]TeacherUnavailabilitySlot} "Unavailable slots saved"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      404 {object} GenericResponse "Teacher not found"
+// @Router       /teachers/{id}/unavailability [put]
+func (h *TeachingAssignmentHandler) ReplaceUnavailability(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "teacher")
+	if !ok {
+		return
+	}
+	var req TeacherUnavailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	inputs := make([]service.TeacherUnavailabilityInput, 0, len(req.Slots))
+	for _, slot := range req.Slots {
+		inputs = append(inputs, service.TeacherUnavailabilityInput{
+			DayOfWeek: slot.DayOfWeek,
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+			Reason:    slot.Reason,
+		})
+	}
+	slots, err := h.service.ReplaceUnavailability(id, inputs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Teacher unavailability saved successfully",
+		Data:    toTeacherUnavailabilityDTOs(slots),
+	})
+}
+
+func toTeacherUnavailabilityDTOs(slots []db.TeacherUnavailabilityModel) []TeacherUnavailabilitySlot {
+	data := make([]TeacherUnavailabilitySlot, 0, len(slots))
+	for _, slot := range slots {
+		data = append(data, ToTeacherUnavailabilityDTO(slot))
+	}
+	return data
+}
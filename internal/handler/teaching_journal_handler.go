@@ -0,0 +1,387 @@
+// internal/handler/teaching_journal_handler.go
+package handler
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type TeachingJournalHandler struct {
+	service *service.TeachingJournalService
+}
+
+func NewTeachingJournalHandler(service *service.TeachingJournalService) *TeachingJournalHandler {
+	return &TeachingJournalHandler{service: service}
+}
+
+// ToTeachingJournalDTO mengubah model jurnal mengajar menjadi data response.
+func ToTeachingJournalDTO(journal db.TeachingJournalModel) TeachingJournalData {
+	data := TeachingJournalData{
+		ID:           int64(journal.ID),
+		ScheduleID:   int64(journal.ScheduleID),
+		TeachingDate: journal.TeachingDate.UTC().Format("2006-01-02"),
+		Topic:        journal.Topic,
+		Locked:       service.JournalLocked(journal.TeachingDate),
+		CreatedAt:    journal.CreatedAt,
+		UpdatedAt:    journal.UpdatedAt,
+	}
+	if summary, ok := journal.StudentAttendanceSummary(); ok {
+		data.StudentAttendanceSummary = summary
+	}
+	if notes, ok := journal.Notes(); ok {
+		data.Notes = notes
+	}
+	if authorID, ok := journal.AuthorTeacherID(); ok {
+		id := int64(authorID)
+		data.AuthorTeacherID = &id
+	}
+	if journal.RelationsTeachingJournal.AuthorTeacher != nil {
+		if author, ok := journal.AuthorTeacher(); ok {
+			data.AuthorTeacherName = author.FullName
+		}
+	}
+	if journal.RelationsTeachingJournal.Schedule != nil {
+		lesson := ToScheduleDTO(*journal.Schedule())
+		data.Lesson = &lesson
+	}
+	if journal.RelationsTeachingJournal.Attendances != nil {
+		data.Attendances = toLessonAttendanceDTOs(journal.Attendances())
+	}
+	return data
+}
+
+// ToLessonAttendanceDTO mengubah model kehadiran siswa per jam pelajaran menjadi data response.
+func ToLessonAttendanceDTO(attendance db.StudentLessonAttendanceModel) LessonAttendanceData {
+	data := LessonAttendanceData{
+		ID:        int64(attendance.ID),
+		JournalID: int64(attendance.JournalID),
+		StudentID: int64(attendance.StudentID),
+		Status:    string(attendance.Status),
+	}
+	if notes, ok := attendance.Notes(); ok {
+		data.Notes = notes
+	}
+	if leaveID, ok := attendance.LeaveRequestID(); ok {
+		id := int64(leaveID)
+		data.LeaveRequestID = &id
+	}
+	if attendance.RelationsStudentLessonAttendance.Student != nil {
+		data.StudentName = attendance.Student().FullName
+		data.NIS = attendance.Student().Nis
+	}
+	if attendance.RelationsStudentLessonAttendance.Journal != nil {
+		journal := attendance.Journal()
+		data.TeachingDate = journal.TeachingDate.UTC().Format("2006-01-02")
+		if journal.RelationsTeachingJournal.Schedule != nil {
+			lesson := ToScheduleDTO(*journal.Schedule())
+			data.Lesson = &lesson
+		}
+	}
+	return data
+}
+
+func toLessonAttendanceDTOs(attendances []db.StudentLessonAttendanceModel) []LessonAttendanceData {
+	data := make([]LessonAttendanceData, 0, len(attendances))
+	for _, attendance := range attendances {
+		data = append(data, ToLessonAttendanceDTO(attendance))
+	}
+	return data
+}
+
+func toAttendanceInputs(requests []StudentAttendanceRequest) []service.StudentAttendanceInput {
+	inputs := make([]service.StudentAttendanceInput, 0, len(requests))
+	for _, req := range requests {
+		inputs = append(inputs, service.StudentAttendanceInput{
+			StudentID: req.StudentID,
+			Status:    req.Status,
+			Notes:     req.Notes,
+		})
+	}
+	return inputs
+}
+
+// GetJournals godoc
+// @Summary      Get teaching journals
+// @Description  Lists teaching journals (Jurnal KBM), newest first. Teachers only see journals of their own schedules or journals they wrote as a substitute.
+// @Tags         Teaching Journals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        page query int false "Page number"
+// @Param        limit query int false "Items per page"
+// @Param        schedule_id query int false "Schedule ID"
+// @Param        class_id query int false "Class ID"
+// @Param        teacher_id query int false "Teacher ID (scheduled teacher or author)"
+// @Param        from query string false "Start date (YYYY-MM-DD)"
+// @Param        to query string false "End date (YYYY-MM-DD)"
+// @Success      200 {object} GenericResponse{data=[]TeachingJournalData} "List of teaching journals"
+// @Router       /teaching-journals [get]
+func (h *TeachingJournalHandler) GetJournals(c *gin.Context) {
+	var filters TeachingJournalQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+	if filters.Page <= 0 {
+		filters.Page = 1
+	}
+	if filters.Limit <= 0 {
+		filters.Limit = 10
+	}
+
+	journals, total, err := h.service.GetJournals(currentUser(c), service.TeachingJournalFilters{
+		ScheduleID: filters.ScheduleID,
+		ClassID:    filters.ClassID,
+		TeacherID:  filters.TeacherID,
+		From:       filters.From,
+		To:         filters.To,
+		Page:       filters.Page,
+		Limit:      filters.Limit,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]TeachingJournalData, 0, len(journals))
+	for _, journal := range journals {
+		data = append(data, ToTeachingJournalDTO(journal))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Teaching journals retrieved successfully",
+		"data":    data,
+		"meta": gin.H{
+			"page":       filters.Page,
+			"limit":      filters.Limit,
+			"total":      total,
+			"totalPages": int(math.Ceil(float64(total) / float64(filters.Limit))),
+		},
+	})
+}
+
+// GetJournalByID godoc
+// @Summary      Get a teaching journal
+// @Description  Retrieves a single teaching journal.
+// @Tags         Teaching Journals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Teaching journal ID"
+// @Success      200 {object} GenericResponse{data=TeachingJournalData} "Teaching journal"
+// @Failure      403 {object} GenericResponse "Not your teaching journal"
+// @Failure      404 {object} GenericResponse "Teaching journal not found"
+// @Router       /teaching-journals/{id} [get]
+func (h *TeachingJournalHandler) GetJournalByID(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "teaching journal")
+	if !ok {
+		return
+	}
+
+	journal, err := h.service.GetJournalByID(currentUser(c), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Teaching journal retrieved successfully",
+		Data:    ToTeachingJournalDTO(*journal),
+	})
+}
+
+// CreateJournal godoc
+// @Summary      Write a teaching journal
+// @Description  Creates the teaching journal of one of the logged-in teacher's schedule slots (or a slot they cover as a substitute) for a teaching date. The date must fall on the schedule's day of week and not be in the future; only one journal per schedule and date is allowed. Attendance is recorded for every student of the class: students with an approved leave default to Sakit/Izin, everyone else to Hadir, and `attendances` overrides individual students. The attendance summary is derived from these records.
+// @Tags         Teaching Journals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        journal body CreateTeachingJournalRequest true "Journal"
+// @Success      201 {object} GenericResponse{data=TeachingJournalData} "Teaching journal created"
+// @Failure      400 {object} GenericResponse "Invalid request or date does not match the schedule day"
+// @Failure      403 {object} GenericResponse "Not your lesson"
+// @Failure      404 {object} GenericResponse "Schedule not found"
+// @Failure      409 {object} GenericResponse "Journal already exists"
+// @Router       /teaching-journals [post]
+func (h *TeachingJournalHandler) CreateJournal(c *gin.Context) {
+	var req CreateTeachingJournalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	journal, err := h.service.CreateJournal(int(currentUser(c).ID), service.JournalInput{
+		ScheduleID:   req.ScheduleID,
+		TeachingDate: req.TeachingDate,
+		Topic:        req.Topic,
+		Notes:        req.Notes,
+		Attendances:  toAttendanceInputs(req.Attendances),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Teaching journal created successfully",
+		Data:    ToTeachingJournalDTO(*journal),
+	})
+}
+
+// UpdateJournal godoc
+// @Summary      Edit a teaching journal
+// @Description  Updates the topic, notes and student attendance of a teaching journal; the attendance summary is derived again. Only the author can edit, and journals are locked JOURNAL_EDIT_LOCK_DAYS days (default 7) after the teaching date.
+// @Tags         Teaching Journals
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Teaching journal ID"
+// @Param        journal body TeachingJournalRequest true "Journal"
+// @Success      200 {object} GenericResponse{data=TeachingJournalData} "Teaching journal updated"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Failure      403 {object} GenericResponse "Not the author or journal locked"
+// @Failure      404 {object} GenericResponse "Teaching journal not found"
+// @Router       /teaching-journals/{id} [put]
+func (h *TeachingJournalHandler) UpdateJournal(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "teaching journal")
+	if !ok {
+		return
+	}
+	var req TeachingJournalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	journal, err := h.service.UpdateJournal(int(currentUser(c).ID), id, service.JournalInput{
+		Topic:       req.Topic,
+		Notes:       req.Notes,
+		Attendances: toAttendanceInputs(req.Attendances),
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Teaching journal updated successfully",
+		Data:    ToTeachingJournalDTO(*journal),
+	})
+}
+
+// GetRoster godoc
+// @Summary      Get the attendance roster of a lesson
+// @Description  Lists the students of the class on the teaching date with their prefilled attendance status (Sakit/Izin from approved leave requests, otherwise Hadir). If the journal already exists, the saved statuses are returned.
+// @Tags         Teaching Journals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        schedule_id query int true "Schedule ID"
+// @Param        teaching_date query string true "Teaching date (YYYY-MM-DD)"
+// @Success      200 {object} GenericResponse{data=[]service.RosterEntry} "Attendance roster"
+// @Failure      400 {object} GenericResponse "Date does not match the schedule day"
+// @Failure      403 {object} GenericResponse "Not your lesson"
+// @Failure      404 {object} GenericResponse "Schedule not found"
+// @Router       /teaching-journals/roster [get]
+func (h *TeachingJournalHandler) GetRoster(c *gin.Context) {
+	var query JournalRosterQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	roster, err := h.service.GetRoster(int(currentUser(c).ID), query.ScheduleID, query.TeachingDate)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Attendance roster retrieved successfully",
+		Data:    roster,
+	})
+}
+
+// GetLessonAttendances godoc
+// @Summary      Get per-lesson student attendance
+// @Description  Lists student attendance records of teaching journals, newest lesson first, e.g. to find who was absent (Alpa) from which lesson.
+// @Tags         Teaching Journals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        student_id query int false "Student ID"
+// @Param        class_id query int false "Class ID"
+// @Param        status query string false "Attendance status (Hadir, Sakit, Izin, Alpa)"
+// @Param        from query string false "Start date (YYYY-MM-DD)"
+// @Param        to query string false "End date (YYYY-MM-DD)"
+// @Success      200 {object} GenericResponse{data=[]LessonAttendanceData} "Lesson attendance records"
+// @Failure      400 {object} GenericResponse "Invalid filter"
+// @Router       /lesson-attendances [get]
+func (h *TeachingJournalHandler) GetLessonAttendances(c *gin.Context) {
+	var filters LessonAttendanceQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	attendances, err := h.service.GetLessonAttendances(service.LessonAttendanceFilters{
+		StudentID: filters.StudentID,
+		ClassID:   filters.ClassID,
+		Status:    filters.Status,
+		From:      filters.From,
+		To:        filters.To,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Lesson attendances retrieved successfully",
+		Data:    toLessonAttendanceDTOs(attendances),
+	})
+}
+
+// GetMyLessonAttendances godoc
+// @Summary      Get my per-lesson attendance
+// @Description  Lists the logged-in student's attendance per lesson, newest lesson first.
+// @Tags         Teaching Journals
+// @Security     BearerAuth
+// @Produce      json
+// @Param        status query string false "Attendance status (Hadir, Sakit, Izin, Alpa)"
+// @Param        from query string false "Start date (YYYY-MM-DD)"
+// @Param        to query string false "End date (YYYY-MM-DD)"
+// @Success      200 {object} GenericResponse{data=[]LessonAttendanceData} "Lesson attendance records"
+// @Failure      403 {object} GenericResponse "User is not a student"
+// @Router       /me/lesson-attendances [get]
+func (h *TeachingJournalHandler) GetMyLessonAttendances(c *gin.Context) {
+	var filters LessonAttendanceQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	attendances, err := h.service.GetMyLessonAttendances(int(currentUser(c).ID), service.LessonAttendanceFilters{
+		Status: filters.Status,
+		From:   filters.From,
+		To:     filters.To,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Lesson attendances retrieved successfully",
+		Data:    toLessonAttendanceDTOs(attendances),
+	})
+}
@@ -0,0 +1,237 @@
+// internal/handler/timetable_draft_handler.go
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type TimetableDraftHandler struct {
+	service *service.TimetableDraftService
+}
+
+func NewTimetableDraftHandler(service *service.TimetableDraftService) *TimetableDraftHandler {
+	return &TimetableDraftHandler{service: service}
+}
+
+// ToTimetableDraftDTO mengubah draft jadwal menjadi data response.
+func ToTimetableDraftDTO(detail service.TimetableDraftDetail) TimetableDraftData {
+	draft := detail.Draft
+	data := TimetableDraftData{
+		ID:           int64(draft.ID),
+		AcademicYear: draft.AcademicYear,
+		Status:       string(draft.Status),
+		ClassIDs:     detail.Settings.ClassIDs,
+		Days:         detail.Settings.Days,
+		Periods:      detail.Settings.Periods,
+		Seed:         detail.Settings.Seed,
+		Unplaced:     detail.Unplaced,
+		CreatedAt:    draft.CreatedAt,
+	}
+	if data.Unplaced == nil {
+		data.Unplaced = []service.UnplacedLesson{}
+	}
+	for _, lesson := range detail.Unplaced {
+		data.UnplacedHours += lesson.Hours
+	}
+	if committedAt, ok := draft.CommittedAt(); ok {
+		data.CommittedAt = &committedAt
+	}
+	if draft.RelationsTimetableDraft.Entries != nil {
+		data.Entries = make([]ScheduleData, 0, len(draft.Entries()))
+		for _, entry := range draft.Entries() {
+			data.Entries = append(data.Entries, toDraftEntryDTO(entry))
+		}
+	}
+	return data
+}
+
+func toDraftEntryDTO(entry db.TimetableDraftEntryModel) ScheduleData {
+	data := ScheduleData{
+		ID:        int64(entry.ID),
+		ClassID:   int64(entry.ClassID),
+		SubjectID: int64(entry.SubjectID),
+		TeacherID: int64(entry.TeacherID),
+		DayOfWeek: string(entry.DayOfWeek),
+		StartTime: entry.StartTime.UTC().Format("15:04"),
+		EndTime:   entry.EndTime.UTC().Format("15:04"),
+	}
+	if room, ok := entry.Room(); ok {
+		data.Room = room
+	}
+	if entry.RelationsTimetableDraftEntry.Class != nil {
+		data.ClassName = entry.Class().ClassName
+	}
+	if entry.RelationsTimetableDraftEntry.Subject != nil {
+		data.SubjectCode = entry.Subject().SubjectCode
+		data.SubjectName = entry.Subject().SubjectName
+	}
+	if entry.RelationsTimetableDraftEntry.Teacher != nil {
+		data.TeacherName = entry.Teacher().FullName
+	}
+	return data
+}
+
+// GenerateDraft godoc
+// @Summary      Generate a timetable draft
+// @Description  Builds a conflict-free weekly timetable for the given classes from curriculum hour quotas, teaching assignments, teacher unavailability and room availability. Existing schedules of other classes in the same academic year are respected. The result is saved as a draft with a report of lessons that could not be placed; nothing is written to the schedules until the draft is committed.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        request body GenerateTimetableRequest true "Generator input"
+// @Success      201 {object} GenericResponse{data=TimetableDraftData} "Timetable draft created"
+// @Failure      400 {object} GenericResponse "Invalid request"
+// @Router       /timetable-drafts [post]
+func (h *TimetableDraftHandler) GenerateDraft(c *gin.Context) {
+	var req GenerateTimetableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: err.Error()})
+		return
+	}
+
+	detail, err := h.service.Generate(service.GenerateTimetableParams{
+		AcademicYear:     req.AcademicYear,
+		ClassIDs:         req.ClassIDs,
+		Days:             req.Days,
+		Periods:          req.Periods,
+		Rooms:            req.Rooms,
+		MaxPeriodsPerDay: req.MaxPeriodsPerDay,
+		Attempts:         req.Attempts,
+		Seed:             req.Seed,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, GenericResponse{
+		Success: true,
+		Message: "Timetable draft generated successfully",
+		Data:    ToTimetableDraftDTO(*detail),
+	})
+}
+
+// GetDrafts godoc
+// @Summary      Get timetable drafts
+// @Description  Lists generated timetable drafts, newest first, without their entries.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Produce      json
+// @Param        academic_year query string false "Academic year, e.g. 2025/2026"
+// @Success      200 {object} GenericResponse{data=[]TimetableDraftData} "List of timetable drafts"
+// @Router       /timetable-drafts [get]
+func (h *TimetableDraftHandler) GetDrafts(c *gin.Context) {
+	var filters TimetableDraftQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	drafts, err := h.service.GetDrafts(filters.AcademicYear)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	data := make([]TimetableDraftData, 0, len(drafts))
+	for _, draft := range drafts {
+		data = append(data, ToTimetableDraftDTO(draft))
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Timetable drafts retrieved successfully",
+		Data:    data,
+	})
+}
+
+// GetDraft godoc
+// @Summary      Get a timetable draft
+// @Description  Retrieves a timetable draft with all generated entries and the unplaced lesson report for review.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Timetable draft ID"
+// @Success      200 {object} GenericResponse{data=TimetableDraftData} "Timetable draft"
+// @Failure      404 {object} GenericResponse "Timetable draft not found"
+// @Router       /timetable-drafts/{id} [get]
+func (h *TimetableDraftHandler) GetDraft(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "timetable draft")
+	if !ok {
+		return
+	}
+
+	detail, err := h.service.GetDraft(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Timetable draft retrieved successfully",
+		Data:    ToTimetableDraftDTO(*detail),
+	})
+}
+
+// CommitDraft godoc
+// @Summary      Commit a timetable draft
+// @Description  Replaces the schedules of every class in the draft with the draft entries in a single transaction. Conflicts are re-checked against the current schedules, and classes whose current schedules already have teaching journals cannot be replaced.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Timetable draft ID"
+// @Success      200 {object} GenericResponse{data=TimetableDraftData} "Timetable draft committed"
+// @Failure      400 {object} GenericResponse "Curriculum violation"
+// @Failure      404 {object} GenericResponse "Timetable draft not found"
+// @Failure      409 {object} GenericResponse{data=[]service.ScheduleConflict} "Already committed, schedule conflict, or existing teaching journals"
+// @Router       /timetable-drafts/{id}/commit [post]
+func (h *TimetableDraftHandler) CommitDraft(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "timetable draft")
+	if !ok {
+		return
+	}
+
+	detail, err := h.service.CommitDraft(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Timetable draft committed successfully",
+		Data:    ToTimetableDraftDTO(*detail),
+	})
+}
+
+// DeleteDraft godoc
+// @Summary      Delete a timetable draft
+// @Description  Deletes a timetable draft. Schedules created by committing the draft are kept.
+// @Tags         Timetable Generator
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Timetable draft ID"
+// @Success      200 {object} GenericResponse "Timetable draft deleted"
+// @Failure      404 {object} GenericResponse "Timetable draft not found"
+// @Router       /timetable-drafts/{id} [delete]
+func (h *TimetableDraftHandler) DeleteDraft(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "timetable draft")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteDraft(id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Timetable draft deleted successfully",
+	})
+}
@@ -0,0 +1,221 @@
+// internal/handler/timetable_handler.go
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/service"
+)
+
+type TimetableHandler struct {
+	service *service.TimetableService
+}
+
+func NewTimetableHandler(service *service.TimetableService) *TimetableHandler {
+	return &TimetableHandler{service: service}
+}
+
+// GetClassTimetable godoc
+// @Summary      Class timetable
+// @Description  Returns the day-by-day weekly grid of a class.
+// @Tags         Timetables
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id   path      int  true  "Class ID"
+// @Success      200 {object} GenericResponse{data=service.Timetable} "Class timetable"
+// @Failure      404 {object} GenericResponse "Class not found"
+// @Router       /timetables/classes/{id} [get]
+func (h *TimetableHandler) GetClassTimetable(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "class")
+	if !ok {
+		return
+	}
+
+	timetable, err := h.service.ClassTimetable(id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{Success: true, Message: "Timetable retrieved successfully", Data: timetable})
+}
+
+// GetTeacherTimetable godoc
+// @Summary      Teacher timetable
+// @Description  Returns the day-by-day weekly teaching grid of a teacher for an academic year (default: current).
+// @Tags         Timetables
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id            path  int     true   "Teacher ID"
+// @Param        academic_year query string  false  "Academic year, e.g. 2024/2025"
+// @Success      200 {object} GenericResponse{data=service.Timetable} "Teacher timetable"
+// @Failure      404 {object} GenericResponse "Teacher not found"
+// @Router       /timetables/teachers/{id} [get]
+func (h *TimetableHandler) GetTeacherTimetable(c *gin.Context) {
+	id, ok := parseIDParam(c, "id", "teacher")
+	if !ok {
+		return
+	}
+	var filters TimetableQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	timetable, err := h.service.TeacherTimetable(id, filters.AcademicYear)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{Success: true, Message: "Timetable retrieved successfully", Data: timetable})
+}
+
+// GetRoomTimetable godoc
+// @Summary      Room timetable
+// @Description  Returns the day-by-day weekly usage grid of a room for an academic year (default: current).
+// @Tags         Timetables
+// @Security     BearerAuth
+// @Produce      json
+// @Param        room          path  string  true   "Room name"
+// @Param        academic_year query string  false  "Academic year, e.g. 2024/2025"
+// @Success      200 {object} GenericResponse{data=service.Timetable} "Room timetable"
+// @Router       /timetables/rooms/{room} [get]
+func (h *TimetableHandler) GetRoomTimetable(c *gin.Context) {
+	var filters TimetableQueryFilters
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.JSON(http.StatusBadRequest, GenericResponse{Success: false, Message: "Invalid query parameters"})
+		return
+	}
+
+	timetable, err := h.service.RoomTimetable(c.Param("room"), filters.AcademicYear)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{Success: true, Message: "Timetable retrieved successfully", Data: timetable})
+}
+
+// GetMyTimetable godoc
+// @Summary      My timetable
+// @Description  Returns the weekly grid of the authenticated teacher (teaching schedule) or student (current class).
+// @Tags         Timetables
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} GenericResponse{data=service.Timetable} "My timetable"
+// @Failure      404 {object} GenericResponse "No teacher/student profile or class"
+// @Router       /me/timetable [get]
+func (h *TimetableHandler) GetMyTimetable(c *gin.Context) {
+	timetable, err := h.service.UserTimetable(int(currentUser(c).ID))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, GenericResponse{Success: true, Message: "Timetable retrieved successfully", Data: timetable})
+}
+
+// DownloadMyCalendar godoc
+// @Summary      Download my timetable as iCalendar
+// @Description  Returns the authenticated user's timetable as weekly recurring events within the academic year.
+// @Tags         Timetables
+// @Security     BearerAuth
+// @Produce      text/calendar
+// @Success      200 {file} file "iCalendar file"
+// @Failure      404 {object} GenericResponse "No teacher/student profile or class"
+// @Router       /me/timetable/ical [get]
+func (h *TimetableHandler) DownloadMyCalendar(c *gin.Context) {
+	h.writeCalendar(c, int(currentUser(c).ID))
+}
+
+// GetMyCalendarFeed godoc
+// @Summary      Get my calendar subscription URL
+// @Description  Returns a signed URL that calendar apps can subscribe to without an Authorization header. The URL stays the same until it is regenerated.
+// @Tags         Timetables
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} GenericResponse{data=CalendarFeedData} "Calendar feed URL"
+// @Router       /me/timetable/feed [get]
+func (h *TimetableHandler) GetMyCalendarFeed(c *gin.Context) {
+	token, err := h.service.FeedToken(int(currentUser(c).ID))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Calendar feed URL generated successfully",
+		Data:    CalendarFeedData{URL: calendarFeedURL(c, token)},
+	})
+}
+
+// RegenerateMyCalendarFeed godoc
+// @Summary      Regenerate my calendar subscription URL
+// @Description  Issues a new calendar feed URL; the previous URL stops working, e.g. after it was shared by accident. Calendar apps must subscribe again with the new URL.
+// @Tags         Timetables
+// @Security     BearerAuth
+// @Produce      json
+// @Success      200 {object} GenericResponse{data=CalendarFeedData} "New calendar feed URL"
+// @Router       /me/timetable/feed/regenerate [post]
+func (h *TimetableHandler) RegenerateMyCalendarFeed(c *gin.Context) {
+	token, err := h.service.RegenerateFeedToken(int(currentUser(c).ID))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, GenericResponse{
+		Success: true,
+		Message: "Calendar feed URL regenerated successfully",
+		Data:    CalendarFeedData{URL: calendarFeedURL(c, token)},
+	})
+}
+
+func calendarFeedURL(c *gin.Context, token string) string {
+	return fmt.Sprintf("%s/api/v1/timetable-feeds/%s.ics", baseURL(c), token)
+}
+
+// GetCalendarFeed godoc
+// @Summary      Calendar subscription feed
+// @Description  Public iCalendar feed identified by a signed token from /me/timetable/feed.
+// @Tags         Timetables
+// @Produce      text/calendar
+// @Param        token path string true "Signed feed token"
+// @Success      200 {file} file "iCalendar file"
+// @Failure      404 {object} GenericResponse "Feed not found"
+// @Router       /timetable-feeds/{token} [get]
+func (h *TimetableHandler) GetCalendarFeed(c *gin.Context) {
+	userID, err := h.service.UserIDFromFeedToken(c.Param("token"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	h.writeCalendar(c, userID)
+}
+
+func (h *TimetableHandler) writeCalendar(c *gin.Context, userID int) {
+	calendar, err := h.service.UserCalendar(userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", `inline; filename="jadwal.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+// baseURL mengembalikan alamat publik API (APP_URL di .env, atau dari request).
+func baseURL(c *gin.Context) string {
+	if url := viper.GetString("APP_URL"); url != "" {
+		return strings.TrimRight(url, "/")
+	}
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
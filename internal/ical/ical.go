@@ -0,0 +1,182 @@
+// internal/ical/ical.go
+// Package ical menulis kalender iCalendar (RFC 5545) sederhana yang bisa
+// di-subscribe dari aplikasi kalender di ponsel.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	dateTimeLayout    = "20060102T150405"
+	utcDateTimeLayout = "20060102T150405Z"
+	dateLayout        = "20060102"
+	maxLineOctets     = 75
+)
+
+// Event adalah satu VEVENT. Start dan End ditulis dalam zona waktu kalender;
+// jika AllDay bernilai true hanya tanggalnya yang dipakai.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	RRule       string // Contoh: FREQ=WEEKLY;UNTIL=20250630T165959Z
+	ExDates     []time.Time
+	Categories  []string
+}
+
+// Calendar adalah satu VCALENDAR beserta event-event di dalamnya.
+type Calendar struct {
+	ProdID   string
+	Name     string
+	Location *time.Location
+	Events   []Event
+}
+
+// Encode menghasilkan isi file .ics.
+func (c Calendar) Encode() []byte {
+	loc := c.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	prodID := c.ProdID
+	if prodID == "" {
+		prodID = "-//STMADB Portal//ID"
+	}
+
+	var buf bytes.Buffer
+	w := &writer{buf: &buf}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + prodID)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME:" + escapeText(c.Name))
+	}
+	if loc != time.UTC {
+		w.line("X-WR-TIMEZONE:" + loc.String())
+		writeTimezone(w, loc)
+	}
+
+	stamp := time.Now().UTC().Format(utcDateTimeLayout)
+	for _, e := range c.Events {
+		w.line("BEGIN:VEVENT")
+		w.line("UID:" + e.UID)
+		w.line("DTSTAMP:" + stamp)
+		if e.AllDay {
+			w.line("DTSTART;VALUE=DATE:" + e.Start.Format(dateLayout))
+			w.line("DTEND;VALUE=DATE:" + e.End.Format(dateLayout))
+		} else {
+			w.line(dateTimeProperty("DTSTART", e.Start, loc))
+			w.line(dateTimeProperty("DTEND", e.End, loc))
+		}
+		if e.RRule != "" {
+			w.line("RRULE:" + e.RRule)
+		}
+		for _, ex := range e.ExDates {
+			if e.AllDay {
+				w.line("EXDATE;VALUE=DATE:" + ex.Format(dateLayout))
+			} else {
+				w.line(dateTimeProperty("EXDATE", ex, loc))
+			}
+		}
+		w.line("SUMMARY:" + escapeText(e.Summary))
+		if e.Description != "" {
+			w.line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		if e.Location != "" {
+			w.line("LOCATION:" + escapeText(e.Location))
+		}
+		if len(e.Categories) > 0 {
+			escaped := make([]string, len(e.Categories))
+			for i, category := range e.Categories {
+				escaped[i] = escapeText(category)
+			}
+			w.line("CATEGORIES:" + strings.Join(escaped, ","))
+		}
+		w.line("END:VEVENT")
+	}
+	w.line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// UntilUTC memformat batas RRULE UNTIL dalam UTC sesuai RFC 5545.
+func UntilUTC(t time.Time) string {
+	return t.UTC().Format(utcDateTimeLayout)
+}
+
+func dateTimeProperty(name string, t time.Time, loc *time.Location) string {
+	if loc == time.UTC {
+		return name + ":" + t.UTC().Format(utcDateTimeLayout)
+	}
+	return fmt.Sprintf("%s;TZID=%s:%s", name, loc.String(), t.In(loc).Format(dateTimeLayout))
+}
+
+// writeTimezone menulis VTIMEZONE dengan offset saat ini. Zona waktu Indonesia
+// tidak memakai daylight saving sehingga satu komponen STANDARD sudah cukup.
+func writeTimezone(w *writer, loc *time.Location) {
+	name, offset := time.Now().In(loc).Zone()
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:" + loc.String())
+	w.line("BEGIN:STANDARD")
+	w.line("DTSTART:19700101T000000")
+	w.line("TZOFFSETFROM:" + formatOffset(offset))
+	w.line("TZOFFSETTO:" + formatOffset(offset))
+	w.line("TZNAME:" + name)
+	w.line("END:STANDARD")
+	w.line("END:VTIMEZONE")
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+// escapeText meng-escape karakter khusus pada nilai TEXT.
+func escapeText(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, ";", `\;`)
+	value = strings.ReplaceAll(value, ",", `\,`)
+	value = strings.ReplaceAll(value, "\r\n", `\n`)
+	value = strings.ReplaceAll(value, "\n", `\n`)
+	return value
+}
+
+type writer struct {
+	buf *bytes.Buffer
+}
+
+// line menulis satu content line dengan CRLF dan melipat baris yang lebih
+// dari 75 octet tanpa memotong karakter UTF-8.
+func (w *writer) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		// Baris lanjutan diawali satu spasi yang ikut dihitung.
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
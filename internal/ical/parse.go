@@ -0,0 +1,205 @@
+// internal/ical/parse.go
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Parse membaca event-event VEVENT dari file .ics, misalnya kalender libur
+// nasional. Hanya properti yang dipakai portal yang dibaca: UID, SUMMARY,
+// DESCRIPTION, LOCATION, DTSTART, DTEND, RRULE dan CATEGORIES. Waktu tanpa
+// zona waktu atau dengan TZID yang tidak dikenal dibaca dalam loc.
+func Parse(r io.Reader, loc *time.Location) ([]Event, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	var current *Event
+	hasEnd := false
+	for i, content := range lines {
+		name, params, value, ok := splitLine(content)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+			hasEnd = false
+		case name == "END" && value == "VEVENT":
+			if current == nil {
+				return nil, fmt.Errorf("line %d: END:VEVENT without BEGIN:VEVENT", i+1)
+			}
+			if current.Start.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no DTSTART", i+1, current.Summary)
+			}
+			if !hasEnd {
+				// Tanpa DTEND, event sehari penuh berlangsung satu hari dan event
+				// berjam berlangsung sesaat (RFC 5545 3.6.1).
+				current.End = current.Start
+				if current.AllDay {
+					current.End = current.Start.AddDate(0, 0, 1)
+				}
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeText(value)
+		case name == "LOCATION":
+			current.Location = unescapeText(value)
+		case name == "RRULE":
+			current.RRule = value
+		case name == "CATEGORIES":
+			for _, category := range splitEscaped(value) {
+				current.Categories = append(current.Categories, unescapeText(category))
+			}
+		case name == "DTSTART" || name == "DTEND":
+			t, allDay, err := parseTime(value, params, loc)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			if name == "DTSTART" {
+				current.Start, current.AllDay = t, allDay
+			} else {
+				current.End, hasEnd = t, true
+			}
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("unterminated VEVENT %q", current.Summary)
+	}
+	return events, nil
+}
+
+// unfold menggabungkan baris lanjutan (diawali spasi atau tab) ke baris sebelumnya.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// splitLine memecah content line "NAME;PARAM=VALUE:nilai".
+func splitLine(content string) (name string, params map[string]string, value string, ok bool) {
+	colon := -1
+	quoted := false
+	for i, r := range content {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", false
+	}
+	parts := strings.Split(content[:colon], ";")
+	params = make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, content[colon+1:], true
+}
+
+func parseTime(value string, params map[string]string, loc *time.Location) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcDateTimeLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return t.In(loc), false, nil
+	}
+	if tzid := params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+	}
+	return t, false, nil
+}
+
+// splitEscaped memecah nilai berdaftar pada koma yang tidak di-escape.
+func splitEscaped(value string) []string {
+	var parts []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			current.WriteRune('\\')
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(parts, current.String())
+}
+
+// unescapeText membalik escapeText.
+func unescapeText(value string) string {
+	var b strings.Builder
+	escaped := false
+	for _, r := range value {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+				continue
+			}
+			b.WriteRune(r)
+			continue
+		}
+		escaped = false
+		if r == 'n' || r == 'N' {
+			b.WriteRune('\n')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,139 @@
+package ical
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseString(t *testing.T, content string, loc *time.Location) []Event {
+	t.Helper()
+	events, err := Parse(strings.NewReader(content), loc)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return events
+}
+
+func TestParseUnfoldsLines(t *testing.T) {
+	content := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:libur-1@portal\r\n" +
+		"SUMMARY:Hari Kemerdekaan Republik\r\n" +
+		"  Indonesia\r\n" +
+		"DESCRIPTION:Upacara bendera\\, pukul 07.00\\n\r\n" +
+		"\tLibur nasional\r\n" +
+		"CATEGORIES:Libur Nasional,Upacara\\, Wajib\r\n" +
+		"DTSTART;VALUE=DATE:20250817\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events := parseString(t, content, nil)
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1", len(events))
+	}
+	event := events[0]
+	if event.Summary != "Hari Kemerdekaan Republik Indonesia" {
+		t.Errorf("Summary = %q", event.Summary)
+	}
+	if event.Description != "Upacara bendera, pukul 07.00\nLibur nasional" {
+		t.Errorf("Description = %q", event.Description)
+	}
+	if want := []string{"Libur Nasional", "Upacara, Wajib"}; !reflect.DeepEqual(event.Categories, want) {
+		t.Errorf("Categories = %q, want %q", event.Categories, want)
+	}
+}
+
+func TestParseTimes(t *testing.T) {
+	wib := time.FixedZone("WIB", 7*3600)
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		properties string
+		wantStart  time.Time
+		wantEnd    time.Time
+		wantAllDay bool
+	}{
+		{
+			name:       "all-day with exclusive DTEND",
+			properties: "DTSTART;VALUE=DATE:20251222\nDTEND;VALUE=DATE:20260103",
+			wantStart:  date(2025, 12, 22),
+			wantEnd:    date(2026, 1, 3),
+			wantAllDay: true,
+		},
+		{
+			name:       "all-day without VALUE parameter",
+			properties: "DTSTART:20250817\nDTEND:20250818",
+			wantStart:  date(2025, 8, 17),
+			wantEnd:    date(2025, 8, 18),
+			wantAllDay: true,
+		},
+		{
+			name:       "all-day without DTEND lasts one day",
+			properties: "DTSTART;VALUE=DATE:20250817",
+			wantStart:  date(2025, 8, 17),
+			wantEnd:    date(2025, 8, 18),
+			wantAllDay: true,
+		},
+		{
+			name:       "UTC date-time",
+			properties: "DTSTART:20250922T010000Z\nDTEND:20250922T030000Z",
+			wantStart:  time.Date(2025, 9, 22, 8, 0, 0, 0, wib),
+			wantEnd:    time.Date(2025, 9, 22, 10, 0, 0, 0, wib),
+		},
+		{
+			name:       "floating date-time uses the default location",
+			properties: "DTSTART:20250922T073000\nDTEND:20250922T090000",
+			wantStart:  time.Date(2025, 9, 22, 7, 30, 0, 0, wib),
+			wantEnd:    time.Date(2025, 9, 22, 9, 0, 0, 0, wib),
+		},
+		{
+			name:       "TZID",
+			properties: "DTSTART;TZID=Asia/Makassar:20250922T080000\nDTEND;TZID=\"Asia/Makassar\":20250922T100000",
+			wantStart:  time.Date(2025, 9, 22, 7, 0, 0, 0, wib),
+			wantEnd:    time.Date(2025, 9, 22, 9, 0, 0, 0, wib),
+		},
+		{
+			name:       "date-time without DTEND is instantaneous",
+			properties: "DTSTART:20250922T073000",
+			wantStart:  time.Date(2025, 9, 22, 7, 30, 0, 0, wib),
+			wantEnd:    time.Date(2025, 9, 22, 7, 30, 0, 0, wib),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Agenda\n" + tt.properties + "\nEND:VEVENT\nEND:VCALENDAR\n"
+			events := parseString(t, content, wib)
+			if len(events) != 1 {
+				t.Fatalf("events = %d, want 1", len(events))
+			}
+			event := events[0]
+			if !event.Start.Equal(tt.wantStart) || !event.End.Equal(tt.wantEnd) {
+				t.Errorf("Start, End = %v, %v, want %v, %v", event.Start, event.End, tt.wantStart, tt.wantEnd)
+			}
+			if event.AllDay != tt.wantAllDay {
+				t.Errorf("AllDay = %v, want %v", event.AllDay, tt.wantAllDay)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing DTSTART":   "BEGIN:VEVENT\nSUMMARY:Rapat\nEND:VEVENT\n",
+		"invalid date":      "BEGIN:VEVENT\nDTSTART;VALUE=DATE:2025-08-17\nEND:VEVENT\n",
+		"unterminated":      "BEGIN:VEVENT\nDTSTART:20250817\n",
+		"END without BEGIN": "END:VEVENT\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(content), nil); err == nil {
+				t.Error("Parse() succeeded, want an error")
+			}
+		})
+	}
+}
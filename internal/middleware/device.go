@@ -0,0 +1,38 @@
+// internal/middleware/device.go
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// AuthenticateDevice adalah middleware untuk perangkat presensi (reader RFID di
+// gerbang, kiosk) yang tidak login sebagai user. Perangkat mengirim kunci di
+// header X-Device-Key yang harus terdaftar di ATTENDANCE_DEVICE_KEYS dengan
+// format "nama:kunci,nama:kunci". Nama perangkat disimpan di context "device".
+func AuthenticateDevice() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-Device-Key")
+		if key == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "X-Device-Key header is required"})
+			return
+		}
+
+		for _, entry := range strings.Split(viper.GetString("ATTENDANCE_DEVICE_KEYS"), ",") {
+			name, secret, ok := strings.Cut(strings.TrimSpace(entry), ":")
+			if !ok || secret == "" {
+				continue
+			}
+			if subtle.ConstantTimeCompare([]byte(secret), []byte(key)) == 1 {
+				c.Set("device", name)
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid device key"})
+	}
+}
@@ -0,0 +1,89 @@
+// internal/pdf/font.go
+package pdf
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Lebar karakter ASCII 32-126 dari metrik AFM font standar, per 1000 unit em.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// TextWidth menghitung lebar teks dalam point.
+func TextWidth(font Font, size float64, text string) float64 {
+	widths := &helveticaWidths
+	if font == HelveticaBold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, c := range encode(text) {
+		if c >= 32 && c <= 126 {
+			total += widths[c-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// WrapText memecah teks menjadi baris-baris yang lebarnya tidak melebihi
+// maxWidth. Kata yang lebih panjang dari maxWidth dipotong per karakter.
+func WrapText(font Font, size, maxWidth float64, text string) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if TextWidth(font, size, candidate) <= maxWidth {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = word
+			// Karakter terakhir tetap satu baris walaupun lebih lebar dari maxWidth.
+			for TextWidth(font, size, line) > maxWidth && utf8.RuneCountInString(line) > 1 {
+				cut := fitRunes(font, size, maxWidth, line)
+				lines = append(lines, line[:cut])
+				line = line[cut:]
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// fitRunes mengembalikan posisi byte terjauh sehingga text[:pos] muat di maxWidth
+// (minimal satu karakter).
+func fitRunes(font Font, size, maxWidth float64, text string) int {
+	cut := 0
+	for i, r := range text {
+		next := i + len(string(r))
+		if cut > 0 && TextWidth(font, size, text[:next]) > maxWidth {
+			break
+		}
+		cut = next
+	}
+	return cut
+}
@@ -0,0 +1,100 @@
+package pdf
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		font Font
+		text string
+		want float64
+	}{
+		{Helvetica, "", 0},
+		{Helvetica, "A", 6.67},
+		{HelveticaBold, "A", 7.22},
+		{Helvetica, "il", 4.44},
+		{Helvetica, "é", 5.56}, // Di luar ASCII memakai lebar rata-rata
+		{Helvetica, "日", 5.56}, // Diganti "?" yang lebarnya juga 556
+	}
+	for _, tt := range tests {
+		if got := TextWidth(tt.font, 10, tt.text); math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("TextWidth(%d, 10, %q) = %v, want %v", tt.font, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	const size, maxWidth = 10, 100
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "fits", text: "Rekap presensi", want: []string{"Rekap presensi"}},
+		{name: "empty", text: "", want: []string{""}},
+		{
+			name: "wraps between words",
+			text: "Siswa wajib mengikuti upacara bendera setiap hari Senin pagi",
+			want: []string{"Siswa wajib mengikuti", "upacara bendera", "setiap hari Senin pagi"},
+		},
+		{
+			name: "keeps paragraphs",
+			text: "Baris pertama\r\n\nBaris ketiga",
+			want: []string{"Baris pertama", "", "Baris ketiga"},
+		},
+		{
+			name: "collapses spaces",
+			text: "  dua   spasi  ",
+			want: []string{"dua spasi"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapText(Helvetica, size, maxWidth, tt.text)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("WrapText() = %q, want %q", got, tt.want)
+			}
+			for _, line := range got {
+				if width := TextWidth(Helvetica, size, line); width > maxWidth {
+					t.Errorf("line %q is %v wide, limit %v", line, width, maxWidth)
+				}
+			}
+		})
+	}
+}
+
+func TestWrapTextBreaksLongWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		maxWidth float64
+	}{
+		{name: "ascii", word: strings.Repeat("W", 40), maxWidth: 60},
+		{name: "multibyte", word: strings.Repeat("é", 40), maxWidth: 50},
+		{name: "narrower than one character", word: "WWW", maxWidth: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := WrapText(HelveticaBold, 10, tt.maxWidth, tt.word)
+			if len(lines) < 2 {
+				t.Fatalf("WrapText() = %q, want the word split over several lines", lines)
+			}
+			if joined := strings.Join(lines, ""); joined != tt.word {
+				t.Errorf("lines join to %q, want %q", joined, tt.word)
+			}
+			for _, line := range lines {
+				if !utf8.ValidString(line) || line == "" {
+					t.Errorf("line %q is empty or splits a character", line)
+				}
+				// Satu karakter selalu ditulis walaupun lebih lebar dari batas.
+				if width := TextWidth(HelveticaBold, 10, line); width > tt.maxWidth && len([]rune(line)) > 1 {
+					t.Errorf("line %q is %v wide, limit %v", line, width, tt.maxWidth)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// internal/pdf/image.go
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/jpeg"
+
+	// Decoder PNG dan GIF didaftarkan untuk image.Decode.
+	_ "image/gif"
+	_ "image/png"
+)
+
+type imageObject struct {
+	width, height int
+	colorSpace    string
+	filter        string
+	data          []byte
+	mask          []byte // kanal alpha (FlateDecode), nil jika gambar tidak transparan
+}
+
+// ImageSize adalah ukuran asli gambar dalam piksel.
+type ImageSize struct {
+	Width, Height int
+}
+
+// Image menyisipkan gambar JPEG, PNG atau GIF ke halaman aktif dengan pojok kiri
+// atas (x, y) dan ukuran w x h point. Gambar PNG transparan (misalnya tanda
+// tangan hasil pindai) tetap transparan di PDF.
+func (d *Document) Image(data []byte, x, y, w, h float64) error {
+	img, err := loadImage(data)
+	if err != nil {
+		return err
+	}
+	d.images = append(d.images, img)
+	fmt.Fprintf(d.content(), "q %s 0 0 %s %s %s cm /Im%d Do Q\n",
+		num(w), num(h), num(x), num(d.height-y-h), len(d.images))
+	return nil
+}
+
+// DecodeImageSize membaca ukuran gambar tanpa men-decode seluruh piksel, untuk
+// menghitung ukuran tampil yang menjaga rasio.
+func DecodeImageSize(data []byte) (ImageSize, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ImageSize{}, fmt.Errorf("unsupported image: %w", err)
+	}
+	return ImageSize{Width: config.Width, Height: config.Height}, nil
+}
+
+func loadImage(data []byte) (*imageObject, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported image: %w", err)
+	}
+	// JPEG RGB dan grayscale bisa disalin apa adanya (DCTDecode); JPEG CMYK
+	// di-encode ulang seperti format lain.
+	if format == "jpeg" {
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid jpeg image: %w", err)
+		}
+		switch img.(type) {
+		case *image.Gray:
+			return &imageObject{width: config.Width, height: config.Height, colorSpace: "DeviceGray", filter: "DCTDecode", data: data}, nil
+		case *image.YCbCr:
+			return &imageObject{width: config.Width, height: config.Height, colorSpace: "DeviceRGB", filter: "DCTDecode", data: data}, nil
+		}
+		return flateImage(img)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+	return flateImage(img)
+}
+
+// flateImage menyimpan piksel sebagai RGB terkompresi beserta kanal alpha jika ada.
+func flateImage(img image.Image) (*imageObject, error) {
+	bounds := img.Bounds()
+	rgb := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	alpha := make([]byte, 0, bounds.Dx()*bounds.Dy())
+	transparent := false
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			if a < 0xffff {
+				transparent = true
+			}
+			// RGBA() sudah dikalikan alpha; kembalikan ke warna asli.
+			if a > 0 && a < 0xffff {
+				r, g, b = r*0xffff/a, g*0xffff/a, b*0xffff/a
+			}
+			rgb = append(rgb, byte(r>>8), byte(g>>8), byte(b>>8))
+			alpha = append(alpha, byte(a>>8))
+		}
+	}
+
+	object := &imageObject{width: bounds.Dx(), height: bounds.Dy(), colorSpace: "DeviceRGB", filter: "FlateDecode"}
+	var err error
+	if object.data, err = deflate(rgb); err != nil {
+		return nil, err
+	}
+	if transparent {
+		if object.mask, err = deflate(alpha); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,122 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+)
+
+// pngImage membuat PNG 4x2 merah; jika transparent, kolom kiri setengah transparan.
+func pngImage(t *testing.T, transparent bool) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			alpha := uint8(255)
+			if transparent && x == 0 {
+				alpha = 128
+			}
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, A: alpha})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func inflate(t *testing.T, data []byte) []byte {
+	t.Helper()
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("zlib: %v", err)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("zlib: %v", err)
+	}
+	return out
+}
+
+func TestLoadImageJPEG(t *testing.T) {
+	tests := []struct {
+		name       string
+		img        image.Image
+		colorSpace string
+	}{
+		{name: "rgb", img: image.NewRGBA(image.Rect(0, 0, 8, 4)), colorSpace: "DeviceRGB"},
+		{name: "gray", img: image.NewGray(image.Rect(0, 0, 8, 4)), colorSpace: "DeviceGray"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := jpeg.Encode(&buf, tt.img, nil); err != nil {
+				t.Fatal(err)
+			}
+			object, err := loadImage(buf.Bytes())
+			if err != nil {
+				t.Fatalf("loadImage() error = %v", err)
+			}
+			if object.filter != "DCTDecode" || object.colorSpace != tt.colorSpace {
+				t.Errorf("filter, colorSpace = %s, %s, want DCTDecode, %s", object.filter, object.colorSpace, tt.colorSpace)
+			}
+			if !bytes.Equal(object.data, buf.Bytes()) {
+				t.Error("JPEG data was re-encoded instead of copied")
+			}
+			if object.width != 8 || object.height != 4 || object.mask != nil {
+				t.Errorf("size = %dx%d, mask = %v", object.width, object.height, object.mask != nil)
+			}
+		})
+	}
+}
+
+func TestLoadImagePNG(t *testing.T) {
+	object, err := loadImage(pngImage(t, true))
+	if err != nil {
+		t.Fatalf("loadImage() error = %v", err)
+	}
+	if object.filter != "FlateDecode" || object.colorSpace != "DeviceRGB" {
+		t.Errorf("filter, colorSpace = %s, %s", object.filter, object.colorSpace)
+	}
+	rgb := inflate(t, object.data)
+	if len(rgb) != 4*2*3 {
+		t.Fatalf("rgb bytes = %d, want %d", len(rgb), 4*2*3)
+	}
+	// Warna piksel setengah transparan dikembalikan ke warna aslinya.
+	if rgb[0] < 198 || rgb[0] > 200 || rgb[3] != 200 {
+		t.Errorf("red channel = %d, %d, want 200", rgb[0], rgb[3])
+	}
+	if object.mask == nil {
+		t.Fatal("transparent PNG has no soft mask")
+	}
+	if alpha := inflate(t, object.mask); len(alpha) != 8 || alpha[0] != 128 || alpha[1] != 255 {
+		t.Errorf("alpha = %v, want 128 then 255", alpha)
+	}
+
+	opaque, err := loadImage(pngImage(t, false))
+	if err != nil {
+		t.Fatalf("loadImage() error = %v", err)
+	}
+	if opaque.mask != nil {
+		t.Error("opaque PNG has a soft mask")
+	}
+}
+
+func TestLoadImageRejectsInvalidData(t *testing.T) {
+	if _, err := loadImage([]byte("bukan gambar")); err == nil {
+		t.Error("loadImage() accepted invalid data")
+	}
+	doc := New(A4Width, A4Height)
+	if err := doc.Image([]byte("bukan gambar"), 0, 0, 10, 10); err == nil {
+		t.Error("Image() accepted invalid data")
+	}
+	if len(doc.images) != 0 {
+		t.Errorf("images = %d, want none after a failed Image()", len(doc.images))
+	}
+}
@@ -0,0 +1,219 @@
+// internal/pdf/pdf.go
+// Package pdf menulis dokumen PDF sederhana (teks, garis, kotak dan gambar)
+// untuk rekap yang dicetak dan ditandatangani, tanpa dependensi eksternal.
+// Koordinat memakai satuan point (1/72 inci) dengan titik (0,0) di pojok kiri
+// atas halaman.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Ukuran kertas A4 dalam point.
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Font adalah salah satu font standar PDF yang tidak perlu disematkan.
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+var fontNames = [...]string{"Helvetica", "Helvetica-Bold"}
+
+// Document adalah dokumen PDF yang sedang disusun. Halaman ditambahkan dengan
+// AddPage lalu diisi lewat method gambar; Bytes menghasilkan file PDF-nya.
+type Document struct {
+	width, height float64
+	pages         []*bytes.Buffer
+	images        []*imageObject
+	title         string
+}
+
+// New membuat dokumen kosong dengan ukuran halaman tertentu.
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// SetTitle mengisi judul dokumen yang tampil di penampil PDF.
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// Width dan Height mengembalikan ukuran halaman.
+func (d *Document) Width() float64  { return d.width }
+func (d *Document) Height() float64 { return d.height }
+
+// AddPage memulai halaman baru; gambar berikutnya masuk ke halaman ini.
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+}
+
+// PageCount mengembalikan jumlah halaman.
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+func (d *Document) content() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// Text menulis satu baris teks dengan baseline pada y.
+func (d *Document) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(d.content(), "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		int(font)+1, num(size), num(x), num(d.height-y), escape(encode(text)))
+}
+
+// TextRight menulis teks rata kanan dengan ujung kanan pada x.
+func (d *Document) TextRight(x, y float64, font Font, size float64, text string) {
+	d.Text(x-TextWidth(font, size, text), y, font, size, text)
+}
+
+// TextCenter menulis teks rata tengah terhadap x.
+func (d *Document) TextCenter(x, y float64, font Font, size float64, text string) {
+	d.Text(x-TextWidth(font, size, text)/2, y, font, size, text)
+}
+
+// Line menggambar garis lurus.
+func (d *Document) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(d.content(), "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(d.height-y1), num(x2), num(d.height-y2))
+}
+
+// Rect menggambar kotak dengan pojok kiri atas (x, y). Jika fill bernilai true
+// kotak diisi warna abu-abu gray (0 hitam, 1 putih) tanpa garis tepi.
+func (d *Document) Rect(x, y, w, h, lineWidth float64, fill bool, gray float64) {
+	if fill {
+		fmt.Fprintf(d.content(), "q %s g %s %s %s %s re f Q\n",
+			num(gray), num(x), num(d.height-y-h), num(w), num(h))
+		return
+	}
+	fmt.Fprintf(d.content(), "%s w %s %s %s %s re S\n",
+		num(lineWidth), num(x), num(d.height-y-h), num(w), num(h))
+}
+
+// Bytes menghasilkan isi file PDF.
+func (d *Document) Bytes() []byte {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body []byte) int {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n", len(offsets))
+		out.Write(body)
+		out.WriteString("\nendobj\n")
+		return len(offsets)
+	}
+	stream := func(dict string, data []byte) []byte {
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "<< %s /Length %d >>\nstream\n", dict, len(data))
+		b.Write(data)
+		b.WriteString("\nendstream")
+		return b.Bytes()
+	}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Nomor objek: 1 katalog, 2 daftar halaman, lalu font, gambar dan halaman.
+	object([]byte("<< /Type /Catalog /Pages 2 0 R >>"))
+	offsets = append(offsets, 0) // tempat objek 2, ditulis setelah halaman diketahui
+
+	var fonts strings.Builder
+	for i, name := range fontNames {
+		id := object([]byte(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name)))
+		fmt.Fprintf(&fonts, "/F%d %d 0 R ", i+1, id)
+	}
+	var xobjects strings.Builder
+	for i, img := range d.images {
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /%s /BitsPerComponent 8 /Filter /%s",
+			img.width, img.height, img.colorSpace, img.filter)
+		if img.mask != nil {
+			maskID := object(stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode",
+				img.width, img.height), img.mask))
+			dict += fmt.Sprintf(" /SMask %d 0 R", maskID)
+		}
+		id := object(stream(dict, img.data))
+		fmt.Fprintf(&xobjects, "/Im%d %d 0 R ", i+1, id)
+	}
+	resources := fmt.Sprintf("<< /Font << %s>> /XObject << %s>> >>", fonts.String(), xobjects.String())
+
+	var kids strings.Builder
+	for _, page := range d.pages {
+		contentID := object(stream("", page.Bytes()))
+		pageID := object([]byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources %s /Contents %d 0 R >>",
+			num(d.width), num(d.height), resources, contentID)))
+		fmt.Fprintf(&kids, "%d 0 R ", pageID)
+	}
+	infoID := object([]byte(fmt.Sprintf("<< /Title (%s) /Producer (STMADB Portal) >>", escape(encode(d.title)))))
+
+	// Objek daftar halaman ditulis terakhir; urutan objek di file tidak harus urut nomor.
+	offsets[1] = out.Len()
+	fmt.Fprintf(&out, "2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", kids.String(), len(d.pages))
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, infoID, xref)
+	return out.Bytes()
+}
+
+// num memformat angka tanpa nol berlebih, misalnya 12.5 atau 300.
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// encode mengubah teks UTF-8 ke WinAnsiEncoding. Karakter yang tidak ada di
+// encoding tersebut diganti "?".
+func encode(text string) []byte {
+	b := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			b = append(b, ' ')
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF):
+			b = append(b, byte(r))
+		default:
+			if c, ok := winAnsiExtra[r]; ok {
+				b = append(b, c)
+			} else {
+				b = append(b, '?')
+			}
+		}
+	}
+	return b
+}
+
+// winAnsiExtra adalah karakter WinAnsi di rentang 0x80-0x9F yang sering muncul.
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+func escape(b []byte) string {
+	var s strings.Builder
+	for _, c := range b {
+		if c == '(' || c == ')' || c == '\\' {
+			s.WriteByte('\\')
+		}
+		s.WriteByte(c)
+	}
+	return s.String()
+}
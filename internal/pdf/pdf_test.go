@@ -0,0 +1,173 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sampleDocument membuat dokumen dua halaman berisi teks, garis, kotak dan
+// gambar transparan agar semua jenis objek ikut ditulis.
+func sampleDocument(t *testing.T) *Document {
+	t.Helper()
+	doc := New(A4Width, A4Height)
+	doc.SetTitle("Rekap (September) \\ 2025")
+	doc.AddPage()
+	doc.Text(40, 60, HelveticaBold, 14, "REKAP PRESENSI (GURU)")
+	doc.Line(40, 70, 555, 70, 1)
+	doc.Rect(40, 80, 100, 20, 0.5, false, 0)
+	doc.Rect(40, 110, 100, 20, 0, true, 0.9)
+	if err := doc.Image(pngImage(t, true), 40, 140, 60, 30); err != nil {
+		t.Fatalf("Image() error = %v", err)
+	}
+	doc.AddPage()
+	doc.TextRight(555, 60, Helvetica, 10, "Yogyakarta, 22 September 2025")
+	return doc
+}
+
+func TestBytesCrossReference(t *testing.T) {
+	data := sampleDocument(t).Bytes()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header: %q", data[:16])
+	}
+	if !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatalf("missing %%%%EOF trailer")
+	}
+
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(data)
+	if match == nil {
+		t.Fatal("startxref not found")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table: %q", xref, data[xref:min(xref+16, len(data))])
+	}
+
+	table := string(data[xref:])
+	lines := strings.Split(table, "\n")
+	if lines[0] != "xref" {
+		t.Fatalf("xref header = %q", lines[0])
+	}
+	header := strings.Fields(lines[1])
+	first, _ := strconv.Atoi(header[0])
+	count, _ := strconv.Atoi(header[1])
+	if first != 0 || count < 2 {
+		t.Fatalf("xref subsection = %q", lines[1])
+	}
+	if lines[2] != "0000000000 65535 f " {
+		t.Errorf("free entry = %q", lines[2])
+	}
+	for id := 1; id < count; id++ {
+		entry := lines[2+id]
+		if len(entry) != 19 || !strings.HasSuffix(entry, " 00000 n ") {
+			t.Errorf("entry %d = %q, want 20-byte in-use entry", id, entry)
+			continue
+		}
+		offset, _ := strconv.Atoi(entry[:10])
+		want := strconv.Itoa(id) + " 0 obj\n"
+		if !bytes.HasPrefix(data[offset:], []byte(want)) {
+			t.Errorf("object %d offset %d points at %q", id, offset, data[offset:min(offset+16, len(data))])
+		}
+	}
+
+	trailer := regexp.MustCompile(`/Size (\d+) /Root 1 0 R /Info (\d+) 0 R`).FindStringSubmatch(table)
+	if trailer == nil {
+		t.Fatalf("trailer not found in %q", table)
+	}
+	if size, _ := strconv.Atoi(trailer[1]); size != count {
+		t.Errorf("/Size = %d, want %d", size, count)
+	}
+	if !bytes.Contains(data, []byte("/Type /Pages /Kids [")) || !bytes.Contains(data, []byte("/Count 2 >>")) {
+		t.Error("page tree does not list two pages")
+	}
+	if !bytes.Contains(data, []byte(`/Title (Rekap \(September\) \\ 2025)`)) {
+		t.Error("title is not escaped in the info dictionary")
+	}
+}
+
+func TestStreamLength(t *testing.T) {
+	data := sampleDocument(t).Bytes()
+	streams := regexp.MustCompile(`/Length (\d+) >>\nstream\n`).FindAllSubmatchIndex(data, -1)
+	if len(streams) == 0 {
+		t.Fatal("no streams found")
+	}
+	for _, loc := range streams {
+		length, _ := strconv.Atoi(string(data[loc[2]:loc[3]]))
+		end := loc[1] + length
+		if !bytes.HasPrefix(data[end:], []byte("\nendstream")) {
+			t.Errorf("stream at %d with /Length %d is not followed by endstream", loc[0], length)
+		}
+	}
+}
+
+func TestEmptyDocumentHasPage(t *testing.T) {
+	doc := New(A4Width, A4Height)
+	data := doc.Bytes()
+	if doc.PageCount() != 1 || !bytes.Contains(data, []byte("/Count 1 >>")) {
+		t.Errorf("PageCount() = %d, want a single blank page", doc.PageCount())
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := map[string]string{
+		"Rekap":          "Rekap",
+		"(catatan)":      `\(catatan\)`,
+		`C:\rekap`:       `C:\\rekap`,
+		`a) \ (b`:        `a\) \\ \(b`,
+		"tanpa escape %": "tanpa escape %",
+	}
+	for in, want := range tests {
+		if got := escape([]byte(in)); got != want {
+			t.Errorf("escape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []byte
+	}{
+		{"Rekap 2025", []byte("Rekap 2025")},
+		{"Café", []byte("Caf\xe9")},
+		{"± ½ ©", []byte("\xb1 \xbd \xa9")},
+		{"Rp 5.000 – €", []byte("Rp 5.000 \x96 \x80")},
+		{"“kutip” ‘tunggal’ …", []byte("\x93kutip\x94 \x91tunggal\x92 \x85")},
+		{"baris\nbaru\tdan\rtab", []byte("baris baru dan tab")},
+		{"日本", []byte("??")},
+		{"tanda ✓ 😀", []byte("tanda ? ?")},
+		{"Ā", []byte("?")},
+	}
+	for _, tt := range tests {
+		if got := encode(tt.in); !bytes.Equal(got, tt.want) {
+			t.Errorf("encode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestTextIsEscapedInContent(t *testing.T) {
+	doc := New(A4Width, A4Height)
+	doc.Text(10, 20, Helvetica, 10, `Nilai (rata\rata) ✓`)
+	content := doc.content().String()
+	if want := `(Nilai \(rata\\rata\) ?) Tj`; !strings.Contains(content, want) {
+		t.Errorf("content = %q, want it to contain %q", content, want)
+	}
+}
+
+func TestNum(t *testing.T) {
+	tests := map[float64]string{
+		300:    "300",
+		12.5:   "12.5",
+		595.28: "595.28",
+		0.333:  "0.33",
+		-0.001: "0",
+		-12.5:  "-12.5",
+	}
+	for in, want := range tests {
+		if got := num(in); got != want {
+			t.Errorf("num(%v) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -19,6 +19,56 @@ func SetupRouter(dbClient *db.PrismaClient) *gin.Engine {
 	authHandler := handler.NewAuthHandler(authService)
 	userService := service.NewUserService(dbClient)
 	userHandler := handler.NewUserHandler(userService)
+	rolloverService := service.NewRolloverService(dbClient)
+	rolloverHandler := handler.NewRolloverHandler(rolloverService)
+	studentService := service.NewStudentService(dbClient)
+	studentHandler := handler.NewStudentHandler(studentService)
+	subjectService := service.NewSubjectService(dbClient)
+	subjectHandler := handler.NewSubjectHandler(subjectService)
+	scheduleService := service.NewScheduleService(dbClient)
+	scheduleHandler := handler.NewScheduleHandler(scheduleService)
+	timetableService := service.NewTimetableService(dbClient)
+	timetableHandler := handler.NewTimetableHandler(timetableService)
+	teachingAssignmentService := service.NewTeachingAssignmentService(dbClient)
+	teachingAssignmentHandler := handler.NewTeachingAssignmentHandler(teachingAssignmentService)
+	timetableDraftService := service.NewTimetableDraftService(dbClient)
+	timetableDraftHandler := handler.NewTimetableDraftHandler(timetableDraftService)
+	substitutionService := service.NewSubstitutionService(dbClient)
+	substitutionHandler := handler.NewSubstitutionHandler(substitutionService)
+	teachingJournalService := service.NewTeachingJournalService(dbClient)
+	teachingJournalHandler := handler.NewTeachingJournalHandler(teachingJournalService)
+	journalComplianceService := service.NewJournalComplianceService(dbClient)
+	journalComplianceHandler := handler.NewJournalComplianceHandler(journalComplianceService)
+	calendarService := service.NewCalendarService(dbClient)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	journalRecapService := service.NewJournalRecapService(dbClient)
+	journalRecapHandler := handler.NewJournalRecapHandler(journalRecapService)
+	companyService := service.NewCompanyService(dbClient)
+	companyHandler := handler.NewCompanyHandler(companyService)
+	internshipPlacementService := service.NewInternshipPlacementService(dbClient)
+	internshipPlacementHandler := handler.NewInternshipPlacementHandler(internshipPlacementService)
+	internshipJournalService := service.NewInternshipJournalService(dbClient)
+	internshipJournalHandler := handler.NewInternshipJournalHandler(internshipJournalService)
+	internshipAttendanceService := service.NewInternshipAttendanceService(dbClient)
+	internshipAttendanceHandler := handler.NewInternshipAttendanceHandler(internshipAttendanceService)
+	internshipRubricService := service.NewInternshipRubricService(dbClient)
+	internshipRubricHandler := handler.NewInternshipRubricHandler(internshipRubricService)
+	internshipAssessmentService := service.NewInternshipAssessmentService(dbClient)
+	internshipAssessmentHandler := handler.NewInternshipAssessmentHandler(internshipAssessmentService)
+	internshipMonitoringService := service.NewInternshipMonitoringService(dbClient)
+	internshipMonitoringHandler := handler.NewInternshipMonitoringHandler(internshipMonitoringService)
+	attendanceService := service.NewAttendanceService(dbClient)
+	attendanceHandler := handler.NewAttendanceHandler(attendanceService)
+	attendanceRuleService := service.NewAttendanceRuleService(dbClient)
+	attendanceRuleHandler := handler.NewAttendanceRuleHandler(attendanceRuleService)
+	dailyAttendanceService := service.NewDailyAttendanceService(dbClient)
+	dailyAttendanceHandler := handler.NewDailyAttendanceHandler(dailyAttendanceService)
+	attendanceRecapService := service.NewAttendanceRecapService(dbClient)
+	attendanceRecapHandler := handler.NewAttendanceRecapHandler(attendanceRecapService)
+	fileService := service.NewFileService(dbClient)
+	fileHandler := handler.NewFileHandler(fileService)
+	leaveRequestService := service.NewLeaveRequestService(dbClient)
+	leaveRequestHandler := handler.NewLeaveRequestHandler(leaveRequestService)
 
 	router := gin.Default()
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -45,6 +95,277 @@ func SetupRouter(dbClient *db.PrismaClient) *gin.Engine {
 			users.PUT("/:id", userHandler.UpdateUser)
 			users.DELETE("/:id", userHandler.DeleteUser)
 		}
+
+		// Rute Tahun Ajaran (semester dan kenaikan kelas)
+		academicYears := v1.Group("/academic-years")
+		academicYears.Use(middleware.Authenticate(dbClient))
+		{
+			academicYears.GET("", calendarHandler.GetAcademicYears)
+			academicYears.GET("/:id", calendarHandler.GetAcademicYearByID)
+			academicYears.POST("", middleware.Authorize("admin"), calendarHandler.CreateAcademicYear)
+			academicYears.PUT("/:id", middleware.Authorize("admin"), calendarHandler.UpdateAcademicYear)
+			academicYears.DELETE("/:id", middleware.Authorize("admin"), calendarHandler.DeleteAcademicYear)
+			academicYears.POST("/rollover/preview", middleware.Authorize("admin"), rolloverHandler.PreviewRollover)
+			academicYears.POST("/rollover/apply", middleware.Authorize("admin"), rolloverHandler.ApplyRollover)
+		}
+
+		// Rute Kalender Sekolah (libur dan hari tanpa KBM)
+		calendar := v1.Group("/calendar")
+		calendar.Use(middleware.Authenticate(dbClient))
+		{
+			calendar.GET("/events", calendarHandler.GetEvents)
+			calendar.GET("/events/:id", calendarHandler.GetEventByID)
+			calendar.GET("/school-days", calendarHandler.CheckSchoolDay)
+			calendar.POST("/events", middleware.Authorize("admin"), calendarHandler.CreateEvent)
+			calendar.PUT("/events/:id", middleware.Authorize("admin"), calendarHandler.UpdateEvent)
+			calendar.DELETE("/events/:id", middleware.Authorize("admin"), calendarHandler.DeleteEvent)
+			calendar.POST("/import", middleware.Authorize("admin"), calendarHandler.ImportICal)
+		}
+
+		// Rute Siswa
+		students := v1.Group("/students")
+		students.Use(middleware.Authenticate(dbClient))
+		{
+			students.GET("/:id/class-history", middleware.Authorize("admin", "teacher", "staff"), studentHandler.GetClassHistory)
+			students.PUT("/:id/class", middleware.Authorize("admin"), studentHandler.ChangeClass)
+			students.PUT("/:id/rfid", middleware.Authorize("admin", "staff"), studentHandler.EnrollCard)
+			students.DELETE("/:id/rfid", middleware.Authorize("admin", "staff"), studentHandler.RemoveCard)
+		}
+
+		// Rute Mata Pelajaran & Kurikulum
+		subjects := v1.Group("/subjects")
+		subjects.Use(middleware.Authenticate(dbClient))
+		{
+			subjects.GET("", subjectHandler.GetSubjects)
+			subjects.GET("/:id", subjectHandler.GetSubjectByID)
+			subjects.POST("", middleware.Authorize("admin"), subjectHandler.CreateSubject)
+			subjects.PUT("/:id", middleware.Authorize("admin"), subjectHandler.UpdateSubject)
+			subjects.DELETE("/:id", middleware.Authorize("admin"), subjectHandler.DeleteSubject)
+		}
+		curriculum := v1.Group("/curriculum")
+		curriculum.Use(middleware.Authenticate(dbClient))
+		{
+			curriculum.GET("", subjectHandler.GetCurriculum)
+			curriculum.PUT("", middleware.Authorize("admin"), subjectHandler.UpsertCurriculumItem)
+			curriculum.DELETE("/:id", middleware.Authorize("admin"), subjectHandler.DeleteCurriculumItem)
+		}
+
+		// Rute Kelas
+		classes := v1.Group("/classes")
+		classes.Use(middleware.Authenticate(dbClient))
+		{
+			classes.GET("/:id/curriculum-report", middleware.Authorize("admin", "teacher"), subjectHandler.GetClassCurriculumReport)
+			classes.PUT("/:id/schedules", middleware.Authorize("admin"), scheduleHandler.ReplaceClassTimetable)
+		}
+
+		// Rute Jadwal Pelajaran
+		schedules := v1.Group("/schedules")
+		schedules.Use(middleware.Authenticate(dbClient))
+		{
+			schedules.GET("", scheduleHandler.GetSchedules)
+			schedules.GET("/:id", scheduleHandler.GetScheduleByID)
+			schedules.POST("", middleware.Authorize("admin"), scheduleHandler.CreateSchedule)
+			schedules.PUT("/:id", middleware.Authorize("admin"), scheduleHandler.UpdateSchedule)
+			schedules.DELETE("/:id", middleware.Authorize("admin"), scheduleHandler.DeleteSchedule)
+		}
+
+		// Rute Tampilan Jadwal Mingguan
+		timetables := v1.Group("/timetables")
+		timetables.Use(middleware.Authenticate(dbClient))
+		{
+			timetables.GET("/classes/:id", timetableHandler.GetClassTimetable)
+			timetables.GET("/teachers/:id", timetableHandler.GetTeacherTimetable)
+			timetables.GET("/rooms/:room", timetableHandler.GetRoomTimetable)
+		}
+		// Rute Penyusun Jadwal Otomatis, khusus admin
+		teachingAssignments := v1.Group("/teaching-assignments")
+		teachingAssignments.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin"))
+		{
+			teachingAssignments.GET("", teachingAssignmentHandler.GetAssignments)
+			teachingAssignments.PUT("", teachingAssignmentHandler.UpsertAssignment)
+			teachingAssignments.DELETE("/:id", teachingAssignmentHandler.DeleteAssignment)
+		}
+		teachers := v1.Group("/teachers")
+		teachers.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin"))
+		{
+			teachers.GET("/:id/unavailability", teachingAssignmentHandler.GetUnavailability)
+			teachers.PUT("/:id/unavailability", teachingAssignmentHandler.ReplaceUnavailability)
+			teachers.GET("/:id/signature", fileHandler.GetSignature)
+			teachers.PUT("/:id/signature", fileHandler.UploadSignature)
+		}
+		timetableDrafts := v1.Group("/timetable-drafts")
+		timetableDrafts.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin"))
+		{
+			timetableDrafts.POST("", timetableDraftHandler.GenerateDraft)
+			timetableDrafts.GET("", timetableDraftHandler.GetDrafts)
+			timetableDrafts.GET("/:id", timetableDraftHandler.GetDraft)
+			timetableDrafts.POST("/:id/commit", timetableDraftHandler.CommitDraft)
+			timetableDrafts.DELETE("/:id", timetableDraftHandler.DeleteDraft)
+		}
+
+		// Rute Guru Pengganti
+		substitutions := v1.Group("/substitutions")
+		substitutions.Use(middleware.Authenticate(dbClient))
+		{
+			substitutions.GET("/affected-lessons", middleware.Authorize("admin"), substitutionHandler.GetAffectedLessons)
+			substitutions.GET("/suggestions", middleware.Authorize("admin"), substitutionHandler.SuggestSubstitutes)
+			substitutions.GET("", middleware.Authorize("admin"), substitutionHandler.GetSubstitutions)
+			substitutions.POST("", middleware.Authorize("admin"), substitutionHandler.CreateSubstitution)
+			substitutions.DELETE("/:id", middleware.Authorize("admin"), substitutionHandler.DeleteSubstitution)
+			substitutions.POST("/:id/journal", middleware.Authorize("teacher"), substitutionHandler.WriteJournal)
+		}
+
+		// Rute Jurnal KBM
+		teachingJournals := v1.Group("/teaching-journals")
+		teachingJournals.Use(middleware.Authenticate(dbClient))
+		{
+			teachingJournals.GET("", middleware.Authorize("admin", "teacher", "staff"), teachingJournalHandler.GetJournals)
+			teachingJournals.GET("/roster", middleware.Authorize("teacher"), teachingJournalHandler.GetRoster)
+			teachingJournals.GET("/recap", middleware.Authorize("admin", "teacher", "staff"), journalRecapHandler.DownloadMonthlyRecap)
+			teachingJournals.GET("/:id", middleware.Authorize("admin", "teacher", "staff"), teachingJournalHandler.GetJournalByID)
+			teachingJournals.POST("", middleware.Authorize("teacher"), teachingJournalHandler.CreateJournal)
+			teachingJournals.PUT("/:id", middleware.Authorize("teacher"), teachingJournalHandler.UpdateJournal)
+		}
+		lessonAttendances := v1.Group("/lesson-attendances")
+		lessonAttendances.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin", "teacher", "staff"))
+		{
+			lessonAttendances.GET("", teachingJournalHandler.GetLessonAttendances)
+		}
+
+		// Rute PKL: DU/DI mitra, dikelola koordinator PKL (admin/staf)
+		companies := v1.Group("/companies")
+		companies.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin", "teacher", "staff"))
+		{
+			companies.GET("", companyHandler.GetCompanies)
+			companies.GET("/:id", companyHandler.GetCompanyByID)
+			companies.POST("", middleware.Authorize("admin", "staff"), companyHandler.CreateCompany)
+			companies.PUT("/:id", middleware.Authorize("admin", "staff"), companyHandler.UpdateCompany)
+			companies.DELETE("/:id", middleware.Authorize("admin", "staff"), companyHandler.DeleteCompany)
+		}
+		placements := v1.Group("/internship-placements")
+		placements.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin", "teacher", "staff"))
+		{
+			placements.GET("", internshipPlacementHandler.GetPlacements)
+			placements.GET("/:id", internshipPlacementHandler.GetPlacementByID)
+			placements.POST("", middleware.Authorize("admin", "staff"), internshipPlacementHandler.CreatePlacement)
+			placements.POST("/bulk", middleware.Authorize("admin", "staff"), internshipPlacementHandler.BulkAssign)
+			placements.PUT("/:id", middleware.Authorize("admin", "staff"), internshipPlacementHandler.UpdatePlacement)
+			placements.PUT("/:id/status", middleware.Authorize("admin", "staff"), internshipPlacementHandler.ChangeStatus)
+			placements.DELETE("/:id", middleware.Authorize("admin", "staff"), internshipPlacementHandler.DeletePlacement)
+		}
+		internshipJournals := v1.Group("/internship-journals")
+		internshipJournals.Use(middleware.Authenticate(dbClient))
+		{
+			internshipJournals.GET("", middleware.Authorize("admin", "teacher", "staff"), internshipJournalHandler.GetJournals)
+			internshipJournals.GET("/pending", middleware.Authorize("admin", "teacher", "staff"), internshipJournalHandler.GetPendingQueue)
+			internshipJournals.POST("/review", middleware.Authorize("teacher"), internshipJournalHandler.ReviewJournals)
+			internshipJournals.GET("/:id", internshipJournalHandler.GetJournalByID)
+			internshipJournals.POST("", middleware.Authorize("student"), internshipJournalHandler.SubmitJournal)
+			internshipJournals.PUT("/:id", middleware.Authorize("student"), internshipJournalHandler.UpdateJournal)
+		}
+		internshipAttendances := v1.Group("/internship-attendances")
+		internshipAttendances.Use(middleware.Authenticate(dbClient))
+		{
+			internshipAttendances.GET("", middleware.Authorize("admin", "teacher", "staff"), internshipAttendanceHandler.GetAttendances)
+			internshipAttendances.GET("/placements/:id", internshipAttendanceHandler.GetPresence)
+			internshipAttendances.POST("/check-in", middleware.Authorize("student"), internshipAttendanceHandler.CheckIn)
+			internshipAttendances.POST("/check-out", middleware.Authorize("student"), internshipAttendanceHandler.CheckOut)
+		}
+		internshipRubrics := v1.Group("/internship-rubrics")
+		internshipRubrics.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin", "teacher", "staff"))
+		{
+			internshipRubrics.GET("", internshipRubricHandler.GetRubrics)
+			internshipRubrics.GET("/:id", internshipRubricHandler.GetRubricByID)
+			internshipRubrics.POST("", middleware.Authorize("admin", "staff"), internshipRubricHandler.CreateRubric)
+			internshipRubrics.PUT("/:id", middleware.Authorize("admin", "staff"), internshipRubricHandler.UpdateRubric)
+			internshipRubrics.DELETE("/:id", middleware.Authorize("admin", "staff"), internshipRubricHandler.DeleteRubric)
+		}
+		internshipAssessments := v1.Group("/internship-assessments")
+		internshipAssessments.Use(middleware.Authenticate(dbClient))
+		{
+			internshipAssessments.GET("/:id", internshipAssessmentHandler.GetAssessment)
+			internshipAssessments.GET("/:id/certificate", internshipAssessmentHandler.DownloadCertificate)
+			internshipAssessments.PUT("/:id/supervisor-scores", middleware.Authorize("teacher"), internshipAssessmentHandler.SaveSupervisorScores)
+			internshipAssessments.POST("/:id/mentor-links", middleware.Authorize("admin", "teacher", "staff"), internshipAssessmentHandler.CreateMentorLink)
+		}
+		internshipMonitoring := v1.Group("/internship-monitoring")
+		internshipMonitoring.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin", "teacher", "staff"))
+		{
+			internshipMonitoring.GET("", internshipMonitoringHandler.GetMonitoring)
+		}
+		attendances := v1.Group("/attendances")
+		attendances.Use(middleware.Authenticate(dbClient))
+		{
+			attendances.GET("", middleware.Authorize("admin", "staff"), attendanceHandler.GetAttendances)
+			attendances.GET("/daily", middleware.Authorize("admin", "staff"), dailyAttendanceHandler.GetDaily)
+			attendances.POST("/check-in", middleware.Authorize("teacher", "staff", "student"), attendanceHandler.CheckIn)
+			attendances.POST("/check-out", middleware.Authorize("teacher", "staff", "student"), attendanceHandler.CheckOut)
+			attendances.GET("/:id/photo", fileHandler.GetAttendancePhoto)
+		}
+		attendanceRules := v1.Group("/attendance-rules")
+		attendanceRules.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin", "staff"))
+		{
+			attendanceRules.GET("", attendanceRuleHandler.GetRules)
+			attendanceRules.POST("", middleware.Authorize("admin"), attendanceRuleHandler.CreateRule)
+			attendanceRules.PUT("/:id", middleware.Authorize("admin"), attendanceRuleHandler.UpdateRule)
+			attendanceRules.DELETE("/:id", middleware.Authorize("admin"), attendanceRuleHandler.DeleteRule)
+		}
+		leaveRequests := v1.Group("/leave-requests")
+		leaveRequests.Use(middleware.Authenticate(dbClient))
+		{
+			leaveRequests.GET("", middleware.Authorize("admin", "teacher", "staff"), leaveRequestHandler.GetLeaveRequests)
+			leaveRequests.POST("", middleware.Authorize("teacher", "staff", "student"), leaveRequestHandler.SubmitLeaveRequest)
+			leaveRequests.GET("/:id", leaveRequestHandler.GetLeaveRequestByID)
+			leaveRequests.POST("/:id/cancel", leaveRequestHandler.CancelLeaveRequest)
+			leaveRequests.POST("/:id/review", middleware.Authorize("admin", "teacher", "staff"), leaveRequestHandler.ReviewLeaveRequest)
+			leaveRequests.GET("/:id/attachment", fileHandler.GetLeaveAttachment)
+			leaveRequests.PUT("/:id/attachment", fileHandler.UploadLeaveAttachment)
+		}
+
+		// Rute Laporan
+		reports := v1.Group("/reports")
+		reports.Use(middleware.Authenticate(dbClient), middleware.Authorize("admin", "staff"))
+		{
+			reports.GET("/journal-compliance", journalComplianceHandler.GetReport)
+			reports.GET("/attendance-recap", attendanceRecapHandler.GetMonthlyRecap)
+		}
+
+		// Feed kalender publik, diamankan dengan token bertanda tangan
+		v1.GET("/timetable-feeds/:token", timetableHandler.GetCalendarFeed)
+
+		// Unduhan file unggahan, diamankan dengan tautan bertanda tangan yang kedaluwarsa
+		v1.GET("/files/:token", fileHandler.Download)
+
+		// Rute perangkat presensi, diamankan dengan kunci perangkat
+		devices := v1.Group("/devices")
+		devices.Use(middleware.AuthenticateDevice())
+		{
+			devices.POST("/rfid-taps", attendanceHandler.TapRFID)
+			devices.POST("/attendance-sync", attendanceHandler.SyncDeviceAttendances)
+		}
+
+		// Penilaian PKL oleh mentor DU/DI, diamankan dengan token sekali pakai
+		v1.GET("/mentor-assessments/:token", internshipAssessmentHandler.GetMentorForm)
+		v1.POST("/mentor-assessments/:token", internshipAssessmentHandler.SubmitMentorScores)
+
+		// Rute milik user yang sedang login
+		me := v1.Group("/me")
+		me.Use(middleware.Authenticate(dbClient))
+		{
+			me.GET("/timetable", timetableHandler.GetMyTimetable)
+			me.GET("/timetable/ical", timetableHandler.DownloadMyCalendar)
+			me.GET("/timetable/feed", timetableHandler.GetMyCalendarFeed)
+			me.POST("/timetable/feed/regenerate", timetableHandler.RegenerateMyCalendarFeed)
+			me.GET("/substitutions", middleware.Authorize("teacher"), substitutionHandler.GetMySubstitutions)
+			me.GET("/lesson-attendances", middleware.Authorize("student"), teachingJournalHandler.GetMyLessonAttendances)
+			me.GET("/journal-reminders", middleware.Authorize("teacher"), journalComplianceHandler.GetMyReminders)
+			me.GET("/internship-journals", middleware.Authorize("student"), internshipJournalHandler.GetMyJournals)
+			me.GET("/attendance", attendanceHandler.GetMyToday)
+			me.GET("/attendance/daily", dailyAttendanceHandler.GetMyDaily)
+			me.GET("/leave-requests", leaveRequestHandler.GetMyLeaveRequests)
+			me.GET("/signature", middleware.Authorize("teacher"), fileHandler.GetMySignature)
+			me.PUT("/signature", middleware.Authorize("teacher"), fileHandler.UploadMySignature)
+		}
 	}
 
 	return router
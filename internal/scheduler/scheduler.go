@@ -0,0 +1,71 @@
+// internal/scheduler/scheduler.go
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Job adalah tugas yang dijalankan setiap hari pada jam tertentu.
+type Job struct {
+	Name string
+	At   string // HH:MM menurut zona waktu scheduler
+	Run  func(now time.Time) error
+}
+
+// Scheduler menjalankan tugas harian di latar belakang selama server hidup.
+type Scheduler struct {
+	loc  *time.Location
+	jobs []Job
+}
+
+func New(loc *time.Location) *Scheduler {
+	return &Scheduler{loc: loc}
+}
+
+// Daily mendaftarkan tugas harian. Jam yang tidak valid langsung ditolak agar
+// kesalahan konfigurasi terlihat saat server dinyalakan.
+func (s *Scheduler) Daily(name, at string, run func(now time.Time) error) error {
+	if _, err := time.Parse("15:04", at); err != nil {
+		return fmt.Errorf("invalid time %q for job %s, expected HH:MM", at, name)
+	}
+	s.jobs = append(s.jobs, Job{Name: name, At: at, Run: run})
+	return nil
+}
+
+// Start menjalankan setiap tugas di goroutine masing-masing sampai ctx dibatalkan.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	for {
+		next := nextRun(time.Now().In(s.loc), job.At)
+		logrus.Infof("Job %s scheduled at %s", job.Name, next.Format(time.RFC3339))
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case now := <-timer.C:
+			if err := job.Run(now.In(s.loc)); err != nil {
+				logrus.Errorf("Job %s failed: %v", job.Name, err)
+			}
+		}
+	}
+}
+
+// nextRun menghitung waktu jalan berikutnya setelah now untuk jam HH:MM.
+func nextRun(now time.Time, at string) time.Time {
+	clock, _ := time.Parse("15:04", at)
+	next := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
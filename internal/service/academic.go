@@ -0,0 +1,120 @@
+// internal/service/academic.go
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gradeLevels adalah urutan tingkat kelas SMK. Setiap tingkat bisa ditulis
+// dalam angka ("10") maupun romawi ("X"), sesuai kolom Class.grade_level.
+var gradeLevels = [][]string{
+	{"10", "X"},
+	{"11", "XI"},
+	{"12", "XII"},
+}
+
+// gradeIndex mengembalikan posisi tingkat pada gradeLevels dan apakah ditulis romawi.
+func gradeIndex(grade string) (index int, roman bool, ok bool) {
+	g := strings.ToUpper(strings.TrimSpace(grade))
+	for i, names := range gradeLevels {
+		if g == names[0] {
+			return i, false, true
+		}
+		if g == names[1] {
+			return i, true, true
+		}
+	}
+	return 0, false, false
+}
+
+// normalizeGradeLevel mengubah "X"/"10" menjadi bentuk angka agar mudah dibandingkan.
+func normalizeGradeLevel(grade string) string {
+	i, _, ok := gradeIndex(grade)
+	if !ok {
+		return strings.ToUpper(strings.TrimSpace(grade))
+	}
+	return gradeLevels[i][0]
+}
+
+// sameGradeLevel membandingkan dua tingkat kelas tanpa peduli format penulisannya.
+func sameGradeLevel(a, b string) bool {
+	return normalizeGradeLevel(a) == normalizeGradeLevel(b)
+}
+
+// nextGradeLevel mengembalikan tingkat berikutnya dengan format yang sama.
+// final bernilai true jika tingkat tersebut adalah tingkat akhir (siswa lulus).
+func nextGradeLevel(grade string) (next string, final bool, err error) {
+	i, roman, ok := gradeIndex(grade)
+	if !ok {
+		return "", false, validationError("unknown grade level %q", grade)
+	}
+	if i == len(gradeLevels)-1 {
+		return "", true, nil
+	}
+	if roman {
+		return gradeLevels[i+1][1], false, nil
+	}
+	return gradeLevels[i+1][0], false, nil
+}
+
+// nextAcademicYear mengubah "2024/2025" menjadi "2025/2026".
+func nextAcademicYear(year string) (string, error) {
+	start, end, err := parseAcademicYear(year)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d/%d", start+1, end+1), nil
+}
+
+// parseAcademicYear memecah tahun ajaran "2024/2025" menjadi tahun awal dan akhir.
+func parseAcademicYear(year string) (start, end int, err error) {
+	parts := strings.Split(strings.TrimSpace(year), "/")
+	if len(parts) != 2 {
+		return 0, 0, validationError("academic year must use the format YYYY/YYYY")
+	}
+	start, errStart := strconv.Atoi(parts[0])
+	end, errEnd := strconv.Atoi(parts[1])
+	if errStart != nil || errEnd != nil || end != start+1 {
+		return 0, 0, validationError("academic year must use the format YYYY/YYYY")
+	}
+	return start, end, nil
+}
+
+// promotedClassName mengganti token tingkat pada nama kelas, misalnya
+// "X RPL 1" menjadi "XI RPL 1". ok bernilai false jika nama kelas tidak
+// mengandung token tingkat sehingga nama baru harus ditentukan manual.
+func promotedClassName(className, grade, nextGrade string) (string, bool) {
+	tokens := strings.Fields(className)
+	for i, token := range tokens {
+		if strings.EqualFold(token, grade) {
+			tokens[i] = nextGrade
+			return strings.Join(tokens, " "), true
+		}
+	}
+	return "", false
+}
+
+// academicYearOf mengembalikan tahun ajaran standar untuk sebuah tanggal: tahun
+// ajaran dimulai bulan Juli, sehingga 2025-08-01 termasuk "2025/2026". Gunakan
+// resolveAcademicYear agar rentang tahun ajaran yang terdaftar diutamakan.
+func academicYearOf(date time.Time) string {
+	if date.Month() >= time.July {
+		return fmt.Sprintf("%d/%d", date.Year(), date.Year()+1)
+	}
+	return fmt.Sprintf("%d/%d", date.Year()-1, date.Year())
+}
+
+// defaultAcademicYearRange mengembalikan rentang tanggal standar sebuah tahun
+// ajaran: 1 Juli tahun awal sampai 30 Juni tahun akhir.
+func defaultAcademicYearRange(year string) (start, end time.Time, err error) {
+	startYear, endYear, err := parseAcademicYear(year)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start = time.Date(startYear, time.July, 1, 0, 0, 0, 0, time.UTC)
+	end = time.Date(endYear, time.June, 30, 0, 0, 0, 0, time.UTC)
+	return start, end, nil
+}
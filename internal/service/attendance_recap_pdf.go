@@ -0,0 +1,245 @@
+// internal/service/attendance_recap_pdf.go
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/pdf"
+)
+
+// Tata letak rekap presensi bulanan pada A4 mendatar (satuan point).
+const (
+	attendanceRecapFontSize = 7.0
+	attendanceRecapRowH     = 12.0
+	attendanceRecapHeaderH  = 20.0
+	attendanceRecapDayW     = 13.0
+	attendanceRecapSumW     = 19.0
+	attendanceRecapLogoSize = 52.0
+)
+
+// attendanceRecapSummaryColumns adalah judul kolom ringkasan di kanan kisi.
+var attendanceRecapSummaryColumns = []string{"Hadir", "Telat", "S", "I", "C", "DL", "A"}
+
+// attendanceRecapPDFData adalah isi PDF rekap presensi bulanan.
+type attendanceRecapPDFData struct {
+	Recap     *AttendanceRecap
+	Head      letterhead
+	Principal signatory
+	PlaceDate string // Contoh: "Yogyakarta, 30 September 2025"
+}
+
+// attendanceRecapTitle adalah judul rekap, misalnya "REKAP PRESENSI GURU".
+func attendanceRecapTitle(recap *AttendanceRecap) string {
+	if strings.HasPrefix(recap.Scope, "Kelas ") {
+		return "REKAP PRESENSI SISWA " + strings.ToUpper(recap.Scope)
+	}
+	return "REKAP PRESENSI " + strings.ToUpper(recap.Scope)
+}
+
+// attendanceRecapLegend menjelaskan kode pada kisi rekap.
+func attendanceRecapLegend() string {
+	parts := []string{recapCodeOnTime + " = Hadir tepat waktu", recapCodeLate + " = Terlambat"}
+	for _, leave := range recapLeaveCodes {
+		parts = append(parts, leave.Code+" = "+leave.Label)
+	}
+	parts = append(parts, recapCodeAbsent+" = Tanpa keterangan", recapCodeHoliday+" = Libur")
+	return "Keterangan: " + strings.Join(parts, ", ") + ". Hadir = H + T."
+}
+
+// cells mengubah ringkasan menjadi isi kolom ringkasan sesuai attendanceRecapSummaryColumns.
+func (summary AttendanceRecapSummary) cells() []string {
+	cells := []string{strconv.Itoa(summary.Present), strconv.Itoa(summary.Late)}
+	for _, leave := range recapLeaveCodes {
+		cells = append(cells, strconv.Itoa(summary.Leave[string(leave.Type)]))
+	}
+	return append(cells, strconv.Itoa(summary.Absent))
+}
+
+// renderAttendanceRecapPDF menyusun PDF rekap presensi bulanan berkop sekolah.
+func renderAttendanceRecapPDF(data attendanceRecapPDFData) ([]byte, error) {
+	recap := data.Recap
+	doc := pdf.New(pdf.A4Height, pdf.A4Width)
+	doc.SetTitle(fmt.Sprintf("Rekap Presensi %s - %s", recap.Scope, recap.MonthName))
+	w := &recapWriter{doc: doc}
+	w.newPage()
+
+	if err := w.letterhead(data.Head); err != nil {
+		return nil, err
+	}
+	doc.TextCenter(doc.Width()/2, w.y, pdf.HelveticaBold, 12, attendanceRecapTitle(recap))
+	w.y += 15
+	doc.TextCenter(doc.Width()/2, w.y, pdf.Helvetica, 10, "Bulan "+recap.MonthName)
+	w.y += 16
+
+	// Kolom nama mengisi sisa lebar halaman setelah kolom tetap.
+	identifierTitle := "NIP"
+	if strings.HasPrefix(recap.Scope, "Kelas ") {
+		identifierTitle = "NIS"
+	}
+	fixed := 18 + 78 + float64(len(recap.Days))*attendanceRecapDayW + float64(len(attendanceRecapSummaryColumns))*attendanceRecapSumW
+	columns := []recapColumn{
+		{"No", 18},
+		{"Nama", doc.Width() - 2*recapMargin - fixed},
+		{identifierTitle, 78},
+	}
+	for _, day := range recap.Days {
+		columns = append(columns, recapColumn{strconv.Itoa(day.Day), attendanceRecapDayW})
+	}
+	for _, title := range attendanceRecapSummaryColumns {
+		columns = append(columns, recapColumn{title, attendanceRecapSumW})
+	}
+	table := attendanceRecapTable{w: w, columns: columns, days: recap.Days}
+
+	table.header()
+	if len(recap.Rows) == 0 {
+		doc.Text(recapMargin, w.y+14, pdf.Helvetica, 10, "Tidak ada data presensi untuk rekap ini.")
+		w.y += 20
+	}
+	for i, row := range recap.Rows {
+		if w.ensure(attendanceRecapRowH) {
+			table.header()
+		}
+		cells := []string{strconv.Itoa(i + 1), row.Name, orDash(row.Identifier)}
+		cells = append(cells, row.Days...)
+		table.row(append(cells, row.Summary.cells()...), false)
+	}
+	if len(recap.Rows) > 0 {
+		w.ensure(attendanceRecapRowH)
+		cells := make([]string, 3+len(recap.Days))
+		cells[1] = "Jumlah"
+		table.row(append(cells, recap.Totals.cells()...), true)
+	}
+
+	w.y += 6
+	for _, line := range pdf.WrapText(pdf.Helvetica, 8, doc.Width()-2*recapMargin, attendanceRecapLegend()) {
+		w.ensure(10)
+		doc.Text(recapMargin, w.y+8, pdf.Helvetica, 8, line)
+		w.y += 10
+	}
+
+	// Rekap kelas ditandatangani kepala sekolah dan wali kelas, rekap guru/staf
+	// cukup oleh kepala sekolah.
+	w.ensure(recapSignatureBox)
+	w.y += 14
+	half := (doc.Width() - 2*recapMargin) / 2
+	right := recapMargin + half + half/2
+	if recap.homeroom != nil {
+		if err := w.signatureBlock(recapMargin+half/2, []string{"Mengetahui,", data.Principal.Title}, data.Principal); err != nil {
+			return nil, err
+		}
+		if err := w.signatureBlock(right, []string{data.PlaceDate, recap.homeroom.Title}, *recap.homeroom); err != nil {
+			return nil, err
+		}
+	} else if err := w.signatureBlock(right, []string{data.PlaceDate, data.Principal.Title}, data.Principal); err != nil {
+		return nil, err
+	}
+	w.y += recapSignatureBox
+	return doc.Bytes(), nil
+}
+
+// letterhead menggambar kop sekolah: logo di kiri, nama dan alamat sekolah di
+// tengah, lalu garis ganda.
+func (w *recapWriter) letterhead(head letterhead) error {
+	top := w.y - 10
+	if head.Logo != nil {
+		size, err := pdf.DecodeImageSize(head.Logo)
+		if err != nil {
+			return fmt.Errorf("invalid school logo: %w", err)
+		}
+		width, height := attendanceRecapLogoSize, attendanceRecapLogoSize
+		if ratio := float64(size.Width) / float64(size.Height); ratio < 1 {
+			width = ratio * height
+		} else {
+			height = width / ratio
+		}
+		if err := w.doc.Image(head.Logo, recapMargin, top+(attendanceRecapLogoSize-height)/2, width, height); err != nil {
+			return fmt.Errorf("invalid school logo: %w", err)
+		}
+	}
+
+	center := w.doc.Width() / 2
+	y := w.y + 6
+	if head.SchoolName != "" {
+		w.doc.TextCenter(center, y, pdf.HelveticaBold, 15, strings.ToUpper(head.SchoolName))
+		y += 16
+	}
+	for _, line := range pdf.WrapText(pdf.Helvetica, 9, w.doc.Width()-2*recapMargin-2*attendanceRecapLogoSize, head.Address) {
+		w.doc.TextCenter(center, y, pdf.Helvetica, 9, line)
+		y += 11
+	}
+	if head.Logo != nil {
+		y = max(y, top+attendanceRecapLogoSize+6)
+	}
+
+	w.doc.Line(recapMargin, y, w.doc.Width()-recapMargin, y, 1.5)
+	w.doc.Line(recapMargin, y+2.5, w.doc.Width()-recapMargin, y+2.5, 0.5)
+	w.y = y + 22
+	return nil
+}
+
+// attendanceRecapTable menggambar kisi rekap presensi: kolom No, Nama, NIP/NIS,
+// satu kolom per tanggal lalu kolom ringkasan.
+type attendanceRecapTable struct {
+	w       *recapWriter
+	columns []recapColumn
+	days    []AttendanceRecapDay
+}
+
+func (t attendanceRecapTable) header() {
+	doc := t.w.doc
+	width := 0.0
+	for _, column := range t.columns {
+		width += column.Width
+	}
+	doc.Rect(recapMargin, t.w.y, width, attendanceRecapHeaderH, 0, true, 0.9)
+
+	x := recapMargin
+	for i, column := range t.columns {
+		doc.Rect(x, t.w.y, column.Width, attendanceRecapHeaderH, 0.5, false, 0)
+		day := i - 3
+		switch {
+		case day >= 0 && day < len(t.days):
+			// Nomor tanggal dengan inisial hari di bawahnya.
+			doc.TextCenter(x+column.Width/2, t.w.y+8, pdf.HelveticaBold, 6, column.Title)
+			doc.TextCenter(x+column.Width/2, t.w.y+16, pdf.Helvetica, 5.5, t.days[day].DayOfWeek[:1])
+		case day >= len(t.days):
+			doc.TextCenter(x+column.Width/2, t.w.y+12, pdf.HelveticaBold, 6, column.Title)
+		default:
+			doc.TextCenter(x+column.Width/2, t.w.y+12, pdf.HelveticaBold, attendanceRecapFontSize, column.Title)
+		}
+		x += column.Width
+	}
+	t.w.y += attendanceRecapHeaderH
+}
+
+// row menggambar satu baris kisi. Nama yang terlalu panjang dipotong agar
+// tinggi baris tetap, dan tanggal libur diberi latar abu-abu.
+func (t attendanceRecapTable) row(cells []string, bold bool) {
+	doc := t.w.doc
+	font := pdf.Helvetica
+	if bold {
+		font = pdf.HelveticaBold
+	}
+	x := recapMargin
+	baseline := t.w.y + attendanceRecapRowH/2 + attendanceRecapFontSize/2 - 1
+	for i, column := range t.columns {
+		text := cells[i]
+		if text == recapCodeHoliday && i >= 3 && i < 3+len(t.days) {
+			doc.Rect(x, t.w.y, column.Width, attendanceRecapRowH, 0, true, 0.85)
+		}
+		doc.Rect(x, t.w.y, column.Width, attendanceRecapRowH, 0.5, false, 0)
+		if i == 1 || i == 2 {
+			lines := pdf.WrapText(font, attendanceRecapFontSize, column.Width-2*3, text)
+			if len(lines) > 0 {
+				text = lines[0]
+			}
+			doc.Text(x+3, baseline, font, attendanceRecapFontSize, text)
+		} else {
+			doc.TextCenter(x+column.Width/2, baseline, font, attendanceRecapFontSize, text)
+		}
+		x += column.Width
+	}
+	t.w.y += attendanceRecapRowH
+}
@@ -0,0 +1,392 @@
+// internal/service/attendance_recap_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// AttendanceRecapService menyusun rekap presensi bulanan guru/staf dan siswa
+// per kelas dari rekap harian, untuk dilaporkan tata usaha dalam bentuk XLSX
+// atau PDF berkop sekolah.
+type AttendanceRecapService struct {
+	db    *db.PrismaClient
+	daily *DailyAttendanceService
+}
+
+func NewAttendanceRecapService(db *db.PrismaClient) *AttendanceRecapService {
+	return &AttendanceRecapService{db: db, daily: NewDailyAttendanceService(db)}
+}
+
+// Kode status harian pada kisi rekap bulanan.
+const (
+	recapCodeOnTime  = "H"
+	recapCodeLate    = "T"
+	recapCodeAbsent  = "A"
+	recapCodeHoliday = "L"
+)
+
+// recapLeaveCodes adalah kode izin per LeaveType, urut sesuai kolom rekap.
+var recapLeaveCodes = []struct {
+	Type  db.LeaveType
+	Code  string
+	Label string
+}{
+	{db.LeaveTypeSakit, "S", "Sakit"},
+	{db.LeaveTypeIzin, "I", "Izin"},
+	{db.LeaveTypeCuti, "C", "Cuti"},
+	{db.LeaveTypeDinasLuar, "DL", "Dinas Luar"},
+}
+
+// AttendanceRecapFilters menentukan isi rekap: satu kelas jika ClassID diisi,
+// selain itu seluruh user aktif dengan peran Role (teacher atau staff).
+type AttendanceRecapFilters struct {
+	Month   string
+	Role    string
+	ClassID int64
+}
+
+// AttendanceRecapSummary adalah jumlah per status dalam satu bulan.
+type AttendanceRecapSummary struct {
+	WorkDays    int            `json:"work_days" example:"22"`
+	Present     int            `json:"present" example:"20"` // Termasuk yang terlambat
+	Late        int            `json:"late" example:"3"`
+	MinutesLate int            `json:"minutes_late" example:"41"`
+	EarlyLeave  int            `json:"early_leave" example:"1"`
+	Absent      int            `json:"absent" example:"1"`
+	Leave       map[string]int `json:"leave"` // Jumlah hari izin per jenis (Sakit, Izin, Cuti, DinasLuar)
+}
+
+// AttendanceRecapRow adalah satu baris rekap: kode status per tanggal dan ringkasannya.
+type AttendanceRecapRow struct {
+	UserID     int64                  `json:"user_id" example:"12"`
+	Name       string                 `json:"name" example:"Siti Aminah, S.Kom"`
+	Identifier string                 `json:"identifier,omitempty" example:"198703122010012003"` // NIP guru atau NIS siswa
+	Days       []string               `json:"days"`                                              // Kode per tanggal: H, T, A, S, I, C, DL, L, atau kosong untuk hari yang belum lewat
+	Summary    AttendanceRecapSummary `json:"summary"`
+}
+
+// AttendanceRecapDay adalah satu kolom tanggal pada rekap.
+type AttendanceRecapDay struct {
+	Date      string `json:"date" example:"2025-09-01"`
+	Day       int    `json:"day" example:"1"`
+	DayOfWeek string `json:"day_of_week" example:"Senin"`
+}
+
+// AttendanceRecap adalah rekap presensi bulanan.
+type AttendanceRecap struct {
+	Month     string                 `json:"month" example:"2025-09"`
+	MonthName string                 `json:"month_name" example:"September 2025"`
+	Scope     string                 `json:"scope" example:"Guru"` // Guru, Staf, atau Kelas <nama kelas>
+	Days      []AttendanceRecapDay   `json:"days"`
+	Rows      []AttendanceRecapRow   `json:"rows"`
+	Totals    AttendanceRecapSummary `json:"totals"`
+
+	homeroom *signatory // Wali kelas untuk tanda tangan rekap siswa
+}
+
+// AttendanceRecapFile adalah rekap yang sudah diekspor beserta nama file dan tipe kontennya.
+type AttendanceRecapFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+func newRecapSummary() AttendanceRecapSummary {
+	summary := AttendanceRecapSummary{Leave: make(map[string]int, len(recapLeaveCodes))}
+	for _, leave := range recapLeaveCodes {
+		summary.Leave[string(leave.Type)] = 0
+	}
+	return summary
+}
+
+// add menambahkan satu rekap harian ke ringkasan dan mengembalikan kodenya.
+func (summary *AttendanceRecapSummary) add(record DailyAttendance) string {
+	if record.EarlyLeave {
+		summary.EarlyLeave++
+	}
+	switch record.Status {
+	case DailyOnTime:
+		summary.WorkDays++
+		summary.Present++
+		return recapCodeOnTime
+	case DailyLate:
+		summary.WorkDays++
+		summary.Present++
+		summary.Late++
+		summary.MinutesLate += record.MinutesLate
+		return recapCodeLate
+	case DailyAbsent:
+		summary.WorkDays++
+		summary.Absent++
+		return recapCodeAbsent
+	case DailyOnLeave:
+		summary.WorkDays++
+		summary.Leave[record.LeaveType]++
+		for _, leave := range recapLeaveCodes {
+			if string(leave.Type) == record.LeaveType {
+				return leave.Code
+			}
+		}
+		return record.LeaveType
+	case DailyHoliday:
+		return recapCodeHoliday
+	}
+	return ""
+}
+
+func (summary *AttendanceRecapSummary) merge(other AttendanceRecapSummary) {
+	summary.WorkDays += other.WorkDays
+	summary.Present += other.Present
+	summary.Late += other.Late
+	summary.MinutesLate += other.MinutesLate
+	summary.EarlyLeave += other.EarlyLeave
+	summary.Absent += other.Absent
+	for leaveType, days := range other.Leave {
+		summary.Leave[leaveType] += days
+	}
+}
+
+// GetRecap menyusun rekap presensi satu bulan (YYYY-MM). Tanggal setelah hari
+// ini dibiarkan kosong.
+func (s *AttendanceRecapService) GetRecap(filters AttendanceRecapFilters) (*AttendanceRecap, error) {
+	ctx := context.Background()
+	first, last, err := parseMonth(filters.Month)
+	if err != nil {
+		return nil, err
+	}
+	if first.After(today()) {
+		return nil, validationError("cannot create a recap for a future month")
+	}
+
+	recap := &AttendanceRecap{
+		Month:     first.Format(monthLayout),
+		MonthName: formatMonthName(first),
+		Days:      []AttendanceRecapDay{},
+		Rows:      []AttendanceRecapRow{},
+		Totals:    newRecapSummary(),
+	}
+	users, identifiers, members, err := s.recapUsers(ctx, recap, filters, first, last)
+	if err != nil {
+		return nil, err
+	}
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		recap.Days = append(recap.Days, AttendanceRecapDay{
+			Date:      formatDate(date),
+			Day:       date.Day(),
+			DayOfWeek: string(dayOfWeekOf(date)),
+		})
+	}
+
+	end := last
+	if end.After(today()) {
+		end = today()
+	}
+	records, err := s.daily.compute(ctx, users, first, end)
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[int64][]DailyAttendance, len(users))
+	for _, record := range records {
+		byUser[record.UserID] = append(byUser[record.UserID], record)
+	}
+
+	for _, user := range users {
+		row := AttendanceRecapRow{
+			UserID:     int64(user.ID),
+			Name:       dailyUserName(user),
+			Identifier: identifiers[user.ID],
+			Days:       make([]string, len(recap.Days)),
+			Summary:    newRecapSummary(),
+		}
+		for _, record := range byUser[row.UserID] {
+			date, _ := parseDate(record.Date)
+			if members != nil && !classMemberOn(members[user.ID], date) {
+				continue
+			}
+			row.Days[date.Day()-1] = row.Summary.add(record)
+		}
+		recap.Totals.merge(row.Summary)
+		recap.Rows = append(recap.Rows, row)
+	}
+	sort.SliceStable(recap.Rows, func(i, j int) bool {
+		return recap.Rows[i].Name < recap.Rows[j].Name
+	})
+	return recap, nil
+}
+
+// recapUsers mengambil user yang direkap beserta NIP/NIS-nya dan mengisi cakupan
+// rekap. Untuk rekap kelas, siswa diambil dari riwayat kelas yang beririsan
+// dengan first-last (bukan kelas saat ini, yang bisa sudah berubah setelah
+// kenaikan atau pindah kelas) dan riwayat itu dikembalikan per user agar hanya
+// hari keanggotaannya yang direkap.
+func (s *AttendanceRecapService) recapUsers(ctx context.Context, recap *AttendanceRecap, filters AttendanceRecapFilters, first, last time.Time) ([]db.UserModel, map[db.BigInt]string, map[db.BigInt][]db.StudentClassHistoryModel, error) {
+	identifiers := make(map[db.BigInt]string)
+	if filters.ClassID > 0 {
+		class, err := s.db.Class.FindUnique(db.Class.ID.Equals(db.BigInt(filters.ClassID))).With(
+			db.Class.HomeroomTeacher.Fetch(),
+		).Exec(ctx)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, nil, nil, notFoundError("class not found")
+			}
+			return nil, nil, nil, err
+		}
+		recap.Scope = "Kelas " + class.ClassName
+		if teacher, ok := class.HomeroomTeacher(); ok {
+			recap.homeroom = &signatory{
+				Title:     "Wali Kelas",
+				Name:      teacher.FullName,
+				NIP:       optionalValue(teacher.Nip()),
+				Signature: readSignature(optionalValue(teacher.SignatureImagePath())),
+			}
+		}
+
+		histories, err := s.db.StudentClassHistory.FindMany(
+			db.StudentClassHistory.ClassID.Equals(class.ID),
+			db.StudentClassHistory.StartDate.Lte(last),
+			db.StudentClassHistory.Or(
+				db.StudentClassHistory.EndDate.IsNull(),
+				db.StudentClassHistory.EndDate.Gt(first),
+			),
+		).With(
+			db.StudentClassHistory.Student.Fetch(),
+		).Exec(ctx)
+		if err != nil {
+			return nil, nil, nil, errors.New("failed to retrieve class members")
+		}
+		members := make(map[db.BigInt][]db.StudentClassHistoryModel)
+		var userIDs []db.BigInt
+		for _, history := range histories {
+			student := history.Student()
+			if _, ok := members[student.UserID]; !ok {
+				userIDs = append(userIDs, student.UserID)
+				identifiers[student.UserID] = student.Nis
+			}
+			members[student.UserID] = append(members[student.UserID], history)
+		}
+		if len(userIDs) == 0 {
+			return nil, identifiers, members, nil
+		}
+
+		users, err := s.db.User.FindMany(db.User.ID.In(userIDs)).With(
+			db.User.Student.Fetch(),
+		).Exec(ctx)
+		if err != nil {
+			return nil, nil, nil, errors.New("failed to retrieve students")
+		}
+		return users, identifiers, members, nil
+	}
+
+	role := db.UserRoleTeacher
+	if filters.Role != "" {
+		role = db.UserRole(filters.Role)
+	}
+	switch role {
+	case db.UserRoleTeacher:
+		recap.Scope = "Guru"
+	case db.UserRoleStaff:
+		recap.Scope = "Staf"
+	default:
+		return nil, nil, nil, validationError("role must be teacher or staff; use class_id for students")
+	}
+	users, err := s.db.User.FindMany(
+		db.User.IsActive.Equals(true),
+		db.User.Role.Equals(role),
+	).With(
+		db.User.Teacher.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, nil, nil, errors.New("failed to retrieve users")
+	}
+	for _, user := range users {
+		if teacher, ok := user.Teacher(); ok {
+			identifiers[user.ID] = optionalValue(teacher.Nip())
+		}
+	}
+	return users, identifiers, nil, nil
+}
+
+// classMemberOn melaporkan apakah salah satu riwayat kelas mencakup date.
+// Tanggal selesai riwayat bersifat eksklusif, sama seperti classRoster.
+func classMemberOn(histories []db.StudentClassHistoryModel, date time.Time) bool {
+	for _, history := range histories {
+		end, ok := history.EndDate()
+		if !date.Before(history.StartDate) && (!ok || date.Before(end)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportRecap menyusun rekap lalu mengekspornya sebagai xlsx atau pdf.
+func (s *AttendanceRecapService) ExportRecap(filters AttendanceRecapFilters, format string) (*AttendanceRecapFile, error) {
+	recap, err := s.GetRecap(filters)
+	if err != nil {
+		return nil, err
+	}
+	head := schoolLetterhead()
+	name := fmt.Sprintf("rekap-presensi-%s-%s", fileNameSlug(recap.Scope), recap.Month)
+
+	switch strings.ToLower(format) {
+	case "xlsx":
+		content, err := renderAttendanceRecapXLSX(recap, head)
+		if err != nil {
+			return nil, errors.New("failed to create attendance recap spreadsheet")
+		}
+		return &AttendanceRecapFile{
+			FileName:    name + ".xlsx",
+			ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			Content:     content,
+		}, nil
+	case "pdf":
+		data := attendanceRecapPDFData{
+			Recap: recap,
+			Head:  head,
+			Principal: signatory{
+				Title:     "Kepala Sekolah",
+				Name:      viper.GetString("PRINCIPAL_NAME"),
+				NIP:       viper.GetString("PRINCIPAL_NIP"),
+				Signature: readSignature(viper.GetString("PRINCIPAL_SIGNATURE_PATH")),
+			},
+			PlaceDate: formatLongDate(today()),
+		}
+		if city := viper.GetString("SCHOOL_CITY"); city != "" {
+			data.PlaceDate = city + ", " + data.PlaceDate
+		}
+		content, err := renderAttendanceRecapPDF(data)
+		if err != nil {
+			return nil, err
+		}
+		return &AttendanceRecapFile{
+			FileName:    name + ".pdf",
+			ContentType: "application/pdf",
+			Content:     content,
+		}, nil
+	}
+	return nil, validationError("invalid format %q, expected xlsx or pdf", format)
+}
+
+// letterhead adalah kop sekolah pada dokumen resmi.
+type letterhead struct {
+	SchoolName string
+	Address    string
+	Logo       []byte // Gambar logo, nil jika tidak ada
+}
+
+// schoolLetterhead membaca kop sekolah dari SCHOOL_NAME, SCHOOL_ADDRESS dan SCHOOL_LOGO_PATH.
+func schoolLetterhead() letterhead {
+	return letterhead{
+		SchoolName: viper.GetString("SCHOOL_NAME"),
+		Address:    viper.GetString("SCHOOL_ADDRESS"),
+		Logo:       readSignature(viper.GetString("SCHOOL_LOGO_PATH")),
+	}
+}
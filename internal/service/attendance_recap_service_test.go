@@ -0,0 +1,100 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRecapSummaryAdd(t *testing.T) {
+	tests := []struct {
+		name   string
+		record DailyAttendance
+		code   string
+		want   AttendanceRecapSummary
+	}{
+		{
+			name:   "on time",
+			record: DailyAttendance{Status: DailyOnTime},
+			code:   "H",
+			want:   AttendanceRecapSummary{WorkDays: 1, Present: 1},
+		},
+		{
+			name:   "late",
+			record: DailyAttendance{Status: DailyLate, MinutesLate: 18},
+			code:   "T",
+			want:   AttendanceRecapSummary{WorkDays: 1, Present: 1, Late: 1, MinutesLate: 18},
+		},
+		{
+			name:   "early leave",
+			record: DailyAttendance{Status: DailyOnTime, EarlyLeave: true},
+			code:   "H",
+			want:   AttendanceRecapSummary{WorkDays: 1, Present: 1, EarlyLeave: 1},
+		},
+		{
+			name:   "absent",
+			record: DailyAttendance{Status: DailyAbsent},
+			code:   "A",
+			want:   AttendanceRecapSummary{WorkDays: 1, Absent: 1},
+		},
+		{
+			name:   "sick",
+			record: DailyAttendance{Status: DailyOnLeave, LeaveType: "Sakit"},
+			code:   "S",
+			want:   AttendanceRecapSummary{WorkDays: 1, Leave: map[string]int{"Sakit": 1}},
+		},
+		{
+			name:   "official duty",
+			record: DailyAttendance{Status: DailyOnLeave, LeaveType: "DinasLuar"},
+			code:   "DL",
+			want:   AttendanceRecapSummary{WorkDays: 1, Leave: map[string]int{"DinasLuar": 1}},
+		},
+		{
+			name:   "holiday",
+			record: DailyAttendance{Status: DailyHoliday},
+			code:   "L",
+		},
+		{
+			name:   "not yet",
+			record: DailyAttendance{Status: DailyNotYet},
+			code:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := newRecapSummary()
+			if code := summary.add(tt.record); code != tt.code {
+				t.Errorf("add() code = %q, want %q", code, tt.code)
+			}
+			want := newRecapSummary()
+			want.merge(tt.want)
+			if !reflect.DeepEqual(summary, want) {
+				t.Errorf("summary = %+v, want %+v", summary, want)
+			}
+		})
+	}
+}
+
+func TestRecapSummaryMerge(t *testing.T) {
+	first, second := newRecapSummary(), newRecapSummary()
+	first.add(DailyAttendance{Status: DailyLate, MinutesLate: 5, EarlyLeave: true})
+	first.add(DailyAttendance{Status: DailyOnLeave, LeaveType: "Izin"})
+	second.add(DailyAttendance{Status: DailyLate, MinutesLate: 7})
+	second.add(DailyAttendance{Status: DailyAbsent})
+	second.add(DailyAttendance{Status: DailyOnLeave, LeaveType: "Izin"})
+
+	totals := newRecapSummary()
+	totals.merge(first)
+	totals.merge(second)
+	want := AttendanceRecapSummary{
+		WorkDays:    5,
+		Present:     2,
+		Late:        2,
+		MinutesLate: 12,
+		EarlyLeave:  1,
+		Absent:      1,
+		Leave:       map[string]int{"Sakit": 0, "Izin": 2, "Cuti": 0, "DinasLuar": 0},
+	}
+	if !reflect.DeepEqual(totals, want) {
+		t.Errorf("totals = %+v, want %+v", totals, want)
+	}
+}
@@ -0,0 +1,98 @@
+// internal/service/attendance_recap_xlsx.go
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/xlsx"
+)
+
+// renderAttendanceRecapXLSX menyusun rekap presensi bulanan sebagai lembar
+// Excel: kop sekolah, judul, kisi per tanggal, ringkasan dan keterangan kode.
+func renderAttendanceRecapXLSX(recap *AttendanceRecap, head letterhead) ([]byte, error) {
+	book := xlsx.New()
+	sheet := book.AddSheet("Rekap " + recap.MonthName)
+
+	identifierTitle := "NIP"
+	if strings.HasPrefix(recap.Scope, "Kelas ") {
+		identifierTitle = "NIS"
+	}
+	header := []xlsx.Cell{
+		xlsx.Text("No", xlsx.Header),
+		xlsx.Text("Nama", xlsx.Header),
+		xlsx.Text(identifierTitle, xlsx.Header),
+	}
+	for _, day := range recap.Days {
+		header = append(header, xlsx.Text(day.DayOfWeek[:3]+" "+strconv.Itoa(day.Day), xlsx.Header))
+	}
+	summaryTitles := []string{"Hari Kerja", "Hadir", "Terlambat", "Menit Terlambat", "Pulang Cepat"}
+	for _, leave := range recapLeaveCodes {
+		summaryTitles = append(summaryTitles, leave.Label)
+	}
+	summaryTitles = append(summaryTitles, "Tanpa Keterangan")
+	for _, title := range summaryTitles {
+		header = append(header, xlsx.Text(title, xlsx.Header))
+	}
+	last := len(header) - 1
+
+	// Kop dan judul digabung selebar tabel.
+	title := func(text string, style xlsx.Style) {
+		row := sheet.AddRow(xlsx.Text(text, style))
+		sheet.Merge(0, row, last, row)
+	}
+	if head.SchoolName != "" {
+		title(strings.ToUpper(head.SchoolName), xlsx.Title)
+	}
+	if head.Address != "" {
+		title(head.Address, xlsx.Normal)
+	}
+	sheet.AddRow()
+	title(attendanceRecapTitle(recap), xlsx.Bold)
+	title("Bulan "+recap.MonthName, xlsx.Normal)
+	sheet.AddRow()
+	sheet.AddRow(header...)
+
+	summaryCells := func(summary AttendanceRecapSummary) []xlsx.Cell {
+		values := []int{summary.WorkDays, summary.Present, summary.Late, summary.MinutesLate, summary.EarlyLeave}
+		for _, leave := range recapLeaveCodes {
+			values = append(values, summary.Leave[string(leave.Type)])
+		}
+		values = append(values, summary.Absent)
+		cells := make([]xlsx.Cell, 0, len(values))
+		for _, value := range values {
+			cells = append(cells, xlsx.Number(float64(value), xlsx.Centered))
+		}
+		return cells
+	}
+	for i, row := range recap.Rows {
+		cells := []xlsx.Cell{
+			xlsx.Number(float64(i+1), xlsx.Centered),
+			xlsx.Text(row.Name, xlsx.Bordered),
+			xlsx.Text(row.Identifier, xlsx.Bordered),
+		}
+		for _, code := range row.Days {
+			cells = append(cells, xlsx.Text(code, xlsx.Centered))
+		}
+		sheet.AddRow(append(cells, summaryCells(row.Summary)...)...)
+	}
+	totals := []xlsx.Cell{xlsx.Text("", xlsx.Header), xlsx.Text("Jumlah", xlsx.Header), xlsx.Text("", xlsx.Header)}
+	for range recap.Days {
+		totals = append(totals, xlsx.Text("", xlsx.Header))
+	}
+	sheet.AddRow(append(totals, summaryCells(recap.Totals)...)...)
+
+	sheet.AddRow()
+	sheet.AddRow(xlsx.Text(attendanceRecapLegend(), xlsx.Normal))
+
+	sheet.SetColumnWidth(0, 5)
+	sheet.SetColumnWidth(1, 32)
+	sheet.SetColumnWidth(2, 20)
+	for i := range recap.Days {
+		sheet.SetColumnWidth(3+i, 7)
+	}
+	for i := range summaryTitles {
+		sheet.SetColumnWidth(3+len(recap.Days)+i, 11)
+	}
+	return book.Bytes()
+}
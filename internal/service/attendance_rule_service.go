@@ -0,0 +1,190 @@
+// internal/service/attendance_rule_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// AttendanceRuleService mengelola aturan jam kerja presensi per peran dan hari,
+// misalnya jam pulang Jumat yang lebih awal.
+type AttendanceRuleService struct {
+	db *db.PrismaClient
+}
+
+func NewAttendanceRuleService(db *db.PrismaClient) *AttendanceRuleService {
+	return &AttendanceRuleService{db: db}
+}
+
+// AttendanceRuleInput adalah data aturan jam kerja.
+type AttendanceRuleInput struct {
+	Role         string
+	DayOfWeek    string
+	StartTime    string // HH:MM
+	EndTime      string // HH:MM
+	GraceMinutes int
+}
+
+func parseUserRole(value string) (db.UserRole, error) {
+	switch db.UserRole(value) {
+	case db.UserRoleAdmin, db.UserRoleTeacher, db.UserRoleStudent, db.UserRoleStaff:
+		return db.UserRole(value), nil
+	}
+	return "", validationError("invalid role %q", value)
+}
+
+// attendanceRulePlan adalah input aturan yang sudah divalidasi.
+type attendanceRulePlan struct {
+	role  db.UserRole
+	day   db.DayOfWeek
+	start time.Time
+	end   time.Time
+	grace int
+}
+
+func parseAttendanceRuleInput(input AttendanceRuleInput) (*attendanceRulePlan, error) {
+	role, err := parseUserRole(input.Role)
+	if err != nil {
+		return nil, err
+	}
+	day, err := parseDayOfWeek(input.DayOfWeek)
+	if err != nil {
+		return nil, err
+	}
+	start, err := parseClock(input.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(input.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, validationError("end time must be after start time")
+	}
+	if input.GraceMinutes < 0 || input.GraceMinutes >= minutesOfDay(end)-minutesOfDay(start) {
+		return nil, validationError("grace minutes must be between 0 and the working hours")
+	}
+	return &attendanceRulePlan{role: role, day: day, start: start, end: end, grace: input.GraceMinutes}, nil
+}
+
+// GetRules mengambil aturan jam kerja, urut peran lalu hari. role kosong berarti semua peran.
+func (s *AttendanceRuleService) GetRules(role string) ([]db.AttendanceRuleModel, error) {
+	var where []db.AttendanceRuleWhereParam
+	if role != "" {
+		userRole, err := parseUserRole(role)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.AttendanceRule.Role.Equals(userRole))
+	}
+	rules, err := s.db.AttendanceRule.FindMany(where...).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve attendance rules")
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Role != rules[j].Role {
+			return rules[i].Role < rules[j].Role
+		}
+		return dayIndex(rules[i].DayOfWeek) < dayIndex(rules[j].DayOfWeek)
+	})
+	return rules, nil
+}
+
+// GetRuleByID mengambil satu aturan jam kerja.
+func (s *AttendanceRuleService) GetRuleByID(id int) (*db.AttendanceRuleModel, error) {
+	rule, err := s.db.AttendanceRule.FindUnique(db.AttendanceRule.ID.Equals(db.BigInt(id))).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("attendance rule not found")
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+// checkRuleUnique memastikan belum ada aturan lain untuk peran dan hari yang sama.
+func (s *AttendanceRuleService) checkRuleUnique(ctx context.Context, plan *attendanceRulePlan, exceptID db.BigInt) error {
+	existing, err := s.db.AttendanceRule.FindUnique(db.AttendanceRule.RoleDayUnique(
+		db.AttendanceRule.Role.Equals(plan.role),
+		db.AttendanceRule.DayOfWeek.Equals(plan.day),
+	)).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if existing.ID != exceptID {
+		return conflictError("attendance rule for %s on %s already exists", plan.role, plan.day)
+	}
+	return nil
+}
+
+// CreateRule menambahkan aturan jam kerja untuk satu peran pada satu hari.
+func (s *AttendanceRuleService) CreateRule(input AttendanceRuleInput) (*db.AttendanceRuleModel, error) {
+	ctx := context.Background()
+	plan, err := parseAttendanceRuleInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRuleUnique(ctx, plan, 0); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.db.AttendanceRule.CreateOne(
+		db.AttendanceRule.Role.Set(plan.role),
+		db.AttendanceRule.DayOfWeek.Set(plan.day),
+		db.AttendanceRule.StartTime.Set(plan.start),
+		db.AttendanceRule.EndTime.Set(plan.end),
+		db.AttendanceRule.GraceMinutes.Set(plan.grace),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to create attendance rule")
+	}
+	return rule, nil
+}
+
+// UpdateRule mengganti seluruh data aturan jam kerja. Rekap harian selalu
+// dihitung ulang, jadi perubahan aturan juga berlaku untuk tanggal yang lalu.
+func (s *AttendanceRuleService) UpdateRule(id int, input AttendanceRuleInput) (*db.AttendanceRuleModel, error) {
+	ctx := context.Background()
+	if _, err := s.GetRuleByID(id); err != nil {
+		return nil, err
+	}
+	plan, err := parseAttendanceRuleInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRuleUnique(ctx, plan, db.BigInt(id)); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.db.AttendanceRule.FindUnique(db.AttendanceRule.ID.Equals(db.BigInt(id))).Update(
+		db.AttendanceRule.Role.Set(plan.role),
+		db.AttendanceRule.DayOfWeek.Set(plan.day),
+		db.AttendanceRule.StartTime.Set(plan.start),
+		db.AttendanceRule.EndTime.Set(plan.end),
+		db.AttendanceRule.GraceMinutes.Set(plan.grace),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to update attendance rule")
+	}
+	return rule, nil
+}
+
+// DeleteRule menghapus aturan jam kerja sehingga hari tersebut bukan lagi hari kerja peran itu.
+func (s *AttendanceRuleService) DeleteRule(id int) error {
+	ctx := context.Background()
+	if _, err := s.GetRuleByID(id); err != nil {
+		return err
+	}
+	if _, err := s.db.AttendanceRule.FindUnique(db.AttendanceRule.ID.Equals(db.BigInt(id))).Delete().Exec(ctx); err != nil {
+		return errors.New("failed to delete attendance rule")
+	}
+	return nil
+}
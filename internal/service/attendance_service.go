@@ -0,0 +1,451 @@
+// internal/service/attendance_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// AttendanceService mengelola presensi harian masuk/pulang guru, staf dan siswa di sekolah.
+type AttendanceService struct {
+	db *db.PrismaClient
+}
+
+func NewAttendanceService(db *db.PrismaClient) *AttendanceService {
+	return &AttendanceService{db: db}
+}
+
+// AttendanceInput adalah data presensi yang dikirim dari perangkat user.
+type AttendanceInput struct {
+	Coordinates string // "lat,long" dari GPS perangkat, wajib jika geofence sekolah diatur
+	Photo       []byte // Foto JPEG atau PNG, opsional
+}
+
+// AttendanceFilters adalah filter daftar presensi harian.
+type AttendanceFilters struct {
+	UserID int64
+	Role   string
+	Status string
+	From   string
+	To     string
+	Page   int
+	Limit  int
+}
+
+// AttendanceToday adalah status presensi user yang sedang login pada hari ini.
+type AttendanceToday struct {
+	Date        string     `json:"date" example:"2025-09-22"`
+	Status      string     `json:"status" example:"Sudah Masuk"` // Belum Presensi, Sudah Masuk atau Sudah Pulang
+	CheckIn     *time.Time `json:"check_in,omitempty"`
+	CheckOut    *time.Time `json:"check_out,omitempty"`
+	CanCheckIn  bool       `json:"can_check_in" example:"false"`
+	CanCheckOut bool       `json:"can_check_out" example:"true"`
+}
+
+// RFIDTapResult adalah jawaban untuk reader RFID setelah kartu siswa ditempel.
+type RFIDTapResult struct {
+	AttendanceID int64     `json:"attendance_id,omitempty" example:"981"` // Presensi yang tercatat, kosong jika tidak dicatat
+	StudentID    int64     `json:"student_id" example:"21"`
+	StudentName  string    `json:"student_name" example:"Budi Santoso"`
+	StudentNis   string    `json:"student_nis" example:"232410001"`
+	ClassName    string    `json:"class_name,omitempty" example:"XI RPL 1"`
+	Status       string    `json:"status" example:"Masuk"`  // Status presensi yang tercatat, atau status terakhir jika tidak dicatat
+	Recorded     bool      `json:"recorded" example:"true"` // false untuk tap berulang atau presensi hari ini yang sudah lengkap
+	Timestamp    time.Time `json:"timestamp"`               // Waktu presensi yang tercatat atau presensi terakhir
+	Message      string    `json:"message" example:"Selamat datang, Budi Santoso"`
+}
+
+// schoolGeofence mengembalikan titik pusat sekolah (SCHOOL_COORDINATES) dan
+// radius presensi dalam meter (SCHOOL_GEOFENCE_RADIUS_METERS, default 100).
+// ok bernilai false jika koordinat sekolah belum diatur sehingga lokasi tidak diperiksa.
+func schoolGeofence() (lat, long float64, radius int, ok bool, err error) {
+	value := viper.GetString("SCHOOL_COORDINATES")
+	if value == "" {
+		return 0, 0, 0, false, nil
+	}
+	lat, long, err = parseCoordinates(value)
+	if err != nil {
+		return 0, 0, 0, false, fmt.Errorf("invalid SCHOOL_COORDINATES: %w", err)
+	}
+	radius = viper.GetInt("SCHOOL_GEOFENCE_RADIUS_METERS")
+	if radius <= 0 {
+		radius = 100
+	}
+	return lat, long, radius, true, nil
+}
+
+// checkSchoolGeofence menolak presensi di luar radius sekolah.
+func checkSchoolGeofence(lat, long, schoolLat, schoolLong float64, radius int) error {
+	meters := int(math.Round(distanceMeters(lat, long, schoolLat, schoolLong)))
+	if meters > radius {
+		return validationError("you are %d m from the school, attendance is only allowed within %d m", meters, radius)
+	}
+	return nil
+}
+
+// attendanceDailyKey adalah nama unique index (user_id, attendance_date, status)
+// yang membatasi Masuk dan Pulang paling banyak sekali sehari.
+const attendanceDailyKey = "attendances_user_id_attendance_date_status_key"
+
+// isDuplicateAttendance mengenali pelanggaran unique index harian, misalnya
+// dua presensi masuk yang dikirim bersamaan dan sama-sama lolos pengecekan.
+func isDuplicateAttendance(err error) bool {
+	violation, ok := db.IsErrUniqueConstraint(err)
+	return ok && violation.Key == attendanceDailyKey
+}
+
+// dayRange mengembalikan awal dan akhir (eksklusif) tanggal date menurut zona
+// waktu sekolah, untuk mencari presensi berdasarkan timestamp.
+func dayRange(date time.Time) (time.Time, time.Time) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, appLocation())
+	return start, start.AddDate(0, 0, 1)
+}
+
+// parseAttendanceStatus memvalidasi status presensi dari filter.
+func parseAttendanceStatus(value string) (db.AttendanceStatus, error) {
+	switch db.AttendanceStatus(value) {
+	case db.AttendanceStatusMasuk, db.AttendanceStatusPulang:
+		return db.AttendanceStatus(value), nil
+	}
+	return "", validationError("invalid attendance status %q", value)
+}
+
+// CheckIn mencatat presensi masuk user hari ini. Presensi masuk hanya boleh sekali sehari.
+func (s *AttendanceService) CheckIn(userID int, input AttendanceInput) (*db.AttendanceModel, error) {
+	return s.record(userID, db.AttendanceStatusMasuk, input)
+}
+
+// CheckOut mencatat presensi pulang user. Presensi masuk hari yang sama harus sudah ada.
+func (s *AttendanceService) CheckOut(userID int, input AttendanceInput) (*db.AttendanceModel, error) {
+	return s.record(userID, db.AttendanceStatusPulang, input)
+}
+
+// record menyimpan satu presensi harian. Jika geofence sekolah diatur, lokasi
+// wajib dikirim dan presensi di luar radius ditolak.
+func (s *AttendanceService) record(userID int, status db.AttendanceStatus, input AttendanceInput) (*db.AttendanceModel, error) {
+	ctx := context.Background()
+	schoolLat, schoolLong, radius, geofenced, err := schoolGeofence()
+	if err != nil {
+		return nil, err
+	}
+
+	var coordinates *string
+	if input.Coordinates != "" {
+		lat, long, err := parseCoordinates(input.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		if geofenced {
+			if err := checkSchoolGeofence(lat, long, schoolLat, schoolLong, radius); err != nil {
+				return nil, err
+			}
+		}
+		value := formatCoordinates(lat, long)
+		coordinates = &value
+	} else if geofenced {
+		return nil, validationError("coordinates are required")
+	}
+	var photo []byte
+	var extension string
+	if len(input.Photo) > 0 {
+		if photo, extension, err = prepareUpload(input.Photo, uploadImage); err != nil {
+			return nil, err
+		}
+	}
+
+	date := today()
+	todays, err := s.todaysAttendances(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+	checkedIn := false
+	for _, attendance := range todays {
+		if attendance.Status == status {
+			return nil, conflictError("%s attendance for %s already exists", status, formatDate(date))
+		}
+		checkedIn = checkedIn || attendance.Status == db.AttendanceStatusMasuk
+	}
+	if status == db.AttendanceStatusPulang && !checkedIn {
+		return nil, conflictError("you have not checked in today")
+	}
+
+	var photoPath *string
+	if extension != "" {
+		saved, err := saveUpload(fmt.Sprintf("attendances/%d/%s-%s%s",
+			userID, formatDate(date), fileNameSlug(string(status)), extension), photo)
+		if err != nil {
+			return nil, err
+		}
+		photoPath = &saved
+	}
+
+	attendance, err := s.db.Attendance.CreateOne(
+		db.Attendance.Timestamp.Set(time.Now()),
+		db.Attendance.AttendanceDate.Set(date),
+		db.Attendance.Status.Set(status),
+		db.Attendance.User.Link(db.User.ID.Equals(db.BigInt(userID))),
+		db.Attendance.LocationCoordinates.SetOptional(coordinates),
+		db.Attendance.PhotoPath.SetOptional(photoPath),
+	).Exec(ctx)
+	if err != nil {
+		if isDuplicateAttendance(err) {
+			return nil, conflictError("%s attendance for %s already exists", status, formatDate(date))
+		}
+		return nil, errors.New("failed to save attendance")
+	}
+	return attendance, nil
+}
+
+// rfidDebounce adalah jeda minimal antar tap kartu yang sama
+// (RFID_DEBOUNCE_SECONDS, default 30). Tap di dalam jeda ini diabaikan.
+func rfidDebounce() time.Duration {
+	seconds := viper.GetInt("RFID_DEBOUNCE_SECONDS")
+	if seconds <= 0 {
+		seconds = 30
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// rfidCheckOutFrom adalah jam mulai tap kedua dicatat sebagai Pulang
+// (RFID_CHECKOUT_FROM, default 12:00).
+func rfidCheckOutFrom() (time.Time, error) {
+	value := viper.GetString("RFID_CHECKOUT_FROM")
+	if value == "" {
+		value = "12:00"
+	}
+	clock, err := parseClock(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid RFID_CHECKOUT_FROM: %w", err)
+	}
+	return clock, nil
+}
+
+// TapRFID mencatat presensi siswa dari tap kartu RFID di reader gerbang. Tap
+// pertama hari itu dicatat Masuk; tap berikutnya mulai RFID_CHECKOUT_FROM
+// dicatat Pulang. Tap berulang dalam RFID_DEBOUNCE_SECONDS, tap kedua sebelum
+// jam pulang, dan tap setelah Pulang tidak dicatat.
+func (s *AttendanceService) TapRFID(uid, device string) (*RFIDTapResult, error) {
+	return s.tap(context.Background(), rfidTap{UID: uid, At: time.Now(), Device: device})
+}
+
+// rfidTap adalah satu tap kartu, langsung dari reader atau dari antrean kiosk
+// yang sedang offline.
+type rfidTap struct {
+	UID           string
+	At            time.Time // Waktu tap menurut perangkat
+	Device        string
+	ClientEventID string // Hanya untuk tap yang disinkronkan kiosk
+}
+
+// tap menerapkan aturan tap RFID pada waktu tap.At. Presensi hari itu yang
+// tercatat setelah tap.At (misalnya dari gerbang lain saat kiosk offline) ikut
+// diperhitungkan agar Masuk dan Pulang tetap tercatat paling banyak sekali.
+func (s *AttendanceService) tap(ctx context.Context, tap rfidTap) (*RFIDTapResult, error) {
+	uid := normalizeRFID(tap.UID)
+	if uid == "" {
+		return nil, validationError("rfid_uid is required")
+	}
+	checkOutFrom, err := rfidCheckOutFrom()
+	if err != nil {
+		return nil, err
+	}
+	student, err := s.db.Student.FindUnique(db.Student.RfidUID.Equals(uid)).With(
+		db.Student.User.Fetch(),
+		db.Student.CurrentClass.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("card is not enrolled")
+		}
+		return nil, err
+	}
+	if student.Status != db.StudentStatusAktif || !student.User().IsActive {
+		return nil, forbiddenError("student %s is not active", student.FullName)
+	}
+
+	result := &RFIDTapResult{
+		StudentID:   int64(student.ID),
+		StudentName: student.FullName,
+		StudentNis:  student.Nis,
+	}
+	if class, ok := student.CurrentClass(); ok {
+		result.ClassName = class.ClassName
+	}
+
+	date := dateOnly(tap.At.In(appLocation()))
+	todays, err := s.todaysAttendances(ctx, int(student.UserID), date)
+	if err != nil {
+		return nil, err
+	}
+	var last *db.AttendanceModel
+	checkedIn, checkedOut := false, false
+	for i := range todays {
+		if !todays[i].Timestamp.After(tap.At) {
+			last = &todays[i]
+		}
+		checkedIn = checkedIn || todays[i].Status == db.AttendanceStatusMasuk
+		checkedOut = checkedOut || todays[i].Status == db.AttendanceStatusPulang
+	}
+	status := db.AttendanceStatusMasuk
+	switch {
+	case last == nil && checkedIn:
+		result.Status = string(db.AttendanceStatusMasuk)
+		result.Timestamp = todays[0].Timestamp
+		result.Message = "Sudah presensi masuk"
+		return result, nil
+	case last != nil:
+		result.Status = string(last.Status)
+		result.Timestamp = last.Timestamp
+		switch {
+		case tap.At.Sub(last.Timestamp) < rfidDebounce():
+			result.Message = "Tap berulang diabaikan"
+			return result, nil
+		case last.Status == db.AttendanceStatusPulang || checkedOut:
+			result.Message = "Presensi hari ini sudah lengkap"
+			return result, nil
+		case minutesOfDay(clockOf(tap.At)) < minutesOfDay(checkOutFrom):
+			result.Message = "Sudah presensi masuk"
+			return result, nil
+		}
+		status = db.AttendanceStatusPulang
+	}
+
+	optional := []db.AttendanceSetParam{
+		db.Attendance.DeviceName.SetOptional(optionalString(tap.Device)),
+	}
+	if tap.ClientEventID != "" {
+		optional = append(optional,
+			db.Attendance.ClientEventID.Set(tap.ClientEventID),
+			db.Attendance.SyncedAt.Set(time.Now()),
+		)
+	}
+	attendance, err := s.db.Attendance.CreateOne(
+		db.Attendance.Timestamp.Set(tap.At),
+		db.Attendance.AttendanceDate.Set(date),
+		db.Attendance.Status.Set(status),
+		db.Attendance.User.Link(db.User.ID.Equals(student.UserID)),
+		optional...,
+	).Exec(ctx)
+	if err != nil {
+		if isDuplicateAttendance(err) {
+			return nil, conflictError("%s attendance for %s already exists", status, formatDate(date))
+		}
+		return nil, errors.New("failed to save attendance")
+	}
+	result.AttendanceID = int64(attendance.ID)
+	result.Status = string(attendance.Status)
+	result.Timestamp = attendance.Timestamp
+	result.Recorded = true
+	if status == db.AttendanceStatusMasuk {
+		result.Message = "Selamat datang, " + student.FullName
+	} else {
+		result.Message = "Sampai jumpa, " + student.FullName
+	}
+	return result, nil
+}
+
+// todaysAttendances mengambil presensi user pada tanggal date, urut waktu.
+func (s *AttendanceService) todaysAttendances(ctx context.Context, userID int, date time.Time) ([]db.AttendanceModel, error) {
+	start, end := dayRange(date)
+	attendances, err := s.db.Attendance.FindMany(
+		db.Attendance.UserID.Equals(db.BigInt(userID)),
+		db.Attendance.Timestamp.Gte(start),
+		db.Attendance.Timestamp.Lt(end),
+	).OrderBy(
+		db.Attendance.Timestamp.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to check attendance")
+	}
+	return attendances, nil
+}
+
+// GetToday mengembalikan status presensi user hari ini beserta aksi yang masih bisa dilakukan.
+func (s *AttendanceService) GetToday(userID int) (*AttendanceToday, error) {
+	ctx := context.Background()
+	date := today()
+	attendances, err := s.todaysAttendances(ctx, userID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AttendanceToday{Date: formatDate(date)}
+	for _, attendance := range attendances {
+		timestamp := attendance.Timestamp
+		if attendance.Status == db.AttendanceStatusMasuk {
+			result.CheckIn = &timestamp
+		} else {
+			result.CheckOut = &timestamp
+		}
+	}
+	switch {
+	case result.CheckOut != nil:
+		result.Status = "Sudah Pulang"
+	case result.CheckIn != nil:
+		result.Status = "Sudah Masuk"
+		result.CanCheckOut = true
+	default:
+		result.Status = "Belum Presensi"
+		result.CanCheckIn = true
+	}
+	return result, nil
+}
+
+// GetAttendances mengambil daftar presensi harian dengan paginasi, terbaru lebih dulu.
+func (s *AttendanceService) GetAttendances(filters AttendanceFilters) ([]db.AttendanceModel, int, error) {
+	ctx := context.Background()
+	var where []db.AttendanceWhereParam
+	if filters.UserID > 0 {
+		where = append(where, db.Attendance.UserID.Equals(db.BigInt(filters.UserID)))
+	}
+	if filters.Role != "" {
+		where = append(where, db.Attendance.User.Where(db.User.Role.Equals(db.UserRole(filters.Role))))
+	}
+	if filters.Status != "" {
+		status, err := parseAttendanceStatus(filters.Status)
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.Attendance.Status.Equals(status))
+	}
+	if filters.From != "" {
+		from, err := parseDate(filters.From)
+		if err != nil {
+			return nil, 0, err
+		}
+		start, _ := dayRange(from)
+		where = append(where, db.Attendance.Timestamp.Gte(start))
+	}
+	if filters.To != "" {
+		to, err := parseDate(filters.To)
+		if err != nil {
+			return nil, 0, err
+		}
+		_, end := dayRange(to)
+		where = append(where, db.Attendance.Timestamp.Lt(end))
+	}
+
+	all, err := s.db.Attendance.FindMany(where...).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to count attendances")
+	}
+	attendances, err := s.db.Attendance.FindMany(where...).With(
+		db.Attendance.User.Fetch().With(
+			db.User.Teacher.Fetch(),
+			db.User.Student.Fetch(),
+		),
+	).OrderBy(
+		db.Attendance.Timestamp.Order(db.SortOrderDesc),
+	).Skip((filters.Page - 1) * filters.Limit).Take(filters.Limit).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to retrieve attendances")
+	}
+	return attendances, len(all), nil
+}
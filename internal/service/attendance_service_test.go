@@ -0,0 +1,45 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckSchoolGeofence(t *testing.T) {
+	const schoolLat, schoolLong, radius = -7.7956, 110.3695, 100
+
+	tests := []struct {
+		name      string
+		lat, long float64
+		wantError bool
+	}{
+		{name: "at the school", lat: schoolLat, long: schoolLong},
+		{name: "inside the radius", lat: -7.7960, long: 110.3697},
+		{name: "outside the radius", lat: -7.8056, long: 110.3695, wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSchoolGeofence(tt.lat, tt.long, schoolLat, schoolLong, radius)
+			if tt.wantError && !errors.Is(err, ErrValidation) {
+				t.Fatalf("checkSchoolGeofence() error = %v, want validation error", err)
+			}
+			if !tt.wantError && err != nil {
+				t.Fatalf("checkSchoolGeofence() error = %v", err)
+			}
+		})
+	}
+}
+
+// Koordinat "NaN,NaN" dari client sebelumnya menghasilkan jarak negatif
+// sehingga presensi lolos dari mana saja.
+func TestGeofenceRejectsNaNCoordinates(t *testing.T) {
+	for _, value := range []string{"NaN,NaN", "nan,110.3695", "-7.7956,+Inf"} {
+		lat, long, err := parseCoordinates(value)
+		if err == nil {
+			err = checkSchoolGeofence(lat, long, -7.7956, 110.3695, 100)
+		}
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("coordinates %q accepted by the geofence, error = %v", value, err)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// internal/service/attendance_sync.go
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// Hasil per event sinkronisasi kiosk.
+const (
+	SyncCreated   = "created"   // Presensi baru dicatat
+	SyncDuplicate = "duplicate" // Event sudah pernah diterima, tidak dicatat ulang
+	SyncIgnored   = "ignored"   // Event sah tetapi tidak menghasilkan presensi, misalnya tap berulang
+	SyncRejected  = "rejected"  // Event tidak valid; kiosk tidak perlu mengirim ulang
+)
+
+// maxSyncEvents membatasi jumlah event dalam satu permintaan sinkronisasi.
+const maxSyncEvents = 500
+
+// AttendanceSyncEvent adalah satu tap kartu yang diantrekan kiosk saat offline.
+type AttendanceSyncEvent struct {
+	ClientID  string    // ID unik buatan kiosk, misalnya UUID
+	RfidUID   string    // UID kartu siswa
+	Timestamp time.Time // Waktu tap menurut jam kiosk
+}
+
+// AttendanceSyncEventResult adalah hasil pemrosesan satu event.
+type AttendanceSyncEventResult struct {
+	ClientID     string     `json:"client_id" example:"5f0c6a7e-2b1d-4c4e-9a57-0b8f3f1d2c11"`
+	Result       string     `json:"result" example:"created"` // created, duplicate, ignored atau rejected
+	AttendanceID *int64     `json:"attendance_id,omitempty" example:"981"`
+	StudentName  string     `json:"student_name,omitempty" example:"Budi Santoso"`
+	Status       string     `json:"status,omitempty" example:"Masuk"`
+	Timestamp    *time.Time `json:"timestamp,omitempty"`
+	Message      string     `json:"message,omitempty" example:"Selamat datang, Budi Santoso"`
+}
+
+// AttendanceSyncResult adalah jawaban untuk kiosk setelah sinkronisasi.
+// Kiosk boleh menghapus event dari antreannya untuk semua hasil selain error
+// HTTP; mengirim ulang event yang sama aman karena diduplikasi lewat ClientID.
+type AttendanceSyncResult struct {
+	ServerTime       time.Time                   `json:"server_time"`
+	ClockSkewSeconds int                         `json:"clock_skew_seconds" example:"-3"` // Jam server dikurangi jam kiosk
+	Created          int                         `json:"created" example:"42"`
+	Duplicates       int                         `json:"duplicates" example:"3"`
+	Ignored          int                         `json:"ignored" example:"5"`
+	Rejected         int                         `json:"rejected" example:"1"`
+	Results          []AttendanceSyncEventResult `json:"results"`
+}
+
+// syncMaxClockSkew adalah selisih maksimal jam kiosk dengan jam server
+// (KIOSK_MAX_CLOCK_SKEW_SECONDS, default 120).
+func syncMaxClockSkew() time.Duration {
+	if seconds := viper.GetInt("KIOSK_MAX_CLOCK_SKEW_SECONDS"); seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 2 * time.Minute
+}
+
+// syncMaxAge adalah umur maksimal event yang masih diterima
+// (KIOSK_SYNC_MAX_AGE_DAYS, default 7).
+func syncMaxAge() time.Duration {
+	if days := viper.GetInt("KIOSK_SYNC_MAX_AGE_DAYS"); days > 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return 7 * 24 * time.Hour
+}
+
+// SyncDeviceAttendances menerima antrean tap kartu dari kiosk yang sempat
+// offline. deviceTime adalah jam kiosk saat mengirim; jika selisihnya dengan
+// jam server melebihi KIOSK_MAX_CLOCK_SKEW_SECONDS seluruh batch ditolak agar
+// kiosk menyetel jamnya dulu dan waktu presensi tidak bergeser. Event diproses
+// urut waktu dengan aturan yang sama seperti tap langsung, dan event yang
+// ClientID-nya sudah pernah diterima dari perangkat ini tidak dicatat ulang.
+func (s *AttendanceService) SyncDeviceAttendances(device string, deviceTime time.Time, events []AttendanceSyncEvent) (*AttendanceSyncResult, error) {
+	ctx := context.Background()
+	if device == "" {
+		return nil, forbiddenError("unknown device")
+	}
+	if len(events) > maxSyncEvents {
+		return nil, validationError("a sync batch may contain at most %d events", maxSyncEvents)
+	}
+	now := time.Now()
+	skew := now.Sub(deviceTime)
+	if math.Abs(skew.Seconds()) > syncMaxClockSkew().Seconds() {
+		return nil, validationError("device clock differs from server time by %d seconds, sync the device clock and retry",
+			int(math.Round(skew.Seconds())))
+	}
+
+	result := &AttendanceSyncResult{
+		ServerTime:       now.In(appLocation()),
+		ClockSkewSeconds: int(math.Round(skew.Seconds())),
+		Results:          make([]AttendanceSyncEventResult, len(events)),
+	}
+
+	clientIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		clientIDs = append(clientIDs, strings.TrimSpace(event.ClientID))
+	}
+	existing, err := s.db.Attendance.FindMany(
+		db.Attendance.DeviceName.Equals(device),
+		db.Attendance.ClientEventID.In(clientIDs),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to check synced attendances")
+	}
+	received := make(map[string]*db.AttendanceModel, len(existing))
+	for i := range existing {
+		if clientID, ok := existing[i].ClientEventID(); ok {
+			received[clientID] = &existing[i]
+		}
+	}
+
+	// Proses urut waktu tap agar Masuk tercatat sebelum Pulang, lalu kembalikan
+	// hasil sesuai urutan kiriman kiosk.
+	order := make([]int, len(events))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return events[order[a]].Timestamp.Before(events[order[b]].Timestamp)
+	})
+	seen := make(map[string]bool, len(events))
+	oldest := now.Add(-syncMaxAge())
+	for _, i := range order {
+		event := events[i]
+		clientID := clientIDs[i]
+		entry := &result.Results[i]
+		entry.ClientID = clientID
+
+		switch {
+		case clientID == "" || len(clientID) > 64:
+			entry.Result, entry.Message = SyncRejected, "client_id must be 1-64 characters"
+		case seen[clientID]:
+			entry.Result, entry.Message = SyncDuplicate, "duplicate client_id in this batch"
+		case received[clientID] != nil:
+			attendance := received[clientID]
+			id := int64(attendance.ID)
+			entry.Result, entry.AttendanceID = SyncDuplicate, &id
+			entry.Status, entry.Timestamp = string(attendance.Status), &attendance.Timestamp
+			entry.Message = "already synced"
+		case event.Timestamp.IsZero():
+			entry.Result, entry.Message = SyncRejected, "timestamp is required"
+		case event.Timestamp.After(deviceTime.Add(syncMaxClockSkew())):
+			entry.Result, entry.Message = SyncRejected, "timestamp is later than the device time"
+		case event.Timestamp.Before(oldest):
+			entry.Result, entry.Message = SyncRejected, "event is too old to be synced"
+		default:
+			if err := s.syncEvent(ctx, device, clientID, event, entry); err != nil {
+				return nil, err
+			}
+		}
+		seen[clientID] = true
+
+		switch entry.Result {
+		case SyncCreated:
+			result.Created++
+		case SyncDuplicate:
+			result.Duplicates++
+		case SyncIgnored:
+			result.Ignored++
+		default:
+			result.Rejected++
+		}
+	}
+	return result, nil
+}
+
+// syncEvent mencatat satu event kiosk. Event yang ditolak aturan presensi
+// (kartu tidak terdaftar, siswa tidak aktif, presensi hari itu sudah tercatat
+// dari gerbang lain pada saat yang sama) menjadi hasil rejected; error
+// database dikembalikan agar kiosk mengirim ulang seluruh batch.
+func (s *AttendanceService) syncEvent(ctx context.Context, device, clientID string, event AttendanceSyncEvent, entry *AttendanceSyncEventResult) error {
+	tapped, err := s.tap(ctx, rfidTap{
+		UID:           event.RfidUID,
+		At:            event.Timestamp,
+		Device:        device,
+		ClientEventID: clientID,
+	})
+	if err != nil {
+		if errors.Is(err, ErrValidation) || errors.Is(err, ErrNotFound) || errors.Is(err, ErrForbidden) || errors.Is(err, ErrConflict) {
+			entry.Result, entry.Message = SyncRejected, err.Error()
+			return nil
+		}
+		// Kiosk lain dengan nama yang sama bisa mengirim event yang sama
+		// bersamaan; unique index membuat salah satunya gagal.
+		attendance, findErr := s.db.Attendance.FindFirst(
+			db.Attendance.DeviceName.Equals(device),
+			db.Attendance.ClientEventID.Equals(clientID),
+		).Exec(ctx)
+		if findErr != nil {
+			return err
+		}
+		id := int64(attendance.ID)
+		entry.Result, entry.AttendanceID = SyncDuplicate, &id
+		entry.Status, entry.Timestamp = string(attendance.Status), &attendance.Timestamp
+		entry.Message = "already synced"
+		return nil
+	}
+
+	entry.StudentName = tapped.StudentName
+	entry.Status = tapped.Status
+	entry.Message = tapped.Message
+	if !tapped.Timestamp.IsZero() {
+		entry.Timestamp = &tapped.Timestamp
+	}
+	if tapped.Recorded {
+		entry.Result, entry.AttendanceID = SyncCreated, &tapped.AttendanceID
+	} else {
+		entry.Result = SyncIgnored
+	}
+	return nil
+}
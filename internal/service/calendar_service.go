@@ -0,0 +1,689 @@
+// internal/service/calendar_service.go
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/ical"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// maxCalendarRangeDays membatasi rentang daftar agenda kalender.
+const maxCalendarRangeDays = 400
+
+type CalendarService struct {
+	db *db.PrismaClient
+}
+
+func NewCalendarService(db *db.PrismaClient) *CalendarService {
+	return &CalendarService{db: db}
+}
+
+// SemesterInput adalah rentang tanggal satu semester.
+type SemesterInput struct {
+	Term      string // Ganjil atau Genap
+	StartDate string
+	EndDate   string
+}
+
+// AcademicYearInput adalah data tahun ajaran beserta semesternya.
+type AcademicYearInput struct {
+	Name      string // Contoh: 2025/2026
+	StartDate string
+	EndDate   string
+	Semesters []SemesterInput
+}
+
+// CalendarEventInput adalah data agenda kalender. NonTeaching nil berarti
+// mengikuti jenis agenda: hanya KegiatanSekolah yang tetap ada KBM.
+type CalendarEventInput struct {
+	Title       string
+	Description string
+	EventType   string
+	StartDate   string
+	EndDate     string
+	NonTeaching *bool
+	GradeLevel  string
+}
+
+// CalendarEventFilters adalah filter daftar agenda kalender.
+type CalendarEventFilters struct {
+	From       string
+	To         string
+	EventType  string
+	GradeLevel string // Agenda umum ikut ditampilkan
+}
+
+// CalendarImportOptions adalah pengaturan untuk agenda hasil impor file .ics.
+type CalendarImportOptions struct {
+	EventType   string
+	NonTeaching *bool
+	GradeLevel  string
+}
+
+// CalendarImportResult adalah rekap impor file .ics.
+type CalendarImportResult struct {
+	Created int      `json:"created" example:"15"`
+	Updated int      `json:"updated" example:"2"`
+	Skipped []string `json:"skipped"` // Event yang tidak diimpor beserta alasannya
+}
+
+// schoolWeekDays adalah hari masuk sekolah dalam seminggu (SCHOOL_WEEK_DAYS di
+// .env, dipisah koma, default Senin sampai Jumat).
+func schoolWeekDays() (map[db.DayOfWeek]bool, error) {
+	value := viper.GetString("SCHOOL_WEEK_DAYS")
+	if value == "" {
+		value = "Senin,Selasa,Rabu,Kamis,Jumat"
+	}
+	days := make(map[db.DayOfWeek]bool)
+	for _, name := range strings.Split(value, ",") {
+		day, err := parseDayOfWeek(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		days[day] = true
+	}
+	return days, nil
+}
+
+// IsSchoolDay menentukan apakah sebuah tanggal adalah hari KBM bagi sebuah
+// kelas. Urutannya:
+//  1. tanggal di luar tahun ajaran kelas bukan hari sekolah;
+//  2. agenda khusus tingkat kelas tersebut menjadi pengecualian atas aturan
+//     lain (misalnya kelas XII tetap masuk saat libur semester);
+//  3. hari di luar SCHOOL_WEEK_DAYS, tanggal di antara semester, dan agenda
+//     umum yang meniadakan KBM bukan hari sekolah.
+//
+// classID 0 berarti aturan umum untuk seluruh sekolah.
+func (s *CalendarService) IsSchoolDay(date time.Time, classID int64) (bool, error) {
+	ctx := context.Background()
+	date = dateOnly(date)
+
+	var yearName, gradeLevel string
+	if classID > 0 {
+		class, err := s.db.Class.FindUnique(db.Class.ID.Equals(db.BigInt(classID))).Exec(ctx)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return false, notFoundError("class not found")
+			}
+			return false, err
+		}
+		yearName, gradeLevel = class.AcademicYear, class.GradeLevel
+	} else {
+		var err error
+		if yearName, err = resolveAcademicYear(ctx, s.db, date); err != nil {
+			return false, err
+		}
+	}
+
+	year, err := s.db.AcademicYear.FindFirst(db.AcademicYear.Name.Equals(yearName)).With(
+		db.AcademicYear.Semesters.Fetch(),
+	).Exec(ctx)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return false, err
+	}
+	if year == nil {
+		start, end, err := defaultAcademicYearRange(yearName)
+		if err != nil {
+			return false, err
+		}
+		if date.Before(start) || date.After(end) {
+			return false, nil
+		}
+	} else if date.Before(year.StartDate) || date.After(year.EndDate) {
+		return false, nil
+	}
+
+	events, err := s.db.CalendarEvent.FindMany(
+		db.CalendarEvent.StartDate.Lte(date),
+		db.CalendarEvent.EndDate.Gte(date),
+	).Exec(ctx)
+	if err != nil {
+		return false, errors.New("failed to retrieve calendar events")
+	}
+	var general, forGrade []db.CalendarEventModel
+	for _, event := range events {
+		grade, scoped := event.GradeLevel()
+		switch {
+		case !scoped:
+			general = append(general, event)
+		case gradeLevel != "" && sameGradeLevel(grade, gradeLevel):
+			forGrade = append(forGrade, event)
+		}
+	}
+	if len(forGrade) > 0 {
+		return !anyNonTeaching(forGrade), nil
+	}
+
+	weekDays, err := schoolWeekDays()
+	if err != nil {
+		return false, err
+	}
+	if !weekDays[dayOfWeekOf(date)] {
+		return false, nil
+	}
+	if year != nil && len(year.Semesters()) > 0 {
+		inSemester := false
+		for _, semester := range year.Semesters() {
+			if !date.Before(semester.StartDate) && !date.After(semester.EndDate) {
+				inSemester = true
+				break
+			}
+		}
+		if !inSemester {
+			return false, nil
+		}
+	}
+	return !anyNonTeaching(general), nil
+}
+
+// CheckSchoolDay adalah IsSchoolDay dengan tanggal dalam format YYYY-MM-DD.
+func (s *CalendarService) CheckSchoolDay(date string, classID int64) (bool, error) {
+	day, err := parseDate(date)
+	if err != nil {
+		return false, err
+	}
+	return s.IsSchoolDay(day, classID)
+}
+
+func anyNonTeaching(events []db.CalendarEventModel) bool {
+	for _, event := range events {
+		if event.NonTeaching {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAcademicYears mengambil semua tahun ajaran beserta semesternya, terbaru lebih dulu.
+func (s *CalendarService) GetAcademicYears() ([]db.AcademicYearModel, error) {
+	years, err := s.db.AcademicYear.FindMany().With(
+		db.AcademicYear.Semesters.Fetch().OrderBy(db.Semester.StartDate.Order(db.SortOrderAsc)),
+	).OrderBy(
+		db.AcademicYear.StartDate.Order(db.SortOrderDesc),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve academic years")
+	}
+	return years, nil
+}
+
+// GetAcademicYearByID mengambil satu tahun ajaran beserta semesternya.
+func (s *CalendarService) GetAcademicYearByID(id int) (*db.AcademicYearModel, error) {
+	year, err := s.db.AcademicYear.FindUnique(db.AcademicYear.ID.Equals(db.BigInt(id))).With(
+		db.AcademicYear.Semesters.Fetch().OrderBy(db.Semester.StartDate.Order(db.SortOrderAsc)),
+	).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("academic year not found")
+		}
+		return nil, err
+	}
+	return year, nil
+}
+
+// academicYearPlan adalah input tahun ajaran yang sudah divalidasi.
+type academicYearPlan struct {
+	name       string
+	start, end time.Time
+	semesters  []semesterPlan
+}
+
+type semesterPlan struct {
+	term       db.SemesterTerm
+	start, end time.Time
+}
+
+func parseAcademicYearInput(input AcademicYearInput) (*academicYearPlan, error) {
+	name := strings.TrimSpace(input.Name)
+	if _, _, err := parseAcademicYear(name); err != nil {
+		return nil, err
+	}
+	plan := &academicYearPlan{name: name}
+	var err error
+	if input.StartDate == "" && input.EndDate == "" {
+		plan.start, plan.end, err = defaultAcademicYearRange(name)
+	} else {
+		plan.start, plan.end, err = parseDateRange(input.StartDate, input.EndDate, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[db.SemesterTerm]bool{}
+	for _, semester := range input.Semesters {
+		var term db.SemesterTerm
+		switch semester.Term {
+		case string(db.SemesterTermGanjil), string(db.SemesterTermGenap):
+			term = db.SemesterTerm(semester.Term)
+		default:
+			return nil, validationError("invalid semester term %q, expected Ganjil or Genap", semester.Term)
+		}
+		if seen[term] {
+			return nil, validationError("semester %s is listed more than once", term)
+		}
+		seen[term] = true
+		start, end, err := parseDateRange(semester.StartDate, semester.EndDate, 0)
+		if err != nil {
+			return nil, err
+		}
+		if start.Before(plan.start) || end.After(plan.end) {
+			return nil, validationError("semester %s must fall within academic year %s", term, name)
+		}
+		plan.semesters = append(plan.semesters, semesterPlan{term: term, start: start, end: end})
+	}
+	sort.Slice(plan.semesters, func(i, j int) bool {
+		return plan.semesters[i].start.Before(plan.semesters[j].start)
+	})
+	for i := 1; i < len(plan.semesters); i++ {
+		if !plan.semesters[i].start.After(plan.semesters[i-1].end) {
+			return nil, validationError("semesters %s and %s overlap", plan.semesters[i-1].term, plan.semesters[i].term)
+		}
+	}
+	return plan, nil
+}
+
+func semesterCreateTxs(client *db.PrismaClient, year string, semesters []semesterPlan) []transaction.Param {
+	txs := make([]transaction.Param, 0, len(semesters))
+	for _, semester := range semesters {
+		txs = append(txs, client.Semester.CreateOne(
+			db.Semester.Term.Set(semester.term),
+			db.Semester.StartDate.Set(semester.start),
+			db.Semester.EndDate.Set(semester.end),
+			db.Semester.AcademicYear.Link(db.AcademicYear.Name.Equals(year)),
+		).Tx())
+	}
+	return txs
+}
+
+// CreateAcademicYear membuat tahun ajaran beserta semesternya. Tanpa tanggal,
+// rentang standar 1 Juli sampai 30 Juni dipakai.
+func (s *CalendarService) CreateAcademicYear(input AcademicYearInput) (*db.AcademicYearModel, error) {
+	ctx := context.Background()
+	plan, err := parseAcademicYearInput(input)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.db.AcademicYear.FindUnique(db.AcademicYear.Name.Equals(plan.name)).Exec(ctx)
+	if err == nil {
+		return nil, conflictError("academic year %s already exists", plan.name)
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	txs := []transaction.Param{
+		s.db.AcademicYear.CreateOne(
+			db.AcademicYear.Name.Set(plan.name),
+			db.AcademicYear.StartDate.Set(plan.start),
+			db.AcademicYear.EndDate.Set(plan.end),
+		).Tx(),
+	}
+	txs = append(txs, semesterCreateTxs(s.db, plan.name, plan.semesters)...)
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to create academic year")
+	}
+
+	created, err := s.db.AcademicYear.FindUnique(db.AcademicYear.Name.Equals(plan.name)).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetAcademicYearByID(int(created.ID))
+}
+
+// UpdateAcademicYear mengganti rentang tanggal dan seluruh semester sebuah tahun ajaran.
+// Nama tahun ajaran tidak bisa diubah karena dipakai oleh kelas.
+func (s *CalendarService) UpdateAcademicYear(id int, input AcademicYearInput) (*db.AcademicYearModel, error) {
+	ctx := context.Background()
+	year, err := s.GetAcademicYearByID(id)
+	if err != nil {
+		return nil, err
+	}
+	input.Name = year.Name
+	plan, err := parseAcademicYearInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := []transaction.Param{
+		s.db.AcademicYear.FindUnique(db.AcademicYear.ID.Equals(year.ID)).Update(
+			db.AcademicYear.StartDate.Set(plan.start),
+			db.AcademicYear.EndDate.Set(plan.end),
+		).Tx(),
+		s.db.Semester.FindMany(db.Semester.AcademicYearID.Equals(year.ID)).Delete().Tx(),
+	}
+	txs = append(txs, semesterCreateTxs(s.db, plan.name, plan.semesters)...)
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to update academic year")
+	}
+	return s.GetAcademicYearByID(id)
+}
+
+// DeleteAcademicYear menghapus tahun ajaran yang belum dipakai oleh kelas.
+func (s *CalendarService) DeleteAcademicYear(id int) error {
+	ctx := context.Background()
+	year, err := s.GetAcademicYearByID(id)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Class.FindFirst(db.Class.AcademicYear.Equals(year.Name)).Exec(ctx)
+	if err == nil {
+		return conflictError("academic year %s is still used by classes", year.Name)
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return err
+	}
+	_, err = s.db.AcademicYear.FindUnique(db.AcademicYear.ID.Equals(year.ID)).Delete().Exec(ctx)
+	return err
+}
+
+// academicYearRange mengembalikan rentang tanggal tahun ajaran dari kalender,
+// atau rentang standar jika tahun ajaran belum didaftarkan.
+func academicYearRange(ctx context.Context, client *db.PrismaClient, name string) (time.Time, time.Time, error) {
+	year, err := client.AcademicYear.FindUnique(db.AcademicYear.Name.Equals(name)).Exec(ctx)
+	if err == nil {
+		return year.StartDate, year.EndDate, nil
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return time.Time{}, time.Time{}, err
+	}
+	return defaultAcademicYearRange(name)
+}
+
+// academicYearContaining mencari tahun ajaran terdaftar yang rentang
+// tanggalnya memuat date. Hasilnya nil jika tidak ada.
+func academicYearContaining(ctx context.Context, client *db.PrismaClient, date time.Time) (*db.AcademicYearModel, error) {
+	year, err := client.AcademicYear.FindFirst(
+		db.AcademicYear.StartDate.Lte(date),
+		db.AcademicYear.EndDate.Gte(date),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	return year, err
+}
+
+// resolveAcademicYear mengembalikan nama tahun ajaran sebuah tanggal menurut
+// rentang tanggal tahun ajaran terdaftar, karena tahun ajaran tidak selalu
+// dimulai bulan Juli. Aturan Juli academicYearOf hanya dipakai jika tanggal itu
+// belum tercakup tahun ajaran mana pun.
+func resolveAcademicYear(ctx context.Context, client *db.PrismaClient, date time.Time) (string, error) {
+	year, err := academicYearContaining(ctx, client, date)
+	if err != nil {
+		return "", err
+	}
+	if year == nil {
+		return academicYearOf(date), nil
+	}
+	return year.Name, nil
+}
+
+// academicYearsBetween seperti resolveAcademicYear untuk banyak tanggal
+// sekaligus: tahun ajaran yang beririsan dengan start-end dimuat sekali dan
+// fungsi yang dikembalikan menentukan tahun ajaran setiap tanggal di rentang itu.
+func academicYearsBetween(ctx context.Context, client *db.PrismaClient, start, end time.Time) (func(time.Time) string, error) {
+	years, err := client.AcademicYear.FindMany(
+		db.AcademicYear.StartDate.Lte(end),
+		db.AcademicYear.EndDate.Gte(start),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve academic years")
+	}
+	return func(date time.Time) string {
+		for _, year := range years {
+			if !date.Before(year.StartDate) && !date.After(year.EndDate) {
+				return year.Name
+			}
+		}
+		return academicYearOf(date)
+	}, nil
+}
+
+// GetEvents mengambil agenda kalender yang beririsan dengan rentang tanggal.
+func (s *CalendarService) GetEvents(filters CalendarEventFilters) ([]db.CalendarEventModel, error) {
+	start, end, err := parseDateRange(filters.From, filters.To, maxCalendarRangeDays)
+	if err != nil {
+		return nil, err
+	}
+	where := []db.CalendarEventWhereParam{
+		db.CalendarEvent.StartDate.Lte(end),
+		db.CalendarEvent.EndDate.Gte(start),
+	}
+	if filters.EventType != "" {
+		eventType, err := parseCalendarEventType(filters.EventType)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.CalendarEvent.EventType.Equals(eventType))
+	}
+	if filters.GradeLevel != "" {
+		where = append(where, db.CalendarEvent.Or(
+			db.CalendarEvent.GradeLevel.IsNull(),
+			db.CalendarEvent.GradeLevel.Equals(normalizeGradeLevel(filters.GradeLevel)),
+		))
+	}
+
+	events, err := s.db.CalendarEvent.FindMany(where...).OrderBy(
+		db.CalendarEvent.StartDate.Order(db.SortOrderAsc),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve calendar events")
+	}
+	return events, nil
+}
+
+// GetEventByID mengambil satu agenda kalender.
+func (s *CalendarService) GetEventByID(id int) (*db.CalendarEventModel, error) {
+	event, err := s.db.CalendarEvent.FindUnique(db.CalendarEvent.ID.Equals(db.BigInt(id))).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("calendar event not found")
+		}
+		return nil, err
+	}
+	return event, nil
+}
+
+func parseCalendarEventType(value string) (db.CalendarEventType, error) {
+	for _, eventType := range []db.CalendarEventType{
+		db.CalendarEventTypeLiburNasional,
+		db.CalendarEventTypeLiburSekolah,
+		db.CalendarEventTypeKegiatanSekolah,
+		db.CalendarEventTypeUjian,
+	} {
+		if string(eventType) == value {
+			return eventType, nil
+		}
+	}
+	return "", validationError("invalid event type %q, expected LiburNasional, LiburSekolah, KegiatanSekolah or Ujian", value)
+}
+
+// calendarEventParams memvalidasi input agenda dan menyusun kolom-kolomnya.
+func (s *CalendarService) calendarEventParams(ctx context.Context, input CalendarEventInput) (title string, eventType db.CalendarEventType, start, end time.Time, optional []db.CalendarEventSetParam, err error) {
+	title = strings.TrimSpace(input.Title)
+	if title == "" {
+		return "", "", time.Time{}, time.Time{}, nil, validationError("title is required")
+	}
+	eventType, err = parseCalendarEventType(input.EventType)
+	if err != nil {
+		return "", "", time.Time{}, time.Time{}, nil, err
+	}
+	if input.EndDate == "" {
+		input.EndDate = input.StartDate
+	}
+	start, end, err = parseDateRange(input.StartDate, input.EndDate, 0)
+	if err != nil {
+		return "", "", time.Time{}, time.Time{}, nil, err
+	}
+
+	nonTeaching := eventType != db.CalendarEventTypeKegiatanSekolah
+	if input.NonTeaching != nil {
+		nonTeaching = *input.NonTeaching
+	}
+	var gradeLevel *string
+	if grade := strings.TrimSpace(input.GradeLevel); grade != "" {
+		if _, _, ok := gradeIndex(grade); !ok {
+			return "", "", time.Time{}, time.Time{}, nil, validationError("unknown grade level %q", grade)
+		}
+		grade = normalizeGradeLevel(grade)
+		gradeLevel = &grade
+	}
+	optional = []db.CalendarEventSetParam{
+		db.CalendarEvent.Description.SetOptional(optionalString(input.Description)),
+		db.CalendarEvent.NonTeaching.Set(nonTeaching),
+		db.CalendarEvent.GradeLevel.SetOptional(gradeLevel),
+	}
+
+	year, err := academicYearContaining(ctx, s.db, start)
+	if err != nil {
+		return "", "", time.Time{}, time.Time{}, nil, err
+	}
+	if year != nil {
+		optional = append(optional, db.CalendarEvent.AcademicYear.Link(db.AcademicYear.ID.Equals(year.ID)))
+	}
+	return title, eventType, start, end, optional, nil
+}
+
+// CreateEvent membuat agenda kalender. Tahun ajaran ditautkan otomatis dari tanggal mulai.
+func (s *CalendarService) CreateEvent(input CalendarEventInput) (*db.CalendarEventModel, error) {
+	ctx := context.Background()
+	title, eventType, start, end, optional, err := s.calendarEventParams(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.db.CalendarEvent.CreateOne(
+		db.CalendarEvent.Title.Set(title),
+		db.CalendarEvent.EventType.Set(eventType),
+		db.CalendarEvent.StartDate.Set(start),
+		db.CalendarEvent.EndDate.Set(end),
+		optional...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to create calendar event")
+	}
+	return event, nil
+}
+
+// UpdateEvent mengganti seluruh isi agenda kalender.
+func (s *CalendarService) UpdateEvent(id int, input CalendarEventInput) (*db.CalendarEventModel, error) {
+	ctx := context.Background()
+	if _, err := s.GetEventByID(id); err != nil {
+		return nil, err
+	}
+	title, eventType, start, end, optional, err := s.calendarEventParams(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	params := append([]db.CalendarEventSetParam{
+		db.CalendarEvent.Title.Set(title),
+		db.CalendarEvent.EventType.Set(eventType),
+		db.CalendarEvent.StartDate.Set(start),
+		db.CalendarEvent.EndDate.Set(end),
+	}, optional...)
+	event, err := s.db.CalendarEvent.FindUnique(db.CalendarEvent.ID.Equals(db.BigInt(id))).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to update calendar event")
+	}
+	return event, nil
+}
+
+// DeleteEvent menghapus agenda kalender.
+func (s *CalendarService) DeleteEvent(id int) error {
+	_, err := s.db.CalendarEvent.FindUnique(db.CalendarEvent.ID.Equals(db.BigInt(id))).Delete().Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("calendar event not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// ImportICal mengimpor agenda dari file .ics, misalnya kalender libur nasional.
+// Event dicocokkan lewat UID sehingga impor ulang memperbarui agenda yang sama.
+// Event berulang (RRULE) dilewati karena kalender sekolah mencatat tanggal konkret.
+func (s *CalendarService) ImportICal(data []byte, options CalendarImportOptions) (*CalendarImportResult, error) {
+	ctx := context.Background()
+	if options.EventType == "" {
+		options.EventType = string(db.CalendarEventTypeLiburNasional)
+	}
+	events, err := ical.Parse(bytes.NewReader(data), appLocation())
+	if err != nil {
+		return nil, validationError("invalid iCalendar file: %v", err)
+	}
+
+	result := &CalendarImportResult{Skipped: []string{}}
+	var txs []transaction.Param
+	for _, event := range events {
+		if event.RRule != "" {
+			result.Skipped = append(result.Skipped, fmt.Sprintf("%s: recurring events are not supported", event.Summary))
+			continue
+		}
+		start, end := dateOnly(event.Start), dateOnly(event.End)
+		if event.AllDay && end.After(start) {
+			end = end.AddDate(0, 0, -1) // DTEND sehari penuh bersifat eksklusif
+		}
+		uid := event.UID
+		if uid == "" {
+			uid = fmt.Sprintf("%s-%s", formatDate(start), strings.ToLower(strings.Join(strings.Fields(event.Summary), "-")))
+		}
+		if len(uid) > 255 {
+			uid = uid[:255]
+		}
+
+		title, eventType, start, end, optional, err := s.calendarEventParams(ctx, CalendarEventInput{
+			Title:       event.Summary,
+			Description: event.Description,
+			EventType:   options.EventType,
+			StartDate:   formatDate(start),
+			EndDate:     formatDate(end),
+			NonTeaching: options.NonTeaching,
+			GradeLevel:  options.GradeLevel,
+		})
+		if err != nil {
+			if errors.Is(err, ErrValidation) {
+				result.Skipped = append(result.Skipped, fmt.Sprintf("%s: %v", event.Summary, err))
+				continue
+			}
+			return nil, err
+		}
+
+		_, err = s.db.CalendarEvent.FindUnique(db.CalendarEvent.IcalUID.Equals(uid)).Exec(ctx)
+		switch {
+		case err == nil:
+			result.Updated++
+		case errors.Is(err, db.ErrNotFound):
+			result.Created++
+		default:
+			return nil, err
+		}
+		update := append([]db.CalendarEventSetParam{
+			db.CalendarEvent.Title.Set(title),
+			db.CalendarEvent.EventType.Set(eventType),
+			db.CalendarEvent.StartDate.Set(start),
+			db.CalendarEvent.EndDate.Set(end),
+		}, optional...)
+		txs = append(txs, s.db.CalendarEvent.UpsertOne(db.CalendarEvent.IcalUID.Equals(uid)).Create(
+			db.CalendarEvent.Title.Set(title),
+			db.CalendarEvent.EventType.Set(eventType),
+			db.CalendarEvent.StartDate.Set(start),
+			db.CalendarEvent.EndDate.Set(end),
+			append(optional, db.CalendarEvent.IcalUID.Set(uid))...,
+		).Update(update...).Tx())
+	}
+	if len(txs) > 0 {
+		if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+			return nil, errors.New("failed to import calendar events")
+		}
+	}
+	return result, nil
+}
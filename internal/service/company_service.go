@@ -0,0 +1,172 @@
+// internal/service/company_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// CompanyService mengelola DU/DI (dunia usaha/dunia industri) mitra tempat PKL.
+type CompanyService struct {
+	db *db.PrismaClient
+}
+
+func NewCompanyService(db *db.PrismaClient) *CompanyService {
+	return &CompanyService{db: db}
+}
+
+// CompanyInput adalah data DU/DI. Capacity nil berarti kuota tidak dibatasi.
+type CompanyInput struct {
+	Name          string
+	Address       string
+	Coordinates   string // lat,long
+	ContactPerson string
+	ContactPhone  string
+	ContactEmail  string
+	Capacity      *int
+}
+
+// GetCompanies mengambil daftar DU/DI dengan paginasi dan pencarian nama, alamat
+// atau narahubung. Penempatan yang masih aktif ikut diambil untuk menghitung sisa kuota.
+func (s *CompanyService) GetCompanies(page, limit int, search string) ([]db.CompanyModel, int, error) {
+	ctx := context.Background()
+	var where []db.CompanyWhereParam
+	if search != "" {
+		where = append(where, db.Company.Or(
+			db.Company.Name.Contains(search),
+			db.Company.Address.Contains(search),
+			db.Company.ContactPerson.Contains(search),
+		))
+	}
+
+	all, err := s.db.Company.FindMany(where...).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to count companies")
+	}
+
+	companies, err := s.db.Company.FindMany(where...).With(
+		db.Company.InternshipPlacements.Fetch(db.InternshipPlacement.Status.Equals(db.InternshipStatusAktif)),
+	).OrderBy(
+		db.Company.Name.Order(db.SortOrderAsc),
+	).Skip((page - 1) * limit).Take(limit).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to retrieve companies")
+	}
+	return companies, len(all), nil
+}
+
+// GetCompanyByID mengambil satu DU/DI beserta seluruh riwayat penempatan PKL-nya,
+// terbaru lebih dulu.
+func (s *CompanyService) GetCompanyByID(id int) (*db.CompanyModel, error) {
+	company, err := s.db.Company.FindUnique(db.Company.ID.Equals(db.BigInt(id))).With(
+		db.Company.InternshipPlacements.Fetch().With(
+			db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+			db.InternshipPlacement.SupervisorTeacher.Fetch(),
+		).OrderBy(db.InternshipPlacement.StartDate.Order(db.SortOrderDesc)),
+	).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("company not found")
+		}
+		return nil, err
+	}
+	return company, nil
+}
+
+// companyParams memvalidasi input DU/DI dan menyusun kolom-kolom opsionalnya.
+func companyParams(input CompanyInput) (name string, optional []db.CompanySetParam, err error) {
+	name = strings.TrimSpace(input.Name)
+	if name == "" {
+		return "", nil, validationError("name is required")
+	}
+
+	var coordinates *string
+	if value := strings.TrimSpace(input.Coordinates); value != "" {
+		lat, long, err := parseCoordinates(value)
+		if err != nil {
+			return "", nil, err
+		}
+		formatted := formatCoordinates(lat, long)
+		coordinates = &formatted
+	}
+	email := strings.TrimSpace(input.ContactEmail)
+	if email != "" {
+		if _, err := mail.ParseAddress(email); err != nil {
+			return "", nil, validationError("invalid contact email %q", email)
+		}
+	}
+	if input.Capacity != nil && *input.Capacity <= 0 {
+		return "", nil, validationError("capacity must be greater than zero")
+	}
+
+	optional = []db.CompanySetParam{
+		db.Company.Address.SetOptional(optionalString(input.Address)),
+		db.Company.Coordinates.SetOptional(coordinates),
+		db.Company.ContactPerson.SetOptional(optionalString(input.ContactPerson)),
+		db.Company.ContactPhone.SetOptional(optionalString(input.ContactPhone)),
+		db.Company.ContactEmail.SetOptional(optionalString(email)),
+		db.Company.Capacity.SetOptional(input.Capacity),
+	}
+	return name, optional, nil
+}
+
+// CreateCompany mendaftarkan DU/DI mitra baru.
+func (s *CompanyService) CreateCompany(input CompanyInput) (*db.CompanyModel, error) {
+	name, optional, err := companyParams(input)
+	if err != nil {
+		return nil, err
+	}
+	company, err := s.db.Company.CreateOne(
+		db.Company.Name.Set(name),
+		optional...,
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to create company")
+	}
+	return company, nil
+}
+
+// UpdateCompany mengganti seluruh data DU/DI. Kuota boleh diturunkan di bawah
+// jumlah siswa aktif; pembatasannya hanya berlaku untuk penempatan baru.
+func (s *CompanyService) UpdateCompany(id int, input CompanyInput) (*db.CompanyModel, error) {
+	ctx := context.Background()
+	name, optional, err := companyParams(input)
+	if err != nil {
+		return nil, err
+	}
+	params := append([]db.CompanySetParam{db.Company.Name.Set(name)}, optional...)
+	company, err := s.db.Company.FindUnique(db.Company.ID.Equals(db.BigInt(id))).Update(params...).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("company not found")
+		}
+		return nil, err
+	}
+	return company, nil
+}
+
+// DeleteCompany menghapus DU/DI yang belum pernah menerima siswa PKL. Penempatan
+// dan jurnal PKL ikut terhapus secara cascade, jadi riwayat yang ada tidak boleh hilang.
+func (s *CompanyService) DeleteCompany(id int) error {
+	ctx := context.Background()
+	_, err := s.db.InternshipPlacement.FindFirst(db.InternshipPlacement.CompanyID.Equals(db.BigInt(id))).Exec(ctx)
+	if err == nil {
+		return conflictError("company still has internship placements")
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return err
+	}
+
+	_, err = s.db.Company.FindUnique(db.Company.ID.Equals(db.BigInt(id))).Delete().Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("company not found")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+// internal/service/daily_attendance_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// Status rekap presensi harian.
+const (
+	DailyOnTime  = "Tepat Waktu"
+	DailyLate    = "Terlambat"
+	DailyAbsent  = "Tidak Hadir"
+	DailyOnLeave = "Izin"           // Tidak hadir dengan pengajuan izin/cuti yang disetujui
+	DailyHoliday = "Libur"          // Tidak ada aturan jam kerja, hari libur, atau bukan hari sekolah
+	DailyNotYet  = "Belum Presensi" // Hari ini, jam kerja belum selesai
+)
+
+// maxDailyRange adalah rentang tanggal terpanjang yang direkap sekaligus.
+const maxDailyRange = 31
+
+// DailyAttendanceService menghitung rekap presensi harian per user dari data
+// presensi mentah, aturan jam kerja, izin yang disetujui dan kalender sekolah.
+// Rekap tidak disimpan sehingga perubahan aturan langsung berlaku.
+type DailyAttendanceService struct {
+	db         *db.PrismaClient
+	schoolDays SchoolDayChecker
+}
+
+func NewDailyAttendanceService(db *db.PrismaClient) *DailyAttendanceService {
+	return &DailyAttendanceService{db: db, schoolDays: NewCalendarService(db)}
+}
+
+// DailyAttendanceFilters adalah filter rekap presensi harian.
+type DailyAttendanceFilters struct {
+	From   string
+	To     string
+	Role   string
+	UserID int64
+	Status string
+}
+
+// DailyAttendance adalah rekap presensi satu user pada satu tanggal.
+type DailyAttendance struct {
+	Date            string `json:"date" example:"2025-09-22"`
+	DayOfWeek       string `json:"day_of_week" example:"Senin"`
+	UserID          int64  `json:"user_id" example:"12"`
+	Username        string `json:"username" example:"siti.aminah"`
+	FullName        string `json:"full_name" example:"Siti Aminah, S.Kom"`
+	Role            string `json:"role" example:"teacher"`
+	Status          string `json:"status" example:"Terlambat"` // Tepat Waktu, Terlambat, Tidak Hadir, Izin, Libur atau Belum Presensi
+	ExpectedStart   string `json:"expected_start,omitempty" example:"07:00"`
+	ExpectedEnd     string `json:"expected_end,omitempty" example:"15:30"`
+	CheckIn         string `json:"check_in,omitempty" example:"07:18"`
+	CheckOut        string `json:"check_out,omitempty" example:"15:05"`
+	MinutesLate     int    `json:"minutes_late" example:"18"` // Dihitung dari jam masuk, bukan dari akhir toleransi
+	EarlyLeave      bool   `json:"early_leave" example:"true"`
+	MinutesEarly    int    `json:"minutes_early" example:"25"`
+	WorkMinutes     int    `json:"work_minutes" example:"467"`
+	MissingCheckOut bool   `json:"missing_check_out" example:"false"`
+	LeaveType       string `json:"leave_type,omitempty" example:"Sakit"`
+}
+
+// dailyAttendanceContext adalah data pendukung yang diambil sekali untuk
+// seluruh user dan rentang tanggal.
+type dailyAttendanceContext struct {
+	rules       map[db.UserRole]map[db.DayOfWeek]db.AttendanceRuleModel
+	attendances map[db.BigInt]map[string][]db.AttendanceModel
+	leaves      map[db.BigInt][]db.LeaveRequestModel
+	holidays    map[string]bool
+	schoolDays  map[string]bool
+}
+
+// dailyUserName mengambil nama lengkap guru atau siswa, atau username untuk user lain.
+func dailyUserName(user db.UserModel) string {
+	if user.RelationsUser.Teacher != nil {
+		if teacher, ok := user.Teacher(); ok {
+			return teacher.FullName
+		}
+	}
+	if user.RelationsUser.Student != nil {
+		if student, ok := user.Student(); ok {
+			return student.FullName
+		}
+	}
+	return user.Username
+}
+
+// dailyRange membaca rentang tanggal rekap. Default hari ini; tanggal setelah
+// hari ini dipotong karena belum bisa direkap.
+func dailyRange(from, to string) (time.Time, time.Time, error) {
+	start, end := today(), today()
+	var err error
+	if from != "" {
+		if start, err = parseDate(from); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end = start
+	}
+	if to != "" {
+		if end, err = parseDate(to); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, validationError("end date must not be before start date")
+	}
+	if start.After(today()) {
+		return time.Time{}, time.Time{}, validationError("cannot compute attendance for a future date")
+	}
+	if end.After(today()) {
+		end = today()
+	}
+	if int(end.Sub(start).Hours()/24)+1 > maxDailyRange {
+		return time.Time{}, time.Time{}, validationError("date range must not exceed %d days", maxDailyRange)
+	}
+	return start, end, nil
+}
+
+// GetDaily menyusun rekap presensi harian user aktif untuk rentang tanggal
+// (default hari ini, maksimal 31 hari), urut tanggal lalu nama.
+func (s *DailyAttendanceService) GetDaily(filters DailyAttendanceFilters) ([]DailyAttendance, error) {
+	ctx := context.Background()
+	from, to, err := dailyRange(filters.From, filters.To)
+	if err != nil {
+		return nil, err
+	}
+	where := []db.UserWhereParam{db.User.IsActive.Equals(true)}
+	if filters.Role != "" {
+		role, err := parseUserRole(filters.Role)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.User.Role.Equals(role))
+	}
+	if filters.UserID > 0 {
+		where = append(where, db.User.ID.Equals(db.BigInt(filters.UserID)))
+	}
+	users, err := s.db.User.FindMany(where...).With(
+		db.User.Teacher.Fetch(),
+		db.User.Student.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve users")
+	}
+
+	records, err := s.compute(ctx, users, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if filters.Status == "" {
+		return records, nil
+	}
+	filtered := make([]DailyAttendance, 0, len(records))
+	for _, record := range records {
+		if record.Status == filters.Status {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered, nil
+}
+
+// GetMyDaily menyusun rekap presensi harian user yang sedang login. Rentang
+// default adalah awal bulan ini sampai hari ini.
+func (s *DailyAttendanceService) GetMyDaily(userID int, from, to string) ([]DailyAttendance, error) {
+	ctx := context.Background()
+	if from == "" {
+		date := today()
+		from = formatDate(time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC))
+		if to == "" {
+			to = formatDate(date)
+		}
+	}
+	start, end, err := dailyRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.db.User.FindUnique(db.User.ID.Equals(db.BigInt(userID))).With(
+		db.User.Teacher.Fetch(),
+		db.User.Student.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("user not found")
+		}
+		return nil, err
+	}
+	return s.compute(ctx, []db.UserModel{*user}, start, end)
+}
+
+// compute menghitung rekap harian untuk users dari tanggal from sampai to;
+// dipakai juga oleh rekap presensi bulanan.
+func (s *DailyAttendanceService) compute(ctx context.Context, users []db.UserModel, from, to time.Time) ([]DailyAttendance, error) {
+	data, err := s.load(ctx, users, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	records := []DailyAttendance{}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		for _, user := range users {
+			workDay, err := s.isWorkDay(data, user, date)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, classifyDay(data, user, date, workDay, now))
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Date != records[j].Date {
+			return records[i].Date < records[j].Date
+		}
+		return records[i].FullName < records[j].FullName
+	})
+	return records, nil
+}
+
+// load mengambil aturan jam kerja, presensi, izin yang disetujui dan hari libur
+// untuk seluruh user sekaligus.
+func (s *DailyAttendanceService) load(ctx context.Context, users []db.UserModel, from, to time.Time) (*dailyAttendanceContext, error) {
+	data := &dailyAttendanceContext{
+		rules:       make(map[db.UserRole]map[db.DayOfWeek]db.AttendanceRuleModel),
+		attendances: make(map[db.BigInt]map[string][]db.AttendanceModel),
+		leaves:      make(map[db.BigInt][]db.LeaveRequestModel),
+		holidays:    make(map[string]bool),
+		schoolDays:  make(map[string]bool),
+	}
+	if len(users) == 0 {
+		return data, nil
+	}
+	ids := make([]db.BigInt, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+
+	rules, err := s.db.AttendanceRule.FindMany().Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve attendance rules")
+	}
+	for _, rule := range rules {
+		if data.rules[rule.Role] == nil {
+			data.rules[rule.Role] = make(map[db.DayOfWeek]db.AttendanceRuleModel)
+		}
+		data.rules[rule.Role][rule.DayOfWeek] = rule
+	}
+
+	start, _ := dayRange(from)
+	_, end := dayRange(to)
+	attendances, err := s.db.Attendance.FindMany(
+		db.Attendance.UserID.In(ids),
+		db.Attendance.Timestamp.Gte(start),
+		db.Attendance.Timestamp.Lt(end),
+	).OrderBy(
+		db.Attendance.Timestamp.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve attendances")
+	}
+	loc := appLocation()
+	for _, attendance := range attendances {
+		if data.attendances[attendance.UserID] == nil {
+			data.attendances[attendance.UserID] = make(map[string][]db.AttendanceModel)
+		}
+		key := formatDate(dateOnly(attendance.Timestamp.In(loc)))
+		data.attendances[attendance.UserID][key] = append(data.attendances[attendance.UserID][key], attendance)
+	}
+
+	leaves, err := s.db.LeaveRequest.FindMany(
+		db.LeaveRequest.UserID.In(ids),
+		db.LeaveRequest.Status.Equals(db.LeaveStatusApproved),
+		db.LeaveRequest.StartDate.Lte(to),
+		db.LeaveRequest.EndDate.Gte(from),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve leave requests")
+	}
+	for _, leave := range leaves {
+		data.leaves[leave.UserID] = append(data.leaves[leave.UserID], leave)
+	}
+
+	// Libur nasional dan libur sekolah untuk seluruh sekolah berlaku bagi guru
+	// dan staf; hari sekolah siswa mengikuti kalender kelasnya.
+	events, err := s.db.CalendarEvent.FindMany(
+		db.CalendarEvent.StartDate.Lte(to),
+		db.CalendarEvent.EndDate.Gte(from),
+		db.CalendarEvent.GradeLevel.IsNull(),
+		db.CalendarEvent.EventType.In([]db.CalendarEventType{db.CalendarEventTypeLiburNasional, db.CalendarEventTypeLiburSekolah}),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve calendar events")
+	}
+	for _, event := range events {
+		for date := event.StartDate; !date.After(event.EndDate); date = date.AddDate(0, 0, 1) {
+			data.holidays[formatDate(date)] = true
+		}
+	}
+	return data, nil
+}
+
+// isWorkDay menentukan apakah date adalah hari kerja user: harus ada aturan jam
+// kerja untuk peran dan harinya, bukan hari libur, dan untuk siswa harus hari
+// sekolah bagi kelasnya.
+func (s *DailyAttendanceService) isWorkDay(data *dailyAttendanceContext, user db.UserModel, date time.Time) (bool, error) {
+	if _, ok := data.rules[user.Role][dayOfWeekOf(date)]; !ok {
+		return false, nil
+	}
+	if user.Role != db.UserRoleStudent {
+		return !data.holidays[formatDate(date)], nil
+	}
+
+	classID := int64(0)
+	if user.RelationsUser.Student != nil {
+		if student, ok := user.Student(); ok {
+			if id, ok := student.CurrentClassID(); ok {
+				classID = int64(id)
+			}
+		}
+	}
+	key := fmt.Sprintf("%d|%s", classID, formatDate(date))
+	if schoolDay, ok := data.schoolDays[key]; ok {
+		return schoolDay, nil
+	}
+	schoolDay, err := s.schoolDays.IsSchoolDay(date, classID)
+	if err != nil {
+		return false, err
+	}
+	data.schoolDays[key] = schoolDay
+	return schoolDay, nil
+}
+
+// classifyDay menentukan status presensi satu user pada satu tanggal.
+// Keterlambatan dihitung jika presensi masuk melewati jam masuk ditambah
+// toleransi; pulang cepat jika presensi pulang sebelum jam pulang.
+func classifyDay(data *dailyAttendanceContext, user db.UserModel, date time.Time, workDay bool, now time.Time) DailyAttendance {
+	record := DailyAttendance{
+		Date:      formatDate(date),
+		DayOfWeek: string(dayOfWeekOf(date)),
+		UserID:    int64(user.ID),
+		Username:  user.Username,
+		FullName:  dailyUserName(user),
+		Role:      string(user.Role),
+		Status:    DailyHoliday,
+	}
+
+	var checkIn, checkOut *time.Time
+	for _, attendance := range data.attendances[user.ID][record.Date] {
+		timestamp := attendance.Timestamp
+		if attendance.Status == db.AttendanceStatusMasuk && checkIn == nil {
+			checkIn = &timestamp
+		}
+		if attendance.Status == db.AttendanceStatusPulang {
+			checkOut = &timestamp
+		}
+	}
+	if checkIn != nil {
+		record.CheckIn = formatClock(clockOf(*checkIn))
+	}
+	if checkOut != nil {
+		record.CheckOut = formatClock(clockOf(*checkOut))
+	}
+	if checkIn != nil && checkOut != nil {
+		record.WorkMinutes = int(checkOut.Sub(*checkIn).Minutes())
+	}
+	for _, leave := range data.leaves[user.ID] {
+		if !date.Before(leave.StartDate) && !date.After(leave.EndDate) {
+			record.LeaveType = string(leave.RequestType)
+			break
+		}
+	}
+	if !workDay {
+		return record
+	}
+
+	rule := data.rules[user.Role][dayOfWeekOf(date)]
+	record.ExpectedStart = formatClock(rule.StartTime)
+	record.ExpectedEnd = formatClock(rule.EndTime)
+	isToday := record.Date == formatDate(today())
+	workEnded := !isToday || minutesOfDay(clockOf(now)) >= minutesOfDay(rule.EndTime)
+
+	switch {
+	case checkIn != nil:
+		record.Status = DailyOnTime
+		arrival := minutesOfDay(clockOf(*checkIn))
+		if arrival > minutesOfDay(rule.StartTime)+rule.GraceMinutes {
+			record.Status = DailyLate
+			record.MinutesLate = arrival - minutesOfDay(rule.StartTime)
+		}
+	case record.LeaveType != "":
+		record.Status = DailyOnLeave
+		return record
+	case workEnded:
+		record.Status = DailyAbsent
+		return record
+	default:
+		record.Status = DailyNotYet
+		return record
+	}
+
+	if checkOut != nil {
+		if departure := minutesOfDay(clockOf(*checkOut)); departure < minutesOfDay(rule.EndTime) {
+			record.EarlyLeave = true
+			record.MinutesEarly = minutesOfDay(rule.EndTime) - departure
+		}
+	} else if workEnded {
+		record.MissingCheckOut = true
+	}
+	return record
+}
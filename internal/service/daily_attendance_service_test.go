@@ -0,0 +1,215 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// clock membuat nilai jam seperti kolom @db.Time.
+func clock(hour, minute int) time.Time {
+	return time.Date(1970, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+// dailyFixture menyiapkan aturan jam kerja guru 07:00-15:00 dengan toleransi
+// 10 menit untuk setiap hari.
+func dailyFixture() (*dailyAttendanceContext, db.UserModel) {
+	user := db.UserModel{InnerUser: db.InnerUser{ID: 1, Username: "siti.aminah", Role: db.UserRoleTeacher}}
+	data := &dailyAttendanceContext{
+		rules:       map[db.UserRole]map[db.DayOfWeek]db.AttendanceRuleModel{db.UserRoleTeacher: {}},
+		attendances: map[db.BigInt]map[string][]db.AttendanceModel{},
+		leaves:      map[db.BigInt][]db.LeaveRequestModel{},
+		holidays:    map[string]bool{},
+		schoolDays:  map[string]bool{},
+	}
+	for _, day := range daysOfWeek {
+		data.rules[db.UserRoleTeacher][day] = db.AttendanceRuleModel{InnerAttendanceRule: db.InnerAttendanceRule{
+			Role:         db.UserRoleTeacher,
+			DayOfWeek:    day,
+			StartTime:    clock(7, 0),
+			EndTime:      clock(15, 0),
+			GraceMinutes: 10,
+		}}
+	}
+	return data, user
+}
+
+func addAttendance(data *dailyAttendanceContext, user db.UserModel, status db.AttendanceStatus, at time.Time) {
+	key := formatDate(dateOnly(at))
+	if data.attendances[user.ID] == nil {
+		data.attendances[user.ID] = map[string][]db.AttendanceModel{}
+	}
+	data.attendances[user.ID][key] = append(data.attendances[user.ID][key], db.AttendanceModel{InnerAttendance: db.InnerAttendance{
+		UserID:    user.ID,
+		Timestamp: at,
+		Status:    status,
+	}})
+}
+
+func TestClassifyDay(t *testing.T) {
+	loc := appLocation()
+	monday := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) *time.Time {
+		value := time.Date(2025, 9, 22, hour, minute, 0, 0, loc)
+		return &value
+	}
+	sick := db.LeaveRequestModel{InnerLeaveRequest: db.InnerLeaveRequest{
+		UserID:      1,
+		RequestType: db.LeaveTypeSakit,
+		StartDate:   monday.AddDate(0, 0, -1),
+		EndDate:     monday,
+	}}
+
+	tests := []struct {
+		name         string
+		checkIn      *time.Time
+		checkOut     *time.Time
+		leave        bool
+		workDay      bool
+		want         string
+		minutesLate  int
+		minutesEarly int
+		earlyLeave   bool
+		missingOut   bool
+		workMinutes  int
+	}{
+		{name: "on time", checkIn: at(6, 55), checkOut: at(15, 5), workDay: true, want: DailyOnTime, workMinutes: 490},
+		{name: "within grace period", checkIn: at(7, 10), checkOut: at(15, 0), workDay: true, want: DailyOnTime, workMinutes: 470},
+		{name: "late counted from start time", checkIn: at(7, 11), checkOut: at(15, 0), workDay: true, want: DailyLate, minutesLate: 11, workMinutes: 469},
+		{name: "early leave", checkIn: at(7, 0), checkOut: at(14, 30), workDay: true, want: DailyOnTime, earlyLeave: true, minutesEarly: 30, workMinutes: 450},
+		{name: "missing check-out", checkIn: at(7, 0), workDay: true, want: DailyOnTime, missingOut: true},
+		{name: "on leave", leave: true, workDay: true, want: DailyOnLeave},
+		{name: "attendance wins over leave", checkIn: at(7, 30), checkOut: at(15, 0), leave: true, workDay: true, want: DailyLate, minutesLate: 30, workMinutes: 450},
+		{name: "absent", workDay: true, want: DailyAbsent},
+		{name: "not a work day", checkIn: at(9, 0), want: DailyHoliday},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, user := dailyFixture()
+			if tt.checkIn != nil {
+				addAttendance(data, user, db.AttendanceStatusMasuk, *tt.checkIn)
+			}
+			if tt.checkOut != nil {
+				addAttendance(data, user, db.AttendanceStatusPulang, *tt.checkOut)
+			}
+			if tt.leave {
+				data.leaves[user.ID] = []db.LeaveRequestModel{sick}
+			}
+
+			got := classifyDay(data, user, monday, tt.workDay, time.Now())
+			if got.Status != tt.want {
+				t.Fatalf("Status = %q, want %q", got.Status, tt.want)
+			}
+			if got.MinutesLate != tt.minutesLate {
+				t.Errorf("MinutesLate = %d, want %d", got.MinutesLate, tt.minutesLate)
+			}
+			if got.EarlyLeave != tt.earlyLeave || got.MinutesEarly != tt.minutesEarly {
+				t.Errorf("EarlyLeave, MinutesEarly = %v, %d, want %v, %d", got.EarlyLeave, got.MinutesEarly, tt.earlyLeave, tt.minutesEarly)
+			}
+			if got.MissingCheckOut != tt.missingOut {
+				t.Errorf("MissingCheckOut = %v, want %v", got.MissingCheckOut, tt.missingOut)
+			}
+			if got.WorkMinutes != tt.workMinutes {
+				t.Errorf("WorkMinutes = %d, want %d", got.WorkMinutes, tt.workMinutes)
+			}
+			if tt.leave && got.LeaveType != string(db.LeaveTypeSakit) {
+				t.Errorf("LeaveType = %q, want Sakit", got.LeaveType)
+			}
+		})
+	}
+}
+
+func TestClassifyDayBeforeWorkEnds(t *testing.T) {
+	date := today()
+	earlyMorning := time.Date(date.Year(), date.Month(), date.Day(), 0, 30, 0, 0, appLocation())
+
+	data, user := dailyFixture()
+	if got := classifyDay(data, user, date, true, earlyMorning); got.Status != DailyNotYet {
+		t.Errorf("Status without attendance = %q, want %q", got.Status, DailyNotYet)
+	}
+
+	// Sudah presensi masuk tetapi jam kerja belum selesai: belum dianggap lupa pulang.
+	addAttendance(data, user, db.AttendanceStatusMasuk, earlyMorning)
+	if got := classifyDay(data, user, date, true, earlyMorning); got.Status != DailyOnTime || got.MissingCheckOut {
+		t.Errorf("Status, MissingCheckOut = %q, %v, want %q, false", got.Status, got.MissingCheckOut, DailyOnTime)
+	}
+}
+
+func TestIsWorkDay(t *testing.T) {
+	s := &DailyAttendanceService{}
+	data, teacher := dailyFixture()
+	monday := time.Date(2025, 9, 22, 0, 0, 0, 0, time.UTC)
+	holiday := time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC)
+	data.holidays[formatDate(holiday)] = true
+	delete(data.rules[db.UserRoleTeacher], db.DayOfWeekMinggu)
+
+	staff := db.UserModel{InnerUser: db.InnerUser{ID: 2, Username: "tu", Role: db.UserRoleStaff}}
+	student := db.UserModel{InnerUser: db.InnerUser{ID: 3, Username: "andi", Role: db.UserRoleStudent}}
+	data.rules[db.UserRoleStudent] = data.rules[db.UserRoleTeacher]
+	data.schoolDays["0|"+formatDate(monday)] = false
+
+	tests := []struct {
+		name string
+		user db.UserModel
+		date time.Time
+		want bool
+	}{
+		{name: "teacher on a weekday", user: teacher, date: monday, want: true},
+		{name: "teacher on a holiday", user: teacher, date: holiday, want: false},
+		{name: "teacher on a day without rule", user: teacher, date: monday.AddDate(0, 0, 6), want: false},
+		{name: "role without rules", user: staff, date: monday, want: false},
+		{name: "student follows the school calendar", user: student, date: monday, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.isWorkDay(data, tt.user, tt.date)
+			if err != nil {
+				t.Fatalf("isWorkDay() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("isWorkDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyRange(t *testing.T) {
+	now := today()
+	day := func(offset int) string { return formatDate(now.AddDate(0, 0, offset)) }
+
+	tests := []struct {
+		name      string
+		from, to  string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantError bool
+	}{
+		{name: "defaults to today", wantStart: now, wantEnd: now},
+		{name: "from only", from: day(-3), wantStart: now.AddDate(0, 0, -3), wantEnd: now.AddDate(0, 0, -3)},
+		{name: "end capped at today", from: day(-2), to: day(5), wantStart: now.AddDate(0, 0, -2), wantEnd: now},
+		{name: "31 days", from: day(-30), to: day(0), wantStart: now.AddDate(0, 0, -30), wantEnd: now},
+		{name: "32 days", from: day(-31), to: day(0), wantError: true},
+		{name: "end before start", from: day(-1), to: day(-2), wantError: true},
+		{name: "future start", from: day(1), wantError: true},
+		{name: "invalid date", from: "22-09-2025", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := dailyRange(tt.from, tt.to)
+			if tt.wantError {
+				if !errors.Is(err, ErrValidation) {
+					t.Errorf("dailyRange() error = %v, want validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dailyRange() error = %v", err)
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("dailyRange() = %s..%s, want %s..%s", formatDate(start), formatDate(end), formatDate(tt.wantStart), formatDate(tt.wantEnd))
+			}
+		})
+	}
+}
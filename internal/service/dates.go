@@ -0,0 +1,170 @@
+// internal/service/dates.go
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+const dateLayout = "2006-01-02"
+
+// appLocation adalah zona waktu sekolah (TIMEZONE di .env, default Asia/Jakarta).
+func appLocation() *time.Location {
+	name := viper.GetString("TIMEZONE")
+	if name == "" {
+		name = "Asia/Jakarta"
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return loc
+}
+
+// AppLocation mengekspor zona waktu sekolah untuk paket lain, misalnya penjadwal tugas harian.
+func AppLocation() *time.Location {
+	return appLocation()
+}
+
+// dateOnly mengubah waktu menjadi tanggal (tengah malam UTC) sesuai kalender
+// zona waktu aslinya, sama seperti kolom @db.Date dikembalikan oleh Prisma.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// today mengembalikan tanggal hari ini menurut zona waktu sekolah.
+func today() time.Time {
+	return dateOnly(time.Now().In(appLocation()))
+}
+
+// parseDate membaca tanggal berformat YYYY-MM-DD.
+func parseDate(value string) (time.Time, error) {
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, validationError("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return t, nil
+}
+
+// formatDate menulis tanggal dengan format YYYY-MM-DD.
+func formatDate(t time.Time) string {
+	return t.UTC().Format(dateLayout)
+}
+
+const clockLayout = "15:04"
+
+// parseClock membaca jam berformat HH:MM menjadi nilai untuk kolom @db.Time.
+func parseClock(value string) (time.Time, error) {
+	t, err := time.Parse(clockLayout, value)
+	if err != nil {
+		return time.Time{}, validationError("invalid time %q, expected HH:MM", value)
+	}
+	return time.Date(1970, 1, 1, t.Hour(), t.Minute(), 0, 0, time.UTC), nil
+}
+
+// formatClock menulis jam dari kolom @db.Time dengan format HH:MM.
+func formatClock(t time.Time) string {
+	return t.UTC().Format(clockLayout)
+}
+
+// minutesOfDay mengubah jam menjadi jumlah menit sejak tengah malam.
+func minutesOfDay(t time.Time) int {
+	t = t.UTC()
+	return t.Hour()*60 + t.Minute()
+}
+
+// clockOf mengambil jam dari sebuah waktu menurut zona waktu sekolah, dalam
+// bentuk yang sama dengan nilai kolom @db.Time.
+func clockOf(t time.Time) time.Time {
+	t = t.In(appLocation())
+	return time.Date(1970, 1, 1, t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// daysOfWeek mengikuti urutan enum DayOfWeek, indeksnya sama dengan time.Weekday
+// setelah digeser satu (Senin = 0, Minggu = 6).
+var daysOfWeek = []db.DayOfWeek{
+	db.DayOfWeekSenin,
+	db.DayOfWeekSelasa,
+	db.DayOfWeekRabu,
+	db.DayOfWeekKamis,
+	db.DayOfWeekJumat,
+	db.DayOfWeekSabtu,
+	db.DayOfWeekMinggu,
+}
+
+// parseDayOfWeek memvalidasi nama hari sesuai enum DayOfWeek.
+func parseDayOfWeek(value string) (db.DayOfWeek, error) {
+	for _, day := range daysOfWeek {
+		if string(day) == value {
+			return day, nil
+		}
+	}
+	return "", validationError("invalid day of week %q", value)
+}
+
+// dayOfWeekOf mengembalikan nama hari (enum DayOfWeek) dari sebuah tanggal.
+func dayOfWeekOf(date time.Time) db.DayOfWeek {
+	return daysOfWeek[(int(date.Weekday())+6)%7]
+}
+
+// dayIndex mengembalikan urutan hari (Senin = 0) untuk pengurutan jadwal.
+func dayIndex(day db.DayOfWeek) int {
+	for i, d := range daysOfWeek {
+		if d == day {
+			return i
+		}
+	}
+	return len(daysOfWeek)
+}
+
+// parseDateRange membaca rentang tanggal from..to (inklusif) dan membatasi
+// panjangnya agar query yang mengembang per tanggal tidak terlalu berat.
+func parseDateRange(from, to string, maxDays int) (start, end time.Time, err error) {
+	start, err = parseDate(from)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err = parseDate(to)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, validationError("end date must not be before start date")
+	}
+	if maxDays > 0 && int(end.Sub(start).Hours()/24)+1 > maxDays {
+		return time.Time{}, time.Time{}, validationError("date range must not exceed %d days", maxDays)
+	}
+	return start, end, nil
+}
+
+const monthLayout = "2006-01"
+
+// parseMonth membaca bulan berformat YYYY-MM dan mengembalikan tanggal pertama
+// dan terakhirnya.
+func parseMonth(value string) (first, last time.Time, err error) {
+	first, err = time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, time.Time{}, validationError("invalid month %q, expected YYYY-MM", value)
+	}
+	return first, first.AddDate(0, 1, -1), nil
+}
+
+var monthNames = [...]string{
+	"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+	"Juli", "Agustus", "September", "Oktober", "November", "Desember",
+}
+
+// formatMonthName menulis bulan dalam bahasa Indonesia, misalnya "September 2025".
+func formatMonthName(t time.Time) string {
+	return monthNames[t.Month()-1] + " " + t.Format("2006")
+}
+
+// formatLongDate menulis tanggal dalam bahasa Indonesia, misalnya "5 September 2025".
+func formatLongDate(t time.Time) string {
+	t = t.UTC()
+	return fmt.Sprintf("%d %s", t.Day(), formatMonthName(t))
+}
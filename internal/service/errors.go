@@ -0,0 +1,49 @@
+// internal/service/errors.go
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Jenis error yang dikenali handler untuk menentukan HTTP status code.
+// Pesan yang dikirim ke client tetap berasal dari error aslinya.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict")
+	ErrForbidden  = errors.New("forbidden")
+)
+
+// DetailedError diimplementasikan error yang membawa data tambahan untuk client,
+// misalnya daftar jadwal yang bentrok.
+type DetailedError interface {
+	error
+	Details() interface{}
+}
+
+// serviceError membungkus pesan error dengan salah satu jenis error di atas.
+type serviceError struct {
+	kind    error
+	message string
+}
+
+func (e *serviceError) Error() string { return e.message }
+
+func (e *serviceError) Unwrap() error { return e.kind }
+
+func notFoundError(format string, args ...interface{}) error {
+	return &serviceError{kind: ErrNotFound, message: fmt.Sprintf(format, args...)}
+}
+
+func validationError(format string, args ...interface{}) error {
+	return &serviceError{kind: ErrValidation, message: fmt.Sprintf(format, args...)}
+}
+
+func conflictError(format string, args ...interface{}) error {
+	return &serviceError{kind: ErrConflict, message: fmt.Sprintf(format, args...)}
+}
+
+func forbiddenError(format string, args ...interface{}) error {
+	return &serviceError{kind: ErrForbidden, message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,217 @@
+// internal/service/file_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/storage"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// FileService mengelola unggahan tanda tangan guru dan lampiran izin serta
+// tautan unduhan bertanda tangan untuk file unggahan. File tidak pernah
+// disajikan langsung dari folder; pemanggil harus berhak atas datanya untuk
+// mendapatkan tautan yang berlaku sementara.
+type FileService struct {
+	db *db.PrismaClient
+}
+
+func NewFileService(db *db.PrismaClient) *FileService {
+	return &FileService{db: db}
+}
+
+// StoredFile adalah isi file yang diunduh melalui tautan bertanda tangan.
+type StoredFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+	ExpiresAt   time.Time
+}
+
+// OpenFile membaca file dari token tautan unduhan.
+func (s *FileService) OpenFile(token string) (*StoredFile, error) {
+	key, expires, err := verifyFileLink(token)
+	if err != nil {
+		return nil, err
+	}
+	content, err := readUpload(key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, notFoundError("file not found")
+		}
+		return nil, errors.New("failed to read file")
+	}
+	return &StoredFile{
+		FileName:    path.Base(key),
+		ContentType: http.DetectContentType(content),
+		Content:     content,
+		ExpiresAt:   expires,
+	}, nil
+}
+
+// canViewAll menentukan apakah user boleh melihat file milik user lain.
+func canViewAll(user *db.UserModel) bool {
+	return user.Role == db.UserRoleAdmin || user.Role == db.UserRoleStaff
+}
+
+// AttendancePhoto membuat tautan unduhan foto presensi. Hanya pemilik presensi,
+// admin dan staf yang boleh melihatnya.
+func (s *FileService) AttendancePhoto(user *db.UserModel, attendanceID int) (*FileLink, error) {
+	attendance, err := s.db.Attendance.FindUnique(db.Attendance.ID.Equals(db.BigInt(attendanceID))).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("attendance not found")
+		}
+		return nil, err
+	}
+	if attendance.UserID != user.ID && !canViewAll(user) {
+		return nil, forbiddenError("you can only view your own attendance photos")
+	}
+	photoPath, ok := attendance.PhotoPath()
+	if !ok {
+		return nil, notFoundError("attendance has no photo")
+	}
+	link := signFileLink(photoPath)
+	return &link, nil
+}
+
+func (s *FileService) findTeacher(ctx context.Context, teacherID int) (*db.TeacherModel, error) {
+	teacher, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(teacherID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("teacher not found")
+		}
+		return nil, err
+	}
+	return teacher, nil
+}
+
+// UploadSignature menyimpan gambar tanda tangan guru yang dipakai pada rekap
+// jurnal, sertifikat PKL dan rekap presensi. Tanda tangan lama dihapus.
+func (s *FileService) UploadSignature(teacherID int, data []byte) (*FileLink, error) {
+	teacher, err := s.findTeacher(context.Background(), teacherID)
+	if err != nil {
+		return nil, err
+	}
+	return s.saveSignature(teacher, data)
+}
+
+// UploadMySignature menyimpan tanda tangan guru yang sedang login.
+func (s *FileService) UploadMySignature(user *db.UserModel, data []byte) (*FileLink, error) {
+	teacher, err := teacherOfUser(context.Background(), s.db, int(user.ID))
+	if err != nil {
+		return nil, err
+	}
+	return s.saveSignature(teacher, data)
+}
+
+func (s *FileService) saveSignature(teacher *db.TeacherModel, data []byte) (*FileLink, error) {
+	ctx := context.Background()
+	content, extension, err := prepareUpload(data, uploadImage)
+	if err != nil {
+		return nil, err
+	}
+	// Nama file memakai waktu unggah agar tautan lama tidak menampilkan tanda tangan baru.
+	key, err := saveUpload(fmt.Sprintf("signatures/%d-%d%s", teacher.ID, time.Now().Unix(), extension), content)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(teacher.ID)).Update(
+		db.Teacher.SignatureImagePath.Set(key),
+	).Exec(ctx); err != nil {
+		deleteUpload(key)
+		return nil, errors.New("failed to save signature")
+	}
+	if old, ok := teacher.SignatureImagePath(); ok && old != key {
+		deleteUpload(old)
+	}
+	link := signFileLink(key)
+	return &link, nil
+}
+
+// Signature membuat tautan unduhan tanda tangan guru.
+func (s *FileService) Signature(teacherID int) (*FileLink, error) {
+	teacher, err := s.findTeacher(context.Background(), teacherID)
+	if err != nil {
+		return nil, err
+	}
+	return signatureLink(teacher)
+}
+
+// MySignature membuat tautan unduhan tanda tangan guru yang sedang login.
+func (s *FileService) MySignature(user *db.UserModel) (*FileLink, error) {
+	teacher, err := teacherOfUser(context.Background(), s.db, int(user.ID))
+	if err != nil {
+		return nil, err
+	}
+	return signatureLink(teacher)
+}
+
+func signatureLink(teacher *db.TeacherModel) (*FileLink, error) {
+	signaturePath, ok := teacher.SignatureImagePath()
+	if !ok || signaturePath == "" {
+		return nil, notFoundError("teacher has no signature")
+	}
+	link := signFileLink(signaturePath)
+	return &link, nil
+}
+
+// UploadLeaveAttachment menyimpan lampiran (surat dokter, surat tugas) untuk
+// pengajuan izin milik user yang masih Pending. Lampiran lama diganti.
+func (s *FileService) UploadLeaveAttachment(user *db.UserModel, leaveID int, data []byte) (*FileLink, error) {
+	ctx := context.Background()
+	leave, err := findLeaveRequest(ctx, s.db, leaveID)
+	if err != nil {
+		return nil, err
+	}
+	if leave.UserID != user.ID {
+		return nil, forbiddenError("you can only attach files to your own leave requests")
+	}
+	if leave.Status != db.LeaveStatusPending {
+		return nil, conflictError("only pending leave requests can be changed")
+	}
+	content, extension, err := prepareUpload(data, uploadAttachment)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := saveUpload(fmt.Sprintf("leave-requests/%d/lampiran-%d%s", leave.ID, time.Now().Unix(), extension), content)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.LeaveRequest.FindUnique(db.LeaveRequest.ID.Equals(leave.ID)).Update(
+		db.LeaveRequest.AttachmentPath.Set(key),
+	).Exec(ctx); err != nil {
+		deleteUpload(key)
+		return nil, errors.New("failed to save leave attachment")
+	}
+	if old, ok := leave.AttachmentPath(); ok && old != key {
+		deleteUpload(old)
+	}
+	link := signFileLink(key)
+	return &link, nil
+}
+
+// LeaveAttachment membuat tautan unduhan lampiran izin untuk pengaju dan
+// verifikatornya.
+func (s *FileService) LeaveAttachment(user *db.UserModel, leaveID int) (*FileLink, error) {
+	ctx := context.Background()
+	leave, err := findLeaveRequest(ctx, s.db, leaveID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLeaveAccess(ctx, s.db, user, leave); err != nil {
+		return nil, err
+	}
+	attachmentPath, ok := leave.AttachmentPath()
+	if !ok || attachmentPath == "" {
+		return nil, notFoundError("leave request has no attachment")
+	}
+	link := signFileLink(attachmentPath)
+	return &link, nil
+}
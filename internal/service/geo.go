@@ -0,0 +1,48 @@
+// internal/service/geo.go
+package service
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusMeters adalah jari-jari rata-rata bumi untuk rumus haversine.
+const earthRadiusMeters = 6371000.0
+
+// parseCoordinates membaca koordinat berformat "lat,long" dalam derajat desimal,
+// misalnya "-7.7956,110.3695". NaN dan Inf ditolak.
+func parseCoordinates(value string) (lat, long float64, err error) {
+	latText, longText, ok := strings.Cut(value, ",")
+	if !ok {
+		return 0, 0, validationError("invalid coordinates %q, expected lat,long", value)
+	}
+	lat, err = strconv.ParseFloat(strings.TrimSpace(latText), 64)
+	if err != nil || !isFinite(lat) || lat < -90 || lat > 90 {
+		return 0, 0, validationError("invalid latitude in coordinates %q", value)
+	}
+	long, err = strconv.ParseFloat(strings.TrimSpace(longText), 64)
+	if err != nil || !isFinite(long) || long < -180 || long > 180 {
+		return 0, 0, validationError("invalid longitude in coordinates %q", value)
+	}
+	return lat, long, nil
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// formatCoordinates menulis koordinat dalam bentuk baku "lat,long" tanpa spasi.
+func formatCoordinates(lat, long float64) string {
+	return strconv.FormatFloat(lat, 'f', -1, 64) + "," + strconv.FormatFloat(long, 'f', -1, 64)
+}
+
+// distanceMeters menghitung jarak dua titik di permukaan bumi dengan rumus haversine.
+func distanceMeters(lat1, long1, lat2, long2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLong := toRadians(long2 - long1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantLat   float64
+		wantLong  float64
+		wantError bool
+	}{
+		{name: "valid", value: "-7.7956,110.3695", wantLat: -7.7956, wantLong: 110.3695},
+		{name: "spaces", value: " -7.7956 , 110.3695 ", wantLat: -7.7956, wantLong: 110.3695},
+		{name: "bounds", value: "90,-180", wantLat: 90, wantLong: -180},
+		{name: "latitude out of range", value: "90.5,110", wantError: true},
+		{name: "longitude out of range", value: "-7,180.1", wantError: true},
+		{name: "NaN", value: "NaN,NaN", wantError: true},
+		{name: "NaN longitude", value: "-7.7956,nan", wantError: true},
+		{name: "Inf", value: "Inf,110", wantError: true},
+		{name: "negative Inf", value: "-7,-Inf", wantError: true},
+		{name: "missing comma", value: "-7.7956 110.3695", wantError: true},
+		{name: "not a number", value: "abc,110", wantError: true},
+		{name: "empty", value: "", wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lat, long, err := parseCoordinates(tt.value)
+			if tt.wantError {
+				if !errors.Is(err, ErrValidation) {
+					t.Fatalf("parseCoordinates(%q) error = %v, want validation error", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCoordinates(%q) error = %v", tt.value, err)
+			}
+			if lat != tt.wantLat || long != tt.wantLong {
+				t.Errorf("parseCoordinates(%q) = %v,%v, want %v,%v", tt.value, lat, long, tt.wantLat, tt.wantLong)
+			}
+		})
+	}
+}
+
+func TestDistanceMeters(t *testing.T) {
+	tests := []struct {
+		name                     string
+		lat1, long1, lat2, long2 float64
+		want, tolerance          float64
+	}{
+		{name: "same point", lat1: -7.7956, long1: 110.3695, lat2: -7.7956, long2: 110.3695, want: 0, tolerance: 0.001},
+		// Satu derajat bujur di khatulistiwa = 2πR/360.
+		{name: "one degree at the equator", lat1: 0, long1: 0, lat2: 0, long2: 1, want: 111194.93, tolerance: 0.5},
+		// Tugu Yogyakarta ke Keraton Yogyakarta, sekitar 2 km.
+		{name: "Tugu to Keraton", lat1: -7.782889, long1: 110.367083, lat2: -7.805284, long2: 110.364203, want: 2510, tolerance: 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := distanceMeters(tt.lat1, tt.long1, tt.lat2, tt.long2)
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("distanceMeters() = %.2f, want %.2f ± %.2f", got, tt.want, tt.tolerance)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// internal/service/images.go
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/spf13/viper"
+)
+
+// maxImagePixels menolak gambar yang terlalu besar untuk didekode di memori.
+const maxImagePixels = 50_000_000
+
+// imageMaxDimension adalah sisi terpanjang gambar setelah diperkecil
+// (IMAGE_MAX_DIMENSION, default 1600 piksel).
+func imageMaxDimension() int {
+	if size := viper.GetInt("IMAGE_MAX_DIMENSION"); size > 0 {
+		return size
+	}
+	return 1600
+}
+
+// processImage memperkecil gambar JPEG/PNG sampai sisi terpanjangnya tidak
+// melebihi IMAGE_MAX_DIMENSION dan meluruskan foto JPEG sesuai orientasi EXIF
+// dari kamera ponsel. Gambar yang sudah sesuai dikembalikan apa adanya.
+func processImage(data []byte, contentType string) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, validationError("image could not be read")
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, validationError("image dimensions are too large")
+	}
+	orientation := 1
+	if contentType == "image/jpeg" {
+		orientation = jpegOrientation(data)
+	}
+	maxSize := imageMaxDimension()
+	if orientation == 1 && config.Width <= maxSize && config.Height <= maxSize {
+		return data, nil
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, validationError("image could not be read")
+	}
+	bounds := decoded.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), decoded, bounds.Min, draw.Src)
+	result := downscale(orient(src, orientation), maxSize)
+
+	var buf bytes.Buffer
+	if contentType == "image/png" {
+		err = png.Encode(&buf, result)
+	} else {
+		err = jpeg.Encode(&buf, result, &jpeg.Options{Quality: 85})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// downscale memperkecil gambar dengan rata-rata kotak sehingga sisi
+// terpanjangnya menjadi maxSize.
+func downscale(src *image.RGBA, maxSize int) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	if w <= maxSize && h <= maxSize {
+		return src
+	}
+	scale := float64(maxSize) / float64(max(w, h))
+	dw := max(1, int(float64(w)*scale+0.5))
+	dh := max(1, int(float64(h)*scale+0.5))
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		y0 := dy * h / dh
+		y1 := max((dy+1)*h/dh, y0+1)
+		for dx := 0; dx < dw; dx++ {
+			x0 := dx * w / dw
+			x1 := max((dx+1)*w/dw, x0+1)
+			var sum [4]int
+			for y := y0; y < y1; y++ {
+				i := src.PixOffset(x0, y)
+				for x := x0; x < x1; x++ {
+					sum[0] += int(src.Pix[i])
+					sum[1] += int(src.Pix[i+1])
+					sum[2] += int(src.Pix[i+2])
+					sum[3] += int(src.Pix[i+3])
+					i += 4
+				}
+			}
+			n := (y1 - y0) * (x1 - x0)
+			o := dst.PixOffset(dx, dy)
+			for c := 0; c < 4; c++ {
+				dst.Pix[o+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
+
+// orient memutar/membalik gambar sesuai nilai orientasi EXIF (1-8).
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):][:4], src.Pix[src.PixOffset(x, y):][:4])
+		}
+	}
+	return dst
+}
+
+// jpegOrientation membaca tag Orientation (0x0112) dari segmen EXIF JPEG.
+// Mengembalikan 1 (normal) jika tidak ada.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) > 14 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	offset := int(order.Uint32(tiff[4:]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if value := int(order.Uint16(tiff[entry+8:])); value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decodedSize(t *testing.T, data []byte) (int, int) {
+	t.Helper()
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("result is not a valid image: %v", err)
+	}
+	return config.Width, config.Height
+}
+
+// jpegWithOrientation menyisipkan segmen EXIF berisi tag Orientation ke JPEG.
+func jpegWithOrientation(t *testing.T, img image.Image, orientation uint16) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	var tiff bytes.Buffer
+	tiff.WriteString("II")
+	binary.Write(&tiff, binary.LittleEndian, uint16(42))
+	binary.Write(&tiff, binary.LittleEndian, uint32(8)) // Offset IFD pertama
+	binary.Write(&tiff, binary.LittleEndian, uint16(1)) // Jumlah entri
+	binary.Write(&tiff, binary.LittleEndian, []uint16{0x0112, 3})
+	binary.Write(&tiff, binary.LittleEndian, uint32(1))
+	binary.Write(&tiff, binary.LittleEndian, []uint16{orientation, 0})
+	binary.Write(&tiff, binary.LittleEndian, uint32(0)) // Tidak ada IFD berikutnya
+	segment := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+
+	var out bytes.Buffer
+	out.Write(data[:2]) // SOI
+	out.Write([]byte{0xFF, 0xE1})
+	binary.Write(&out, binary.BigEndian, uint16(len(segment)+2))
+	out.Write(segment)
+	out.Write(data[2:])
+	return out.Bytes()
+}
+
+func TestProcessImageDownscales(t *testing.T) {
+	viper.Set("IMAGE_MAX_DIMENSION", 400)
+	t.Cleanup(func() { viper.Set("IMAGE_MAX_DIMENSION", nil) })
+
+	data := encodePNG(t, image.NewRGBA(image.Rect(0, 0, 1200, 600)))
+	processed, err := processImage(data, "image/png")
+	if err != nil {
+		t.Fatalf("processImage() error = %v", err)
+	}
+	if w, h := decodedSize(t, processed); w != 400 || h != 200 {
+		t.Errorf("processed size = %dx%d, want 400x200", w, h)
+	}
+}
+
+func TestProcessImageKeepsSmallImage(t *testing.T) {
+	data := encodePNG(t, image.NewRGBA(image.Rect(0, 0, 300, 200)))
+	processed, err := processImage(data, "image/png")
+	if err != nil {
+		t.Fatalf("processImage() error = %v", err)
+	}
+	if !bytes.Equal(processed, data) {
+		t.Error("processImage() re-encoded an image that needed no change")
+	}
+}
+
+func TestProcessImageAppliesOrientation(t *testing.T) {
+	data := jpegWithOrientation(t, image.NewRGBA(image.Rect(0, 0, 40, 20)), 6)
+	if got := jpegOrientation(data); got != 6 {
+		t.Fatalf("jpegOrientation() = %d, want 6", got)
+	}
+	processed, err := processImage(data, "image/jpeg")
+	if err != nil {
+		t.Fatalf("processImage() error = %v", err)
+	}
+	if w, h := decodedSize(t, processed); w != 20 || h != 40 {
+		t.Errorf("processed size = %dx%d, want 20x40 after rotating", w, h)
+	}
+}
+
+func TestProcessImageRejectsInvalidData(t *testing.T) {
+	if _, err := processImage([]byte("not an image"), "image/png"); !errors.Is(err, ErrValidation) {
+		t.Errorf("processImage() error = %v, want validation error", err)
+	}
+}
+
+func TestDownscaleAveragesPixels(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			if x%2 == 0 {
+				src.Set(x, y, color.RGBA{R: 200, A: 255})
+			} else {
+				src.Set(x, y, color.RGBA{B: 100, A: 255})
+			}
+		}
+	}
+	dst := downscale(src, 2)
+	if w, h := dst.Bounds().Dx(), dst.Bounds().Dy(); w != 2 || h != 1 {
+		t.Fatalf("downscale() size = %dx%d, want 2x1", w, h)
+	}
+	want := color.RGBA{R: 100, B: 50, A: 255}
+	for x := 0; x < 2; x++ {
+		if got := dst.RGBAAt(x, 0); got != want {
+			t.Errorf("pixel %d = %v, want %v", x, got, want)
+		}
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// Piksel merah di pojok kiri atas gambar 3x2.
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	red := color.RGBA{R: 255, A: 255}
+	src.SetRGBA(0, 0, red)
+
+	tests := []struct {
+		orientation int
+		w, h        int
+		x, y        int // Posisi piksel merah setelah diputar
+	}{
+		{orientation: 1, w: 3, h: 2, x: 0, y: 0},
+		{orientation: 2, w: 3, h: 2, x: 2, y: 0},
+		{orientation: 3, w: 3, h: 2, x: 2, y: 1},
+		{orientation: 4, w: 3, h: 2, x: 0, y: 1},
+		{orientation: 5, w: 2, h: 3, x: 0, y: 0},
+		{orientation: 6, w: 2, h: 3, x: 1, y: 0},
+		{orientation: 7, w: 2, h: 3, x: 1, y: 2},
+		{orientation: 8, w: 2, h: 3, x: 0, y: 2},
+	}
+	for _, tt := range tests {
+		dst := orient(src, tt.orientation)
+		if w, h := dst.Bounds().Dx(), dst.Bounds().Dy(); w != tt.w || h != tt.h {
+			t.Errorf("orientation %d: size = %dx%d, want %dx%d", tt.orientation, w, h, tt.w, tt.h)
+			continue
+		}
+		if got := dst.RGBAAt(tt.x, tt.y); got != red {
+			t.Errorf("orientation %d: pixel (%d,%d) = %v, want red", tt.orientation, tt.x, tt.y, got)
+		}
+	}
+}
@@ -0,0 +1,572 @@
+// internal/service/internship_assessment_service.go
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// mentorLinkValidity adalah masa berlaku tautan penilaian mentor DU/DI.
+const mentorLinkValidity = 14 * 24 * time.Hour
+
+// InternshipAssessmentService mengelola penilaian akhir PKL oleh guru pembimbing
+// dan mentor DU/DI, nilai akhir, dan sertifikat PKL.
+type InternshipAssessmentService struct {
+	db *db.PrismaClient
+}
+
+func NewInternshipAssessmentService(db *db.PrismaClient) *InternshipAssessmentService {
+	return &InternshipAssessmentService{db: db}
+}
+
+// ScoreInput adalah nilai untuk satu item rubrik.
+type ScoreInput struct {
+	RubricItemID int64
+	Score        int // 0-100
+	Notes        string
+}
+
+// InternshipAssessmentItem adalah satu kompetensi beserta nilainya, jika sudah dinilai.
+type InternshipAssessmentItem struct {
+	RubricItemID int64      `json:"rubric_item_id" example:"4"`
+	Competency   string     `json:"competency" example:"Menerapkan K3 di tempat kerja"`
+	Assessor     string     `json:"assessor" example:"Mentor"`
+	Weight       int        `json:"weight" example:"20"`
+	Score        *int       `json:"score,omitempty" example:"85"`
+	Notes        string     `json:"notes,omitempty" example:"Selalu memakai APD"`
+	AssessedBy   string     `json:"assessed_by,omitempty" example:"Andi Wijaya"`
+	AssessedAt   *time.Time `json:"assessed_at,omitempty"`
+}
+
+// InternshipAssessment adalah penilaian PKL seorang siswa pada satu penempatan.
+// Nilai akhir adalah rata-rata berbobot dan baru tersedia setelah semua item dinilai.
+type InternshipAssessment struct {
+	PlacementID           int64                      `json:"placement_id" example:"3"`
+	StudentName           string                     `json:"student_name" example:"Budi Santoso"`
+	StudentNis            string                     `json:"student_nis" example:"232410001"`
+	ClassName             string                     `json:"class_name,omitempty" example:"XII RPL 1"`
+	Major                 string                     `json:"major" example:"Rekayasa Perangkat Lunak"`
+	CompanyName           string                     `json:"company_name" example:"PT Teknologi Nusantara"`
+	SupervisorTeacherName string                     `json:"supervisor_teacher_name,omitempty" example:"Siti Aminah, S.Kom"`
+	StartDate             string                     `json:"start_date" example:"2025-07-14"`
+	EndDate               string                     `json:"end_date,omitempty" example:"2025-12-19"`
+	RubricID              int64                      `json:"rubric_id" example:"1"`
+	RubricName            string                     `json:"rubric_name" example:"Penilaian PKL RPL"`
+	Items                 []InternshipAssessmentItem `json:"items"`
+	Complete              bool                       `json:"complete" example:"true"`
+	FinalScore            *float64                   `json:"final_score,omitempty" example:"86.5"`
+	Predicate             string                     `json:"predicate,omitempty" example:"Baik"`
+}
+
+// MentorLink adalah tautan penilaian sekali pakai untuk mentor DU/DI.
+type MentorLink struct {
+	Token      string
+	MentorName string
+	ExpiresAt  time.Time
+}
+
+// MentorAssessmentForm adalah isi formulir penilaian yang dibuka mentor lewat tautan.
+type MentorAssessmentForm struct {
+	MentorName string
+	ExpiresAt  time.Time
+	Assessment *InternshipAssessment // Hanya berisi item penilaian mentor
+}
+
+// InternshipCertificate adalah file PDF sertifikat PKL beserta nama file unduhannya.
+type InternshipCertificate struct {
+	FileName string
+	Content  []byte
+}
+
+// assessmentPredicate mengubah nilai akhir menjadi predikat.
+func assessmentPredicate(score float64) string {
+	switch {
+	case score >= 90:
+		return "Sangat Baik"
+	case score >= 80:
+		return "Baik"
+	case score >= 70:
+		return "Cukup"
+	}
+	return "Kurang"
+}
+
+// hashMentorToken menyimpan token sebagai SHA-256 agar tautan yang bocor dari
+// database tidak bisa dipakai.
+func hashMentorToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// findAssessmentPlacement mengambil penempatan beserta data yang dibutuhkan penilaian.
+func (s *InternshipAssessmentService) findAssessmentPlacement(ctx context.Context, id db.BigInt) (*db.InternshipPlacementModel, error) {
+	placement, err := s.db.InternshipPlacement.FindUnique(db.InternshipPlacement.ID.Equals(id)).With(
+		db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+		db.InternshipPlacement.Company.Fetch(),
+		db.InternshipPlacement.SupervisorTeacher.Fetch(),
+		db.InternshipPlacement.Scores.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("internship placement not found")
+		}
+		return nil, err
+	}
+	return placement, nil
+}
+
+// rubricOf mengambil rubrik sesuai jurusan kelas siswa.
+func (s *InternshipAssessmentService) rubricOf(ctx context.Context, placement *db.InternshipPlacementModel) (*db.InternshipRubricModel, error) {
+	class, ok := placement.Student().CurrentClass()
+	if !ok {
+		return nil, validationError("student has no class, so the major is unknown")
+	}
+	major, ok := class.Major()
+	if !ok || major == "" {
+		return nil, validationError("class %s has no major", class.ClassName)
+	}
+	rubric, err := s.db.InternshipRubric.FindUnique(db.InternshipRubric.Major.Equals(major)).With(
+		db.InternshipRubric.Items.Fetch().OrderBy(db.InternshipRubricItem.SortOrder.Order(db.SortOrderAsc)),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("no internship rubric for major %s", major)
+		}
+		return nil, err
+	}
+	return rubric, nil
+}
+
+// buildAssessment menggabungkan item rubrik dengan nilai yang sudah masuk dan
+// menghitung nilai akhir jika semua item sudah dinilai.
+func buildAssessment(placement *db.InternshipPlacementModel, rubric *db.InternshipRubricModel) *InternshipAssessment {
+	student := placement.Student()
+	assessment := &InternshipAssessment{
+		PlacementID: int64(placement.ID),
+		StudentName: student.FullName,
+		StudentNis:  student.Nis,
+		CompanyName: placement.Company().Name,
+		StartDate:   formatDate(placement.StartDate),
+		RubricID:    int64(rubric.ID),
+		RubricName:  rubric.Name,
+		Major:       rubric.Major,
+		Items:       []InternshipAssessmentItem{},
+	}
+	if class, ok := student.CurrentClass(); ok {
+		assessment.ClassName = class.ClassName
+	}
+	if end, ok := placement.EndDate(); ok {
+		assessment.EndDate = formatDate(end)
+	}
+	if teacher, ok := placement.SupervisorTeacher(); ok {
+		assessment.SupervisorTeacherName = teacher.FullName
+	}
+
+	scores := make(map[db.BigInt]db.InternshipScoreModel)
+	for _, score := range placement.Scores() {
+		scores[score.RubricItemID] = score
+	}
+	complete := true
+	total, weights := 0.0, 0
+	for _, item := range rubric.Items() {
+		entry := InternshipAssessmentItem{
+			RubricItemID: int64(item.ID),
+			Competency:   item.Competency,
+			Assessor:     string(item.Assessor),
+			Weight:       item.Weight,
+		}
+		if score, ok := scores[item.ID]; ok {
+			value := score.Score
+			assessedAt := score.AssessedAt
+			entry.Score = &value
+			entry.Notes = optionalValue(score.Notes())
+			entry.AssessedBy = score.AssessedBy
+			entry.AssessedAt = &assessedAt
+			total += float64(value * item.Weight)
+			weights += item.Weight
+		} else {
+			complete = false
+		}
+		assessment.Items = append(assessment.Items, entry)
+	}
+	if complete && weights > 0 {
+		final := math.Round(total/float64(weights)*100) / 100
+		assessment.Complete = true
+		assessment.FinalScore = &final
+		assessment.Predicate = assessmentPredicate(final)
+	}
+	return assessment
+}
+
+// saveScoresTx memvalidasi nilai untuk item milik penilai tertentu dan
+// menyiapkan upsert-nya. Jika requireAll, semua item penilai itu wajib diisi.
+func (s *InternshipAssessmentService) saveScoresTx(placement *db.InternshipPlacementModel, rubric *db.InternshipRubricModel, assessor db.InternshipAssessor, assessedBy string, inputs []ScoreInput, requireAll bool) ([]transaction.Param, error) {
+	if len(inputs) == 0 {
+		return nil, validationError("at least one score is required")
+	}
+	items := make(map[db.BigInt]db.InternshipRubricItemModel)
+	for _, item := range rubric.Items() {
+		if item.Assessor == assessor {
+			items[item.ID] = item
+		}
+	}
+
+	now := time.Now()
+	seen := make(map[db.BigInt]bool)
+	var txs []transaction.Param
+	for _, input := range inputs {
+		itemID := db.BigInt(input.RubricItemID)
+		item, ok := items[itemID]
+		if !ok {
+			return nil, validationError("rubric item %d is not assessed by %s", input.RubricItemID, assessor)
+		}
+		if seen[itemID] {
+			return nil, validationError("rubric item %d is scored more than once", input.RubricItemID)
+		}
+		seen[itemID] = true
+		if input.Score < 0 || input.Score > 100 {
+			return nil, validationError("score for %q must be between 0 and 100", item.Competency)
+		}
+		notes := optionalString(input.Notes)
+		txs = append(txs, s.db.InternshipScore.UpsertOne(db.InternshipScore.PlacementItemUnique(
+			db.InternshipScore.PlacementID.Equals(placement.ID),
+			db.InternshipScore.RubricItemID.Equals(itemID),
+		)).Create(
+			db.InternshipScore.Score.Set(input.Score),
+			db.InternshipScore.AssessedBy.Set(assessedBy),
+			db.InternshipScore.Placement.Link(db.InternshipPlacement.ID.Equals(placement.ID)),
+			db.InternshipScore.RubricItem.Link(db.InternshipRubricItem.ID.Equals(itemID)),
+			db.InternshipScore.Notes.SetOptional(notes),
+			db.InternshipScore.AssessedAt.Set(now),
+		).Update(
+			db.InternshipScore.Score.Set(input.Score),
+			db.InternshipScore.AssessedBy.Set(assessedBy),
+			db.InternshipScore.Notes.SetOptional(notes),
+			db.InternshipScore.AssessedAt.Set(now),
+		).Tx())
+	}
+	if requireAll {
+		for id, item := range items {
+			if !seen[id] {
+				return nil, validationError("score for %q is required", item.Competency)
+			}
+		}
+	}
+	return txs, nil
+}
+
+// GetAssessment mengambil penilaian PKL sebuah penempatan. Siswa hanya melihat
+// penilaiannya sendiri dan guru hanya siswa bimbingannya.
+func (s *InternshipAssessmentService) GetAssessment(user *db.UserModel, placementID int) (*InternshipAssessment, error) {
+	ctx := context.Background()
+	placement, err := s.findAssessmentPlacement(ctx, db.BigInt(placementID))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlacementAccess(ctx, s.db, user, placement); err != nil {
+		return nil, err
+	}
+	rubric, err := s.rubricOf(ctx, placement)
+	if err != nil {
+		return nil, err
+	}
+	return buildAssessment(placement, rubric), nil
+}
+
+// SaveSupervisorScores menyimpan nilai item rubrik bagian guru pembimbing.
+// Nilai dapat diisi bertahap dan diubah selama penempatan tidak dibatalkan.
+func (s *InternshipAssessmentService) SaveSupervisorScores(userID, placementID int, inputs []ScoreInput) (*InternshipAssessment, error) {
+	ctx := context.Background()
+	teacher, err := teacherOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	placement, err := s.findAssessmentPlacement(ctx, db.BigInt(placementID))
+	if err != nil {
+		return nil, err
+	}
+	if supervisorID, ok := placement.SupervisorTeacherID(); !ok || supervisorID != teacher.ID {
+		return nil, forbiddenError("only the supervisor teacher can score this placement")
+	}
+	if placement.Status == db.InternshipStatusBatal {
+		return nil, conflictError("cancelled internship placements cannot be assessed")
+	}
+	rubric, err := s.rubricOf(ctx, placement)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := s.saveScoresTx(placement, rubric, db.InternshipAssessorPembimbing, teacher.FullName, inputs, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to save internship scores")
+	}
+
+	placement, err = s.findAssessmentPlacement(ctx, placement.ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildAssessment(placement, rubric), nil
+}
+
+// CreateMentorLink membuat tautan penilaian sekali pakai untuk mentor DU/DI.
+// Tautan lama yang belum dipakai tidak berlaku lagi. Hanya guru pembimbing,
+// admin, atau staf yang dapat membuatnya.
+func (s *InternshipAssessmentService) CreateMentorLink(user *db.UserModel, placementID int, mentorName string) (*MentorLink, error) {
+	ctx := context.Background()
+	mentorName = strings.TrimSpace(mentorName)
+	if mentorName == "" {
+		return nil, validationError("mentor name is required")
+	}
+	placement, err := s.findAssessmentPlacement(ctx, db.BigInt(placementID))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlacementAccess(ctx, s.db, user, placement); err != nil {
+		return nil, err
+	}
+	if placement.Status == db.InternshipStatusBatal {
+		return nil, conflictError("cancelled internship placements cannot be assessed")
+	}
+	rubric, err := s.rubricOf(ctx, placement)
+	if err != nil {
+		return nil, err
+	}
+	hasMentorItems := false
+	for _, item := range rubric.Items() {
+		hasMentorItems = hasMentorItems || item.Assessor == db.InternshipAssessorMentor
+	}
+	if !hasMentorItems {
+		return nil, validationError("rubric %s has no items for the company mentor", rubric.Name)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, errors.New("failed to generate mentor token")
+	}
+	link := &MentorLink{
+		Token:      base64.RawURLEncoding.EncodeToString(raw),
+		MentorName: mentorName,
+		ExpiresAt:  time.Now().Add(mentorLinkValidity),
+	}
+	err = s.db.Prisma.Transaction(
+		s.db.InternshipMentorToken.FindMany(
+			db.InternshipMentorToken.PlacementID.Equals(placement.ID),
+			db.InternshipMentorToken.UsedAt.IsNull(),
+		).Delete().Tx(),
+		s.db.InternshipMentorToken.CreateOne(
+			db.InternshipMentorToken.TokenHash.Set(hashMentorToken(link.Token)),
+			db.InternshipMentorToken.MentorName.Set(mentorName),
+			db.InternshipMentorToken.ExpiresAt.Set(link.ExpiresAt),
+			db.InternshipMentorToken.Placement.Link(db.InternshipPlacement.ID.Equals(placement.ID)),
+		).Tx(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to create mentor link")
+	}
+	return link, nil
+}
+
+// mentorToken memvalidasi token tautan mentor: harus ada, belum dipakai, dan
+// belum kedaluwarsa.
+func (s *InternshipAssessmentService) mentorToken(ctx context.Context, token string) (*db.InternshipMentorTokenModel, error) {
+	record, err := s.db.InternshipMentorToken.FindUnique(
+		db.InternshipMentorToken.TokenHash.Equals(hashMentorToken(token)),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("assessment link not found")
+		}
+		return nil, err
+	}
+	if _, used := record.UsedAt(); used {
+		return nil, conflictError("assessment link has already been used")
+	}
+	if time.Now().After(record.ExpiresAt) {
+		return nil, conflictError("assessment link has expired")
+	}
+	return record, nil
+}
+
+// mentorAssessment menyusun penilaian yang hanya berisi item bagian mentor.
+func (s *InternshipAssessmentService) mentorAssessment(ctx context.Context, record *db.InternshipMentorTokenModel) (*InternshipAssessment, *db.InternshipPlacementModel, *db.InternshipRubricModel, error) {
+	placement, err := s.findAssessmentPlacement(ctx, record.PlacementID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if placement.Status == db.InternshipStatusBatal {
+		return nil, nil, nil, conflictError("internship placement has been cancelled")
+	}
+	rubric, err := s.rubricOf(ctx, placement)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	assessment := buildAssessment(placement, rubric)
+	items := assessment.Items[:0]
+	for _, item := range assessment.Items {
+		if item.Assessor == string(db.InternshipAssessorMentor) {
+			items = append(items, item)
+		}
+	}
+	assessment.Items = items
+	// Nilai akhir tidak ditampilkan kepada mentor.
+	assessment.Complete, assessment.FinalScore, assessment.Predicate = false, nil, ""
+	return assessment, placement, rubric, nil
+}
+
+// GetMentorForm mengambil formulir penilaian untuk tautan mentor.
+func (s *InternshipAssessmentService) GetMentorForm(token string) (*MentorAssessmentForm, error) {
+	ctx := context.Background()
+	record, err := s.mentorToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	assessment, _, _, err := s.mentorAssessment(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	return &MentorAssessmentForm{
+		MentorName: record.MentorName,
+		ExpiresAt:  record.ExpiresAt,
+		Assessment: assessment,
+	}, nil
+}
+
+// SubmitMentorScores menyimpan nilai dari mentor DU/DI. Semua item bagian
+// mentor wajib diisi dan tautan tidak bisa dipakai lagi setelahnya.
+func (s *InternshipAssessmentService) SubmitMentorScores(token string, inputs []ScoreInput) (*MentorAssessmentForm, error) {
+	ctx := context.Background()
+	record, err := s.mentorToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	_, placement, rubric, err := s.mentorAssessment(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := s.saveScoresTx(placement, rubric, db.InternshipAssessorMentor, record.MentorName, inputs, true)
+	if err != nil {
+		return nil, err
+	}
+	tokenID := db.InternshipMentorToken.ID.Equals(record.ID)
+	err = claimThenSave(
+		func() (int, error) {
+			result, err := s.db.InternshipMentorToken.FindMany(tokenID, db.InternshipMentorToken.UsedAt.IsNull()).Update(
+				db.InternshipMentorToken.UsedAt.Set(time.Now()),
+			).Exec(ctx)
+			if err != nil {
+				return 0, errors.New("failed to save internship scores")
+			}
+			return result.Count, nil
+		},
+		func() error {
+			if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+				return errors.New("failed to save internship scores")
+			}
+			return nil
+		},
+		func() {
+			s.db.InternshipMentorToken.FindUnique(tokenID).Update(db.InternshipMentorToken.UsedAt.SetOptional(nil)).Exec(ctx)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	assessment, _, _, err := s.mentorAssessment(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	return &MentorAssessmentForm{
+		MentorName: record.MentorName,
+		ExpiresAt:  record.ExpiresAt,
+		Assessment: assessment,
+	}, nil
+}
+
+// claimThenSave menandai tautan mentor terpakai lebih dulu (hanya berhasil
+// jika tepat satu baris yang masih kosong used_at-nya berubah) dan baru
+// menyimpan nilai setelah klaim berhasil, sehingga pengiriman kedua tidak
+// menimpa nilai pengiriman pertama. Klaim dilepas lagi jika penyimpanan gagal
+// agar mentor dapat mengirim ulang.
+func claimThenSave(claim func() (int, error), save func() error, release func()) error {
+	claimed, err := claim()
+	if err != nil {
+		return err
+	}
+	if claimed != 1 {
+		return conflictError("assessment link has already been used")
+	}
+	if err := save(); err != nil {
+		release()
+		return err
+	}
+	return nil
+}
+
+// Certificate membuat PDF sertifikat PKL. Sertifikat hanya tersedia setelah
+// semua item rubrik dinilai dan penempatan tidak dibatalkan.
+func (s *InternshipAssessmentService) Certificate(user *db.UserModel, placementID int) (*InternshipCertificate, error) {
+	ctx := context.Background()
+	assessment, err := s.GetAssessment(user, placementID)
+	if err != nil {
+		return nil, err
+	}
+	placement, err := s.db.InternshipPlacement.FindUnique(db.InternshipPlacement.ID.Equals(db.BigInt(placementID))).With(
+		db.InternshipPlacement.SupervisorTeacher.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if placement.Status == db.InternshipStatusBatal {
+		return nil, conflictError("cancelled internship placements have no certificate")
+	}
+	if !assessment.Complete {
+		return nil, conflictError("internship assessment is not complete yet")
+	}
+
+	data := internshipCertificateData{
+		SchoolName: viper.GetString("SCHOOL_NAME"),
+		Number:     fmt.Sprintf("PKL/%d/%s", placement.ID, today().Format("2006")),
+		Assessment: assessment,
+		Principal: signatory{
+			Title:     "Kepala Sekolah",
+			Name:      viper.GetString("PRINCIPAL_NAME"),
+			NIP:       viper.GetString("PRINCIPAL_NIP"),
+			Signature: readSignature(viper.GetString("PRINCIPAL_SIGNATURE_PATH")),
+		},
+		Supervisor: signatory{Title: "Guru Pembimbing"},
+		PlaceDate:  formatLongDate(today()),
+	}
+	if teacher, ok := placement.SupervisorTeacher(); ok {
+		data.Supervisor.Name = teacher.FullName
+		data.Supervisor.NIP = optionalValue(teacher.Nip())
+		data.Supervisor.Signature = readSignature(optionalValue(teacher.SignatureImagePath()))
+	}
+	if city := viper.GetString("SCHOOL_CITY"); city != "" {
+		data.PlaceDate = city + ", " + data.PlaceDate
+	}
+
+	content, err := renderInternshipCertificate(data)
+	if err != nil {
+		return nil, err
+	}
+	return &InternshipCertificate{
+		FileName: fmt.Sprintf("sertifikat-pkl-%s.pdf", fileNameSlug(assessment.StudentName)),
+		Content:  content,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"errors"
+	"testing"
+)
+
+// mentorLink meniru baris internship_mentor_tokens: klaim hanya berhasil
+// selama used_at masih kosong.
+type mentorLink struct {
+	used     bool
+	saves    int
+	releases int
+	failSave bool
+}
+
+func (l *mentorLink) submit() error {
+	return claimThenSave(
+		func() (int, error) {
+			if l.used {
+				return 0, nil
+			}
+			l.used = true
+			return 1, nil
+		},
+		func() error {
+			if l.failSave {
+				return errors.New("failed to save internship scores")
+			}
+			l.saves++
+			return nil
+		},
+		func() {
+			l.used = false
+			l.releases++
+		},
+	)
+}
+
+func TestClaimThenSave(t *testing.T) {
+	link := &mentorLink{}
+	if err := link.submit(); err != nil {
+		t.Fatalf("first submit error = %v", err)
+	}
+	if link.saves != 1 {
+		t.Fatalf("saves after first submit = %d, want 1", link.saves)
+	}
+	if err := link.submit(); !errors.Is(err, ErrConflict) {
+		t.Errorf("second submit error = %v, want conflict", err)
+	}
+	if link.saves != 1 {
+		t.Errorf("second submit saved scores (saves = %d), want the first submit kept", link.saves)
+	}
+}
+
+func TestClaimThenSaveReleasesOnFailure(t *testing.T) {
+	link := &mentorLink{failSave: true}
+	if err := link.submit(); err == nil || errors.Is(err, ErrConflict) {
+		t.Fatalf("submit with a failing save error = %v, want the save error", err)
+	}
+	if link.used || link.releases != 1 {
+		t.Fatalf("used = %v, releases = %d, want the link released", link.used, link.releases)
+	}
+
+	link.failSave = false
+	if err := link.submit(); err != nil {
+		t.Errorf("retry after a failed save error = %v", err)
+	}
+	if link.saves != 1 || !link.used {
+		t.Errorf("saves = %d, used = %v, want the retry saved", link.saves, link.used)
+	}
+}
+
+func TestClaimThenSaveClaimError(t *testing.T) {
+	saved := false
+	err := claimThenSave(
+		func() (int, error) { return 0, errors.New("database unavailable") },
+		func() error { saved = true; return nil },
+		func() { t.Error("release called without a claim") },
+	)
+	if err == nil || errors.Is(err, ErrConflict) || saved {
+		t.Errorf("error = %v, saved = %v, want the claim error and nothing saved", err, saved)
+	}
+}
@@ -0,0 +1,349 @@
+// internal/service/internship_attendance_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// maxPresenceRangeDays membatasi rentang rekap kehadiran PKL.
+const maxPresenceRangeDays = 400
+
+// InternshipAttendanceService mengelola presensi masuk/pulang siswa PKL di lokasi DU/DI.
+type InternshipAttendanceService struct {
+	db *db.PrismaClient
+}
+
+func NewInternshipAttendanceService(db *db.PrismaClient) *InternshipAttendanceService {
+	return &InternshipAttendanceService{db: db}
+}
+
+// InternshipAttendanceInput adalah data presensi yang dikirim siswa dari lokasi PKL.
+type InternshipAttendanceInput struct {
+	Coordinates string // "lat,long" dari GPS perangkat
+	Photo       []byte // Foto JPEG atau PNG
+}
+
+// InternshipAttendanceFilters adalah filter daftar presensi PKL.
+type InternshipAttendanceFilters struct {
+	PlacementID         int64
+	SupervisorTeacherID int64
+	OutOfRange          *bool
+	From                string
+	To                  string
+	Page                int
+	Limit               int
+}
+
+// InternshipPresenceDay adalah kehadiran siswa PKL pada satu tanggal.
+type InternshipPresenceDay struct {
+	Date               string `json:"date" example:"2025-09-22"`
+	DayOfWeek          string `json:"day_of_week" example:"Senin"`
+	Status             string `json:"status" example:"Hadir"` // Hadir, Tidak Hadir atau Libur
+	CheckIn            string `json:"check_in,omitempty" example:"07:55"`
+	CheckOut           string `json:"check_out,omitempty" example:"16:02"`
+	CheckInOutOfRange  bool   `json:"check_in_out_of_range"`
+	CheckOutOutOfRange bool   `json:"check_out_out_of_range"`
+}
+
+// InternshipPresenceReport adalah rekap kehadiran harian satu penempatan PKL.
+type InternshipPresenceReport struct {
+	PlacementID int64                   `json:"placement_id" example:"3"`
+	StudentName string                  `json:"student_name" example:"Budi Santoso"`
+	CompanyName string                  `json:"company_name" example:"PT Teknologi Nusantara"`
+	From        string                  `json:"from" example:"2025-09-01"`
+	To          string                  `json:"to" example:"2025-09-30"`
+	WorkDays    int                     `json:"work_days" example:"22"`
+	Present     int                     `json:"present" example:"20"`
+	Absent      int                     `json:"absent" example:"2"`
+	OutOfRange  int                     `json:"out_of_range" example:"1"` // Jumlah presensi di luar radius
+	Days        []InternshipPresenceDay `json:"days"`
+}
+
+// internshipCheckInRadius adalah jarak maksimal (meter) dari koordinat DU/DI
+// agar presensi dianggap di lokasi (INTERNSHIP_CHECKIN_RADIUS_METERS, default 200).
+func internshipCheckInRadius() int {
+	radius := viper.GetInt("INTERNSHIP_CHECKIN_RADIUS_METERS")
+	if radius <= 0 {
+		return 200
+	}
+	return radius
+}
+
+// checkPlacementAccess memastikan user boleh melihat data sebuah penempatan:
+// siswa hanya penempatannya sendiri dan guru hanya siswa bimbingannya.
+func checkPlacementAccess(ctx context.Context, client *db.PrismaClient, user *db.UserModel, placement *db.InternshipPlacementModel) error {
+	switch user.Role {
+	case db.UserRoleStudent:
+		student, err := studentOfUser(ctx, client, int(user.ID))
+		if err != nil {
+			return err
+		}
+		if placement.StudentID != student.ID {
+			return forbiddenError("you can only view your own internship placement")
+		}
+	case db.UserRoleTeacher:
+		teacher, err := teacherOfUser(ctx, client, int(user.ID))
+		if err != nil {
+			return err
+		}
+		if supervisorID, ok := placement.SupervisorTeacherID(); !ok || supervisorID != teacher.ID {
+			return forbiddenError("you can only view students you supervise")
+		}
+	}
+	return nil
+}
+
+// CheckIn mencatat presensi masuk siswa di lokasi PKL hari ini.
+func (s *InternshipAttendanceService) CheckIn(userID int, input InternshipAttendanceInput) (*db.InternshipAttendanceModel, error) {
+	return s.record(userID, db.AttendanceStatusMasuk, input)
+}
+
+// CheckOut mencatat presensi pulang siswa. Presensi masuk hari yang sama harus sudah ada.
+func (s *InternshipAttendanceService) CheckOut(userID int, input InternshipAttendanceInput) (*db.InternshipAttendanceModel, error) {
+	return s.record(userID, db.AttendanceStatusPulang, input)
+}
+
+// record menyimpan satu presensi PKL beserta fotonya. Jarak ke koordinat DU/DI
+// dihitung dengan haversine; presensi di luar radius tetap disimpan tetapi
+// ditandai agar diperiksa guru pembimbing.
+func (s *InternshipAttendanceService) record(userID int, status db.AttendanceStatus, input InternshipAttendanceInput) (*db.InternshipAttendanceModel, error) {
+	ctx := context.Background()
+	student, err := studentOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	placement, err := activePlacementOf(ctx, s.db, student.ID)
+	if err != nil {
+		return nil, err
+	}
+	date := today()
+	if err := checkPlacementDate(placement, date); err != nil {
+		return nil, err
+	}
+	lat, long, err := parseCoordinates(input.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+	if len(input.Photo) == 0 {
+		return nil, validationError("photo is required")
+	}
+	photo, extension, err := prepareUpload(input.Photo, uploadImage)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.db.InternshipAttendance.FindMany(
+		db.InternshipAttendance.PlacementID.Equals(placement.ID),
+		db.InternshipAttendance.AttendanceDate.Equals(date),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to check internship attendance")
+	}
+	checkedIn := false
+	for _, attendance := range existing {
+		if attendance.Status == status {
+			return nil, conflictError("%s attendance for %s already exists", status, formatDate(date))
+		}
+		checkedIn = checkedIn || attendance.Status == db.AttendanceStatusMasuk
+	}
+	if status == db.AttendanceStatusPulang && !checkedIn {
+		return nil, conflictError("you have not checked in today")
+	}
+
+	var distance *int
+	outOfRange := false
+	company := placement.Company()
+	if coordinates, ok := company.Coordinates(); ok {
+		companyLat, companyLong, err := parseCoordinates(coordinates)
+		if err != nil {
+			return nil, err
+		}
+		meters := int(math.Round(distanceMeters(lat, long, companyLat, companyLong)))
+		distance = &meters
+		outOfRange = meters > internshipCheckInRadius()
+	}
+
+	photoPath, err := saveUpload(fmt.Sprintf("internship-attendances/%d/%s-%s%s",
+		placement.ID, formatDate(date), fileNameSlug(string(status)), extension), photo)
+	if err != nil {
+		return nil, err
+	}
+
+	attendance, err := s.db.InternshipAttendance.CreateOne(
+		db.InternshipAttendance.AttendanceDate.Set(date),
+		db.InternshipAttendance.Status.Set(status),
+		db.InternshipAttendance.Timestamp.Set(time.Now()),
+		db.InternshipAttendance.LocationCoordinates.Set(formatCoordinates(lat, long)),
+		db.InternshipAttendance.PhotoPath.Set(photoPath),
+		db.InternshipAttendance.Placement.Link(db.InternshipPlacement.ID.Equals(placement.ID)),
+		db.InternshipAttendance.DistanceMeters.SetOptional(distance),
+		db.InternshipAttendance.OutOfRange.Set(outOfRange),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to save internship attendance")
+	}
+	return attendance, nil
+}
+
+// GetAttendances mengambil daftar presensi PKL dengan paginasi, terbaru lebih
+// dulu. Guru hanya melihat siswa bimbingannya, misalnya untuk memeriksa
+// presensi yang ditandai di luar radius.
+func (s *InternshipAttendanceService) GetAttendances(user *db.UserModel, filters InternshipAttendanceFilters) ([]db.InternshipAttendanceModel, int, error) {
+	ctx := context.Background()
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, 0, err
+		}
+		filters.SupervisorTeacherID = int64(teacher.ID)
+	}
+
+	var where []db.InternshipAttendanceWhereParam
+	if filters.PlacementID > 0 {
+		where = append(where, db.InternshipAttendance.PlacementID.Equals(db.BigInt(filters.PlacementID)))
+	}
+	if filters.SupervisorTeacherID > 0 {
+		where = append(where, db.InternshipAttendance.Placement.Where(db.InternshipPlacement.SupervisorTeacherID.Equals(db.BigInt(filters.SupervisorTeacherID))))
+	}
+	if filters.OutOfRange != nil {
+		where = append(where, db.InternshipAttendance.OutOfRange.Equals(*filters.OutOfRange))
+	}
+	if filters.From != "" {
+		from, err := parseDate(filters.From)
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.InternshipAttendance.AttendanceDate.Gte(from))
+	}
+	if filters.To != "" {
+		to, err := parseDate(filters.To)
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.InternshipAttendance.AttendanceDate.Lte(to))
+	}
+
+	all, err := s.db.InternshipAttendance.FindMany(where...).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to count internship attendances")
+	}
+	attendances, err := s.db.InternshipAttendance.FindMany(where...).With(
+		db.InternshipAttendance.Placement.Fetch().With(
+			db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+			db.InternshipPlacement.Company.Fetch(),
+			db.InternshipPlacement.SupervisorTeacher.Fetch(),
+		),
+	).OrderBy(
+		db.InternshipAttendance.Timestamp.Order(db.SortOrderDesc),
+	).Skip((filters.Page - 1) * filters.Limit).Take(filters.Limit).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to retrieve internship attendances")
+	}
+	return attendances, len(all), nil
+}
+
+// GetPresence menyusun rekap kehadiran harian sebuah penempatan. Rentang
+// default adalah awal penempatan sampai hari ini atau akhir penempatan. Hari di
+// luar SCHOOL_WEEK_DAYS tanpa presensi dihitung Libur.
+func (s *InternshipAttendanceService) GetPresence(user *db.UserModel, placementID int, from, to string) (*InternshipPresenceReport, error) {
+	ctx := context.Background()
+	placement, err := s.db.InternshipPlacement.FindUnique(db.InternshipPlacement.ID.Equals(db.BigInt(placementID))).With(
+		db.InternshipPlacement.Student.Fetch(),
+		db.InternshipPlacement.Company.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("internship placement not found")
+		}
+		return nil, err
+	}
+	if err := checkPlacementAccess(ctx, s.db, user, placement); err != nil {
+		return nil, err
+	}
+
+	if from == "" {
+		from = formatDate(placement.StartDate)
+	}
+	if to == "" {
+		end := today()
+		if placementEnd, ok := placement.EndDate(); ok && placementEnd.Before(end) {
+			end = placementEnd
+		}
+		to = formatDate(end)
+	}
+	start, end, err := parseDateRange(from, to, maxPresenceRangeDays)
+	if err != nil {
+		return nil, err
+	}
+	workDays, err := schoolWeekDays()
+	if err != nil {
+		return nil, err
+	}
+
+	attendances, err := s.db.InternshipAttendance.FindMany(
+		db.InternshipAttendance.PlacementID.Equals(placement.ID),
+		db.InternshipAttendance.AttendanceDate.Gte(start),
+		db.InternshipAttendance.AttendanceDate.Lte(end),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve internship attendances")
+	}
+	byDate := make(map[string][]db.InternshipAttendanceModel)
+	for _, attendance := range attendances {
+		key := formatDate(attendance.AttendanceDate)
+		byDate[key] = append(byDate[key], attendance)
+	}
+
+	report := &InternshipPresenceReport{
+		PlacementID: int64(placement.ID),
+		StudentName: placement.Student().FullName,
+		CompanyName: placement.Company().Name,
+		From:        formatDate(start),
+		To:          formatDate(end),
+		Days:        []InternshipPresenceDay{},
+	}
+	loc := appLocation()
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		day := InternshipPresenceDay{
+			Date:      formatDate(date),
+			DayOfWeek: string(dayOfWeekOf(date)),
+			Status:    "Tidak Hadir",
+		}
+		for _, attendance := range byDate[day.Date] {
+			clock := attendance.Timestamp.In(loc).Format(clockLayout)
+			if attendance.Status == db.AttendanceStatusMasuk {
+				day.CheckIn = clock
+				day.CheckInOutOfRange = attendance.OutOfRange
+			} else {
+				day.CheckOut = clock
+				day.CheckOutOutOfRange = attendance.OutOfRange
+			}
+			if attendance.OutOfRange {
+				report.OutOfRange++
+			}
+		}
+
+		switch {
+		case day.CheckIn != "":
+			day.Status = "Hadir"
+			report.Present++
+		case !workDays[dayOfWeekOf(date)]:
+			day.Status = "Libur"
+		default:
+			report.Absent++
+		}
+		if workDays[dayOfWeekOf(date)] {
+			report.WorkDays++
+		}
+		report.Days = append(report.Days, day)
+	}
+	return report, nil
+}
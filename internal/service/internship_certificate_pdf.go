@@ -0,0 +1,209 @@
+// internal/service/internship_certificate_pdf.go
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/pdf"
+)
+
+// internshipCertificateData adalah seluruh isi sertifikat PKL.
+type internshipCertificateData struct {
+	SchoolName string
+	Number     string
+	Assessment *InternshipAssessment
+	Principal  signatory
+	Supervisor signatory
+	PlaceDate  string
+}
+
+// certificateColumns adalah kolom tabel daftar nilai di halaman kedua.
+var certificateColumns = []recapColumn{
+	{"No", 30},
+	{"Kompetensi", 380},
+	{"Penilai", 120},
+	{"Bobot", 70},
+	{"Nilai", 70},
+}
+
+// renderInternshipCertificate menyusun PDF sertifikat PKL pada A4 mendatar:
+// halaman pertama sertifikat, halaman kedua daftar nilai per kompetensi.
+func renderInternshipCertificate(data internshipCertificateData) ([]byte, error) {
+	a := data.Assessment
+	doc := pdf.New(pdf.A4Height, pdf.A4Width)
+	doc.SetTitle("Sertifikat PKL " + a.StudentName)
+	center := doc.Width() / 2
+
+	doc.AddPage()
+	doc.Rect(25, 25, doc.Width()-50, doc.Height()-50, 2, false, 0)
+	doc.Rect(31, 31, doc.Width()-62, doc.Height()-62, 0.6, false, 0)
+	y := 85.0
+	if data.SchoolName != "" {
+		doc.TextCenter(center, y, pdf.HelveticaBold, 14, strings.ToUpper(data.SchoolName))
+		y += 36
+	}
+	doc.TextCenter(center, y, pdf.HelveticaBold, 26, "SERTIFIKAT")
+	y += 22
+	doc.TextCenter(center, y, pdf.HelveticaBold, 13, "PRAKTIK KERJA LAPANGAN")
+	y += 16
+	doc.TextCenter(center, y, pdf.Helvetica, 10, "Nomor: "+data.Number)
+	y += 34
+	doc.TextCenter(center, y, pdf.Helvetica, 11, "Diberikan kepada:")
+	y += 28
+	doc.TextCenter(center, y, pdf.HelveticaBold, 20, a.StudentName)
+	nameWidth := pdf.TextWidth(pdf.HelveticaBold, 20, a.StudentName)
+	doc.Line(center-nameWidth/2-10, y+5, center+nameWidth/2+10, y+5, 0.8)
+	y += 22
+	identity := "NIS " + a.StudentNis
+	if a.ClassName != "" {
+		identity += " - Kelas " + a.ClassName
+	}
+	doc.TextCenter(center, y, pdf.Helvetica, 11, identity+" - "+a.Major)
+	y += 28
+
+	period := formatLongDateString(a.StartDate)
+	if a.EndDate != "" {
+		period += " sampai " + formatLongDateString(a.EndDate)
+	}
+	body := fmt.Sprintf("telah melaksanakan Praktik Kerja Lapangan di %s pada %s dengan nilai akhir %s (%s).",
+		a.CompanyName, period, formatScore(*a.FinalScore), a.Predicate)
+	for _, line := range pdf.WrapText(pdf.Helvetica, 11, doc.Width()-220, body) {
+		doc.TextCenter(center, y, pdf.Helvetica, 11, line)
+		y += 15
+	}
+
+	if err := certificateSignatures(doc, data, doc.Height()-175); err != nil {
+		return nil, err
+	}
+
+	doc.AddPage()
+	y = 60
+	doc.TextCenter(center, y, pdf.HelveticaBold, 13, "DAFTAR NILAI PRAKTIK KERJA LAPANGAN")
+	y += 28
+	for _, row := range [][2]string{
+		{"Nama Siswa", a.StudentName + " (" + a.StudentNis + ")"},
+		{"Tempat PKL", a.CompanyName},
+		{"Guru Pembimbing", orDash(a.SupervisorTeacherName)},
+		{"Rubrik", a.RubricName},
+	} {
+		doc.Text(recapMargin+30, y, pdf.Helvetica, 10, row[0])
+		doc.Text(recapMargin+140, y, pdf.Helvetica, 10, ": "+row[1])
+		y += 14
+	}
+	y += 10
+
+	x0 := (doc.Width() - certificateTableWidth()) / 2
+	header := make([][]string, len(certificateColumns))
+	for i, column := range certificateColumns {
+		header[i] = []string{column.Title}
+	}
+	y = certificateRow(doc, x0, y, header, true)
+	for i, item := range a.Items {
+		score := "-"
+		if item.Score != nil {
+			score = strconv.Itoa(*item.Score)
+		}
+		y = certificateRow(doc, x0, y, [][]string{
+			{strconv.Itoa(i + 1)},
+			pdf.WrapText(pdf.Helvetica, recapFontSize, certificateColumns[1].Width-2*recapCellPadding, item.Competency),
+			{assessorLabel(item.Assessor)},
+			{strconv.Itoa(item.Weight)},
+			{score},
+		}, false)
+	}
+	y += 16
+	doc.Text(x0, y, pdf.HelveticaBold, 11, fmt.Sprintf("Nilai Akhir: %s (%s)", formatScore(*a.FinalScore), a.Predicate))
+	doc.Text(x0, y+14, pdf.Helvetica, 8, "Nilai akhir adalah rata-rata nilai kompetensi yang dibobot.")
+	return doc.Bytes(), nil
+}
+
+// certificateRow menggambar satu baris tabel daftar nilai dan mengembalikan posisi y berikutnya.
+func certificateRow(doc *pdf.Document, x, y float64, cells [][]string, header bool) float64 {
+	lines := 1
+	for _, cell := range cells {
+		lines = max(lines, len(cell))
+	}
+	height := float64(lines)*recapLineHeight + 2*recapCellPadding
+	font := pdf.Helvetica
+	if header {
+		font = pdf.HelveticaBold
+		doc.Rect(x, y, certificateTableWidth(), height, 0, true, 0.9)
+	}
+	for i, column := range certificateColumns {
+		doc.Rect(x, y, column.Width, height, 0.5, false, 0)
+		for j, line := range cells[i] {
+			doc.Text(x+recapCellPadding, y+recapCellPadding+recapFontSize+float64(j)*recapLineHeight, font, recapFontSize, line)
+		}
+		x += column.Width
+	}
+	return y + height
+}
+
+func certificateTableWidth() float64 {
+	total := 0.0
+	for _, column := range certificateColumns {
+		total += column.Width
+	}
+	return total
+}
+
+// certificateSignatures menggambar tanda tangan kepala sekolah (kiri) dan guru pembimbing (kanan).
+func certificateSignatures(doc *pdf.Document, data internshipCertificateData, top float64) error {
+	w := &recapWriter{doc: doc, y: top}
+	quarter := doc.Width() / 4
+	blocks := []struct {
+		center float64
+		lines  []string
+		signer signatory
+	}{
+		{quarter, []string{"", data.Principal.Title}, data.Principal},
+		{3 * quarter, []string{data.PlaceDate, data.Supervisor.Title}, data.Supervisor},
+	}
+	for _, block := range blocks {
+		y := top
+		for _, line := range block.lines {
+			doc.TextCenter(block.center, y, pdf.Helvetica, 10, line)
+			y += 13
+		}
+		if block.signer.Signature != nil {
+			if err := w.signatureImage(block.signer.Signature, block.center, y); err != nil {
+				return fmt.Errorf("invalid signature image for %s: %w", block.signer.Title, err)
+			}
+		}
+		y += recapSignatureH + 14
+		name := block.signer.Name
+		if name == "" {
+			name = "(.................................)"
+		}
+		doc.TextCenter(block.center, y, pdf.HelveticaBold, 10, name)
+		nameWidth := pdf.TextWidth(pdf.HelveticaBold, 10, name)
+		doc.Line(block.center-nameWidth/2, y+2, block.center+nameWidth/2, y+2, 0.6)
+		if block.signer.NIP != "" {
+			doc.TextCenter(block.center, y+13, pdf.Helvetica, 10, "NIP. "+block.signer.NIP)
+		}
+	}
+	return nil
+}
+
+func assessorLabel(assessor string) string {
+	if assessor == "Mentor" {
+		return "Mentor DU/DI"
+	}
+	return "Guru Pembimbing"
+}
+
+// formatScore menulis nilai dengan koma desimal, misalnya "86,5".
+func formatScore(score float64) string {
+	return strings.Replace(strconv.FormatFloat(score, 'f', -1, 64), ".", ",", 1)
+}
+
+// formatLongDateString menulis tanggal YYYY-MM-DD dalam bahasa Indonesia.
+func formatLongDateString(value string) string {
+	date, err := parseDate(value)
+	if err != nil {
+		return value
+	}
+	return formatLongDate(date)
+}
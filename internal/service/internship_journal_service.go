@@ -0,0 +1,378 @@
+// internal/service/internship_journal_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// InternshipJournalService mengelola jurnal kegiatan harian siswa PKL dan
+// persetujuannya oleh guru pembimbing.
+type InternshipJournalService struct {
+	db *db.PrismaClient
+}
+
+func NewInternshipJournalService(db *db.PrismaClient) *InternshipJournalService {
+	return &InternshipJournalService{db: db}
+}
+
+// InternshipJournalInput adalah isi jurnal PKL untuk satu tanggal.
+type InternshipJournalInput struct {
+	ActivityDate        string // YYYY-MM-DD
+	ActivityDescription string
+}
+
+// InternshipJournalReview adalah keputusan guru pembimbing untuk beberapa jurnal sekaligus.
+type InternshipJournalReview struct {
+	JournalIDs []int64
+	Status     string // Approved atau Rejected
+	Notes      string // Wajib untuk Rejected
+}
+
+// InternshipJournalFilters adalah filter daftar jurnal PKL.
+type InternshipJournalFilters struct {
+	PlacementID         int64
+	StudentID           int64
+	SupervisorTeacherID int64
+	Status              string
+	From                string
+	To                  string
+	Page                int
+	Limit               int
+}
+
+// activePlacementOf mengambil penempatan PKL Aktif milik siswa beserta DU/DI-nya.
+func activePlacementOf(ctx context.Context, client *db.PrismaClient, studentID db.BigInt) (*db.InternshipPlacementModel, error) {
+	placement, err := client.InternshipPlacement.FindFirst(
+		db.InternshipPlacement.StudentID.Equals(studentID),
+		db.InternshipPlacement.Status.Equals(db.InternshipStatusAktif),
+	).With(
+		db.InternshipPlacement.Company.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("you have no active internship placement")
+		}
+		return nil, err
+	}
+	return placement, nil
+}
+
+// checkPlacementDate memastikan tanggal berada dalam periode penempatan dan tidak di masa depan.
+func checkPlacementDate(placement *db.InternshipPlacementModel, date time.Time) error {
+	if date.After(today()) {
+		return validationError("activity date must not be in the future")
+	}
+	if date.Before(placement.StartDate) {
+		return validationError("activity date is before the placement starts on %s", formatDate(placement.StartDate))
+	}
+	if end, ok := placement.EndDate(); ok && date.After(end) {
+		return validationError("activity date is after the placement ends on %s", formatDate(end))
+	}
+	return nil
+}
+
+func parseApprovalStatus(value string) (db.ApprovalStatus, error) {
+	switch db.ApprovalStatus(value) {
+	case db.ApprovalStatusPending, db.ApprovalStatusApproved, db.ApprovalStatusRejected:
+		return db.ApprovalStatus(value), nil
+	}
+	return "", validationError("invalid approval status %q", value)
+}
+
+// internshipJournalWhere menyusun filter umum daftar jurnal PKL.
+func internshipJournalWhere(filters InternshipJournalFilters) ([]db.InternshipJournalWhereParam, error) {
+	var where []db.InternshipJournalWhereParam
+	if filters.PlacementID > 0 {
+		where = append(where, db.InternshipJournal.PlacementID.Equals(db.BigInt(filters.PlacementID)))
+	}
+	if filters.StudentID > 0 {
+		where = append(where, db.InternshipJournal.Placement.Where(db.InternshipPlacement.StudentID.Equals(db.BigInt(filters.StudentID))))
+	}
+	if filters.SupervisorTeacherID > 0 {
+		where = append(where, db.InternshipJournal.Placement.Where(db.InternshipPlacement.SupervisorTeacherID.Equals(db.BigInt(filters.SupervisorTeacherID))))
+	}
+	if filters.Status != "" {
+		status, err := parseApprovalStatus(filters.Status)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.InternshipJournal.Status.Equals(status))
+	}
+	if filters.From != "" {
+		from, err := parseDate(filters.From)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.InternshipJournal.ActivityDate.Gte(from))
+	}
+	if filters.To != "" {
+		to, err := parseDate(filters.To)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.InternshipJournal.ActivityDate.Lte(to))
+	}
+	return where, nil
+}
+
+func (s *InternshipJournalService) findJournals(ctx context.Context, where []db.InternshipJournalWhereParam, order db.SortOrder, page, limit int) ([]db.InternshipJournalModel, int, error) {
+	all, err := s.db.InternshipJournal.FindMany(where...).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to count internship journals")
+	}
+	journals, err := s.db.InternshipJournal.FindMany(where...).With(
+		db.InternshipJournal.Placement.Fetch().With(
+			db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+			db.InternshipPlacement.Company.Fetch(),
+			db.InternshipPlacement.SupervisorTeacher.Fetch(),
+		),
+	).OrderBy(
+		db.InternshipJournal.ActivityDate.Order(order),
+	).Skip((page - 1) * limit).Take(limit).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to retrieve internship journals")
+	}
+	return journals, len(all), nil
+}
+
+// GetJournals mengambil daftar jurnal PKL dengan paginasi, terbaru lebih dulu.
+// Guru hanya melihat jurnal siswa yang ia bimbing.
+func (s *InternshipJournalService) GetJournals(user *db.UserModel, filters InternshipJournalFilters) ([]db.InternshipJournalModel, int, error) {
+	ctx := context.Background()
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, 0, err
+		}
+		filters.SupervisorTeacherID = int64(teacher.ID)
+	}
+	where, err := internshipJournalWhere(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.findJournals(ctx, where, db.SortOrderDesc, filters.Page, filters.Limit)
+}
+
+// GetPendingQueue mengambil antrean jurnal Pending milik siswa bimbingan seorang
+// guru, terlama lebih dulu. Admin dan staf melihat seluruh antrean atau antrean
+// guru tertentu lewat filter.
+func (s *InternshipJournalService) GetPendingQueue(user *db.UserModel, filters InternshipJournalFilters) ([]db.InternshipJournalModel, int, error) {
+	ctx := context.Background()
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, 0, err
+		}
+		filters.SupervisorTeacherID = int64(teacher.ID)
+	}
+	filters.Status = string(db.ApprovalStatusPending)
+	where, err := internshipJournalWhere(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.findJournals(ctx, where, db.SortOrderAsc, filters.Page, filters.Limit)
+}
+
+// GetMyJournals mengambil jurnal PKL milik siswa yang sedang login dari seluruh penempatannya.
+func (s *InternshipJournalService) GetMyJournals(userID int, filters InternshipJournalFilters) ([]db.InternshipJournalModel, int, error) {
+	ctx := context.Background()
+	student, err := studentOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	filters.StudentID = int64(student.ID)
+	filters.SupervisorTeacherID = 0
+	where, err := internshipJournalWhere(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.findJournals(ctx, where, db.SortOrderDesc, filters.Page, filters.Limit)
+}
+
+// GetJournalByID mengambil satu jurnal PKL. Siswa hanya boleh melihat jurnalnya
+// sendiri dan guru hanya jurnal siswa bimbingannya.
+func (s *InternshipJournalService) GetJournalByID(user *db.UserModel, id int) (*db.InternshipJournalModel, error) {
+	ctx := context.Background()
+	journal, err := s.findJournal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlacementAccess(ctx, s.db, user, journal.Placement()); err != nil {
+		return nil, err
+	}
+	return journal, nil
+}
+
+func (s *InternshipJournalService) findJournal(ctx context.Context, id int) (*db.InternshipJournalModel, error) {
+	journal, err := s.db.InternshipJournal.FindUnique(db.InternshipJournal.ID.Equals(db.BigInt(id))).With(
+		db.InternshipJournal.Placement.Fetch().With(
+			db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+			db.InternshipPlacement.Company.Fetch(),
+			db.InternshipPlacement.SupervisorTeacher.Fetch(),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("internship journal not found")
+		}
+		return nil, err
+	}
+	return journal, nil
+}
+
+// SubmitJournal menyimpan jurnal kegiatan harian siswa untuk penempatan Aktif-nya.
+// Satu tanggal hanya boleh memiliki satu jurnal, dalam periode penempatan.
+func (s *InternshipJournalService) SubmitJournal(userID int, input InternshipJournalInput) (*db.InternshipJournalModel, error) {
+	ctx := context.Background()
+	student, err := studentOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	placement, err := activePlacementOf(ctx, s.db, student.ID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseDate(input.ActivityDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPlacementDate(placement, date); err != nil {
+		return nil, err
+	}
+	description := strings.TrimSpace(input.ActivityDescription)
+	if description == "" {
+		return nil, validationError("activity description is required")
+	}
+
+	_, err = s.db.InternshipJournal.FindUnique(db.InternshipJournal.PlacementDateUnique(
+		db.InternshipJournal.PlacementID.Equals(placement.ID),
+		db.InternshipJournal.ActivityDate.Equals(date),
+	)).Exec(ctx)
+	if err == nil {
+		return nil, conflictError("internship journal for %s already exists", formatDate(date))
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	journal, err := s.db.InternshipJournal.CreateOne(
+		db.InternshipJournal.ActivityDate.Set(date),
+		db.InternshipJournal.ActivityDescription.Set(description),
+		db.InternshipJournal.Placement.Link(db.InternshipPlacement.ID.Equals(placement.ID)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to create internship journal")
+	}
+	return s.findJournal(ctx, int(journal.ID))
+}
+
+// UpdateJournal mengubah isi jurnal milik siswa yang belum disetujui. Jurnal
+// yang ditolak kembali berstatus Pending untuk diperiksa ulang.
+func (s *InternshipJournalService) UpdateJournal(userID, id int, description string) (*db.InternshipJournalModel, error) {
+	ctx := context.Background()
+	student, err := studentOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := s.findJournal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	placement := journal.Placement()
+	if placement.StudentID != student.ID {
+		return nil, forbiddenError("you can only edit your own internship journals")
+	}
+	if journal.Status == db.ApprovalStatusApproved {
+		return nil, conflictError("approved internship journals can no longer be changed")
+	}
+	if placement.Status != db.InternshipStatusAktif {
+		return nil, conflictError("internship placement is no longer active")
+	}
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil, validationError("activity description is required")
+	}
+
+	_, err = s.db.InternshipJournal.FindUnique(db.InternshipJournal.ID.Equals(journal.ID)).Update(
+		db.InternshipJournal.ActivityDescription.Set(description),
+		db.InternshipJournal.Status.Set(db.ApprovalStatusPending),
+		db.InternshipJournal.ApprovedAt.SetOptional(nil),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to update internship journal")
+	}
+	return s.findJournal(ctx, id)
+}
+
+// ReviewJournals menyetujui atau menolak beberapa jurnal Pending sekaligus oleh
+// guru pembimbing. Satu jurnal yang tidak memenuhi syarat membatalkan seluruh
+// pemeriksaan.
+func (s *InternshipJournalService) ReviewJournals(userID int, review InternshipJournalReview) ([]db.InternshipJournalModel, error) {
+	ctx := context.Background()
+	teacher, err := teacherOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	status, err := parseApprovalStatus(review.Status)
+	if err != nil {
+		return nil, err
+	}
+	if status == db.ApprovalStatusPending {
+		return nil, validationError("status must be Approved or Rejected")
+	}
+	notes := optionalString(review.Notes)
+	if status == db.ApprovalStatusRejected && notes == nil {
+		return nil, validationError("notes are required to reject internship journals")
+	}
+	if len(review.JournalIDs) == 0 {
+		return nil, validationError("at least one journal is required")
+	}
+
+	ids := make([]db.BigInt, 0, len(review.JournalIDs))
+	for _, id := range review.JournalIDs {
+		ids = append(ids, db.BigInt(id))
+	}
+	journals, err := s.db.InternshipJournal.FindMany(db.InternshipJournal.ID.In(ids)).With(
+		db.InternshipJournal.Placement.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve internship journals")
+	}
+	byID := make(map[db.BigInt]db.InternshipJournalModel, len(journals))
+	for _, journal := range journals {
+		byID[journal.ID] = journal
+	}
+
+	now := time.Now()
+	var txs []transaction.Param
+	for _, id := range ids {
+		journal, ok := byID[id]
+		if !ok {
+			return nil, notFoundError("internship journal %d not found", id)
+		}
+		if supervisorID, ok := journal.Placement().SupervisorTeacherID(); !ok || supervisorID != teacher.ID {
+			return nil, forbiddenError("internship journal %d belongs to a student you do not supervise", id)
+		}
+		if journal.Status != db.ApprovalStatusPending {
+			return nil, conflictError("internship journal %d is already %s", id, journal.Status)
+		}
+		txs = append(txs, s.db.InternshipJournal.FindUnique(db.InternshipJournal.ID.Equals(id)).Update(
+			db.InternshipJournal.Status.Set(status),
+			db.InternshipJournal.SupervisorNotes.SetOptional(notes),
+			db.InternshipJournal.ApprovedAt.Set(now),
+		).Tx())
+	}
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to review internship journals")
+	}
+
+	reviewed, _, err := s.findJournals(ctx, []db.InternshipJournalWhereParam{db.InternshipJournal.ID.In(ids)}, db.SortOrderAsc, 1, len(ids))
+	return reviewed, err
+}
@@ -0,0 +1,261 @@
+// internal/service/internship_monitoring_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// InternshipMonitoringService menyusun ringkasan pemantauan PKL untuk guru
+// pembimbing dan koordinator PKL (admin/staf).
+type InternshipMonitoringService struct {
+	db *db.PrismaClient
+}
+
+func NewInternshipMonitoringService(db *db.PrismaClient) *InternshipMonitoringService {
+	return &InternshipMonitoringService{db: db}
+}
+
+// InternshipMonitoringFilters adalah filter dashboard pemantauan PKL.
+type InternshipMonitoringFilters struct {
+	SupervisorTeacherID int64
+	CompanyID           int64
+	ClassID             int64
+	AtRiskOnly          bool
+}
+
+// PlacementMonitoring adalah ringkasan perkembangan satu penempatan Aktif.
+type PlacementMonitoring struct {
+	PlacementID        int64      `json:"placement_id" example:"3"`
+	StudentID          int64      `json:"student_id" example:"21"`
+	StudentName        string     `json:"student_name" example:"Budi Santoso"`
+	StudentNis         string     `json:"student_nis" example:"232410001"`
+	ClassName          string     `json:"class_name,omitempty" example:"XII RPL 1"`
+	CompanyName        string     `json:"company_name" example:"PT Teknologi Nusantara"`
+	SupervisorTeacher  string     `json:"supervisor_teacher_name,omitempty" example:"Siti Aminah, S.Kom"`
+	StartDate          string     `json:"start_date" example:"2025-07-14"`
+	EndDate            string     `json:"end_date,omitempty" example:"2025-12-19"`
+	DaysElapsed        int        `json:"days_elapsed" example:"45"`          // Hari kerja yang sudah dilalui, termasuk hari ini
+	TotalDays          *int       `json:"total_days,omitempty" example:"115"` // Hari kerja sepanjang penempatan, kosong jika belum ada tanggal selesai
+	JournalsSubmitted  int        `json:"journals_submitted" example:"40"`    // Jurnal pada hari kerja yang sudah dilalui
+	JournalsExpected   int        `json:"journals_expected" example:"45"`
+	JournalsApproved   int        `json:"journals_approved" example:"35"`
+	PendingApprovals   int        `json:"pending_approvals" example:"4"`
+	LastJournalDate    string     `json:"last_journal_date,omitempty" example:"2025-09-19"`
+	DaysWithoutJournal int        `json:"days_without_journal" example:"1"` // Hari kerja sejak jurnal terakhir
+	LastCheckIn        *time.Time `json:"last_check_in,omitempty"`
+	LastCheckInOutside bool       `json:"last_check_in_out_of_range"`
+	AtRisk             bool       `json:"at_risk" example:"false"`
+}
+
+// SupervisorMonitoring adalah rekap per guru pembimbing untuk koordinator.
+type SupervisorMonitoring struct {
+	SupervisorTeacherID *int64 `json:"supervisor_teacher_id,omitempty" example:"5"` // Kosong untuk siswa tanpa pembimbing
+	SupervisorTeacher   string `json:"supervisor_teacher_name" example:"Siti Aminah, S.Kom"`
+	Placements          int    `json:"placements" example:"8"`
+	AtRisk              int    `json:"at_risk" example:"1"`
+	PendingApprovals    int    `json:"pending_approvals" example:"12"`
+}
+
+// InternshipMonitoring adalah isi dashboard pemantauan PKL.
+type InternshipMonitoring struct {
+	Date             string                 `json:"date" example:"2025-09-22"`
+	AtRiskDays       int                    `json:"at_risk_days" example:"3"` // Batas hari kerja tanpa jurnal sebelum ditandai berisiko
+	Placements       int                    `json:"placements" example:"120"`
+	AtRisk           int                    `json:"at_risk" example:"7"`
+	PendingApprovals int                    `json:"pending_approvals" example:"64"`
+	Supervisors      []SupervisorMonitoring `json:"supervisors"`
+	Students         []PlacementMonitoring  `json:"students"`
+}
+
+// internshipAtRiskDays adalah jumlah hari kerja tanpa jurnal sebelum siswa
+// ditandai berisiko (INTERNSHIP_AT_RISK_DAYS, default 3).
+func internshipAtRiskDays() int {
+	days := viper.GetInt("INTERNSHIP_AT_RISK_DAYS")
+	if days <= 0 {
+		return 3
+	}
+	return days
+}
+
+// countWorkDays menghitung hari kerja dari start sampai end (inklusif).
+func countWorkDays(start, end time.Time, workDays map[db.DayOfWeek]bool) int {
+	count := 0
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if workDays[dayOfWeekOf(date)] {
+			count++
+		}
+	}
+	return count
+}
+
+// GetMonitoring menyusun dashboard penempatan Aktif. Guru hanya melihat siswa
+// bimbingannya; admin dan staf sebagai koordinator melihat semua penempatan
+// beserta rekap per guru pembimbing. Hari kerja mengikuti SCHOOL_WEEK_DAYS.
+func (s *InternshipMonitoringService) GetMonitoring(user *db.UserModel, filters InternshipMonitoringFilters) (*InternshipMonitoring, error) {
+	ctx := context.Background()
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, err
+		}
+		filters.SupervisorTeacherID = int64(teacher.ID)
+	}
+	workDays, err := schoolWeekDays()
+	if err != nil {
+		return nil, err
+	}
+
+	where := []db.InternshipPlacementWhereParam{
+		db.InternshipPlacement.Status.Equals(db.InternshipStatusAktif),
+	}
+	if filters.SupervisorTeacherID > 0 {
+		where = append(where, db.InternshipPlacement.SupervisorTeacherID.Equals(db.BigInt(filters.SupervisorTeacherID)))
+	}
+	if filters.CompanyID > 0 {
+		where = append(where, db.InternshipPlacement.CompanyID.Equals(db.BigInt(filters.CompanyID)))
+	}
+	if filters.ClassID > 0 {
+		where = append(where, db.InternshipPlacement.Student.Where(db.Student.CurrentClassID.Equals(db.BigInt(filters.ClassID))))
+	}
+	placements, err := s.db.InternshipPlacement.FindMany(where...).With(
+		db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+		db.InternshipPlacement.Company.Fetch(),
+		db.InternshipPlacement.SupervisorTeacher.Fetch(),
+		db.InternshipPlacement.Journals.Fetch(),
+		db.InternshipPlacement.Attendances.Fetch(
+			db.InternshipAttendance.Status.Equals(db.AttendanceStatusMasuk),
+		).OrderBy(db.InternshipAttendance.Timestamp.Order(db.SortOrderDesc)).Take(1),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve internship placements")
+	}
+
+	date := today()
+	atRiskDays := internshipAtRiskDays()
+	monitoring := &InternshipMonitoring{
+		Date:        formatDate(date),
+		AtRiskDays:  atRiskDays,
+		Supervisors: []SupervisorMonitoring{},
+		Students:    []PlacementMonitoring{},
+	}
+	supervisors := make(map[db.BigInt]*SupervisorMonitoring)
+	for _, placement := range placements {
+		row := monitorPlacement(placement, date, workDays, atRiskDays)
+
+		key := db.BigInt(0)
+		if teacherID, ok := placement.SupervisorTeacherID(); ok {
+			key = teacherID
+		}
+		supervisor, ok := supervisors[key]
+		if !ok {
+			supervisor = &SupervisorMonitoring{SupervisorTeacher: "Belum ada pembimbing"}
+			if key != 0 {
+				id := int64(key)
+				supervisor.SupervisorTeacherID = &id
+				supervisor.SupervisorTeacher = row.SupervisorTeacher
+			}
+			supervisors[key] = supervisor
+		}
+		supervisor.Placements++
+		supervisor.PendingApprovals += row.PendingApprovals
+		monitoring.Placements++
+		monitoring.PendingApprovals += row.PendingApprovals
+		if row.AtRisk {
+			supervisor.AtRisk++
+			monitoring.AtRisk++
+		}
+		if filters.AtRiskOnly && !row.AtRisk {
+			continue
+		}
+		monitoring.Students = append(monitoring.Students, row)
+	}
+
+	for _, supervisor := range supervisors {
+		monitoring.Supervisors = append(monitoring.Supervisors, *supervisor)
+	}
+	sort.Slice(monitoring.Supervisors, func(i, j int) bool {
+		return monitoring.Supervisors[i].SupervisorTeacher < monitoring.Supervisors[j].SupervisorTeacher
+	})
+	// Siswa berisiko dan yang paling lama tidak menulis jurnal ditampilkan lebih dulu.
+	sort.SliceStable(monitoring.Students, func(i, j int) bool {
+		a, b := monitoring.Students[i], monitoring.Students[j]
+		if a.AtRisk != b.AtRisk {
+			return a.AtRisk
+		}
+		if a.DaysWithoutJournal != b.DaysWithoutJournal {
+			return a.DaysWithoutJournal > b.DaysWithoutJournal
+		}
+		return a.StudentName < b.StudentName
+	})
+	return monitoring, nil
+}
+
+// monitorPlacement menghitung perkembangan satu penempatan per tanggal date.
+func monitorPlacement(placement db.InternshipPlacementModel, date time.Time, workDays map[db.DayOfWeek]bool, atRiskDays int) PlacementMonitoring {
+	student := placement.Student()
+	row := PlacementMonitoring{
+		PlacementID: int64(placement.ID),
+		StudentID:   int64(student.ID),
+		StudentName: student.FullName,
+		StudentNis:  student.Nis,
+		CompanyName: placement.Company().Name,
+		StartDate:   formatDate(placement.StartDate),
+	}
+	if class, ok := student.CurrentClass(); ok {
+		row.ClassName = class.ClassName
+	}
+	if teacher, ok := placement.SupervisorTeacher(); ok {
+		row.SupervisorTeacher = teacher.FullName
+	}
+
+	until := date
+	if end, ok := placement.EndDate(); ok {
+		row.EndDate = formatDate(end)
+		total := countWorkDays(placement.StartDate, end, workDays)
+		row.TotalDays = &total
+		if end.Before(until) {
+			until = end
+		}
+	}
+	row.DaysElapsed = countWorkDays(placement.StartDate, until, workDays)
+	row.JournalsExpected = row.DaysElapsed
+
+	var lastJournal time.Time
+	for _, journal := range placement.Journals() {
+		switch journal.Status {
+		case db.ApprovalStatusPending:
+			row.PendingApprovals++
+		case db.ApprovalStatusApproved:
+			row.JournalsApproved++
+		}
+		if workDays[dayOfWeekOf(journal.ActivityDate)] && !journal.ActivityDate.After(until) {
+			row.JournalsSubmitted++
+		}
+		if journal.ActivityDate.After(lastJournal) {
+			lastJournal = journal.ActivityDate
+		}
+	}
+	// Hari kerja tanpa jurnal dihitung sejak jurnal terakhir, atau sejak awal
+	// penempatan jika belum pernah menulis jurnal.
+	since := placement.StartDate
+	if !lastJournal.IsZero() {
+		row.LastJournalDate = formatDate(lastJournal)
+		since = lastJournal.AddDate(0, 0, 1)
+	}
+	row.DaysWithoutJournal = countWorkDays(since, until, workDays)
+	row.AtRisk = row.DaysWithoutJournal >= atRiskDays
+
+	if attendances := placement.Attendances(); len(attendances) > 0 {
+		last := attendances[0]
+		row.LastCheckIn = &last.Timestamp
+		row.LastCheckInOutside = last.OutOfRange
+	}
+	return row
+}
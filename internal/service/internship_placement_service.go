@@ -0,0 +1,634 @@
+// internal/service/internship_placement_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// InternshipPlacementService mengelola penempatan siswa PKL di DU/DI.
+type InternshipPlacementService struct {
+	db *db.PrismaClient
+}
+
+func NewInternshipPlacementService(db *db.PrismaClient) *InternshipPlacementService {
+	return &InternshipPlacementService{db: db}
+}
+
+// PlacementInput adalah data satu penempatan PKL.
+type PlacementInput struct {
+	StudentID           int64
+	CompanyID           int64
+	SupervisorTeacherID int64 // 0 berarti belum ada guru pembimbing
+	StartDate           string
+	EndDate             string // Boleh kosong jika belum ditentukan
+}
+
+// BulkPlacementGroup adalah siswa-siswa yang ditempatkan di satu DU/DI. Jika
+// StudentIDs kosong di semua grup, siswa kelas dibagi otomatis ke DU/DI sesuai
+// urutan dan sisa kuotanya.
+type BulkPlacementGroup struct {
+	CompanyID           int64
+	SupervisorTeacherID int64
+	StudentIDs          []int64
+}
+
+// BulkPlacementInput adalah penempatan satu kelas sekaligus ke beberapa DU/DI
+// dengan periode PKL yang sama.
+type BulkPlacementInput struct {
+	ClassID   int64
+	StartDate string
+	EndDate   string
+	Groups    []BulkPlacementGroup
+}
+
+// PlacementStatusInput adalah perubahan status penempatan dari Aktif.
+type PlacementStatusInput struct {
+	Status  string // Selesai atau Batal
+	Reason  string
+	EndDate string // Default: hari ini, atau tanggal selesai yang sudah ada untuk status Selesai
+}
+
+// PlacementFilters adalah filter daftar penempatan PKL.
+type PlacementFilters struct {
+	CompanyID           int64
+	StudentID           int64
+	ClassID             int64
+	SupervisorTeacherID int64
+	Status              string
+	Page                int
+	Limit               int
+}
+
+// PlacementConflict menjelaskan satu siswa yang tidak bisa ditempatkan.
+type PlacementConflict struct {
+	Reason      string `json:"reason" example:"active_placement"` // active_placement, capacity, not_in_class, inactive_student atau duplicate
+	StudentID   int64  `json:"student_id,omitempty" example:"21"`
+	StudentName string `json:"student_name,omitempty" example:"Siti Nurhaliza"`
+	CompanyID   int64  `json:"company_id,omitempty" example:"1"`
+	CompanyName string `json:"company_name,omitempty" example:"PT Telkom Indonesia Witel Yogyakarta"`
+	PlacementID int64  `json:"placement_id,omitempty" example:"10"` // Penempatan aktif yang sudah ada
+}
+
+// PlacementConflictError dikembalikan jika ada siswa yang tidak bisa ditempatkan.
+// Tidak ada penempatan yang disimpan sampai seluruh konflik diselesaikan.
+type PlacementConflictError struct {
+	Conflicts []PlacementConflict
+}
+
+func (e *PlacementConflictError) Error() string {
+	return fmt.Sprintf("%d placement conflicts found", len(e.Conflicts))
+}
+
+func (e *PlacementConflictError) Unwrap() error { return ErrConflict }
+
+func (e *PlacementConflictError) Details() interface{} { return e.Conflicts }
+
+// GetPlacements mengambil daftar penempatan dengan paginasi, terbaru lebih dulu.
+// Guru hanya melihat siswa yang ia bimbing.
+func (s *InternshipPlacementService) GetPlacements(user *db.UserModel, filters PlacementFilters) ([]db.InternshipPlacementModel, int, error) {
+	ctx := context.Background()
+	var where []db.InternshipPlacementWhereParam
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.InternshipPlacement.SupervisorTeacherID.Equals(teacher.ID))
+	}
+	if filters.CompanyID > 0 {
+		where = append(where, db.InternshipPlacement.CompanyID.Equals(db.BigInt(filters.CompanyID)))
+	}
+	if filters.StudentID > 0 {
+		where = append(where, db.InternshipPlacement.StudentID.Equals(db.BigInt(filters.StudentID)))
+	}
+	if filters.ClassID > 0 {
+		where = append(where, db.InternshipPlacement.Student.Where(db.Student.CurrentClassID.Equals(db.BigInt(filters.ClassID))))
+	}
+	if filters.SupervisorTeacherID > 0 {
+		where = append(where, db.InternshipPlacement.SupervisorTeacherID.Equals(db.BigInt(filters.SupervisorTeacherID)))
+	}
+	if filters.Status != "" {
+		status, err := parseInternshipStatus(filters.Status)
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.InternshipPlacement.Status.Equals(status))
+	}
+
+	all, err := s.db.InternshipPlacement.FindMany(where...).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to count internship placements")
+	}
+
+	placements, err := s.db.InternshipPlacement.FindMany(where...).With(
+		db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+		db.InternshipPlacement.Company.Fetch(),
+		db.InternshipPlacement.SupervisorTeacher.Fetch(),
+	).OrderBy(
+		db.InternshipPlacement.StartDate.Order(db.SortOrderDesc),
+	).Skip((filters.Page - 1) * filters.Limit).Take(filters.Limit).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to retrieve internship placements")
+	}
+	return placements, len(all), nil
+}
+
+// GetPlacementByID mengambil satu penempatan. Guru hanya boleh melihat siswa bimbingannya.
+func (s *InternshipPlacementService) GetPlacementByID(user *db.UserModel, id int) (*db.InternshipPlacementModel, error) {
+	ctx := context.Background()
+	placement, err := s.findPlacement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, err
+		}
+		if supervisorID, ok := placement.SupervisorTeacherID(); !ok || supervisorID != teacher.ID {
+			return nil, forbiddenError("you can only view placements you supervise")
+		}
+	}
+	return placement, nil
+}
+
+func (s *InternshipPlacementService) findPlacement(ctx context.Context, id int) (*db.InternshipPlacementModel, error) {
+	placement, err := s.db.InternshipPlacement.FindUnique(db.InternshipPlacement.ID.Equals(db.BigInt(id))).With(
+		db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+		db.InternshipPlacement.Company.Fetch(),
+		db.InternshipPlacement.SupervisorTeacher.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("internship placement not found")
+		}
+		return nil, err
+	}
+	return placement, nil
+}
+
+func parseInternshipStatus(value string) (db.InternshipStatus, error) {
+	switch db.InternshipStatus(value) {
+	case db.InternshipStatusAktif, db.InternshipStatusSelesai, db.InternshipStatusBatal:
+		return db.InternshipStatus(value), nil
+	}
+	return "", validationError("invalid internship status %q", value)
+}
+
+// parsePlacementPeriod membaca periode PKL; tanggal selesai boleh kosong.
+func parsePlacementPeriod(startDate, endDate string) (start time.Time, end *time.Time, err error) {
+	start, err = parseDate(startDate)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if endDate == "" {
+		return start, nil, nil
+	}
+	_, last, err := parseDateRange(startDate, endDate, 0)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return start, &last, nil
+}
+
+// findCompany mengambil DU/DI beserta penempatan aktifnya untuk pengecekan kuota.
+func (s *InternshipPlacementService) findCompany(ctx context.Context, id int64) (*db.CompanyModel, error) {
+	company, err := s.db.Company.FindUnique(db.Company.ID.Equals(db.BigInt(id))).With(
+		db.Company.InternshipPlacements.Fetch(db.InternshipPlacement.Status.Equals(db.InternshipStatusAktif)),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("company %d not found", id)
+		}
+		return nil, err
+	}
+	return company, nil
+}
+
+// remainingCapacity mengembalikan sisa kuota DU/DI pada periode start-end,
+// -1 jika tidak dibatasi. Hanya penempatan yang periodenya beririsan yang
+// dihitung; penempatan exceptID dilewati (dipakai saat memindahkan penempatan).
+func remainingCapacity(company *db.CompanyModel, exceptID db.BigInt, start time.Time, end *time.Time) int {
+	capacity, ok := company.Capacity()
+	if !ok {
+		return -1
+	}
+	used := 0
+	for _, placement := range company.InternshipPlacements() {
+		var placementEnd *time.Time
+		if value, ok := placement.EndDate(); ok {
+			placementEnd = &value
+		}
+		if placement.ID != exceptID && periodsOverlap(placement.StartDate, placementEnd, start, end) {
+			used++
+		}
+	}
+	return max(capacity-used, 0)
+}
+
+// periodsOverlap melaporkan apakah dua periode beririsan. Tanggal selesai nil
+// berarti periode belum ditentukan akhirnya.
+func periodsOverlap(start time.Time, end *time.Time, otherStart time.Time, otherEnd *time.Time) bool {
+	if end != nil && end.Before(otherStart) {
+		return false
+	}
+	if otherEnd != nil && otherEnd.Before(start) {
+		return false
+	}
+	return true
+}
+
+func (s *InternshipPlacementService) checkSupervisor(ctx context.Context, teacherID int64) error {
+	if teacherID <= 0 {
+		return nil
+	}
+	_, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(teacherID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("supervisor teacher %d not found", teacherID)
+		}
+		return err
+	}
+	return nil
+}
+
+// activePlacementsOf mengambil penempatan Aktif milik siswa-siswa tertentu,
+// diindeks per siswa.
+func (s *InternshipPlacementService) activePlacementsOf(ctx context.Context, studentIDs []db.BigInt) (map[db.BigInt]db.InternshipPlacementModel, error) {
+	placements, err := s.db.InternshipPlacement.FindMany(
+		db.InternshipPlacement.StudentID.In(studentIDs),
+		db.InternshipPlacement.Status.Equals(db.InternshipStatusAktif),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to check active placements")
+	}
+	active := make(map[db.BigInt]db.InternshipPlacementModel, len(placements))
+	for _, placement := range placements {
+		active[placement.StudentID] = placement
+	}
+	return active, nil
+}
+
+func placementCreateTx(client *db.PrismaClient, studentID, companyID db.BigInt, supervisorID int64, start time.Time, end *time.Time) transaction.Param {
+	optional := []db.InternshipPlacementSetParam{
+		db.InternshipPlacement.EndDate.SetOptional(end),
+	}
+	if supervisorID > 0 {
+		optional = append(optional, db.InternshipPlacement.SupervisorTeacher.Link(db.Teacher.ID.Equals(db.BigInt(supervisorID))))
+	}
+	return client.InternshipPlacement.CreateOne(
+		db.InternshipPlacement.StartDate.Set(start),
+		db.InternshipPlacement.Student.Link(db.Student.ID.Equals(studentID)),
+		db.InternshipPlacement.Company.Link(db.Company.ID.Equals(companyID)),
+		optional...,
+	).Tx()
+}
+
+// CreatePlacement menempatkan satu siswa di DU/DI. Siswa hanya boleh memiliki
+// satu penempatan Aktif dan kuota DU/DI tidak boleh terlampaui.
+func (s *InternshipPlacementService) CreatePlacement(input PlacementInput) (*db.InternshipPlacementModel, error) {
+	placements, err := s.BulkAssign(BulkPlacementInput{
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+		Groups: []BulkPlacementGroup{{
+			CompanyID:           input.CompanyID,
+			SupervisorTeacherID: input.SupervisorTeacherID,
+			StudentIDs:          []int64{input.StudentID},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &placements[0], nil
+}
+
+// BulkAssign menempatkan siswa-siswa sekaligus. Jika ClassID diisi, seluruh
+// siswa harus berasal dari kelas tersebut; jika StudentIDs di semua grup kosong,
+// siswa aktif kelas yang belum memiliki penempatan Aktif dibagi otomatis ke
+// DU/DI sesuai urutan grup dan sisa kuotanya. Penempatan disimpan dalam satu
+// transaksi: satu konflik saja membatalkan semuanya.
+func (s *InternshipPlacementService) BulkAssign(input BulkPlacementInput) ([]db.InternshipPlacementModel, error) {
+	ctx := context.Background()
+	if len(input.Groups) == 0 {
+		return nil, validationError("at least one company is required")
+	}
+	start, end, err := parsePlacementPeriod(input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sisa kuota per DU/DI; DU/DI yang sama di beberapa grup berbagi kuota.
+	// Nilai -1 berarti tidak dibatasi.
+	companies := make([]*db.CompanyModel, len(input.Groups))
+	quota := make(map[db.BigInt]int)
+	for i, group := range input.Groups {
+		if companies[i], err = s.findCompany(ctx, group.CompanyID); err != nil {
+			return nil, err
+		}
+		if err := s.checkSupervisor(ctx, group.SupervisorTeacherID); err != nil {
+			return nil, err
+		}
+		quota[companies[i].ID] = remainingCapacity(companies[i], 0, start, end)
+	}
+
+	var classStudents map[db.BigInt]bool
+	if input.ClassID > 0 {
+		class, err := s.db.Class.FindUnique(db.Class.ID.Equals(db.BigInt(input.ClassID))).With(
+			db.Class.Students.Fetch().OrderBy(db.Student.FullName.Order(db.SortOrderAsc)),
+		).Exec(ctx)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, notFoundError("class not found")
+			}
+			return nil, err
+		}
+		classStudents = make(map[db.BigInt]bool)
+		var candidates []db.BigInt
+		for _, student := range class.Students() {
+			classStudents[student.ID] = true
+			if student.Status == db.StudentStatusAktif {
+				candidates = append(candidates, student.ID)
+			}
+		}
+		if countAssigned(input.Groups) == 0 {
+			active, err := s.activePlacementsOf(ctx, candidates)
+			if err != nil {
+				return nil, err
+			}
+			var unplaced []int64
+			for _, id := range candidates {
+				if _, ok := active[id]; !ok {
+					unplaced = append(unplaced, int64(id))
+				}
+			}
+			if len(unplaced) == 0 {
+				return nil, validationError("every active student of the class already has an active placement")
+			}
+			available := make(map[db.BigInt]int, len(quota))
+			for id, remaining := range quota {
+				available[id] = remaining
+			}
+			if err := distributeStudents(input.Groups, companies, unplaced, available); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var studentIDs []db.BigInt
+	seen := make(map[db.BigInt]bool)
+	var conflicts []PlacementConflict
+	for i, group := range input.Groups {
+		for _, id := range group.StudentIDs {
+			studentID := db.BigInt(id)
+			if seen[studentID] {
+				conflicts = append(conflicts, PlacementConflict{Reason: "duplicate", StudentID: id, CompanyID: int64(companies[i].ID), CompanyName: companies[i].Name})
+				continue
+			}
+			seen[studentID] = true
+			studentIDs = append(studentIDs, studentID)
+		}
+	}
+	if len(studentIDs) == 0 {
+		return nil, validationError("at least one student is required")
+	}
+
+	students, err := s.db.Student.FindMany(db.Student.ID.In(studentIDs)).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve students")
+	}
+	studentByID := make(map[db.BigInt]db.StudentModel, len(students))
+	for _, student := range students {
+		studentByID[student.ID] = student
+	}
+	active, err := s.activePlacementsOf(ctx, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []transaction.Param
+	placed := make(map[db.BigInt]bool)
+	for i, group := range input.Groups {
+		company := companies[i]
+		for _, id := range group.StudentIDs {
+			studentID := db.BigInt(id)
+			if placed[studentID] {
+				continue
+			}
+			placed[studentID] = true
+			student, ok := studentByID[studentID]
+			if !ok {
+				return nil, notFoundError("student %d not found", id)
+			}
+			conflict := PlacementConflict{StudentID: id, StudentName: student.FullName, CompanyID: int64(company.ID), CompanyName: company.Name}
+			if classStudents != nil && !classStudents[studentID] {
+				conflict.Reason = "not_in_class"
+				conflicts = append(conflicts, conflict)
+				continue
+			}
+			if student.Status != db.StudentStatusAktif {
+				conflict.Reason = "inactive_student"
+				conflicts = append(conflicts, conflict)
+				continue
+			}
+			if existing, ok := active[studentID]; ok {
+				conflict.Reason = "active_placement"
+				conflict.PlacementID = int64(existing.ID)
+				conflicts = append(conflicts, conflict)
+				continue
+			}
+			if quota[company.ID] == 0 {
+				conflict.Reason = "capacity"
+				conflicts = append(conflicts, conflict)
+				continue
+			}
+			if quota[company.ID] > 0 {
+				quota[company.ID]--
+			}
+			txs = append(txs, placementCreateTx(s.db, studentID, company.ID, group.SupervisorTeacherID, start, end))
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &PlacementConflictError{Conflicts: conflicts}
+	}
+
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to create internship placements")
+	}
+
+	placements, err := s.db.InternshipPlacement.FindMany(
+		db.InternshipPlacement.StudentID.In(studentIDs),
+		db.InternshipPlacement.Status.Equals(db.InternshipStatusAktif),
+	).With(
+		db.InternshipPlacement.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+		db.InternshipPlacement.Company.Fetch(),
+		db.InternshipPlacement.SupervisorTeacher.Fetch(),
+	).OrderBy(
+		db.InternshipPlacement.ID.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve internship placements")
+	}
+	return placements, nil
+}
+
+// distributeStudents membagi siswa ke grup-grup DU/DI secara bergiliran,
+// melewati DU/DI yang kuotanya sudah habis.
+func distributeStudents(groups []BulkPlacementGroup, companies []*db.CompanyModel, students []int64, quota map[db.BigInt]int) error {
+	next := 0
+	for n, student := range students {
+		assigned := false
+		for tries := 0; tries < len(groups); tries++ {
+			i := (next + tries) % len(groups)
+			companyID := companies[i].ID
+			if quota[companyID] == 0 {
+				continue
+			}
+			if quota[companyID] > 0 {
+				quota[companyID]--
+			}
+			groups[i].StudentIDs = append(groups[i].StudentIDs, student)
+			next = i + 1
+			assigned = true
+			break
+		}
+		if !assigned {
+			return validationError("not enough company capacity: %d students could not be placed", len(students)-n)
+		}
+	}
+	return nil
+}
+
+func countAssigned(groups []BulkPlacementGroup) int {
+	total := 0
+	for _, group := range groups {
+		total += len(group.StudentIDs)
+	}
+	return total
+}
+
+// UpdatePlacement mengubah DU/DI, guru pembimbing dan periode penempatan yang
+// masih Aktif. Pindah DU/DI memeriksa kuota DU/DI tujuan.
+func (s *InternshipPlacementService) UpdatePlacement(id int, input PlacementInput) (*db.InternshipPlacementModel, error) {
+	ctx := context.Background()
+	placement, err := s.findPlacement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if placement.Status != db.InternshipStatusAktif {
+		return nil, conflictError("only active placements can be changed")
+	}
+	start, end, err := parsePlacementPeriod(input.StartDate, input.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkSupervisor(ctx, input.SupervisorTeacherID); err != nil {
+		return nil, err
+	}
+
+	params := []db.InternshipPlacementSetParam{
+		db.InternshipPlacement.StartDate.Set(start),
+		db.InternshipPlacement.EndDate.SetOptional(end),
+	}
+	if input.SupervisorTeacherID > 0 {
+		params = append(params, db.InternshipPlacement.SupervisorTeacher.Link(db.Teacher.ID.Equals(db.BigInt(input.SupervisorTeacherID))))
+	} else if _, ok := placement.SupervisorTeacherID(); ok {
+		params = append(params, db.InternshipPlacement.SupervisorTeacher.Unlink())
+	}
+	if input.CompanyID > 0 && db.BigInt(input.CompanyID) != placement.CompanyID {
+		company, err := s.findCompany(ctx, input.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		if remainingCapacity(company, placement.ID, start, end) == 0 {
+			return nil, &PlacementConflictError{Conflicts: []PlacementConflict{{
+				Reason:      "capacity",
+				StudentID:   int64(placement.StudentID),
+				StudentName: placement.Student().FullName,
+				CompanyID:   int64(company.ID),
+				CompanyName: company.Name,
+			}}}
+		}
+		params = append(params, db.InternshipPlacement.Company.Link(db.Company.ID.Equals(company.ID)))
+	}
+
+	if _, err := s.db.InternshipPlacement.FindUnique(db.InternshipPlacement.ID.Equals(placement.ID)).Update(params...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to update internship placement")
+	}
+	return s.findPlacement(ctx, id)
+}
+
+// ChangeStatus menutup penempatan Aktif menjadi Selesai atau Batal. Alasan wajib
+// diisi untuk pembatalan. Tanggal selesai yang tidak diisi memakai tanggal
+// selesai rencana, atau hari ini jika rencananya belum ada atau belum tercapai.
+func (s *InternshipPlacementService) ChangeStatus(id int, input PlacementStatusInput) (*db.InternshipPlacementModel, error) {
+	ctx := context.Background()
+	placement, err := s.findPlacement(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	status, err := parseInternshipStatus(input.Status)
+	if err != nil {
+		return nil, err
+	}
+	if status == db.InternshipStatusAktif {
+		return nil, validationError("status can only be changed to Selesai or Batal")
+	}
+	if placement.Status != db.InternshipStatusAktif {
+		return nil, conflictError("placement is already %s", placement.Status)
+	}
+	if status == db.InternshipStatusBatal && optionalString(input.Reason) == nil {
+		return nil, validationError("reason is required to cancel a placement")
+	}
+
+	endDate := today()
+	if input.EndDate != "" {
+		if endDate, err = parseDate(input.EndDate); err != nil {
+			return nil, err
+		}
+	} else if planned, ok := placement.EndDate(); ok && planned.Before(endDate) {
+		endDate = planned
+	}
+	if endDate.Before(placement.StartDate) {
+		return nil, validationError("end date must not be before the placement start date")
+	}
+
+	_, err = s.db.InternshipPlacement.FindUnique(db.InternshipPlacement.ID.Equals(placement.ID)).Update(
+		db.InternshipPlacement.Status.Set(status),
+		db.InternshipPlacement.StatusReason.SetOptional(optionalString(input.Reason)),
+		db.InternshipPlacement.StatusChangedAt.Set(time.Now()),
+		db.InternshipPlacement.EndDate.Set(endDate),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to change internship placement status")
+	}
+	return s.findPlacement(ctx, id)
+}
+
+// DeletePlacement menghapus penempatan yang dibuat keliru. Penempatan yang
+// sudah memiliki jurnal PKL tidak boleh dihapus; batalkan saja agar riwayatnya tetap ada.
+func (s *InternshipPlacementService) DeletePlacement(id int) error {
+	ctx := context.Background()
+	_, err := s.db.InternshipJournal.FindFirst(db.InternshipJournal.PlacementID.Equals(db.BigInt(id))).Exec(ctx)
+	if err == nil {
+		return conflictError("placement already has internship journals, cancel it instead")
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return err
+	}
+
+	_, err = s.db.InternshipPlacement.FindUnique(db.InternshipPlacement.ID.Equals(db.BigInt(id))).Delete().Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("internship placement not found")
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodsOverlap(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	end := func(month time.Month, day int) *time.Time {
+		value := date(month, day)
+		return &value
+	}
+
+	// Penempatan yang sudah ada: 1 Juli - 30 September.
+	start, finish := date(7, 1), end(9, 30)
+	tests := []struct {
+		name       string
+		otherStart time.Time
+		otherEnd   *time.Time
+		want       bool
+	}{
+		{name: "same period", otherStart: date(7, 1), otherEnd: end(9, 30), want: true},
+		{name: "starts inside", otherStart: date(9, 1), otherEnd: end(12, 31), want: true},
+		{name: "ends inside", otherStart: date(5, 1), otherEnd: end(7, 1), want: true},
+		{name: "surrounds", otherStart: date(6, 1), otherEnd: end(10, 31), want: true},
+		{name: "before", otherStart: date(4, 1), otherEnd: end(6, 30), want: false},
+		{name: "after", otherStart: date(10, 1), otherEnd: end(12, 31), want: false},
+		{name: "open-ended after", otherStart: date(10, 1), want: false},
+		{name: "open-ended before", otherStart: date(6, 1), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := periodsOverlap(start, finish, tt.otherStart, tt.otherEnd); got != tt.want {
+				t.Errorf("periodsOverlap() = %v, want %v", got, tt.want)
+			}
+			if got := periodsOverlap(tt.otherStart, tt.otherEnd, start, finish); got != tt.want {
+				t.Errorf("periodsOverlap() swapped = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("existing placement without end date", func(t *testing.T) {
+		if !periodsOverlap(date(7, 1), nil, date(12, 1), end(12, 31)) {
+			t.Error("an open-ended placement must overlap every later period")
+		}
+		if periodsOverlap(date(7, 1), nil, date(5, 1), end(6, 30)) {
+			t.Error("an open-ended placement must not overlap an earlier period")
+		}
+	})
+}
@@ -0,0 +1,245 @@
+// internal/service/internship_rubric_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// InternshipRubricService mengelola rubrik penilaian PKL per jurusan.
+type InternshipRubricService struct {
+	db *db.PrismaClient
+}
+
+func NewInternshipRubricService(db *db.PrismaClient) *InternshipRubricService {
+	return &InternshipRubricService{db: db}
+}
+
+// RubricItemInput adalah satu kompetensi yang dinilai. ID kosong berarti item baru.
+// Urutan item mengikuti urutan input.
+type RubricItemInput struct {
+	ID         int64
+	Competency string
+	Assessor   string // Pembimbing atau Mentor
+	Weight     int
+}
+
+// RubricInput adalah isi rubrik penilaian PKL untuk satu jurusan.
+type RubricInput struct {
+	Major string
+	Name  string
+	Items []RubricItemInput
+}
+
+func parseInternshipAssessor(value string) (db.InternshipAssessor, error) {
+	switch db.InternshipAssessor(value) {
+	case db.InternshipAssessorPembimbing, db.InternshipAssessorMentor:
+		return db.InternshipAssessor(value), nil
+	}
+	return "", validationError("invalid assessor %q, expected Pembimbing or Mentor", value)
+}
+
+// validateRubric merapikan dan memeriksa isi rubrik sebelum disimpan.
+func validateRubric(input *RubricInput) error {
+	input.Major = strings.TrimSpace(input.Major)
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Major == "" || input.Name == "" {
+		return validationError("major and name are required")
+	}
+	if len(input.Items) == 0 {
+		return validationError("rubric must have at least one item")
+	}
+	for i := range input.Items {
+		item := &input.Items[i]
+		item.Competency = strings.TrimSpace(item.Competency)
+		if item.Competency == "" {
+			return validationError("competency of item %d is required", i+1)
+		}
+		if _, err := parseInternshipAssessor(item.Assessor); err != nil {
+			return err
+		}
+		if item.Weight <= 0 {
+			return validationError("weight of item %d must be greater than zero", i+1)
+		}
+	}
+	return nil
+}
+
+// GetRubrics mengambil seluruh rubrik beserta itemnya, urut jurusan.
+func (s *InternshipRubricService) GetRubrics() ([]db.InternshipRubricModel, error) {
+	rubrics, err := s.db.InternshipRubric.FindMany().With(
+		db.InternshipRubric.Items.Fetch().OrderBy(db.InternshipRubricItem.SortOrder.Order(db.SortOrderAsc)),
+	).OrderBy(
+		db.InternshipRubric.Major.Order(db.SortOrderAsc),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve internship rubrics")
+	}
+	return rubrics, nil
+}
+
+// GetRubricByID mengambil satu rubrik beserta itemnya.
+func (s *InternshipRubricService) GetRubricByID(id int) (*db.InternshipRubricModel, error) {
+	rubric, err := s.db.InternshipRubric.FindUnique(db.InternshipRubric.ID.Equals(db.BigInt(id))).With(
+		db.InternshipRubric.Items.Fetch().OrderBy(db.InternshipRubricItem.SortOrder.Order(db.SortOrderAsc)),
+	).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("internship rubric not found")
+		}
+		return nil, err
+	}
+	return rubric, nil
+}
+
+// CreateRubric membuat rubrik baru. Satu jurusan hanya memiliki satu rubrik.
+func (s *InternshipRubricService) CreateRubric(input RubricInput) (*db.InternshipRubricModel, error) {
+	ctx := context.Background()
+	if err := validateRubric(&input); err != nil {
+		return nil, err
+	}
+	if _, err := s.db.InternshipRubric.FindUnique(db.InternshipRubric.Major.Equals(input.Major)).Exec(ctx); err == nil {
+		return nil, conflictError("rubric for major %s already exists", input.Major)
+	} else if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	txs := []transaction.Param{
+		s.db.InternshipRubric.CreateOne(
+			db.InternshipRubric.Major.Set(input.Major),
+			db.InternshipRubric.Name.Set(input.Name),
+		).Tx(),
+	}
+	// Item ditautkan lewat jurusan karena ID rubrik baru diketahui setelah transaksi.
+	for i, item := range input.Items {
+		txs = append(txs, s.db.InternshipRubricItem.CreateOne(
+			db.InternshipRubricItem.Competency.Set(item.Competency),
+			db.InternshipRubricItem.Assessor.Set(db.InternshipAssessor(item.Assessor)),
+			db.InternshipRubricItem.Weight.Set(item.Weight),
+			db.InternshipRubricItem.Rubric.Link(db.InternshipRubric.Major.Equals(input.Major)),
+			db.InternshipRubricItem.SortOrder.Set(i),
+		).Tx())
+	}
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to create internship rubric")
+	}
+
+	rubric, err := s.db.InternshipRubric.FindUnique(db.InternshipRubric.Major.Equals(input.Major)).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetRubricByID(int(rubric.ID))
+}
+
+// UpdateRubric mengganti isi rubrik. Item dengan ID diperbarui, item tanpa ID
+// ditambahkan, dan item lama yang tidak dikirim dihapus selama belum dinilai.
+func (s *InternshipRubricService) UpdateRubric(id int, input RubricInput) (*db.InternshipRubricModel, error) {
+	ctx := context.Background()
+	if err := validateRubric(&input); err != nil {
+		return nil, err
+	}
+	rubric, err := s.db.InternshipRubric.FindUnique(db.InternshipRubric.ID.Equals(db.BigInt(id))).With(
+		db.InternshipRubric.Items.Fetch().With(db.InternshipRubricItem.Scores.Fetch().Take(1)),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("internship rubric not found")
+		}
+		return nil, err
+	}
+	if input.Major != rubric.Major {
+		// Rubrik dicari lewat jurusan siswa, jadi jurusan rubrik yang sudah dipakai tidak boleh diganti.
+		for _, item := range rubric.Items() {
+			if len(item.Scores()) > 0 {
+				return nil, conflictError("major of a rubric that has been used for scoring cannot be changed")
+			}
+		}
+		other, err := s.db.InternshipRubric.FindUnique(db.InternshipRubric.Major.Equals(input.Major)).Exec(ctx)
+		if err == nil && other.ID != rubric.ID {
+			return nil, conflictError("rubric for major %s already exists", input.Major)
+		} else if err != nil && !errors.Is(err, db.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	existing := make(map[db.BigInt]db.InternshipRubricItemModel)
+	for _, item := range rubric.Items() {
+		existing[item.ID] = item
+	}
+	kept := make(map[db.BigInt]bool)
+	txs := []transaction.Param{
+		s.db.InternshipRubric.FindUnique(db.InternshipRubric.ID.Equals(rubric.ID)).Update(
+			db.InternshipRubric.Major.Set(input.Major),
+			db.InternshipRubric.Name.Set(input.Name),
+		).Tx(),
+	}
+	for i, item := range input.Items {
+		if item.ID == 0 {
+			txs = append(txs, s.db.InternshipRubricItem.CreateOne(
+				db.InternshipRubricItem.Competency.Set(item.Competency),
+				db.InternshipRubricItem.Assessor.Set(db.InternshipAssessor(item.Assessor)),
+				db.InternshipRubricItem.Weight.Set(item.Weight),
+				db.InternshipRubricItem.Rubric.Link(db.InternshipRubric.ID.Equals(rubric.ID)),
+				db.InternshipRubricItem.SortOrder.Set(i),
+			).Tx())
+			continue
+		}
+		old, ok := existing[db.BigInt(item.ID)]
+		if !ok {
+			return nil, validationError("item %d does not belong to this rubric", item.ID)
+		}
+		if old.Assessor != db.InternshipAssessor(item.Assessor) && len(old.Scores()) > 0 {
+			return nil, conflictError("assessor of item %q cannot be changed because it has been scored", old.Competency)
+		}
+		kept[old.ID] = true
+		txs = append(txs, s.db.InternshipRubricItem.FindUnique(db.InternshipRubricItem.ID.Equals(old.ID)).Update(
+			db.InternshipRubricItem.Competency.Set(item.Competency),
+			db.InternshipRubricItem.Assessor.Set(db.InternshipAssessor(item.Assessor)),
+			db.InternshipRubricItem.Weight.Set(item.Weight),
+			db.InternshipRubricItem.SortOrder.Set(i),
+		).Tx())
+	}
+	for _, old := range rubric.Items() {
+		if kept[old.ID] {
+			continue
+		}
+		if len(old.Scores()) > 0 {
+			return nil, conflictError("item %q cannot be removed because it has been scored", old.Competency)
+		}
+		txs = append(txs, s.db.InternshipRubricItem.FindUnique(db.InternshipRubricItem.ID.Equals(old.ID)).Delete().Tx())
+	}
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to update internship rubric")
+	}
+	return s.GetRubricByID(id)
+}
+
+// DeleteRubric menghapus rubrik yang itemnya belum pernah dipakai menilai.
+func (s *InternshipRubricService) DeleteRubric(id int) error {
+	ctx := context.Background()
+	rubric, err := s.db.InternshipRubric.FindUnique(db.InternshipRubric.ID.Equals(db.BigInt(id))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("internship rubric not found")
+		}
+		return err
+	}
+	scored, err := s.db.InternshipScore.FindMany(
+		db.InternshipScore.RubricItem.Where(db.InternshipRubricItem.RubricID.Equals(rubric.ID)),
+	).Take(1).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if len(scored) > 0 {
+		return conflictError("internship rubric has been used for scoring")
+	}
+	if _, err := s.db.InternshipRubric.FindUnique(db.InternshipRubric.ID.Equals(rubric.ID)).Delete().Exec(ctx); err != nil {
+		return errors.New("failed to delete internship rubric")
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+// internal/service/journal_compliance_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// maxComplianceRangeDays membatasi rentang laporan kepatuhan jurnal (satu semester).
+const maxComplianceRangeDays = 184
+
+type JournalComplianceService struct {
+	db         *db.PrismaClient
+	schoolDays SchoolDayChecker
+}
+
+func NewJournalComplianceService(db *db.PrismaClient) *JournalComplianceService {
+	return &JournalComplianceService{db: db, schoolDays: NewCalendarService(db)}
+}
+
+// MissingJournal adalah satu jam pelajaran yang belum memiliki jurnal.
+type MissingJournal struct {
+	Date           string `json:"date" example:"2025-09-22"`
+	ScheduleID     int64  `json:"schedule_id" example:"12"`
+	DayOfWeek      string `json:"day_of_week" example:"Senin"`
+	StartTime      string `json:"start_time" example:"07:00"`
+	EndTime        string `json:"end_time" example:"08:30"`
+	ClassID        int64  `json:"class_id" example:"3"`
+	ClassName      string `json:"class_name" example:"X RPL 1"`
+	SubjectID      int64  `json:"subject_id" example:"1"`
+	SubjectName    string `json:"subject_name" example:"Matematika"`
+	SubstitutionID *int64 `json:"substitution_id,omitempty" example:"4"` // Diisi jika guru yang bertanggung jawab adalah guru pengganti
+}
+
+// TeacherJournalCompliance adalah rekap pengisian jurnal seorang guru.
+type TeacherJournalCompliance struct {
+	TeacherID       int64            `json:"teacher_id" example:"2"`
+	TeacherName     string           `json:"teacher_name" example:"Budi Santoso, S.Pd"`
+	Expected        int              `json:"expected" example:"40"` // Jam pelajaran yang wajib berjurnal
+	Filled          int              `json:"filled" example:"37"`   // Jam pelajaran yang sudah berjurnal
+	Excused         int              `json:"excused" example:"2"`   // Tidak dihitung karena guru izin dan tidak ada pengganti
+	Percentage      float64          `json:"percentage" example:"92.5"`
+	MissingJournals []MissingJournal `json:"missing_journals"`
+}
+
+// JournalComplianceReport adalah laporan kepatuhan pengisian jurnal KBM.
+type JournalComplianceReport struct {
+	From       string                     `json:"from" example:"2025-09-01"`
+	To         string                     `json:"to" example:"2025-09-30"`
+	Expected   int                        `json:"expected" example:"1200"`
+	Filled     int                        `json:"filled" example:"1130"`
+	Percentage float64                    `json:"percentage" example:"94.2"`
+	Teachers   []TeacherJournalCompliance `json:"teachers"`
+}
+
+// JournalComplianceFilters adalah filter laporan kepatuhan jurnal.
+type JournalComplianceFilters struct {
+	From      string
+	To        string
+	TeacherID int64
+	ClassID   int64
+}
+
+// expectedLesson adalah satu jam pelajaran pada satu tanggal beserta guru yang wajib mengisi jurnalnya.
+type expectedLesson struct {
+	date         time.Time
+	schedule     db.ScheduleModel
+	teacher      db.TeacherModel
+	substitution *db.SubstitutionModel
+	filled       bool
+	excused      bool
+}
+
+// GetReport membandingkan jam pelajaran yang seharusnya berlangsung (jadwal
+// dikembangkan per tanggal, melewati hari libur) dengan jurnal yang sudah diisi.
+// Tanggal setelah hari ini tidak dihitung.
+func (s *JournalComplianceService) GetReport(filters JournalComplianceFilters) (*JournalComplianceReport, error) {
+	start, end, err := parseDateRange(filters.From, filters.To, maxComplianceRangeDays)
+	if err != nil {
+		return nil, err
+	}
+	lessons, err := s.expectedLessons(context.Background(), start, end, filters.ClassID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &JournalComplianceReport{From: formatDate(start), To: formatDate(end), Teachers: []TeacherJournalCompliance{}}
+	byTeacher := map[db.BigInt]*TeacherJournalCompliance{}
+	for _, lesson := range lessons {
+		if filters.TeacherID > 0 && lesson.teacher.ID != db.BigInt(filters.TeacherID) {
+			continue
+		}
+		row, ok := byTeacher[lesson.teacher.ID]
+		if !ok {
+			row = &TeacherJournalCompliance{
+				TeacherID:       int64(lesson.teacher.ID),
+				TeacherName:     lesson.teacher.FullName,
+				MissingJournals: []MissingJournal{},
+			}
+			byTeacher[lesson.teacher.ID] = row
+		}
+		switch {
+		case lesson.filled:
+			row.Expected++
+			row.Filled++
+		case lesson.excused:
+			row.Excused++
+		default:
+			row.Expected++
+			row.MissingJournals = append(row.MissingJournals, toMissingJournal(lesson))
+		}
+	}
+
+	for _, row := range byTeacher {
+		row.Percentage = compliancePercentage(row.Filled, row.Expected)
+		report.Expected += row.Expected
+		report.Filled += row.Filled
+		report.Teachers = append(report.Teachers, *row)
+	}
+	report.Percentage = compliancePercentage(report.Filled, report.Expected)
+	sort.Slice(report.Teachers, func(i, j int) bool {
+		a, b := report.Teachers[i], report.Teachers[j]
+		if a.Percentage != b.Percentage {
+			return a.Percentage < b.Percentage
+		}
+		return a.TeacherName < b.TeacherName
+	})
+	return report, nil
+}
+
+// expectedLessons mengembangkan semua jadwal menjadi jam pelajaran per tanggal
+// pada rentang start..end (dipotong sampai hari ini), lalu menandai mana yang
+// sudah berjurnal dan mana yang dikecualikan karena guru izin tanpa pengganti.
+func (s *JournalComplianceService) expectedLessons(ctx context.Context, start, end time.Time, classID int64) ([]expectedLesson, error) {
+	if now := today(); end.After(now) {
+		end = now
+	}
+	if end.Before(start) {
+		return nil, nil
+	}
+
+	var where []db.ScheduleWhereParam
+	if classID > 0 {
+		where = append(where, db.Schedule.ClassID.Equals(db.BigInt(classID)))
+	}
+	schedules, err := s.db.Schedule.FindMany(where...).With(
+		db.Schedule.Class.Fetch(),
+		db.Schedule.Subject.Fetch(),
+		db.Schedule.Teacher.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve schedules")
+	}
+
+	journals, err := s.db.TeachingJournal.FindMany(
+		db.TeachingJournal.TeachingDate.Gte(start),
+		db.TeachingJournal.TeachingDate.Lte(end),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve teaching journals")
+	}
+	filled := make(map[string]bool, len(journals))
+	for _, journal := range journals {
+		filled[lessonKey(journal.ScheduleID, journal.TeachingDate)] = true
+	}
+
+	substitutions, err := s.db.Substitution.FindMany(
+		db.Substitution.Date.Gte(start),
+		db.Substitution.Date.Lte(end),
+	).With(
+		db.Substitution.SubstituteTeacher.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve substitutions")
+	}
+	covered := make(map[string]db.SubstitutionModel, len(substitutions))
+	for _, substitution := range substitutions {
+		covered[lessonKey(substitution.ScheduleID, substitution.Date)] = substitution
+	}
+
+	leaves, err := s.db.LeaveRequest.FindMany(
+		db.LeaveRequest.Status.Equals(db.LeaveStatusApproved),
+		db.LeaveRequest.StartDate.Lte(end),
+		db.LeaveRequest.EndDate.Gte(start),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve leave requests")
+	}
+	onLeave := func(userID db.BigInt, date time.Time) bool {
+		for _, leave := range leaves {
+			if leave.UserID == userID && !date.Before(leave.StartDate) && !date.After(leave.EndDate) {
+				return true
+			}
+		}
+		return false
+	}
+
+	academicYear, err := academicYearsBetween(ctx, s.db, start, end)
+	if err != nil {
+		return nil, err
+	}
+	schoolDay := map[string]bool{}
+	var lessons []expectedLesson
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		day, year := dayOfWeekOf(date), academicYear(date)
+		for _, schedule := range schedules {
+			if schedule.DayOfWeek != day || schedule.Class().AcademicYear != year {
+				continue
+			}
+			dayKey := lessonKey(schedule.ClassID, date)
+			isSchoolDay, checked := schoolDay[dayKey]
+			if !checked {
+				isSchoolDay, err = s.schoolDays.IsSchoolDay(date, int64(schedule.ClassID))
+				if err != nil {
+					return nil, err
+				}
+				schoolDay[dayKey] = isSchoolDay
+			}
+			if !isSchoolDay {
+				continue
+			}
+
+			key := lessonKey(schedule.ID, date)
+			lesson := expectedLesson{
+				date:     date,
+				schedule: schedule,
+				teacher:  *schedule.Teacher(),
+				filled:   filled[key],
+			}
+			if substitution, ok := covered[key]; ok {
+				lesson.teacher = *substitution.SubstituteTeacher()
+				lesson.substitution = &substitution
+			} else if onLeave(schedule.Teacher().UserID, date) {
+				lesson.excused = true
+			}
+			lessons = append(lessons, lesson)
+		}
+	}
+	return lessons, nil
+}
+
+func toMissingJournal(lesson expectedLesson) MissingJournal {
+	missing := MissingJournal{
+		Date:        formatDate(lesson.date),
+		ScheduleID:  int64(lesson.schedule.ID),
+		DayOfWeek:   string(lesson.schedule.DayOfWeek),
+		StartTime:   formatClock(lesson.schedule.StartTime),
+		EndTime:     formatClock(lesson.schedule.EndTime),
+		ClassID:     int64(lesson.schedule.ClassID),
+		ClassName:   lesson.schedule.Class().ClassName,
+		SubjectID:   int64(lesson.schedule.SubjectID),
+		SubjectName: lesson.schedule.Subject().SubjectName,
+	}
+	if lesson.substitution != nil {
+		id := int64(lesson.substitution.ID)
+		missing.SubstitutionID = &id
+	}
+	return missing
+}
+
+// compliancePercentage menghitung persentase dengan satu angka desimal.
+// Tanpa jam pelajaran wajib, kepatuhan dianggap 100%.
+func compliancePercentage(filled, expected int) float64 {
+	if expected == 0 {
+		return 100
+	}
+	return math.Round(float64(filled)/float64(expected)*1000) / 10
+}
+
+// GenerateReminders membuat pengingat untuk setiap jam pelajaran yang belum
+// berjurnal sejak JOURNAL_EDIT_LOCK_DAYS hari lalu sampai tanggal tersebut.
+// Pengingat yang sudah ada tidak dibuat ulang. Dijalankan oleh tugas harian.
+func (s *JournalComplianceService) GenerateReminders(date time.Time) (int, error) {
+	ctx := context.Background()
+	date = dateOnly(date)
+	lessons, err := s.expectedLessons(ctx, date.AddDate(0, 0, -journalEditLockDays()), date, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	existing, err := s.db.JournalReminder.FindMany(
+		db.JournalReminder.TeachingDate.Gte(date.AddDate(0, 0, -journalEditLockDays())),
+		db.JournalReminder.TeachingDate.Lte(date),
+	).Exec(ctx)
+	if err != nil {
+		return 0, errors.New("failed to retrieve journal reminders")
+	}
+	reminded := make(map[string]db.BigInt, len(existing))
+	for _, reminder := range existing {
+		reminded[lessonKey(reminder.ScheduleID, reminder.TeachingDate)] = reminder.TeacherID
+	}
+
+	var txs []transaction.Param
+	for _, lesson := range lessons {
+		if lesson.filled || lesson.excused {
+			continue
+		}
+		if teacherID, ok := reminded[lessonKey(lesson.schedule.ID, lesson.date)]; ok && teacherID == lesson.teacher.ID {
+			continue
+		}
+		txs = append(txs, s.db.JournalReminder.CreateOne(
+			db.JournalReminder.TeachingDate.Set(lesson.date),
+			db.JournalReminder.Teacher.Link(db.Teacher.ID.Equals(lesson.teacher.ID)),
+			db.JournalReminder.Schedule.Link(db.Schedule.ID.Equals(lesson.schedule.ID)),
+		).Tx())
+	}
+	if len(txs) == 0 {
+		return 0, nil
+	}
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return 0, errors.New("failed to create journal reminders")
+	}
+	return len(txs), nil
+}
+
+// GetMyReminders mengambil pengingat jurnal yang belum diisi milik guru yang login.
+func (s *JournalComplianceService) GetMyReminders(userID int) ([]db.JournalReminderModel, error) {
+	ctx := context.Background()
+	teacher, err := teacherOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	reminders, err := s.db.JournalReminder.FindMany(
+		db.JournalReminder.TeacherID.Equals(teacher.ID),
+	).With(
+		db.JournalReminder.Schedule.Fetch().With(
+			db.Schedule.Class.Fetch(),
+			db.Schedule.Subject.Fetch(),
+			db.Schedule.Teacher.Fetch(),
+		),
+	).OrderBy(
+		db.JournalReminder.TeachingDate.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve journal reminders")
+	}
+	return reminders, nil
+}
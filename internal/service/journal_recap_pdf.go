@@ -0,0 +1,258 @@
+// internal/service/journal_recap_pdf.go
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/pdf"
+)
+
+// Tata letak rekap jurnal pada kertas A4 (satuan point).
+const (
+	recapMargin       = 40.0
+	recapTop          = 50.0
+	recapBottom       = 60.0
+	recapFontSize     = 9.0
+	recapLineHeight   = 11.0
+	recapCellPadding  = 4.0
+	recapSignatureH   = 55.0
+	recapSignatureW   = 150.0
+	recapSignatureBox = 150.0 // Tinggi blok tanda tangan
+)
+
+// recapColumn adalah satu kolom tabel rekap.
+type recapColumn struct {
+	Title string
+	Width float64
+}
+
+var recapColumns = []recapColumn{
+	{"No", 24},
+	{"Tanggal", 62},
+	{"Jam", 62},
+	{"Materi / Catatan", 217},
+	{"Kehadiran Siswa", 150},
+}
+
+// recapWriter menyimpan posisi tulis saat menyusun halaman rekap.
+type recapWriter struct {
+	doc  *pdf.Document
+	y    float64
+	page int
+}
+
+// renderJournalRecap menyusun PDF rekap jurnal mengajar.
+func renderJournalRecap(data journalRecapData) ([]byte, error) {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	doc.SetTitle(fmt.Sprintf("Rekap Jurnal Mengajar %s - %s", data.Teacher.Name, formatMonthName(data.Month)))
+	w := &recapWriter{doc: doc}
+	w.newPage()
+
+	if data.SchoolName != "" {
+		doc.TextCenter(doc.Width()/2, w.y, pdf.HelveticaBold, 13, data.SchoolName)
+		w.y += 18
+	}
+	doc.TextCenter(doc.Width()/2, w.y, pdf.HelveticaBold, 12, "REKAP JURNAL MENGAJAR")
+	w.y += 16
+	doc.TextCenter(doc.Width()/2, w.y, pdf.Helvetica, 10, "Bulan "+formatMonthName(data.Month))
+	w.y += 10
+	doc.Line(recapMargin, w.y, doc.Width()-recapMargin, w.y, 1)
+	w.y += 20
+
+	total := 0
+	for _, group := range data.Groups {
+		total += len(group.Entries)
+	}
+	identity := [][2]string{
+		{"Nama Guru", data.Teacher.Name},
+		{"NIP", orDash(data.Teacher.NIP)},
+		{"Jumlah Jurnal", strconv.Itoa(total) + " pertemuan"},
+	}
+	for _, row := range identity {
+		doc.Text(recapMargin, w.y, pdf.Helvetica, 10, row[0])
+		doc.Text(recapMargin+90, w.y, pdf.Helvetica, 10, ": "+row[1])
+		w.y += 14
+	}
+	w.y += 8
+
+	if len(data.Groups) == 0 {
+		doc.Text(recapMargin, w.y, pdf.Helvetica, 10, "Tidak ada jurnal mengajar pada bulan ini.")
+		w.y += 20
+	}
+	for _, group := range data.Groups {
+		w.group(group)
+	}
+
+	if err := w.signatures(data); err != nil {
+		return nil, err
+	}
+	return doc.Bytes(), nil
+}
+
+func (w *recapWriter) newPage() {
+	w.doc.AddPage()
+	w.page++
+	w.y = recapTop
+	w.doc.TextRight(w.doc.Width()-recapMargin, w.doc.Height()-recapBottom/2, pdf.Helvetica, 8,
+		"Halaman "+strconv.Itoa(w.page))
+}
+
+// ensure pindah ke halaman baru jika sisa ruang kurang dari height.
+func (w *recapWriter) ensure(height float64) bool {
+	if w.y+height <= w.doc.Height()-recapBottom {
+		return false
+	}
+	w.newPage()
+	return true
+}
+
+func (w *recapWriter) group(group journalRecapGroup) {
+	// Judul kelompok tidak boleh terpisah dari baris pertama tabelnya.
+	w.ensure(18 + 2*(recapLineHeight+2*recapCellPadding))
+	w.doc.Text(recapMargin, w.y+recapFontSize, pdf.HelveticaBold, 10,
+		fmt.Sprintf("%s - %s (%d pertemuan)", group.ClassName, group.SubjectName, len(group.Entries)))
+	w.y += 18
+	w.tableHeader()
+
+	for i, entry := range group.Entries {
+		topic := entry.Topic
+		if entry.Substitute {
+			topic = "[Guru pengganti] " + topic
+		}
+		cells := [][]string{
+			{strconv.Itoa(i + 1)},
+			{entry.Date.UTC().Format("02-01-2006")},
+			{entry.StartTime + "-" + entry.EndTime},
+			w.wrap(3, topic),
+			w.wrap(4, orDash(entry.Attendance)),
+		}
+		if entry.Notes != "" {
+			cells[3] = append(cells[3], w.wrap(3, "Catatan: "+entry.Notes)...)
+		}
+		lines := 1
+		for _, cell := range cells {
+			if len(cell) > lines {
+				lines = len(cell)
+			}
+		}
+		height := float64(lines)*recapLineHeight + 2*recapCellPadding
+		if w.ensure(height) {
+			w.tableHeader()
+		}
+		w.row(cells, height, false)
+	}
+	w.y += 14
+}
+
+func (w *recapWriter) tableHeader() {
+	cells := make([][]string, len(recapColumns))
+	for i, column := range recapColumns {
+		cells[i] = []string{column.Title}
+	}
+	w.row(cells, recapLineHeight+2*recapCellPadding, true)
+}
+
+// row menggambar satu baris tabel berisi teks yang sudah dipecah per baris.
+func (w *recapWriter) row(cells [][]string, height float64, header bool) {
+	font := pdf.Helvetica
+	if header {
+		font = pdf.HelveticaBold
+		w.doc.Rect(recapMargin, w.y, w.tableWidth(), height, 0, true, 0.9)
+	}
+	x := recapMargin
+	for i, column := range recapColumns {
+		w.doc.Rect(x, w.y, column.Width, height, 0.5, false, 0)
+		for j, line := range cells[i] {
+			w.doc.Text(x+recapCellPadding, w.y+recapCellPadding+recapFontSize+float64(j)*recapLineHeight, font, recapFontSize, line)
+		}
+		x += column.Width
+	}
+	w.y += height
+}
+
+func (w *recapWriter) tableWidth() float64 {
+	total := 0.0
+	for _, column := range recapColumns {
+		total += column.Width
+	}
+	return total
+}
+
+func (w *recapWriter) wrap(column int, text string) []string {
+	return pdf.WrapText(pdf.Helvetica, recapFontSize, recapColumns[column].Width-2*recapCellPadding, text)
+}
+
+// signatures menggambar blok tanda tangan kepala sekolah (kiri) dan guru (kanan).
+func (w *recapWriter) signatures(data journalRecapData) error {
+	w.ensure(recapSignatureBox)
+	w.y += 10
+	half := (w.doc.Width() - 2*recapMargin) / 2
+	blocks := []struct {
+		center float64
+		lines  []string
+		signer signatory
+	}{
+		{recapMargin + half/2, []string{"Mengetahui,", data.Principal.Title}, data.Principal},
+		{recapMargin + half + half/2, []string{data.PlaceDate, data.Teacher.Title}, data.Teacher},
+	}
+	for _, block := range blocks {
+		if err := w.signatureBlock(block.center, block.lines, block.signer); err != nil {
+			return err
+		}
+	}
+	w.y += recapSignatureBox
+	return nil
+}
+
+// signatureBlock menggambar satu blok tanda tangan berpusat di center mulai
+// dari posisi tulis saat ini tanpa memindahkannya.
+func (w *recapWriter) signatureBlock(center float64, lines []string, signer signatory) error {
+	y := w.y
+	for _, line := range lines {
+		w.doc.TextCenter(center, y, pdf.Helvetica, 10, line)
+		y += 13
+	}
+	if signer.Signature != nil {
+		if err := w.signatureImage(signer.Signature, center, y); err != nil {
+			return fmt.Errorf("invalid signature image for %s: %w", signer.Title, err)
+		}
+	}
+	y += recapSignatureH + 14
+
+	name := signer.Name
+	if name == "" {
+		name = "(.................................)"
+	}
+	w.doc.TextCenter(center, y, pdf.HelveticaBold, 10, name)
+	nameWidth := pdf.TextWidth(pdf.HelveticaBold, 10, name)
+	w.doc.Line(center-nameWidth/2, y+2, center+nameWidth/2, y+2, 0.6)
+	y += 13
+	if signer.NIP != "" {
+		w.doc.TextCenter(center, y, pdf.Helvetica, 10, "NIP. "+signer.NIP)
+	}
+	return nil
+}
+
+// signatureImage menempatkan gambar tanda tangan di tengah area tanda tangan
+// dengan rasio gambar tetap.
+func (w *recapWriter) signatureImage(image []byte, center, y float64) error {
+	size, err := pdf.DecodeImageSize(image)
+	if err != nil {
+		return err
+	}
+	width, height := recapSignatureW, recapSignatureH
+	if ratio := float64(size.Width) / float64(size.Height); ratio*height < width {
+		width = ratio * height
+	} else {
+		height = width / ratio
+	}
+	return w.doc.Image(image, center-width/2, y+(recapSignatureH-height)/2, width, height)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
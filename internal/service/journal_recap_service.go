@@ -0,0 +1,253 @@
+// internal/service/journal_recap_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// JournalRecapService menyusun rekap bulanan jurnal mengajar seorang guru
+// sebagai PDF yang ditandatangani guru dan kepala sekolah.
+type JournalRecapService struct {
+	db *db.PrismaClient
+}
+
+func NewJournalRecapService(db *db.PrismaClient) *JournalRecapService {
+	return &JournalRecapService{db: db}
+}
+
+// JournalRecap adalah file PDF rekap jurnal beserta nama file unduhannya.
+type JournalRecap struct {
+	FileName string
+	Content  []byte
+}
+
+// journalRecapEntry adalah satu jurnal dalam rekap.
+type journalRecapEntry struct {
+	Date       time.Time
+	StartTime  string
+	EndTime    string
+	Topic      string
+	Attendance string
+	Notes      string
+	Substitute bool // Jurnal ditulis sebagai guru pengganti
+}
+
+// journalRecapGroup adalah kumpulan jurnal untuk satu kelas dan satu mapel.
+type journalRecapGroup struct {
+	ClassName   string
+	SubjectName string
+	Entries     []journalRecapEntry
+}
+
+// signatory adalah pihak yang menandatangani rekap.
+type signatory struct {
+	Title     string
+	Name      string
+	NIP       string
+	Signature []byte // Gambar tanda tangan, nil jika tidak ada
+}
+
+// journalRecapData adalah seluruh isi dokumen rekap.
+type journalRecapData struct {
+	SchoolName string
+	Month      time.Time
+	Teacher    signatory
+	Principal  signatory
+	PlaceDate  string
+	Groups     []journalRecapGroup
+}
+
+// MonthlyRecap membuat PDF rekap jurnal mengajar seorang guru untuk satu bulan
+// (YYYY-MM), dikelompokkan per kelas dan mapel. Guru hanya dapat mengunduh
+// rekapnya sendiri; admin dan staf memilih guru lewat teacherID.
+func (s *JournalRecapService) MonthlyRecap(user *db.UserModel, teacherID int64, month string) (*JournalRecap, error) {
+	ctx := context.Background()
+	first, last, err := parseMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	var teacher *db.TeacherModel
+	if user.Role == db.UserRoleTeacher {
+		teacher, err = teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, err
+		}
+		if teacherID > 0 && db.BigInt(teacherID) != teacher.ID {
+			return nil, forbiddenError("you can only download your own journal recap")
+		}
+	} else {
+		if teacherID <= 0 {
+			return nil, validationError("teacher_id is required")
+		}
+		teacher, err = s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(teacherID))).Exec(ctx)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				return nil, notFoundError("teacher not found")
+			}
+			return nil, err
+		}
+	}
+
+	groups, err := s.recapGroups(ctx, teacher.ID, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	data := journalRecapData{
+		SchoolName: viper.GetString("SCHOOL_NAME"),
+		Month:      first,
+		Teacher: signatory{
+			Title:     "Guru Mata Pelajaran",
+			Name:      teacher.FullName,
+			Signature: readSignature(optionalValue(teacher.SignatureImagePath())),
+		},
+		Principal: signatory{
+			Title:     "Kepala Sekolah",
+			Name:      viper.GetString("PRINCIPAL_NAME"),
+			NIP:       viper.GetString("PRINCIPAL_NIP"),
+			Signature: readSignature(viper.GetString("PRINCIPAL_SIGNATURE_PATH")),
+		},
+		PlaceDate: formatLongDate(today()),
+		Groups:    groups,
+	}
+	if nip, ok := teacher.Nip(); ok {
+		data.Teacher.NIP = nip
+	}
+	if city := viper.GetString("SCHOOL_CITY"); city != "" {
+		data.PlaceDate = city + ", " + data.PlaceDate
+	}
+
+	content, err := renderJournalRecap(data)
+	if err != nil {
+		return nil, err
+	}
+	return &JournalRecap{
+		FileName: fmt.Sprintf("rekap-jurnal-%s-%s.pdf", fileNameSlug(teacher.FullName), first.Format(monthLayout)),
+		Content:  content,
+	}, nil
+}
+
+// recapGroups mengambil jurnal yang diajarkan guru pada rentang tanggal. Jurnal
+// jadwal guru tersebut yang ditulis guru pengganti menjadi milik guru pengganti.
+func (s *JournalRecapService) recapGroups(ctx context.Context, teacherID db.BigInt, first, last time.Time) ([]journalRecapGroup, error) {
+	journals, err := s.db.TeachingJournal.FindMany(
+		db.TeachingJournal.TeachingDate.Gte(first),
+		db.TeachingJournal.TeachingDate.Lte(last),
+		db.TeachingJournal.Or(
+			db.TeachingJournal.AuthorTeacherID.Equals(teacherID),
+			db.TeachingJournal.Schedule.Where(db.Schedule.TeacherID.Equals(teacherID)),
+		),
+	).With(
+		db.TeachingJournal.Schedule.Fetch().With(
+			db.Schedule.Class.Fetch(),
+			db.Schedule.Subject.Fetch(),
+		),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve teaching journals")
+	}
+
+	byKey := make(map[string]*journalRecapGroup)
+	for _, journal := range journals {
+		schedule := journal.Schedule()
+		authorID, hasAuthor := journal.AuthorTeacherID()
+		if hasAuthor && authorID != teacherID {
+			continue
+		}
+		className, subjectName := schedule.Class().ClassName, schedule.Subject().SubjectName
+		key := className + "\x00" + subjectName
+		group, ok := byKey[key]
+		if !ok {
+			group = &journalRecapGroup{ClassName: className, SubjectName: subjectName}
+			byKey[key] = group
+		}
+		group.Entries = append(group.Entries, journalRecapEntry{
+			Date:       journal.TeachingDate,
+			StartTime:  formatClock(schedule.StartTime),
+			EndTime:    formatClock(schedule.EndTime),
+			Topic:      journal.Topic,
+			Attendance: optionalValue(journal.StudentAttendanceSummary()),
+			Notes:      optionalValue(journal.Notes()),
+			Substitute: schedule.TeacherID != teacherID,
+		})
+	}
+
+	groups := make([]journalRecapGroup, 0, len(byKey))
+	for _, group := range byKey {
+		sort.Slice(group.Entries, func(i, j int) bool {
+			a, b := group.Entries[i], group.Entries[j]
+			if !a.Date.Equal(b.Date) {
+				return a.Date.Before(b.Date)
+			}
+			return a.StartTime < b.StartTime
+		})
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].ClassName != groups[j].ClassName {
+			return groups[i].ClassName < groups[j].ClassName
+		}
+		return groups[i].SubjectName < groups[j].SubjectName
+	})
+	return groups, nil
+}
+
+// optionalValue mengambil nilai kolom opsional, string kosong jika NULL.
+func optionalValue(value string, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return value
+}
+
+// readSignature membaca gambar tanda tangan. Path absolut dibaca dari disk
+// (misalnya PRINCIPAL_SIGNATURE_PATH), path relatif dari penyimpanan file
+// unggahan. Tanda tangan yang tidak ditemukan dibiarkan kosong agar rekap tetap
+// bisa dicetak dan ditandatangani basah.
+func readSignature(path string) []byte {
+	if path == "" {
+		return nil
+	}
+	if filepath.IsAbs(path) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		return data
+	}
+	data, err := readUpload(path)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// fileNameSlug mengubah nama menjadi potongan nama file, misalnya "budi-santoso".
+func fileNameSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,357 @@
+// internal/service/leave_request_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// LeaveRequestService mengelola pengajuan izin (sakit, izin, cuti, dinas luar)
+// beserta verifikasinya. Izin yang disetujui dipakai oleh presensi harian,
+// presensi per jam pelajaran dan daftar guru pengganti.
+type LeaveRequestService struct {
+	db *db.PrismaClient
+}
+
+func NewLeaveRequestService(db *db.PrismaClient) *LeaveRequestService {
+	return &LeaveRequestService{db: db}
+}
+
+// LeaveRequestInput adalah isi pengajuan izin.
+type LeaveRequestInput struct {
+	RequestType string // Sakit, Izin, Cuti atau DinasLuar
+	StartDate   string // YYYY-MM-DD
+	EndDate     string // YYYY-MM-DD
+	Reason      string
+}
+
+// LeaveRequestReview adalah keputusan verifikator atas satu pengajuan izin.
+type LeaveRequestReview struct {
+	Status          string // Approved atau Rejected
+	RejectionReason string // Wajib untuk Rejected
+}
+
+// LeaveRequestFilters adalah filter daftar pengajuan izin.
+type LeaveRequestFilters struct {
+	UserID      int64
+	Role        string
+	ClassID     int64
+	Status      string
+	RequestType string
+	From        string
+	To          string
+	Page        int
+	Limit       int
+}
+
+func parseLeaveType(value string) (db.LeaveType, error) {
+	switch db.LeaveType(value) {
+	case db.LeaveTypeSakit, db.LeaveTypeIzin, db.LeaveTypeCuti, db.LeaveTypeDinasLuar:
+		return db.LeaveType(value), nil
+	}
+	return "", validationError("invalid leave type %q, expected Sakit, Izin, Cuti or DinasLuar", value)
+}
+
+func parseLeaveStatus(value string) (db.LeaveStatus, error) {
+	switch db.LeaveStatus(value) {
+	case db.LeaveStatusPending, db.LeaveStatusApproved, db.LeaveStatusRejected, db.LeaveStatusCancelled:
+		return db.LeaveStatus(value), nil
+	}
+	return "", validationError("invalid leave status %q, expected Pending, Approved, Rejected or Cancelled", value)
+}
+
+// leaveRequestWhere menyusun filter umum daftar pengajuan izin. Rentang
+// tanggal mencocokkan izin yang beririsan dengan From-To.
+func leaveRequestWhere(filters LeaveRequestFilters) ([]db.LeaveRequestWhereParam, error) {
+	var where []db.LeaveRequestWhereParam
+	if filters.UserID > 0 {
+		where = append(where, db.LeaveRequest.UserID.Equals(db.BigInt(filters.UserID)))
+	}
+	if filters.Role != "" {
+		role := db.UserRole(filters.Role)
+		if role != db.UserRoleTeacher && role != db.UserRoleStaff && role != db.UserRoleStudent {
+			return nil, validationError("invalid role %q, expected teacher, staff or student", filters.Role)
+		}
+		where = append(where, db.LeaveRequest.Requestor.Where(db.User.Role.Equals(role)))
+	}
+	if filters.ClassID > 0 {
+		where = append(where, db.LeaveRequest.Requestor.Where(
+			db.User.Student.Where(db.Student.CurrentClassID.Equals(db.BigInt(filters.ClassID))),
+		))
+	}
+	if filters.Status != "" {
+		status, err := parseLeaveStatus(filters.Status)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.LeaveRequest.Status.Equals(status))
+	}
+	if filters.RequestType != "" {
+		requestType, err := parseLeaveType(filters.RequestType)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.LeaveRequest.RequestType.Equals(requestType))
+	}
+	if filters.From != "" {
+		from, err := parseDate(filters.From)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.LeaveRequest.EndDate.Gte(from))
+	}
+	if filters.To != "" {
+		to, err := parseDate(filters.To)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.LeaveRequest.StartDate.Lte(to))
+	}
+	return where, nil
+}
+
+func (s *LeaveRequestService) findLeaveRequests(ctx context.Context, where []db.LeaveRequestWhereParam, page, limit int) ([]db.LeaveRequestModel, int, error) {
+	all, err := s.db.LeaveRequest.FindMany(where...).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to count leave requests")
+	}
+	leaves, err := s.db.LeaveRequest.FindMany(where...).With(
+		db.LeaveRequest.Requestor.Fetch().With(
+			db.User.Teacher.Fetch(),
+			db.User.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+		),
+		db.LeaveRequest.Verifier.Fetch().With(db.User.Teacher.Fetch()),
+	).OrderBy(
+		db.LeaveRequest.StartDate.Order(db.SortOrderDesc),
+	).Skip((page - 1) * limit).Take(limit).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to retrieve leave requests")
+	}
+	return leaves, len(all), nil
+}
+
+// findLeaveRequest mengambil satu pengajuan izin beserta pengaju (dan kelasnya
+// untuk siswa) serta verifikatornya.
+func findLeaveRequest(ctx context.Context, client *db.PrismaClient, id int) (*db.LeaveRequestModel, error) {
+	leave, err := client.LeaveRequest.FindUnique(db.LeaveRequest.ID.Equals(db.BigInt(id))).With(
+		db.LeaveRequest.Requestor.Fetch().With(
+			db.User.Teacher.Fetch(),
+			db.User.Student.Fetch().With(db.Student.CurrentClass.Fetch()),
+		),
+		db.LeaveRequest.Verifier.Fetch().With(db.User.Teacher.Fetch()),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("leave request not found")
+		}
+		return nil, err
+	}
+	return leave, nil
+}
+
+// checkLeaveVerifier memastikan user boleh memverifikasi pengajuan izin. Admin
+// dan staf memverifikasi semua pengajuan, wali kelas memverifikasi izin siswa
+// di kelas perwaliannya. Tidak ada yang boleh memverifikasi izinnya sendiri.
+// leave harus dimuat bersama pengaju dan kelasnya.
+func checkLeaveVerifier(ctx context.Context, client *db.PrismaClient, user *db.UserModel, leave *db.LeaveRequestModel) error {
+	if leave.UserID == user.ID {
+		return forbiddenError("you cannot verify your own leave request")
+	}
+	switch user.Role {
+	case db.UserRoleAdmin, db.UserRoleStaff:
+		return nil
+	case db.UserRoleTeacher:
+		teacher, err := teacherOfUser(ctx, client, int(user.ID))
+		if err != nil {
+			return err
+		}
+		if student, ok := leave.Requestor().Student(); ok {
+			if class, ok := student.CurrentClass(); ok {
+				if homeroomID, ok := class.HomeroomTeacherID(); ok && homeroomID == teacher.ID {
+					return nil
+				}
+			}
+		}
+		return forbiddenError("only the homeroom teacher can verify this leave request")
+	}
+	return forbiddenError("you are not allowed to verify leave requests")
+}
+
+// checkLeaveAccess memastikan user boleh melihat pengajuan izin: pengajunya
+// sendiri atau verifikator yang berwenang.
+func checkLeaveAccess(ctx context.Context, client *db.PrismaClient, user *db.UserModel, leave *db.LeaveRequestModel) error {
+	if leave.UserID == user.ID {
+		return nil
+	}
+	if err := checkLeaveVerifier(ctx, client, user, leave); err != nil {
+		if errors.Is(err, ErrForbidden) {
+			return forbiddenError("you can only view your own leave requests")
+		}
+		return err
+	}
+	return nil
+}
+
+// GetLeaveRequests mengambil daftar pengajuan izin untuk verifikator, terbaru
+// lebih dulu. Guru hanya melihat izin siswa di kelas perwaliannya.
+func (s *LeaveRequestService) GetLeaveRequests(user *db.UserModel, filters LeaveRequestFilters) ([]db.LeaveRequestModel, int, error) {
+	ctx := context.Background()
+	where, err := leaveRequestWhere(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.LeaveRequest.Requestor.Where(
+			db.User.Student.Where(db.Student.CurrentClass.Where(db.Class.HomeroomTeacherID.Equals(teacher.ID))),
+		))
+	}
+	return s.findLeaveRequests(ctx, where, filters.Page, filters.Limit)
+}
+
+// GetMyLeaveRequests mengambil pengajuan izin milik user yang sedang login.
+func (s *LeaveRequestService) GetMyLeaveRequests(userID int, filters LeaveRequestFilters) ([]db.LeaveRequestModel, int, error) {
+	filters.UserID = int64(userID)
+	filters.Role = ""
+	filters.ClassID = 0
+	where, err := leaveRequestWhere(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.findLeaveRequests(context.Background(), where, filters.Page, filters.Limit)
+}
+
+// GetLeaveRequestByID mengambil satu pengajuan izin untuk pengaju atau verifikatornya.
+func (s *LeaveRequestService) GetLeaveRequestByID(user *db.UserModel, id int) (*db.LeaveRequestModel, error) {
+	ctx := context.Background()
+	leave, err := findLeaveRequest(ctx, s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLeaveAccess(ctx, s.db, user, leave); err != nil {
+		return nil, err
+	}
+	return leave, nil
+}
+
+// SubmitLeaveRequest menyimpan pengajuan izin user yang sedang login dengan
+// status Pending. Siswa hanya dapat mengajukan Sakit atau Izin. Pengajuan
+// ditolak jika beririsan dengan izin lain yang masih Pending atau sudah
+// disetujui.
+func (s *LeaveRequestService) SubmitLeaveRequest(user *db.UserModel, input LeaveRequestInput) (*db.LeaveRequestModel, error) {
+	ctx := context.Background()
+	requestType, err := parseLeaveType(input.RequestType)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == db.UserRoleStudent && requestType != db.LeaveTypeSakit && requestType != db.LeaveTypeIzin {
+		return nil, validationError("students can only request Sakit or Izin")
+	}
+	start, end, err := parseDateRange(input.StartDate, input.EndDate, 0)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, validationError("reason is required")
+	}
+
+	overlapping, err := s.db.LeaveRequest.FindFirst(
+		db.LeaveRequest.UserID.Equals(user.ID),
+		db.LeaveRequest.Status.In([]db.LeaveStatus{db.LeaveStatusPending, db.LeaveStatusApproved}),
+		db.LeaveRequest.StartDate.Lte(end),
+		db.LeaveRequest.EndDate.Gte(start),
+	).Exec(ctx)
+	if err == nil {
+		return nil, conflictError("leave request overlaps with your %s request for %s to %s",
+			strings.ToLower(string(overlapping.Status)), formatDate(overlapping.StartDate), formatDate(overlapping.EndDate))
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	leave, err := s.db.LeaveRequest.CreateOne(
+		db.LeaveRequest.RequestType.Set(requestType),
+		db.LeaveRequest.StartDate.Set(start),
+		db.LeaveRequest.EndDate.Set(end),
+		db.LeaveRequest.Reason.Set(reason),
+		db.LeaveRequest.Requestor.Link(db.User.ID.Equals(user.ID)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to create leave request")
+	}
+	return findLeaveRequest(ctx, s.db, int(leave.ID))
+}
+
+// CancelLeaveRequest membatalkan pengajuan izin milik user yang masih Pending.
+// Pengajuan yang dibatalkan tetap tersimpan sebagai riwayat.
+func (s *LeaveRequestService) CancelLeaveRequest(user *db.UserModel, id int) (*db.LeaveRequestModel, error) {
+	ctx := context.Background()
+	leave, err := findLeaveRequest(ctx, s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	if leave.UserID != user.ID {
+		return nil, forbiddenError("you can only cancel your own leave requests")
+	}
+	if leave.Status != db.LeaveStatusPending {
+		return nil, conflictError("leave request is already %s", leave.Status)
+	}
+
+	_, err = s.db.LeaveRequest.FindUnique(db.LeaveRequest.ID.Equals(leave.ID)).Update(
+		db.LeaveRequest.Status.Set(db.LeaveStatusCancelled),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to cancel leave request")
+	}
+	return findLeaveRequest(ctx, s.db, id)
+}
+
+// ReviewLeaveRequest menyetujui atau menolak pengajuan izin Pending dan
+// mencatat verifikator beserta waktu verifikasinya. Alasan wajib diisi untuk
+// penolakan.
+func (s *LeaveRequestService) ReviewLeaveRequest(user *db.UserModel, id int, review LeaveRequestReview) (*db.LeaveRequestModel, error) {
+	ctx := context.Background()
+	status, err := parseLeaveStatus(review.Status)
+	if err != nil {
+		return nil, err
+	}
+	if status != db.LeaveStatusApproved && status != db.LeaveStatusRejected {
+		return nil, validationError("status must be Approved or Rejected")
+	}
+	var rejectionReason *string
+	if status == db.LeaveStatusRejected {
+		if rejectionReason = optionalString(review.RejectionReason); rejectionReason == nil {
+			return nil, validationError("rejection reason is required to reject a leave request")
+		}
+	}
+
+	leave, err := findLeaveRequest(ctx, s.db, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLeaveVerifier(ctx, s.db, user, leave); err != nil {
+		return nil, err
+	}
+	if leave.Status != db.LeaveStatusPending {
+		return nil, conflictError("leave request is already %s", leave.Status)
+	}
+
+	_, err = s.db.LeaveRequest.FindUnique(db.LeaveRequest.ID.Equals(leave.ID)).Update(
+		db.LeaveRequest.Status.Set(status),
+		db.LeaveRequest.Verifier.Link(db.User.ID.Equals(user.ID)),
+		db.LeaveRequest.VerifiedAt.Set(time.Now()),
+		db.LeaveRequest.RejectionReason.SetOptional(rejectionReason),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to review leave request")
+	}
+	return findLeaveRequest(ctx, s.db, id)
+}
@@ -0,0 +1,278 @@
+// internal/service/lesson_attendance.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// lessonAttendanceStatuses adalah urutan status kehadiran di ringkasan jurnal.
+var lessonAttendanceStatuses = []db.LessonAttendanceStatus{
+	db.LessonAttendanceStatusHadir,
+	db.LessonAttendanceStatusSakit,
+	db.LessonAttendanceStatusIzin,
+	db.LessonAttendanceStatusAlpa,
+}
+
+// StudentAttendanceInput adalah status kehadiran seorang siswa yang diisi guru.
+type StudentAttendanceInput struct {
+	StudentID int64
+	Status    string
+	Notes     string
+}
+
+// RosterEntry adalah satu siswa pada daftar hadir sebuah jam pelajaran.
+type RosterEntry struct {
+	StudentID      int64  `json:"student_id" example:"21"`
+	NIS            string `json:"nis" example:"2324001"`
+	FullName       string `json:"full_name" example:"Ahmad Fauzi"`
+	Status         string `json:"status" example:"Sakit"`
+	Notes          string `json:"notes,omitempty" example:"Demam"`
+	LeaveRequestID *int64 `json:"leave_request_id,omitempty" example:"7"` // Izin disetujui yang menjadi dasar status
+}
+
+// LessonAttendanceFilters adalah filter untuk riwayat kehadiran siswa per jam pelajaran.
+type LessonAttendanceFilters struct {
+	StudentID int64
+	ClassID   int64
+	Status    string
+	From      string
+	To        string
+
+	studentID db.BigInt // diisi dari siswa yang login
+}
+
+func parseLessonAttendanceStatus(value string) (db.LessonAttendanceStatus, error) {
+	for _, status := range lessonAttendanceStatuses {
+		if strings.EqualFold(value, string(status)) {
+			return status, nil
+		}
+	}
+	return "", validationError("invalid attendance status %q, expected Hadir, Sakit, Izin or Alpa", value)
+}
+
+// leaveAttendanceStatus memetakan jenis izin ke status kehadiran jam pelajaran.
+func leaveAttendanceStatus(leaveType db.LeaveType) db.LessonAttendanceStatus {
+	if leaveType == db.LeaveTypeSakit {
+		return db.LessonAttendanceStatusSakit
+	}
+	return db.LessonAttendanceStatusIzin
+}
+
+// attendanceSummary menurunkan teks ringkasan kehadiran, misalnya
+// "Hadir 30, Sakit 1, Izin 1, Alpa 0". Nil jika tidak ada siswa.
+func attendanceSummary(entries []RosterEntry) *string {
+	if len(entries) == 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		counts[entry.Status]++
+	}
+	parts := make([]string, 0, len(lessonAttendanceStatuses))
+	for _, status := range lessonAttendanceStatuses {
+		parts = append(parts, fmt.Sprintf("%s %d", status, counts[string(status)]))
+	}
+	summary := strings.Join(parts, ", ")
+	return &summary
+}
+
+// classRoster mengambil siswa yang tercatat sebagai anggota kelas pada tanggal
+// tersebut berdasarkan riwayat kelas, diurutkan menurut nama.
+func classRoster(ctx context.Context, client *db.PrismaClient, classID db.BigInt, date time.Time) ([]db.StudentModel, error) {
+	histories, err := client.StudentClassHistory.FindMany(
+		db.StudentClassHistory.ClassID.Equals(classID),
+		db.StudentClassHistory.StartDate.Lte(date),
+		db.StudentClassHistory.Or(
+			db.StudentClassHistory.EndDate.IsNull(),
+			db.StudentClassHistory.EndDate.Gt(date),
+		),
+	).With(
+		db.StudentClassHistory.Student.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve class members")
+	}
+
+	seen := make(map[db.BigInt]bool)
+	students := make([]db.StudentModel, 0, len(histories))
+	for _, history := range histories {
+		if seen[history.StudentID] {
+			continue
+		}
+		seen[history.StudentID] = true
+		students = append(students, *history.Student())
+	}
+	sort.Slice(students, func(i, j int) bool {
+		return students[i].FullName < students[j].FullName
+	})
+	return students, nil
+}
+
+// buildRoster menyusun daftar hadir sebuah jam pelajaran. Siswa dengan izin
+// yang disetujui pada tanggal tersebut otomatis berstatus Sakit atau Izin,
+// sisanya Hadir. Status yang sudah tersimpan di jurnal menimpa isian otomatis.
+func buildRoster(ctx context.Context, client *db.PrismaClient, classID db.BigInt, date time.Time, saved []db.StudentLessonAttendanceModel) ([]RosterEntry, error) {
+	students, err := classRoster(ctx, client, classID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]db.BigInt, 0, len(students))
+	for _, student := range students {
+		userIDs = append(userIDs, student.UserID)
+	}
+	leaves, err := client.LeaveRequest.FindMany(
+		db.LeaveRequest.UserID.In(userIDs),
+		db.LeaveRequest.Status.Equals(db.LeaveStatusApproved),
+		db.LeaveRequest.StartDate.Lte(date),
+		db.LeaveRequest.EndDate.Gte(date),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve leave requests")
+	}
+	leaveOf := make(map[db.BigInt]db.LeaveRequestModel)
+	for _, leave := range leaves {
+		leaveOf[leave.UserID] = leave
+	}
+	savedOf := make(map[db.BigInt]db.StudentLessonAttendanceModel)
+	for _, attendance := range saved {
+		savedOf[attendance.StudentID] = attendance
+	}
+
+	roster := make([]RosterEntry, 0, len(students))
+	for _, student := range students {
+		entry := RosterEntry{
+			StudentID: int64(student.ID),
+			NIS:       student.Nis,
+			FullName:  student.FullName,
+			Status:    string(db.LessonAttendanceStatusHadir),
+		}
+		if leave, ok := leaveOf[student.UserID]; ok {
+			id := int64(leave.ID)
+			entry.Status = string(leaveAttendanceStatus(leave.RequestType))
+			entry.LeaveRequestID = &id
+		}
+		if attendance, ok := savedOf[student.ID]; ok {
+			entry.Status = string(attendance.Status)
+			entry.Notes, _ = attendance.Notes()
+			delete(savedOf, student.ID)
+		}
+		roster = append(roster, entry)
+	}
+	// Siswa yang sudah tercatat di jurnal tetapi kini keluar dari kelas tetap ditampilkan.
+	for _, attendance := range saved {
+		if _, ok := savedOf[attendance.StudentID]; !ok {
+			continue
+		}
+		entry := RosterEntry{
+			StudentID: int64(attendance.StudentID),
+			Status:    string(attendance.Status),
+		}
+		entry.Notes, _ = attendance.Notes()
+		if attendance.RelationsStudentLessonAttendance.Student != nil {
+			entry.NIS = attendance.Student().Nis
+			entry.FullName = attendance.Student().FullName
+		}
+		if leaveID, ok := attendance.LeaveRequestID(); ok {
+			id := int64(leaveID)
+			entry.LeaveRequestID = &id
+		}
+		roster = append(roster, entry)
+	}
+	return roster, nil
+}
+
+// applyAttendanceInputs menimpa daftar hadir dengan isian guru. Siswa yang
+// tidak ada di daftar hadir kelas ditolak.
+func applyAttendanceInputs(roster []RosterEntry, inputs []StudentAttendanceInput) ([]RosterEntry, error) {
+	index := make(map[int64]int, len(roster))
+	for i, entry := range roster {
+		index[entry.StudentID] = i
+	}
+	for _, input := range inputs {
+		i, ok := index[input.StudentID]
+		if !ok {
+			return nil, validationError("student %d is not a member of this class", input.StudentID)
+		}
+		status, err := parseLessonAttendanceStatus(input.Status)
+		if err != nil {
+			return nil, err
+		}
+		roster[i].Status = string(status)
+		roster[i].Notes = strings.TrimSpace(input.Notes)
+	}
+	return roster, nil
+}
+
+// attendanceCreateTxs menyusun query transaksi untuk mencatat daftar hadir
+// jurnal baru. Jurnal dirujuk lewat jadwal dan tanggal agar bisa dibuat dalam
+// transaksi yang sama.
+func attendanceCreateTxs(client *db.PrismaClient, scheduleID db.BigInt, date time.Time, roster []RosterEntry) []transaction.Param {
+	journal := db.TeachingJournal.ScheduleDateUnique(
+		db.TeachingJournal.ScheduleID.Equals(scheduleID),
+		db.TeachingJournal.TeachingDate.Equals(date),
+	)
+	txs := make([]transaction.Param, 0, len(roster))
+	for _, entry := range roster {
+		txs = append(txs, client.StudentLessonAttendance.CreateOne(
+			db.StudentLessonAttendance.Journal.Link(journal),
+			db.StudentLessonAttendance.Student.Link(db.Student.ID.Equals(db.BigInt(entry.StudentID))),
+			attendanceOptionals(entry)...,
+		).Tx())
+	}
+	return txs
+}
+
+// attendanceUpsertTxs menyusun query transaksi untuk menyimpan ulang daftar
+// hadir jurnal yang sudah ada.
+func attendanceUpsertTxs(client *db.PrismaClient, journalID db.BigInt, roster []RosterEntry) []transaction.Param {
+	txs := make([]transaction.Param, 0, len(roster))
+	for _, entry := range roster {
+		txs = append(txs, client.StudentLessonAttendance.UpsertOne(
+			db.StudentLessonAttendance.JournalStudentUnique(
+				db.StudentLessonAttendance.JournalID.Equals(journalID),
+				db.StudentLessonAttendance.StudentID.Equals(db.BigInt(entry.StudentID)),
+			),
+		).Create(
+			db.StudentLessonAttendance.Journal.Link(db.TeachingJournal.ID.Equals(journalID)),
+			db.StudentLessonAttendance.Student.Link(db.Student.ID.Equals(db.BigInt(entry.StudentID))),
+			attendanceOptionals(entry)...,
+		).Update(
+			db.StudentLessonAttendance.Status.Set(db.LessonAttendanceStatus(entry.Status)),
+			db.StudentLessonAttendance.Notes.SetOptional(optionalString(entry.Notes)),
+		).Tx())
+	}
+	return txs
+}
+
+func attendanceOptionals(entry RosterEntry) []db.StudentLessonAttendanceSetParam {
+	optional := []db.StudentLessonAttendanceSetParam{
+		db.StudentLessonAttendance.Status.Set(db.LessonAttendanceStatus(entry.Status)),
+		db.StudentLessonAttendance.Notes.SetIfPresent(optionalString(entry.Notes)),
+	}
+	if entry.LeaveRequestID != nil {
+		optional = append(optional, db.StudentLessonAttendance.LeaveRequest.Link(db.LeaveRequest.ID.Equals(db.BigInt(*entry.LeaveRequestID))))
+	}
+	return optional
+}
+
+// studentOfUser mengambil profil siswa milik sebuah user.
+func studentOfUser(ctx context.Context, client *db.PrismaClient, userID int) (*db.StudentModel, error) {
+	student, err := client.Student.FindUnique(db.Student.UserID.Equals(db.BigInt(userID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, forbiddenError("only students can access this resource")
+		}
+		return nil, err
+	}
+	return student, nil
+}
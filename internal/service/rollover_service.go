@@ -0,0 +1,343 @@
+// internal/service/rollover_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// Hasil kenaikan kelas per siswa, sama dengan enum PromotionResult di schema.
+const (
+	PromotionNaik    = "NAIK"
+	PromotionTinggal = "TINGGAL"
+	PromotionLulus   = "LULUS"
+)
+
+type RolloverService struct {
+	db *db.PrismaClient
+}
+
+func NewRolloverService(db *db.PrismaClient) *RolloverService {
+	return &RolloverService{db: db}
+}
+
+// RolloverParams adalah input wizard kenaikan kelas.
+type RolloverParams struct {
+	SourceAcademicYear string
+	TargetAcademicYear string           // Kosong berarti tahun ajaran berikutnya
+	ClassOverrides     map[int64]string // ID kelas asal -> nama kelas tujuan
+	Exceptions         []RolloverException
+}
+
+// RolloverException mengecualikan satu siswa dari aturan kenaikan default.
+type RolloverException struct {
+	StudentID       int64
+	Result          string // NAIK, TINGGAL, atau LULUS
+	TargetClassName string // Opsional, menimpa kelas tujuan default
+}
+
+// RolloverPlan adalah hasil preview sebelum kenaikan kelas diterapkan.
+type RolloverPlan struct {
+	SourceAcademicYear string                `json:"source_academic_year" example:"2024/2025"`
+	TargetAcademicYear string                `json:"target_academic_year" example:"2025/2026"`
+	Classes            []RolloverClassPlan   `json:"classes"`
+	Students           []RolloverStudentPlan `json:"students"`
+	Summary            RolloverSummary       `json:"summary"`
+}
+
+// RolloverClassPlan adalah kelas tujuan pada tahun ajaran baru.
+type RolloverClassPlan struct {
+	ClassName       string  `json:"class_name" example:"XI RPL 1"`
+	GradeLevel      string  `json:"grade_level" example:"XI"`
+	Major           *string `json:"major,omitempty" example:"RPL"`
+	ExistingClassID *int64  `json:"existing_class_id,omitempty" example:"12"`
+	SourceClassIDs  []int64 `json:"source_class_ids"`
+	StudentCount    int     `json:"student_count" example:"32"`
+}
+
+// RolloverStudentPlan adalah rencana perpindahan satu siswa.
+type RolloverStudentPlan struct {
+	StudentID       int64  `json:"student_id" example:"1"`
+	Nis             string `json:"nis" example:"2024001"`
+	FullName        string `json:"full_name" example:"Siti Nurhaliza"`
+	SourceClassID   int64  `json:"source_class_id" example:"3"`
+	SourceClassName string `json:"source_class_name" example:"X RPL 1"`
+	Result          string `json:"result" example:"NAIK"`
+	TargetClassName string `json:"target_class_name,omitempty" example:"XI RPL 1"`
+	IsException     bool   `json:"is_exception" example:"false"`
+}
+
+// RolloverSummary merangkum jumlah siswa per hasil kenaikan.
+type RolloverSummary struct {
+	Promoted   int `json:"promoted" example:"180"`
+	Repeating  int `json:"repeating" example:"2"`
+	Graduating int `json:"graduating" example:"95"`
+	NewClasses int `json:"new_classes" example:"6"`
+}
+
+// Preview menyusun rencana kenaikan kelas tanpa mengubah data.
+func (s *RolloverService) Preview(params RolloverParams) (*RolloverPlan, error) {
+	return s.buildPlan(context.Background(), params)
+}
+
+// Apply menerapkan rencana kenaikan kelas dalam satu transaksi. Kelas lama
+// tidak diubah, setiap perpindahan dicatat di tabel student_promotions dan
+// riwayat kelas siswa ikut ditutup/dibuka sehingga keanggotaan kelas tahun
+// sebelumnya tetap bisa ditelusuri.
+func (s *RolloverService) Apply(params RolloverParams) (*RolloverPlan, error) {
+	ctx := context.Background()
+	plan, err := s.buildPlan(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []transaction.Param
+	for _, class := range plan.Classes {
+		if class.ExistingClassID != nil {
+			continue
+		}
+		txs = append(txs, s.db.Class.CreateOne(
+			db.Class.ClassName.Set(class.ClassName),
+			db.Class.GradeLevel.Set(class.GradeLevel),
+			db.Class.AcademicYear.Set(plan.TargetAcademicYear),
+			db.Class.Major.SetIfPresent(class.Major),
+		).Tx())
+	}
+
+	date := today()
+	for _, student := range plan.Students {
+		studentID := db.BigInt(student.StudentID)
+		promotion := []db.StudentPromotionSetParam{
+			db.StudentPromotion.FromClass.Link(db.Class.ID.Equals(db.BigInt(student.SourceClassID))),
+		}
+
+		if student.Result == PromotionLulus {
+			txs = append(txs, s.db.Student.FindUnique(db.Student.ID.Equals(studentID)).Update(
+				db.Student.Status.Set(db.StudentStatusLulus),
+			).Tx())
+			txs = append(txs, closeClassHistoryTx(s.db, studentID, date))
+		} else {
+			target := targetClassUnique(student.TargetClassName, plan.TargetAcademicYear)
+			txs = append(txs, classHistoryTxs(s.db, studentID, target, plan.TargetAcademicYear, date)...)
+			promotion = append(promotion, db.StudentPromotion.ToClass.Link(target))
+		}
+
+		txs = append(txs, s.db.StudentPromotion.CreateOne(
+			db.StudentPromotion.FromAcademicYear.Set(plan.SourceAcademicYear),
+			db.StudentPromotion.ToAcademicYear.Set(plan.TargetAcademicYear),
+			db.StudentPromotion.Result.Set(db.PromotionResult(student.Result)),
+			db.StudentPromotion.Student.Link(db.Student.ID.Equals(studentID)),
+			promotion...,
+		).Tx())
+	}
+
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to apply academic year rollover")
+	}
+	return plan, nil
+}
+
+// targetClassUnique mencari kelas tujuan lewat kombinasi unik nama kelas dan
+// tahun ajaran, karena ID kelas baru belum diketahui saat transaksi disusun.
+func targetClassUnique(className, academicYear string) db.ClassEqualsUniqueWhereParam {
+	return db.Class.ClassNameAcademicYearUnique(
+		db.Class.ClassName.Equals(className),
+		db.Class.AcademicYear.Equals(academicYear),
+	)
+}
+
+// buildPlan memuat kelas tahun asal beserta siswa aktifnya lalu menentukan
+// kelas tujuan setiap siswa.
+func (s *RolloverService) buildPlan(ctx context.Context, params RolloverParams) (*RolloverPlan, error) {
+	if _, _, err := parseAcademicYear(params.SourceAcademicYear); err != nil {
+		return nil, err
+	}
+	target := params.TargetAcademicYear
+	if target == "" {
+		next, err := nextAcademicYear(params.SourceAcademicYear)
+		if err != nil {
+			return nil, err
+		}
+		target = next
+	} else if _, _, err := parseAcademicYear(target); err != nil {
+		return nil, err
+	}
+	if target == params.SourceAcademicYear {
+		return nil, validationError("target academic year must differ from the source academic year")
+	}
+
+	_, err := s.db.StudentPromotion.FindFirst(
+		db.StudentPromotion.FromAcademicYear.Equals(params.SourceAcademicYear),
+	).Exec(ctx)
+	if err == nil {
+		return nil, conflictError("rollover for academic year %s has already been applied", params.SourceAcademicYear)
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	sourceClasses, err := s.db.Class.FindMany(
+		db.Class.AcademicYear.Equals(params.SourceAcademicYear),
+	).With(
+		db.Class.Students.Fetch(db.Student.Status.Equals(db.StudentStatusAktif)),
+	).OrderBy(
+		db.Class.ClassName.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve source classes")
+	}
+	if len(sourceClasses) == 0 {
+		return nil, notFoundError("no classes found for academic year %s", params.SourceAcademicYear)
+	}
+
+	targetClasses, err := s.db.Class.FindMany(db.Class.AcademicYear.Equals(target)).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve target classes")
+	}
+	existing := make(map[string]db.ClassModel, len(targetClasses))
+	for _, class := range targetClasses {
+		existing[class.ClassName] = class
+	}
+
+	exceptions := make(map[int64]RolloverException, len(params.Exceptions))
+	for _, e := range params.Exceptions {
+		if e.Result != PromotionNaik && e.Result != PromotionTinggal && e.Result != PromotionLulus {
+			return nil, validationError("invalid rollover result %q for student %d", e.Result, e.StudentID)
+		}
+		exceptions[e.StudentID] = e
+	}
+
+	plan := &RolloverPlan{SourceAcademicYear: params.SourceAcademicYear, TargetAcademicYear: target}
+	classPlans := map[string]*RolloverClassPlan{}
+	addToClass := func(name, grade string, major *string, sourceClassID int64) error {
+		cp, ok := classPlans[name]
+		if !ok {
+			cp = &RolloverClassPlan{ClassName: name, GradeLevel: grade, Major: major}
+			if class, found := existing[name]; found {
+				id := int64(class.ID)
+				cp.ExistingClassID = &id
+				cp.GradeLevel = class.GradeLevel
+				if m, ok := class.Major(); ok {
+					cp.Major = &m
+				}
+			}
+			classPlans[name] = cp
+		}
+		if !sameGradeLevel(cp.GradeLevel, grade) {
+			return validationError("class %s would mix grade levels %s and %s", name, cp.GradeLevel, grade)
+		}
+		cp.StudentCount++
+		for _, id := range cp.SourceClassIDs {
+			if id == sourceClassID {
+				return nil
+			}
+		}
+		cp.SourceClassIDs = append(cp.SourceClassIDs, sourceClassID)
+		return nil
+	}
+
+	seen := map[int64]bool{}
+	for _, class := range sourceClasses {
+		classID := int64(class.ID)
+		var major *string
+		if m, ok := class.Major(); ok {
+			major = &m
+		}
+
+		nextGrade, final, err := nextGradeLevel(class.GradeLevel)
+		if err != nil {
+			return nil, validationError("class %s: %s", class.ClassName, err.Error())
+		}
+		promotedName := ""
+		if !final {
+			if name, ok := params.ClassOverrides[classID]; ok && name != "" {
+				promotedName = name
+			} else if name, ok := promotedClassName(class.ClassName, class.GradeLevel, nextGrade); ok {
+				promotedName = name
+			}
+		}
+
+		for _, student := range class.Students() {
+			studentID := int64(student.ID)
+			seen[studentID] = true
+			sp := RolloverStudentPlan{
+				StudentID:       studentID,
+				Nis:             student.Nis,
+				FullName:        student.FullName,
+				SourceClassID:   classID,
+				SourceClassName: class.ClassName,
+			}
+
+			result := PromotionNaik
+			if final {
+				result = PromotionLulus
+			}
+			targetName := ""
+			if e, ok := exceptions[studentID]; ok {
+				sp.IsException = true
+				result = e.Result
+				targetName = e.TargetClassName
+			}
+
+			grade := nextGrade
+			switch result {
+			case PromotionNaik:
+				if final {
+					return nil, validationError("student %s is in the final grade and cannot be promoted", student.FullName)
+				}
+				if targetName == "" {
+					targetName = promotedName
+				}
+				if targetName == "" {
+					return nil, validationError("cannot derive the next class name for %s, provide a class override", class.ClassName)
+				}
+			case PromotionTinggal:
+				grade = class.GradeLevel
+				if targetName == "" {
+					targetName = class.ClassName
+				}
+			case PromotionLulus:
+				targetName = ""
+			}
+
+			sp.Result = result
+			sp.TargetClassName = targetName
+			if targetName != "" {
+				if err := addToClass(targetName, grade, major, classID); err != nil {
+					return nil, err
+				}
+			}
+			plan.Students = append(plan.Students, sp)
+
+			switch result {
+			case PromotionNaik:
+				plan.Summary.Promoted++
+			case PromotionTinggal:
+				plan.Summary.Repeating++
+			case PromotionLulus:
+				plan.Summary.Graduating++
+			}
+		}
+	}
+
+	for _, e := range params.Exceptions {
+		if !seen[e.StudentID] {
+			return nil, validationError("student %d is not an active student of academic year %s", e.StudentID, params.SourceAcademicYear)
+		}
+	}
+
+	for _, cp := range classPlans {
+		if cp.ExistingClassID == nil {
+			plan.Summary.NewClasses++
+		}
+		plan.Classes = append(plan.Classes, *cp)
+	}
+	sort.Slice(plan.Classes, func(i, j int) bool { return plan.Classes[i].ClassName < plan.Classes[j].ClassName })
+
+	return plan, nil
+}
@@ -0,0 +1,568 @@
+// internal/service/schedule_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type ScheduleService struct {
+	db *db.PrismaClient
+}
+
+func NewScheduleService(db *db.PrismaClient) *ScheduleService {
+	return &ScheduleService{db: db}
+}
+
+// ScheduleInput adalah data satu slot jadwal dari request.
+type ScheduleInput struct {
+	ID        int64 // Hanya dipakai bulk upsert, 0 berarti slot baru
+	ClassID   int64
+	SubjectID int64
+	TeacherID int64
+	DayOfWeek string
+	StartTime string // HH:MM
+	EndTime   string // HH:MM
+	Room      string
+}
+
+// ScheduleFilters adalah filter untuk daftar jadwal.
+type ScheduleFilters struct {
+	ClassID      int64
+	TeacherID    int64
+	SubjectID    int64
+	DayOfWeek    string
+	Room         string
+	AcademicYear string
+}
+
+// ScheduleConflict menjelaskan satu slot yang bentrok dengan slot yang diajukan.
+type ScheduleConflict struct {
+	Reason      string `json:"reason" example:"teacher"` // teacher, class, atau room
+	EntryIndex  int    `json:"entry_index" example:"0"`  // Indeks slot pada request yang bentrok
+	ScheduleID  int64  `json:"schedule_id,omitempty" example:"12"`
+	OtherIndex  *int   `json:"other_entry_index,omitempty" example:"3"` // Diisi jika bentrok dengan slot lain di request yang sama
+	ClassID     int64  `json:"class_id" example:"3"`
+	ClassName   string `json:"class_name,omitempty" example:"X RPL 1"`
+	SubjectID   int64  `json:"subject_id" example:"1"`
+	TeacherID   int64  `json:"teacher_id" example:"2"`
+	TeacherName string `json:"teacher_name,omitempty" example:"Budi Santoso, S.Pd"`
+	DayOfWeek   string `json:"day_of_week" example:"Senin"`
+	StartTime   string `json:"start_time" example:"07:00"`
+	EndTime     string `json:"end_time" example:"08:30"`
+	Room        string `json:"room,omitempty" example:"Lab RPL 1"`
+}
+
+// ScheduleConflictError dikembalikan jika slot bentrok dengan jadwal guru, kelas, atau ruangan lain.
+type ScheduleConflictError struct {
+	Conflicts []ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("schedule conflicts with %d other entries", len(e.Conflicts))
+}
+
+func (e *ScheduleConflictError) Unwrap() error { return ErrConflict }
+
+func (e *ScheduleConflictError) Details() interface{} { return e.Conflicts }
+
+// plannedSchedule adalah slot yang sudah divalidasi dan siap disimpan.
+type plannedSchedule struct {
+	id        db.BigInt
+	classID   db.BigInt
+	subjectID db.BigInt
+	teacherID db.BigInt
+	day       db.DayOfWeek
+	start     time.Time
+	end       time.Time
+	room      string
+}
+
+func (p plannedSchedule) overlaps(day db.DayOfWeek, start, end time.Time) bool {
+	return p.day == day && minutesOfDay(p.start) < minutesOfDay(end) && minutesOfDay(start) < minutesOfDay(p.end)
+}
+
+// conflictReason mengembalikan alasan bentrok antara dua slot yang waktunya beririsan.
+func conflictReason(a plannedSchedule, classID, teacherID db.BigInt, room string) string {
+	switch {
+	case a.teacherID == teacherID:
+		return "teacher"
+	case a.classID == classID:
+		return "class"
+	case a.room != "" && strings.EqualFold(a.room, room):
+		return "room"
+	}
+	return ""
+}
+
+func (p plannedSchedule) roomPtr() *string {
+	if p.room == "" {
+		return nil
+	}
+	room := p.room
+	return &room
+}
+
+// GetSchedules mengambil daftar jadwal sesuai filter, diurutkan per hari dan jam.
+func (s *ScheduleService) GetSchedules(filters ScheduleFilters) ([]db.ScheduleModel, error) {
+	var where []db.ScheduleWhereParam
+	if filters.ClassID > 0 {
+		where = append(where, db.Schedule.ClassID.Equals(db.BigInt(filters.ClassID)))
+	}
+	if filters.TeacherID > 0 {
+		where = append(where, db.Schedule.TeacherID.Equals(db.BigInt(filters.TeacherID)))
+	}
+	if filters.SubjectID > 0 {
+		where = append(where, db.Schedule.SubjectID.Equals(db.BigInt(filters.SubjectID)))
+	}
+	if filters.DayOfWeek != "" {
+		day, err := parseDayOfWeek(filters.DayOfWeek)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.Schedule.DayOfWeek.Equals(day))
+	}
+	if filters.Room != "" {
+		where = append(where, db.Schedule.Room.Equals(filters.Room))
+	}
+	if filters.AcademicYear != "" {
+		where = append(where, db.Schedule.Class.Where(db.Class.AcademicYear.Equals(filters.AcademicYear)))
+	}
+
+	schedules, err := s.db.Schedule.FindMany(where...).With(
+		db.Schedule.Class.Fetch(),
+		db.Schedule.Subject.Fetch(),
+		db.Schedule.Teacher.Fetch(),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve schedules")
+	}
+	sortSchedules(schedules)
+	return schedules, nil
+}
+
+// sortSchedules mengurutkan jadwal berdasarkan hari lalu jam mulai.
+func sortSchedules(schedules []db.ScheduleModel) {
+	sort.SliceStable(schedules, func(i, j int) bool {
+		di, dj := dayIndex(schedules[i].DayOfWeek), dayIndex(schedules[j].DayOfWeek)
+		if di != dj {
+			return di < dj
+		}
+		return minutesOfDay(schedules[i].StartTime) < minutesOfDay(schedules[j].StartTime)
+	})
+}
+
+// GetScheduleByID mengambil satu slot jadwal.
+func (s *ScheduleService) GetScheduleByID(id int) (*db.ScheduleModel, error) {
+	schedule, err := s.db.Schedule.FindUnique(db.Schedule.ID.Equals(db.BigInt(id))).With(
+		db.Schedule.Class.Fetch(),
+		db.Schedule.Subject.Fetch(),
+		db.Schedule.Teacher.Fetch(),
+	).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("schedule not found")
+		}
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// CreateSchedule membuat slot jadwal baru setelah memastikan tidak bentrok dan sesuai kurikulum.
+func (s *ScheduleService) CreateSchedule(input ScheduleInput) (*db.ScheduleModel, error) {
+	ctx := context.Background()
+	class, err := s.findClass(ctx, input.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	planned, err := s.resolve(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkConflicts(ctx, class.AcademicYear, []plannedSchedule{*planned}, nil); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.classSchedules(ctx, class.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkCurriculum(ctx, class, append(existing, *planned)); err != nil {
+		return nil, err
+	}
+
+	tx := s.createTx(*planned)
+	if err := s.db.Prisma.Transaction(tx).Exec(ctx); err != nil {
+		return nil, errors.New("failed to create schedule")
+	}
+	return s.GetScheduleByID(int(tx.Result().ID))
+}
+
+// UpdateSchedule mengganti seluruh data satu slot jadwal.
+func (s *ScheduleService) UpdateSchedule(id int, input ScheduleInput) (*db.ScheduleModel, error) {
+	ctx := context.Background()
+	current, err := s.GetScheduleByID(id)
+	if err != nil {
+		return nil, err
+	}
+	class, err := s.findClass(ctx, input.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	planned, err := s.resolve(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	planned.id = current.ID
+
+	if err := s.checkConflicts(ctx, class.AcademicYear, []plannedSchedule{*planned}, []db.BigInt{current.ID}); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.classSchedules(ctx, class.ID)
+	if err != nil {
+		return nil, err
+	}
+	others := []plannedSchedule{*planned}
+	for _, p := range existing {
+		if p.id != current.ID {
+			others = append(others, p)
+		}
+	}
+	if err := s.checkCurriculum(ctx, class, others); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Prisma.Transaction(s.updateTx(*planned)).Exec(ctx); err != nil {
+		return nil, errors.New("failed to update schedule")
+	}
+	return s.GetScheduleByID(id)
+}
+
+// DeleteSchedule menghapus slot jadwal yang belum memiliki jurnal mengajar.
+func (s *ScheduleService) DeleteSchedule(id int) error {
+	ctx := context.Background()
+	_, err := s.db.TeachingJournal.FindFirst(db.TeachingJournal.ScheduleID.Equals(db.BigInt(id))).Exec(ctx)
+	if err == nil {
+		return conflictError("schedule already has teaching journals and cannot be deleted")
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return err
+	}
+
+	_, err = s.db.Schedule.FindUnique(db.Schedule.ID.Equals(db.BigInt(id))).Delete().Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("schedule not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// ReplaceClassTimetable menyimpan seluruh jadwal mingguan satu kelas sekaligus.
+// Slot dengan ID diperbarui, slot tanpa ID dibuat, dan slot lama yang tidak
+// disertakan dihapus. Semua perubahan dijalankan dalam satu transaksi.
+func (s *ScheduleService) ReplaceClassTimetable(classID int, entries []ScheduleInput) ([]db.ScheduleModel, error) {
+	ctx := context.Background()
+	class, err := s.findClass(ctx, int64(classID))
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.classSchedules(ctx, class.ID)
+	if err != nil {
+		return nil, err
+	}
+	existingIDs := make(map[db.BigInt]bool, len(existing))
+	for _, p := range existing {
+		existingIDs[p.id] = true
+	}
+
+	planned := make([]plannedSchedule, 0, len(entries))
+	kept := map[db.BigInt]bool{}
+	for i, entry := range entries {
+		entry.ClassID = int64(class.ID)
+		p, err := s.resolve(ctx, entry)
+		if err != nil {
+			return nil, validationError("entry %d: %s", i, err.Error())
+		}
+		if entry.ID > 0 {
+			id := db.BigInt(entry.ID)
+			if !existingIDs[id] || kept[id] {
+				return nil, validationError("entry %d: schedule %d does not belong to class %s", i, entry.ID, class.ClassName)
+			}
+			p.id = id
+			kept[id] = true
+		}
+		planned = append(planned, *p)
+	}
+
+	// Slot lama milik kelas ini akan diganti, jadi tidak ikut dicek sebagai bentrokan.
+	var exclude []db.BigInt
+	var removed []db.BigInt
+	for _, p := range existing {
+		exclude = append(exclude, p.id)
+		if !kept[p.id] {
+			removed = append(removed, p.id)
+		}
+	}
+	if err := s.checkConflicts(ctx, class.AcademicYear, planned, exclude); err != nil {
+		return nil, err
+	}
+	if err := s.checkCurriculum(ctx, class, planned); err != nil {
+		return nil, err
+	}
+
+	if len(removed) > 0 {
+		journals, err := s.db.TeachingJournal.FindMany(db.TeachingJournal.ScheduleID.In(removed)).Exec(ctx)
+		if err != nil {
+			return nil, errors.New("failed to check teaching journals")
+		}
+		if len(journals) > 0 {
+			return nil, conflictError("schedule %d already has teaching journals and cannot be removed", journals[0].ScheduleID)
+		}
+	}
+
+	var txs []transaction.Param
+	if len(removed) > 0 {
+		txs = append(txs, s.db.Schedule.FindMany(db.Schedule.ID.In(removed)).Delete().Tx())
+	}
+	for _, p := range planned {
+		if p.id > 0 {
+			txs = append(txs, s.updateTx(p))
+		} else {
+			txs = append(txs, s.createTx(p))
+		}
+	}
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to save class timetable")
+	}
+
+	return s.GetSchedules(ScheduleFilters{ClassID: int64(class.ID)})
+}
+
+func (s *ScheduleService) createTx(p plannedSchedule) db.ScheduleUniqueTxResult {
+	return s.db.Schedule.CreateOne(
+		db.Schedule.DayOfWeek.Set(p.day),
+		db.Schedule.StartTime.Set(p.start),
+		db.Schedule.EndTime.Set(p.end),
+		db.Schedule.Class.Link(db.Class.ID.Equals(p.classID)),
+		db.Schedule.Subject.Link(db.Subject.ID.Equals(p.subjectID)),
+		db.Schedule.Teacher.Link(db.Teacher.ID.Equals(p.teacherID)),
+		db.Schedule.Room.SetIfPresent(p.roomPtr()),
+	).Tx()
+}
+
+func (s *ScheduleService) updateTx(p plannedSchedule) db.ScheduleUniqueTxResult {
+	return s.db.Schedule.FindUnique(db.Schedule.ID.Equals(p.id)).Update(
+		db.Schedule.DayOfWeek.Set(p.day),
+		db.Schedule.StartTime.Set(p.start),
+		db.Schedule.EndTime.Set(p.end),
+		db.Schedule.Class.Link(db.Class.ID.Equals(p.classID)),
+		db.Schedule.Subject.Link(db.Subject.ID.Equals(p.subjectID)),
+		db.Schedule.Teacher.Link(db.Teacher.ID.Equals(p.teacherID)),
+		db.Schedule.Room.SetOptional(p.roomPtr()),
+	).Tx()
+}
+
+func (s *ScheduleService) findClass(ctx context.Context, classID int64) (*db.ClassModel, error) {
+	class, err := s.db.Class.FindUnique(db.Class.ID.Equals(db.BigInt(classID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("class not found")
+		}
+		return nil, err
+	}
+	return class, nil
+}
+
+// classSchedules mengambil jadwal yang sudah tersimpan untuk sebuah kelas.
+func (s *ScheduleService) classSchedules(ctx context.Context, classID db.BigInt) ([]plannedSchedule, error) {
+	schedules, err := s.db.Schedule.FindMany(db.Schedule.ClassID.Equals(classID)).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve class schedules")
+	}
+	planned := make([]plannedSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		planned = append(planned, toPlannedSchedule(schedule))
+	}
+	return planned, nil
+}
+
+func toPlannedSchedule(schedule db.ScheduleModel) plannedSchedule {
+	room, _ := schedule.Room()
+	return plannedSchedule{
+		id:        schedule.ID,
+		classID:   schedule.ClassID,
+		subjectID: schedule.SubjectID,
+		teacherID: schedule.TeacherID,
+		day:       schedule.DayOfWeek,
+		start:     schedule.StartTime,
+		end:       schedule.EndTime,
+		room:      room,
+	}
+}
+
+// resolve memvalidasi input slot dan memastikan mapel serta guru ada.
+func (s *ScheduleService) resolve(ctx context.Context, input ScheduleInput) (*plannedSchedule, error) {
+	day, err := parseDayOfWeek(input.DayOfWeek)
+	if err != nil {
+		return nil, err
+	}
+	start, err := parseClock(input.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(input.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if !start.Before(end) {
+		return nil, validationError("start time must be before end time")
+	}
+
+	if _, err := s.db.Subject.FindUnique(db.Subject.ID.Equals(db.BigInt(input.SubjectID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("subject not found")
+		}
+		return nil, err
+	}
+	if _, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(input.TeacherID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("teacher not found")
+		}
+		return nil, err
+	}
+
+	return &plannedSchedule{
+		classID:   db.BigInt(input.ClassID),
+		subjectID: db.BigInt(input.SubjectID),
+		teacherID: db.BigInt(input.TeacherID),
+		day:       day,
+		start:     start,
+		end:       end,
+		room:      strings.TrimSpace(input.Room),
+	}, nil
+}
+
+// checkConflicts membandingkan slot yang diajukan dengan sesamanya dan dengan
+// jadwal lain pada tahun ajaran yang sama. Slot dengan ID pada exclude diabaikan.
+func (s *ScheduleService) checkConflicts(ctx context.Context, academicYear string, planned []plannedSchedule, exclude []db.BigInt) error {
+	var conflicts []ScheduleConflict
+
+	for i := range planned {
+		for j := i + 1; j < len(planned); j++ {
+			a, b := planned[i], planned[j]
+			if !a.overlaps(b.day, b.start, b.end) {
+				continue
+			}
+			if reason := conflictReason(a, b.classID, b.teacherID, b.room); reason != "" {
+				other := j
+				conflicts = append(conflicts, ScheduleConflict{
+					Reason:     reason,
+					EntryIndex: i,
+					OtherIndex: &other,
+					ClassID:    int64(b.classID),
+					SubjectID:  int64(b.subjectID),
+					TeacherID:  int64(b.teacherID),
+					DayOfWeek:  string(b.day),
+					StartTime:  formatClock(b.start),
+					EndTime:    formatClock(b.end),
+					Room:       b.room,
+				})
+			}
+		}
+	}
+
+	days := map[db.DayOfWeek]bool{}
+	var dayList []db.DayOfWeek
+	for _, p := range planned {
+		if !days[p.day] {
+			days[p.day] = true
+			dayList = append(dayList, p.day)
+		}
+	}
+	if len(dayList) > 0 {
+		where := []db.ScheduleWhereParam{
+			db.Schedule.DayOfWeek.In(dayList),
+			db.Schedule.Class.Where(db.Class.AcademicYear.Equals(academicYear)),
+		}
+		if len(exclude) > 0 {
+			where = append(where, db.Schedule.Not(db.Schedule.ID.In(exclude)))
+		}
+		existing, err := s.db.Schedule.FindMany(where...).With(
+			db.Schedule.Class.Fetch(),
+			db.Schedule.Teacher.Fetch(),
+		).Exec(ctx)
+		if err != nil {
+			return errors.New("failed to check schedule conflicts")
+		}
+
+		for i, p := range planned {
+			for _, schedule := range existing {
+				other := toPlannedSchedule(schedule)
+				if !other.overlaps(p.day, p.start, p.end) {
+					continue
+				}
+				reason := conflictReason(other, p.classID, p.teacherID, p.room)
+				if reason == "" {
+					continue
+				}
+				conflicts = append(conflicts, ScheduleConflict{
+					Reason:      reason,
+					EntryIndex:  i,
+					ScheduleID:  int64(schedule.ID),
+					ClassID:     int64(schedule.ClassID),
+					ClassName:   schedule.Class().ClassName,
+					SubjectID:   int64(schedule.SubjectID),
+					TeacherID:   int64(schedule.TeacherID),
+					TeacherName: schedule.Teacher().FullName,
+					DayOfWeek:   string(schedule.DayOfWeek),
+					StartTime:   formatClock(schedule.StartTime),
+					EndTime:     formatClock(schedule.EndTime),
+					Room:        other.room,
+				})
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// checkCurriculum memastikan seluruh jadwal kelas sesuai kurikulum tingkat dan
+// jurusannya. Pengecekan dilewati jika kurikulum kelas tersebut belum diisi.
+func (s *ScheduleService) checkCurriculum(ctx context.Context, class *db.ClassModel, schedules []plannedSchedule) error {
+	curriculum, err := curriculumForClass(ctx, s.db, class)
+	if err != nil {
+		return err
+	}
+	if len(curriculum) == 0 {
+		return nil
+	}
+
+	hours := map[db.BigInt]int{}
+	for _, p := range schedules {
+		item, ok := curriculum[p.subjectID]
+		if !ok {
+			return validationError("subject %d is not part of the curriculum for class %s", p.subjectID, class.ClassName)
+		}
+		hours[p.subjectID] += lessonHours(p.start, p.end)
+		if hours[p.subjectID] > item.WeeklyHours {
+			return validationError("subject %s exceeds its weekly quota of %d hours for class %s",
+				item.Subject().SubjectName, item.WeeklyHours, class.ClassName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+// internal/service/school_days.go
+package service
+
+import "time"
+
+// SchoolDayChecker menentukan apakah sebuah tanggal adalah hari sekolah bagi
+// sebuah kelas. Dipakai oleh fitur yang mengembangkan jadwal mingguan menjadi
+// tanggal-tanggal pelajaran, misalnya laporan kepatuhan jurnal. Implementasinya
+// adalah CalendarService.
+type SchoolDayChecker interface {
+	IsSchoolDay(date time.Time, classID int64) (bool, error)
+}
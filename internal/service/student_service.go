@@ -0,0 +1,197 @@
+// internal/service/student_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type StudentService struct {
+	db *db.PrismaClient
+}
+
+func NewStudentService(db *db.PrismaClient) *StudentService {
+	return &StudentService{db: db}
+}
+
+// ClassHistoryEntry adalah satu periode keanggotaan siswa di sebuah kelas.
+type ClassHistoryEntry struct {
+	ID           int64   `json:"id" example:"1"`
+	ClassID      int64   `json:"class_id" example:"3"`
+	ClassName    string  `json:"class_name" example:"X RPL 1"`
+	GradeLevel   string  `json:"grade_level" example:"X"`
+	AcademicYear string  `json:"academic_year" example:"2024/2025"`
+	StartDate    string  `json:"start_date" example:"2024-07-15"`
+	EndDate      *string `json:"end_date,omitempty" example:"2025-06-30"`
+}
+
+// GetClassHistory mengambil riwayat kelas seorang siswa, dari yang terbaru.
+func (s *StudentService) GetClassHistory(studentID int) ([]ClassHistoryEntry, error) {
+	ctx := context.Background()
+	_, err := s.db.Student.FindUnique(db.Student.ID.Equals(db.BigInt(studentID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("student not found")
+		}
+		return nil, err
+	}
+
+	rows, err := s.db.StudentClassHistory.FindMany(
+		db.StudentClassHistory.StudentID.Equals(db.BigInt(studentID)),
+	).With(
+		db.StudentClassHistory.Class.Fetch(),
+	).OrderBy(
+		db.StudentClassHistory.StartDate.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve class history")
+	}
+
+	history := make([]ClassHistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		class := row.Class()
+		entry := ClassHistoryEntry{
+			ID:           int64(row.ID),
+			ClassID:      int64(row.ClassID),
+			ClassName:    class.ClassName,
+			GradeLevel:   class.GradeLevel,
+			AcademicYear: row.AcademicYear,
+			StartDate:    formatDate(row.StartDate),
+		}
+		if end, ok := row.EndDate(); ok {
+			formatted := formatDate(end)
+			entry.EndDate = &formatted
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// ChangeClass memindahkan siswa ke kelas lain dan mencatat riwayatnya.
+func (s *StudentService) ChangeClass(studentID, classID int) (*db.StudentModel, error) {
+	ctx := context.Background()
+	student, err := s.db.Student.FindUnique(db.Student.ID.Equals(db.BigInt(studentID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("student not found")
+		}
+		return nil, err
+	}
+	class, err := s.db.Class.FindUnique(db.Class.ID.Equals(db.BigInt(classID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("class not found")
+		}
+		return nil, err
+	}
+	if current, ok := student.CurrentClassID(); ok && current == class.ID {
+		return nil, validationError("student is already in class %s", class.ClassName)
+	}
+
+	txs := classHistoryTxs(s.db, student.ID, db.Class.ID.Equals(class.ID), class.AcademicYear, today())
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to change student class")
+	}
+
+	return s.db.Student.FindUnique(db.Student.ID.Equals(student.ID)).With(
+		db.Student.CurrentClass.Fetch(),
+	).Exec(ctx)
+}
+
+// normalizeRFID menyeragamkan UID kartu RFID (huruf besar, tanpa spasi) agar
+// kartu yang sama selalu cocok walau format kiriman reader berbeda.
+func normalizeRFID(uid string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(uid), ""))
+}
+
+// EnrollCard mendaftarkan atau mengganti kartu RFID seorang siswa. Satu kartu
+// hanya boleh terdaftar untuk satu siswa.
+func (s *StudentService) EnrollCard(studentID int, uid string) (*db.StudentModel, error) {
+	ctx := context.Background()
+	uid = normalizeRFID(uid)
+	if uid == "" {
+		return nil, validationError("rfid_uid is required")
+	}
+	student, err := s.db.Student.FindUnique(db.Student.ID.Equals(db.BigInt(studentID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("student not found")
+		}
+		return nil, err
+	}
+	owner, err := s.db.Student.FindUnique(db.Student.RfidUID.Equals(uid)).Exec(ctx)
+	if err == nil && owner.ID != student.ID {
+		return nil, conflictError("card %s is already enrolled to %s (NIS %s)", uid, owner.FullName, owner.Nis)
+	}
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	_, err = s.db.Student.FindUnique(db.Student.ID.Equals(student.ID)).Update(
+		db.Student.RfidUID.Set(uid),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to enroll RFID card")
+	}
+
+	return s.db.Student.FindUnique(db.Student.ID.Equals(student.ID)).With(
+		db.Student.CurrentClass.Fetch(),
+	).Exec(ctx)
+}
+
+// RemoveCard melepas kartu RFID siswa, misalnya karena kartu hilang.
+func (s *StudentService) RemoveCard(studentID int) error {
+	ctx := context.Background()
+	student, err := s.db.Student.FindUnique(db.Student.ID.Equals(db.BigInt(studentID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("student not found")
+		}
+		return err
+	}
+	if _, ok := student.RfidUID(); !ok {
+		return notFoundError("student has no RFID card enrolled")
+	}
+	_, err = s.db.Student.FindUnique(db.Student.ID.Equals(student.ID)).Update(
+		db.Student.RfidUID.SetOptional(nil),
+	).Exec(ctx)
+	if err != nil {
+		return errors.New("failed to remove RFID card")
+	}
+	return nil
+}
+
+// classHistoryTxs menyusun query transaksi untuk memindahkan siswa ke class:
+// mengubah current_class_id, menutup riwayat kelas yang masih terbuka lalu
+// membuka riwayat baru. current_class_id hanya diubah lewat fungsi ini agar
+// riwayat kelas tetap lengkap.
+func classHistoryTxs(client *db.PrismaClient, studentID db.BigInt, class db.ClassEqualsUniqueWhereParam, academicYear string, date time.Time) []transaction.Param {
+	return []transaction.Param{
+		client.Student.FindUnique(db.Student.ID.Equals(studentID)).Update(
+			db.Student.CurrentClass.Link(class),
+		).Tx(),
+		closeClassHistoryTx(client, studentID, date),
+		client.StudentClassHistory.CreateOne(
+			db.StudentClassHistory.AcademicYear.Set(academicYear),
+			db.StudentClassHistory.StartDate.Set(date),
+			db.StudentClassHistory.Student.Link(db.Student.ID.Equals(studentID)),
+			db.StudentClassHistory.Class.Link(class),
+		).Tx(),
+	}
+}
+
+// closeClassHistoryTx menutup riwayat kelas yang masih terbuka, misalnya saat siswa lulus.
+func closeClassHistoryTx(client *db.PrismaClient, studentID db.BigInt, date time.Time) transaction.Param {
+	return client.StudentClassHistory.FindMany(
+		db.StudentClassHistory.StudentID.Equals(studentID),
+		db.StudentClassHistory.EndDate.IsNull(),
+	).Update(
+		db.StudentClassHistory.EndDate.Set(date),
+	).Tx()
+}
@@ -0,0 +1,329 @@
+// internal/service/subject_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type SubjectService struct {
+	db *db.PrismaClient
+}
+
+func NewSubjectService(db *db.PrismaClient) *SubjectService {
+	return &SubjectService{db: db}
+}
+
+// GetSubjects mengambil daftar mata pelajaran dengan paginasi dan pencarian kode/nama.
+func (s *SubjectService) GetSubjects(page, limit int, search string) ([]db.SubjectModel, int, error) {
+	var where []db.SubjectWhereParam
+	if search != "" {
+		where = append(where, db.Subject.Or(
+			db.Subject.SubjectCode.Contains(search),
+			db.Subject.SubjectName.Contains(search),
+		))
+	}
+
+	all, err := s.db.Subject.FindMany(where...).Exec(context.Background())
+	if err != nil {
+		return nil, 0, errors.New("failed to count subjects")
+	}
+
+	subjects, err := s.db.Subject.FindMany(where...).
+		OrderBy(db.Subject.SubjectCode.Order(db.SortOrderAsc)).
+		Skip((page - 1) * limit).
+		Take(limit).
+		Exec(context.Background())
+	if err != nil {
+		return nil, 0, errors.New("failed to retrieve subjects")
+	}
+	return subjects, len(all), nil
+}
+
+// GetSubjectByID mengambil satu mata pelajaran beserta pemetaan kurikulumnya.
+func (s *SubjectService) GetSubjectByID(id int) (*db.SubjectModel, error) {
+	subject, err := s.db.Subject.FindUnique(db.Subject.ID.Equals(db.BigInt(id))).With(
+		db.Subject.Curriculum.Fetch().OrderBy(db.CurriculumSubject.GradeLevel.Order(db.SortOrderAsc)),
+	).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("subject not found")
+		}
+		return nil, err
+	}
+	return subject, nil
+}
+
+// CreateSubject membuat mata pelajaran baru dengan kode yang unik.
+func (s *SubjectService) CreateSubject(code, name string) (*db.SubjectModel, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	_, err := s.db.Subject.FindUnique(db.Subject.SubjectCode.Equals(code)).Exec(context.Background())
+	if err == nil {
+		return nil, conflictError("subject code already exists")
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	return s.db.Subject.CreateOne(
+		db.Subject.SubjectCode.Set(code),
+		db.Subject.SubjectName.Set(strings.TrimSpace(name)),
+	).Exec(context.Background())
+}
+
+// UpdateSubject memperbarui kode dan/atau nama mata pelajaran.
+func (s *SubjectService) UpdateSubject(id int, code, name *string) (*db.SubjectModel, error) {
+	var params []db.SubjectSetParam
+	if code != nil && *code != "" {
+		newCode := strings.ToUpper(strings.TrimSpace(*code))
+		existing, err := s.db.Subject.FindUnique(db.Subject.SubjectCode.Equals(newCode)).Exec(context.Background())
+		if err == nil && existing.ID != db.BigInt(id) {
+			return nil, conflictError("subject code already exists")
+		}
+		params = append(params, db.Subject.SubjectCode.Set(newCode))
+	}
+	if name != nil && *name != "" {
+		params = append(params, db.Subject.SubjectName.Set(strings.TrimSpace(*name)))
+	}
+
+	subject, err := s.db.Subject.FindUnique(db.Subject.ID.Equals(db.BigInt(id))).Update(params...).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("subject not found")
+		}
+		return nil, err
+	}
+	return subject, nil
+}
+
+// DeleteSubject menghapus mata pelajaran yang belum dipakai di jadwal.
+// Jadwal ikut terhapus secara cascade, jadi penghapusan ditolak bila masih ada jadwal.
+func (s *SubjectService) DeleteSubject(id int) error {
+	ctx := context.Background()
+	_, err := s.db.Schedule.FindFirst(db.Schedule.SubjectID.Equals(db.BigInt(id))).Exec(ctx)
+	if err == nil {
+		return conflictError("subject is still used by schedules")
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return err
+	}
+
+	_, err = s.db.Subject.FindUnique(db.Subject.ID.Equals(db.BigInt(id))).Delete().Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("subject not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// GetCurriculum mengambil pemetaan kurikulum, bisa difilter per tingkat dan jurusan.
+// Filter jurusan juga menyertakan baris umum (major kosong) yang berlaku untuk semua jurusan.
+func (s *SubjectService) GetCurriculum(gradeLevel, major string) ([]db.CurriculumSubjectModel, error) {
+	var where []db.CurriculumSubjectWhereParam
+	if gradeLevel != "" {
+		where = append(where, db.CurriculumSubject.GradeLevel.Equals(normalizeGradeLevel(gradeLevel)))
+	}
+	if major != "" {
+		where = append(where, db.CurriculumSubject.Or(
+			db.CurriculumSubject.Major.Equals(major),
+			db.CurriculumSubject.Major.Equals(""),
+		))
+	}
+
+	items, err := s.db.CurriculumSubject.FindMany(where...).With(
+		db.CurriculumSubject.Subject.Fetch(),
+	).OrderBy(
+		db.CurriculumSubject.GradeLevel.Order(db.SortOrderAsc),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve curriculum")
+	}
+	return items, nil
+}
+
+// UpsertCurriculumItem menetapkan kuota jam per minggu sebuah mapel untuk tingkat dan jurusan tertentu.
+func (s *SubjectService) UpsertCurriculumItem(subjectID int, gradeLevel, major string, weeklyHours int) (*db.CurriculumSubjectModel, error) {
+	ctx := context.Background()
+	if weeklyHours <= 0 {
+		return nil, validationError("weekly hours must be greater than zero")
+	}
+	if _, _, ok := gradeIndex(gradeLevel); !ok {
+		return nil, validationError("unknown grade level %q", gradeLevel)
+	}
+	if _, err := s.db.Subject.FindUnique(db.Subject.ID.Equals(db.BigInt(subjectID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("subject not found")
+		}
+		return nil, err
+	}
+
+	grade := normalizeGradeLevel(gradeLevel)
+	major = strings.TrimSpace(major)
+	return s.db.CurriculumSubject.UpsertOne(
+		db.CurriculumSubject.SubjectGradeMajorUnique(
+			db.CurriculumSubject.SubjectID.Equals(db.BigInt(subjectID)),
+			db.CurriculumSubject.GradeLevel.Equals(grade),
+			db.CurriculumSubject.Major.Equals(major),
+		),
+	).Create(
+		db.CurriculumSubject.GradeLevel.Set(grade),
+		db.CurriculumSubject.WeeklyHours.Set(weeklyHours),
+		db.CurriculumSubject.Subject.Link(db.Subject.ID.Equals(db.BigInt(subjectID))),
+		db.CurriculumSubject.Major.Set(major),
+	).Update(
+		db.CurriculumSubject.WeeklyHours.Set(weeklyHours),
+	).With(
+		db.CurriculumSubject.Subject.Fetch(),
+	).Exec(ctx)
+}
+
+// DeleteCurriculumItem menghapus satu baris pemetaan kurikulum.
+func (s *SubjectService) DeleteCurriculumItem(id int) error {
+	_, err := s.db.CurriculumSubject.FindUnique(db.CurriculumSubject.ID.Equals(db.BigInt(id))).Delete().Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("curriculum item not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// curriculumForClass mengembalikan kuota jam per minggu setiap mapel yang berlaku
+// untuk sebuah kelas. Baris khusus jurusan menimpa baris umum untuk mapel yang sama.
+func curriculumForClass(ctx context.Context, client *db.PrismaClient, class *db.ClassModel) (map[db.BigInt]db.CurriculumSubjectModel, error) {
+	major, _ := class.Major()
+	items, err := client.CurriculumSubject.FindMany(
+		db.CurriculumSubject.GradeLevel.Equals(normalizeGradeLevel(class.GradeLevel)),
+		db.CurriculumSubject.Or(
+			db.CurriculumSubject.Major.Equals(major),
+			db.CurriculumSubject.Major.Equals(""),
+		),
+	).With(
+		db.CurriculumSubject.Subject.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve curriculum")
+	}
+
+	result := make(map[db.BigInt]db.CurriculumSubjectModel, len(items))
+	for _, item := range items {
+		if existing, ok := result[item.SubjectID]; ok && existing.Major != "" {
+			continue
+		}
+		result[item.SubjectID] = item
+	}
+	return result, nil
+}
+
+// lessonPeriodMinutes adalah durasi satu jam pelajaran (LESSON_PERIOD_MINUTES, default 45).
+func lessonPeriodMinutes() int {
+	minutes := viper.GetInt("LESSON_PERIOD_MINUTES")
+	if minutes <= 0 {
+		return 45
+	}
+	return minutes
+}
+
+// lessonHours menghitung jumlah jam pelajaran dari satu slot jadwal.
+func lessonHours(start, end time.Time) int {
+	minutes := float64(minutesOfDay(end) - minutesOfDay(start))
+	return int(math.Round(minutes / float64(lessonPeriodMinutes())))
+}
+
+// CurriculumReportItem membandingkan kuota kurikulum dengan jam yang sudah terjadwal.
+type CurriculumReportItem struct {
+	SubjectID      int64  `json:"subject_id" example:"1"`
+	SubjectCode    string `json:"subject_code" example:"MTK"`
+	SubjectName    string `json:"subject_name" example:"Matematika"`
+	RequiredHours  int    `json:"required_hours" example:"4"`
+	ScheduledHours int    `json:"scheduled_hours" example:"2"`
+	MissingHours   int    `json:"missing_hours" example:"2"`
+}
+
+// CurriculumReport adalah laporan kesesuaian jadwal satu kelas dengan kurikulum.
+type CurriculumReport struct {
+	ClassID       int64                  `json:"class_id" example:"3"`
+	ClassName     string                 `json:"class_name" example:"X RPL 1"`
+	Unscheduled   []CurriculumReportItem `json:"unscheduled"`
+	Scheduled     []CurriculumReportItem `json:"scheduled"`
+	OffCurriculum []CurriculumReportItem `json:"off_curriculum"`
+}
+
+// GetClassCurriculumReport melaporkan mapel kurikulum yang belum (atau belum cukup)
+// terjadwal untuk sebuah kelas, serta mapel terjadwal yang tidak ada di kurikulum.
+func (s *SubjectService) GetClassCurriculumReport(classID int) (*CurriculumReport, error) {
+	ctx := context.Background()
+	class, err := s.db.Class.FindUnique(db.Class.ID.Equals(db.BigInt(classID))).With(
+		db.Class.Schedules.Fetch().With(db.Schedule.Subject.Fetch()),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("class not found")
+		}
+		return nil, err
+	}
+
+	curriculum, err := curriculumForClass(ctx, s.db, class)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := map[db.BigInt]int{}
+	subjects := map[db.BigInt]db.SubjectModel{}
+	for _, schedule := range class.Schedules() {
+		scheduled[schedule.SubjectID] += lessonHours(schedule.StartTime, schedule.EndTime)
+		subjects[schedule.SubjectID] = *schedule.Subject()
+	}
+
+	report := &CurriculumReport{
+		ClassID:       int64(class.ID),
+		ClassName:     class.ClassName,
+		Unscheduled:   []CurriculumReportItem{},
+		Scheduled:     []CurriculumReportItem{},
+		OffCurriculum: []CurriculumReportItem{},
+	}
+	for subjectID, item := range curriculum {
+		subject := item.Subject()
+		row := CurriculumReportItem{
+			SubjectID:      int64(subjectID),
+			SubjectCode:    subject.SubjectCode,
+			SubjectName:    subject.SubjectName,
+			RequiredHours:  item.WeeklyHours,
+			ScheduledHours: scheduled[subjectID],
+		}
+		if row.ScheduledHours < row.RequiredHours {
+			row.MissingHours = row.RequiredHours - row.ScheduledHours
+			report.Unscheduled = append(report.Unscheduled, row)
+		} else {
+			report.Scheduled = append(report.Scheduled, row)
+		}
+	}
+	for subjectID, hours := range scheduled {
+		if _, ok := curriculum[subjectID]; ok {
+			continue
+		}
+		subject := subjects[subjectID]
+		report.OffCurriculum = append(report.OffCurriculum, CurriculumReportItem{
+			SubjectID:      int64(subjectID),
+			SubjectCode:    subject.SubjectCode,
+			SubjectName:    subject.SubjectName,
+			ScheduledHours: hours,
+		})
+	}
+
+	for _, list := range [][]CurriculumReportItem{report.Unscheduled, report.Scheduled, report.OffCurriculum} {
+		sort.Slice(list, func(i, j int) bool { return list[i].SubjectCode < list[j].SubjectCode })
+	}
+	return report, nil
+}
@@ -0,0 +1,536 @@
+// internal/service/substitution_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+// maxSubstitutionRangeDays membatasi rentang tanggal daftar pelajaran terdampak.
+const maxSubstitutionRangeDays = 62
+
+type SubstitutionService struct {
+	db       *db.PrismaClient
+	journals *TeachingJournalService
+}
+
+func NewSubstitutionService(db *db.PrismaClient) *SubstitutionService {
+	return &SubstitutionService{db: db, journals: NewTeachingJournalService(db)}
+}
+
+// AffectedLesson adalah satu jam pelajaran yang ditinggalkan guru karena izin yang disetujui.
+type AffectedLesson struct {
+	Date                  string `json:"date" example:"2025-09-22"`
+	ScheduleID            int64  `json:"schedule_id" example:"12"`
+	DayOfWeek             string `json:"day_of_week" example:"Senin"`
+	StartTime             string `json:"start_time" example:"07:00"`
+	EndTime               string `json:"end_time" example:"08:30"`
+	ClassID               int64  `json:"class_id" example:"3"`
+	ClassName             string `json:"class_name" example:"X RPL 1"`
+	SubjectID             int64  `json:"subject_id" example:"1"`
+	SubjectName           string `json:"subject_name" example:"Matematika"`
+	TeacherID             int64  `json:"teacher_id" example:"2"`
+	TeacherName           string `json:"teacher_name" example:"Budi Santoso, S.Pd"`
+	Room                  string `json:"room,omitempty" example:"Lab RPL 1"`
+	LeaveRequestID        int64  `json:"leave_request_id" example:"7"`
+	LeaveType             string `json:"leave_type" example:"Sakit"`
+	SubstitutionID        *int64 `json:"substitution_id,omitempty" example:"4"`
+	SubstituteTeacherID   *int64 `json:"substitute_teacher_id,omitempty" example:"5"`
+	SubstituteTeacherName string `json:"substitute_teacher_name,omitempty" example:"Siti Aminah, S.Kom"`
+}
+
+// SubstituteCandidate adalah guru yang bisa menggantikan sebuah jam pelajaran.
+type SubstituteCandidate struct {
+	TeacherID      int64  `json:"teacher_id" example:"5"`
+	TeacherName    string `json:"teacher_name" example:"Siti Aminah, S.Kom"`
+	SameSubject    bool   `json:"same_subject" example:"true"`   // Mengajar mapel yang sama
+	TeachesClass   bool   `json:"teaches_class" example:"false"` // Sudah mengajar di kelas tersebut
+	LessonsThatDay int    `json:"lessons_that_day" example:"3"`  // Jumlah jam mengajar pada hari itu
+}
+
+// SubstitutionInput adalah data untuk mencatat guru pengganti.
+type SubstitutionInput struct {
+	ScheduleID          int64
+	Date                string // YYYY-MM-DD
+	SubstituteTeacherID int64
+	Notes               string
+}
+
+// SubstitutionFilters adalah filter untuk daftar guru pengganti.
+type SubstitutionFilters struct {
+	From      string
+	To        string
+	TeacherID int64 // Guru pengganti maupun guru yang digantikan
+
+	substituteTeacherID db.BigInt
+}
+
+// GetAffectedLessons mengembalikan jam pelajaran milik guru yang memiliki izin
+// disetujui pada rentang tanggal, beserta guru pengganti jika sudah dicatat.
+func (s *SubstitutionService) GetAffectedLessons(from, to string, uncoveredOnly bool) ([]AffectedLesson, error) {
+	ctx := context.Background()
+	start, end, err := parseDateRange(from, to, maxSubstitutionRangeDays)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves, err := s.db.LeaveRequest.FindMany(
+		db.LeaveRequest.Status.Equals(db.LeaveStatusApproved),
+		db.LeaveRequest.StartDate.Lte(end),
+		db.LeaveRequest.EndDate.Gte(start),
+	).With(
+		db.LeaveRequest.Requestor.Fetch().With(db.User.Teacher.Fetch()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve leave requests")
+	}
+
+	substitutions, err := s.db.Substitution.FindMany(
+		db.Substitution.Date.Gte(start),
+		db.Substitution.Date.Lte(end),
+	).With(
+		db.Substitution.SubstituteTeacher.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve substitutions")
+	}
+	covered := make(map[string]db.SubstitutionModel, len(substitutions))
+	for _, substitution := range substitutions {
+		covered[lessonKey(substitution.ScheduleID, substitution.Date)] = substitution
+	}
+
+	academicYear, err := academicYearsBetween(ctx, s.db, start, end)
+	if err != nil {
+		return nil, err
+	}
+	schedulesOf := map[db.BigInt][]db.ScheduleModel{}
+	seen := map[string]bool{}
+	lessons := []AffectedLesson{}
+	for _, leave := range leaves {
+		teacher, ok := leave.Requestor().Teacher()
+		if !ok {
+			continue
+		}
+		schedules, ok := schedulesOf[teacher.ID]
+		if !ok {
+			schedules, err = s.db.Schedule.FindMany(db.Schedule.TeacherID.Equals(teacher.ID)).With(
+				db.Schedule.Class.Fetch(),
+				db.Schedule.Subject.Fetch(),
+			).Exec(ctx)
+			if err != nil {
+				return nil, errors.New("failed to retrieve schedules")
+			}
+			schedulesOf[teacher.ID] = schedules
+		}
+
+		first, last := leave.StartDate, leave.EndDate
+		if first.Before(start) {
+			first = start
+		}
+		if last.After(end) {
+			last = end
+		}
+		for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+			day, year := dayOfWeekOf(date), academicYear(date)
+			for _, schedule := range schedules {
+				if schedule.DayOfWeek != day || schedule.Class().AcademicYear != year {
+					continue
+				}
+				key := lessonKey(schedule.ID, date)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				substitution, isCovered := covered[key]
+				if uncoveredOnly && isCovered {
+					continue
+				}
+				room, _ := schedule.Room()
+				lesson := AffectedLesson{
+					Date:           formatDate(date),
+					ScheduleID:     int64(schedule.ID),
+					DayOfWeek:      string(schedule.DayOfWeek),
+					StartTime:      formatClock(schedule.StartTime),
+					EndTime:        formatClock(schedule.EndTime),
+					ClassID:        int64(schedule.ClassID),
+					ClassName:      schedule.Class().ClassName,
+					SubjectID:      int64(schedule.SubjectID),
+					SubjectName:    schedule.Subject().SubjectName,
+					TeacherID:      int64(teacher.ID),
+					TeacherName:    teacher.FullName,
+					Room:           room,
+					LeaveRequestID: int64(leave.ID),
+					LeaveType:      string(leave.RequestType),
+				}
+				if isCovered {
+					substitutionID := int64(substitution.ID)
+					substituteID := int64(substitution.SubstituteTeacherID)
+					lesson.SubstitutionID = &substitutionID
+					lesson.SubstituteTeacherID = &substituteID
+					lesson.SubstituteTeacherName = substitution.SubstituteTeacher().FullName
+				}
+				lessons = append(lessons, lesson)
+			}
+		}
+	}
+
+	sort.SliceStable(lessons, func(i, j int) bool {
+		if lessons[i].Date != lessons[j].Date {
+			return lessons[i].Date < lessons[j].Date
+		}
+		if lessons[i].StartTime != lessons[j].StartTime {
+			return lessons[i].StartTime < lessons[j].StartTime
+		}
+		return lessons[i].ClassName < lessons[j].ClassName
+	})
+	return lessons, nil
+}
+
+func lessonKey(scheduleID db.BigInt, date time.Time) string {
+	return fmt.Sprintf("%d@%s", scheduleID, formatDate(date))
+}
+
+// SuggestSubstitutes mengurutkan guru yang bebas pada jam pelajaran tersebut:
+// guru mapel yang sama lebih dulu, lalu guru yang sudah mengajar di kelas itu,
+// lalu guru dengan jam mengajar paling sedikit pada hari itu.
+func (s *SubstitutionService) SuggestSubstitutes(scheduleID int, dateValue string) ([]SubstituteCandidate, error) {
+	ctx := context.Background()
+	schedule, date, err := s.lessonOn(ctx, int64(scheduleID), dateValue)
+	if err != nil {
+		return nil, err
+	}
+	busy, err := s.busyTeachers(ctx, schedule, date)
+	if err != nil {
+		return nil, err
+	}
+	year := schedule.Class().AcademicYear
+
+	teachers, err := s.db.Teacher.FindMany().OrderBy(
+		db.Teacher.FullName.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve teachers")
+	}
+
+	sameSubject := map[db.BigInt]bool{}
+	subjectSchedules, err := s.db.Schedule.FindMany(
+		db.Schedule.SubjectID.Equals(schedule.SubjectID),
+		db.Schedule.Class.Where(db.Class.AcademicYear.Equals(year)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve schedules")
+	}
+	for _, other := range subjectSchedules {
+		sameSubject[other.TeacherID] = true
+	}
+	assignments, err := s.db.TeachingAssignment.FindMany(
+		db.TeachingAssignment.SubjectID.Equals(schedule.SubjectID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve teaching assignments")
+	}
+	for _, assignment := range assignments {
+		sameSubject[assignment.TeacherID] = true
+	}
+
+	teachesClass := map[db.BigInt]bool{}
+	classSchedules, err := s.db.Schedule.FindMany(db.Schedule.ClassID.Equals(schedule.ClassID)).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve schedules")
+	}
+	for _, other := range classSchedules {
+		teachesClass[other.TeacherID] = true
+	}
+
+	load := map[db.BigInt]int{}
+	daySchedules, err := s.db.Schedule.FindMany(
+		db.Schedule.DayOfWeek.Equals(schedule.DayOfWeek),
+		db.Schedule.Class.Where(db.Class.AcademicYear.Equals(year)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve schedules")
+	}
+	for _, other := range daySchedules {
+		load[other.TeacherID] += lessonHours(other.StartTime, other.EndTime)
+	}
+
+	candidates := []SubstituteCandidate{}
+	for _, teacher := range teachers {
+		if _, isBusy := busy[teacher.ID]; isBusy {
+			continue
+		}
+		candidates = append(candidates, SubstituteCandidate{
+			TeacherID:      int64(teacher.ID),
+			TeacherName:    teacher.FullName,
+			SameSubject:    sameSubject[teacher.ID],
+			TeachesClass:   teachesClass[teacher.ID],
+			LessonsThatDay: load[teacher.ID],
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.SameSubject != b.SameSubject {
+			return a.SameSubject
+		}
+		if a.TeachesClass != b.TeachesClass {
+			return a.TeachesClass
+		}
+		return a.LessonsThatDay < b.LessonsThatDay
+	})
+	return candidates, nil
+}
+
+// lessonOn mengambil jadwal dan memastikan tanggal jatuh pada hari jadwal tersebut.
+func (s *SubstitutionService) lessonOn(ctx context.Context, scheduleID int64, dateValue string) (*db.ScheduleModel, time.Time, error) {
+	date, err := parseDate(dateValue)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	schedule, err := s.db.Schedule.FindUnique(db.Schedule.ID.Equals(db.BigInt(scheduleID))).With(
+		db.Schedule.Class.Fetch(),
+		db.Schedule.Subject.Fetch(),
+		db.Schedule.Teacher.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, time.Time{}, notFoundError("schedule not found")
+		}
+		return nil, time.Time{}, err
+	}
+	if day := dayOfWeekOf(date); day != schedule.DayOfWeek {
+		return nil, time.Time{}, validationError("%s is a %s but the schedule is on %s", formatDate(date), day, schedule.DayOfWeek)
+	}
+	return schedule, date, nil
+}
+
+// busyTeachers mengembalikan guru yang tidak bisa menggantikan sebuah jam
+// pelajaran pada tanggal tertentu beserta alasannya.
+func (s *SubstitutionService) busyTeachers(ctx context.Context, schedule *db.ScheduleModel, date time.Time) (map[db.BigInt]string, error) {
+	busy := map[db.BigInt]string{schedule.TeacherID: "original teacher"}
+	lesson := toPlannedSchedule(*schedule)
+
+	teaching, err := s.db.Schedule.FindMany(
+		db.Schedule.DayOfWeek.Equals(schedule.DayOfWeek),
+		db.Schedule.Class.Where(db.Class.AcademicYear.Equals(schedule.Class().AcademicYear)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve schedules")
+	}
+	for _, other := range teaching {
+		if lesson.overlaps(other.DayOfWeek, other.StartTime, other.EndTime) {
+			busy[other.TeacherID] = "teaching"
+		}
+	}
+
+	substituting, err := s.db.Substitution.FindMany(
+		db.Substitution.Date.Equals(date),
+		db.Substitution.Not(db.Substitution.ScheduleID.Equals(schedule.ID)),
+	).With(
+		db.Substitution.Schedule.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve substitutions")
+	}
+	for _, other := range substituting {
+		if lesson.overlaps(other.Schedule().DayOfWeek, other.Schedule().StartTime, other.Schedule().EndTime) {
+			busy[other.SubstituteTeacherID] = "substituting"
+		}
+	}
+
+	unavailable, err := s.db.TeacherUnavailability.FindMany(
+		db.TeacherUnavailability.DayOfWeek.Equals(schedule.DayOfWeek),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve teacher unavailability")
+	}
+	for _, slot := range unavailable {
+		if lesson.overlaps(slot.DayOfWeek, slot.StartTime, slot.EndTime) {
+			busy[slot.TeacherID] = "unavailable"
+		}
+	}
+
+	leaves, err := s.db.LeaveRequest.FindMany(
+		db.LeaveRequest.Status.Equals(db.LeaveStatusApproved),
+		db.LeaveRequest.StartDate.Lte(date),
+		db.LeaveRequest.EndDate.Gte(date),
+	).With(
+		db.LeaveRequest.Requestor.Fetch().With(db.User.Teacher.Fetch()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve leave requests")
+	}
+	for _, leave := range leaves {
+		if teacher, ok := leave.Requestor().Teacher(); ok {
+			busy[teacher.ID] = "on leave"
+		}
+	}
+	return busy, nil
+}
+
+// CreateSubstitution mencatat guru pengganti untuk satu jam pelajaran pada satu
+// tanggal. Izin guru asli yang mencakup tanggal itu otomatis ditautkan.
+func (s *SubstitutionService) CreateSubstitution(input SubstitutionInput) (*db.SubstitutionModel, error) {
+	ctx := context.Background()
+	schedule, date, err := s.lessonOn(ctx, input.ScheduleID, input.Date)
+	if err != nil {
+		return nil, err
+	}
+	if db.BigInt(input.SubstituteTeacherID) == schedule.TeacherID {
+		return nil, validationError("substitute teacher must be different from the scheduled teacher")
+	}
+	if _, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(input.SubstituteTeacherID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("substitute teacher not found")
+		}
+		return nil, err
+	}
+
+	_, err = s.db.Substitution.FindUnique(db.Substitution.ScheduleDateUnique(
+		db.Substitution.ScheduleID.Equals(schedule.ID),
+		db.Substitution.Date.Equals(date),
+	)).Exec(ctx)
+	if err == nil {
+		return nil, conflictError("lesson already has a substitute on %s", formatDate(date))
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	busy, err := s.busyTeachers(ctx, schedule, date)
+	if err != nil {
+		return nil, err
+	}
+	if reason, isBusy := busy[db.BigInt(input.SubstituteTeacherID)]; isBusy {
+		return nil, conflictError("substitute teacher is not available at that time (%s)", reason)
+	}
+
+	var optional []db.SubstitutionSetParam
+	leave, err := s.db.LeaveRequest.FindFirst(
+		db.LeaveRequest.UserID.Equals(schedule.Teacher().UserID),
+		db.LeaveRequest.Status.Equals(db.LeaveStatusApproved),
+		db.LeaveRequest.StartDate.Lte(date),
+		db.LeaveRequest.EndDate.Gte(date),
+	).Exec(ctx)
+	if err == nil {
+		optional = append(optional, db.Substitution.LeaveRequest.Link(db.LeaveRequest.ID.Equals(leave.ID)))
+	} else if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+	if notes := strings.TrimSpace(input.Notes); notes != "" {
+		optional = append(optional, db.Substitution.Notes.Set(notes))
+	}
+
+	created, err := s.db.Substitution.CreateOne(
+		db.Substitution.Date.Set(date),
+		db.Substitution.Schedule.Link(db.Schedule.ID.Equals(schedule.ID)),
+		db.Substitution.SubstituteTeacher.Link(db.Teacher.ID.Equals(db.BigInt(input.SubstituteTeacherID))),
+		optional...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to create substitution")
+	}
+	return s.GetSubstitutionByID(int(created.ID))
+}
+
+// GetSubstitutions mengambil daftar guru pengganti sesuai filter.
+func (s *SubstitutionService) GetSubstitutions(filters SubstitutionFilters) ([]db.SubstitutionModel, error) {
+	var where []db.SubstitutionWhereParam
+	if filters.From != "" {
+		from, err := parseDate(filters.From)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.Substitution.Date.Gte(from))
+	}
+	if filters.To != "" {
+		to, err := parseDate(filters.To)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.Substitution.Date.Lte(to))
+	}
+	if filters.TeacherID > 0 {
+		teacherID := db.BigInt(filters.TeacherID)
+		where = append(where, db.Substitution.Or(
+			db.Substitution.SubstituteTeacherID.Equals(teacherID),
+			db.Substitution.Schedule.Where(db.Schedule.TeacherID.Equals(teacherID)),
+		))
+	}
+	if filters.substituteTeacherID > 0 {
+		where = append(where, db.Substitution.SubstituteTeacherID.Equals(filters.substituteTeacherID))
+	}
+
+	substitutions, err := s.db.Substitution.FindMany(where...).With(
+		db.Substitution.Schedule.Fetch().With(
+			db.Schedule.Class.Fetch(),
+			db.Schedule.Subject.Fetch(),
+			db.Schedule.Teacher.Fetch(),
+		),
+		db.Substitution.SubstituteTeacher.Fetch(),
+	).OrderBy(
+		db.Substitution.Date.Order(db.SortOrderAsc),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve substitutions")
+	}
+	return substitutions, nil
+}
+
+// GetUserSubstitutions mengambil tugas menggantikan milik guru yang login.
+func (s *SubstitutionService) GetUserSubstitutions(userID int, from, to string) ([]db.SubstitutionModel, error) {
+	teacher, err := teacherOfUser(context.Background(), s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetSubstitutions(SubstitutionFilters{From: from, To: to, substituteTeacherID: teacher.ID})
+}
+
+// GetSubstitutionByID mengambil satu catatan guru pengganti.
+func (s *SubstitutionService) GetSubstitutionByID(id int) (*db.SubstitutionModel, error) {
+	substitution, err := s.db.Substitution.FindUnique(db.Substitution.ID.Equals(db.BigInt(id))).With(
+		db.Substitution.Schedule.Fetch().With(
+			db.Schedule.Class.Fetch(),
+			db.Schedule.Subject.Fetch(),
+			db.Schedule.Teacher.Fetch(),
+		),
+		db.Substitution.SubstituteTeacher.Fetch(),
+	).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("substitution not found")
+		}
+		return nil, err
+	}
+	return substitution, nil
+}
+
+// DeleteSubstitution membatalkan guru pengganti.
+func (s *SubstitutionService) DeleteSubstitution(id int) error {
+	_, err := s.db.Substitution.FindUnique(db.Substitution.ID.Equals(db.BigInt(id))).Delete().Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("substitution not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// WriteJournal membuat jurnal mengajar untuk jam pelajaran yang digantikan.
+// Hanya guru pengganti yang tercatat yang boleh mengisinya.
+func (s *SubstitutionService) WriteJournal(userID, substitutionID int, input JournalInput) (*db.TeachingJournalModel, error) {
+	substitution, err := s.GetSubstitutionByID(substitutionID)
+	if err != nil {
+		return nil, err
+	}
+	input.ScheduleID = int64(substitution.ScheduleID)
+	input.TeachingDate = formatDate(substitution.Date)
+	return s.journals.CreateJournal(userID, input)
+}
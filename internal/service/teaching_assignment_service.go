@@ -0,0 +1,175 @@
+// internal/service/teaching_assignment_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type TeachingAssignmentService struct {
+	db *db.PrismaClient
+}
+
+func NewTeachingAssignmentService(db *db.PrismaClient) *TeachingAssignmentService {
+	return &TeachingAssignmentService{db: db}
+}
+
+// TeacherUnavailabilityInput adalah satu slot ketika guru tidak bisa mengajar.
+type TeacherUnavailabilityInput struct {
+	DayOfWeek string
+	StartTime string // HH:MM
+	EndTime   string // HH:MM
+	Reason    string
+}
+
+// GetAssignments mengambil daftar guru pengampu, bisa difilter per kelas, guru, atau tahun ajaran.
+func (s *TeachingAssignmentService) GetAssignments(classID, teacherID int64, academicYear string) ([]db.TeachingAssignmentModel, error) {
+	var where []db.TeachingAssignmentWhereParam
+	if classID > 0 {
+		where = append(where, db.TeachingAssignment.ClassID.Equals(db.BigInt(classID)))
+	}
+	if teacherID > 0 {
+		where = append(where, db.TeachingAssignment.TeacherID.Equals(db.BigInt(teacherID)))
+	}
+	if academicYear != "" {
+		where = append(where, db.TeachingAssignment.Class.Where(db.Class.AcademicYear.Equals(academicYear)))
+	}
+
+	assignments, err := s.db.TeachingAssignment.FindMany(where...).With(
+		db.TeachingAssignment.Class.Fetch(),
+		db.TeachingAssignment.Subject.Fetch(),
+		db.TeachingAssignment.Teacher.Fetch(),
+	).OrderBy(
+		db.TeachingAssignment.ClassID.Order(db.SortOrderAsc),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve teaching assignments")
+	}
+	return assignments, nil
+}
+
+// UpsertAssignment menetapkan guru pengampu sebuah mapel di satu kelas.
+func (s *TeachingAssignmentService) UpsertAssignment(classID, subjectID, teacherID int64) (*db.TeachingAssignmentModel, error) {
+	ctx := context.Background()
+	if _, err := s.db.Class.FindUnique(db.Class.ID.Equals(db.BigInt(classID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("class not found")
+		}
+		return nil, err
+	}
+	if _, err := s.db.Subject.FindUnique(db.Subject.ID.Equals(db.BigInt(subjectID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("subject not found")
+		}
+		return nil, err
+	}
+	if _, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(teacherID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("teacher not found")
+		}
+		return nil, err
+	}
+
+	return s.db.TeachingAssignment.UpsertOne(
+		db.TeachingAssignment.ClassSubjectUnique(
+			db.TeachingAssignment.ClassID.Equals(db.BigInt(classID)),
+			db.TeachingAssignment.SubjectID.Equals(db.BigInt(subjectID)),
+		),
+	).Create(
+		db.TeachingAssignment.Class.Link(db.Class.ID.Equals(db.BigInt(classID))),
+		db.TeachingAssignment.Subject.Link(db.Subject.ID.Equals(db.BigInt(subjectID))),
+		db.TeachingAssignment.Teacher.Link(db.Teacher.ID.Equals(db.BigInt(teacherID))),
+	).Update(
+		db.TeachingAssignment.Teacher.Link(db.Teacher.ID.Equals(db.BigInt(teacherID))),
+	).With(
+		db.TeachingAssignment.Class.Fetch(),
+		db.TeachingAssignment.Subject.Fetch(),
+		db.TeachingAssignment.Teacher.Fetch(),
+	).Exec(ctx)
+}
+
+// DeleteAssignment menghapus guru pengampu.
+func (s *TeachingAssignmentService) DeleteAssignment(id int) error {
+	_, err := s.db.TeachingAssignment.FindUnique(db.TeachingAssignment.ID.Equals(db.BigInt(id))).Delete().Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("teaching assignment not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// GetUnavailability mengambil slot ketika seorang guru tidak bisa mengajar.
+func (s *TeachingAssignmentService) GetUnavailability(teacherID int) ([]db.TeacherUnavailabilityModel, error) {
+	ctx := context.Background()
+	if _, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(teacherID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("teacher not found")
+		}
+		return nil, err
+	}
+
+	slots, err := s.db.TeacherUnavailability.FindMany(
+		db.TeacherUnavailability.TeacherID.Equals(db.BigInt(teacherID)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve teacher unavailability")
+	}
+	return slots, nil
+}
+
+// ReplaceUnavailability mengganti seluruh slot tidak tersedia milik seorang guru.
+func (s *TeachingAssignmentService) ReplaceUnavailability(teacherID int, inputs []TeacherUnavailabilityInput) ([]db.TeacherUnavailabilityModel, error) {
+	ctx := context.Background()
+	if _, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(teacherID))).Exec(ctx); err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("teacher not found")
+		}
+		return nil, err
+	}
+
+	txs := []transaction.Param{
+		s.db.TeacherUnavailability.FindMany(
+			db.TeacherUnavailability.TeacherID.Equals(db.BigInt(teacherID)),
+		).Delete().Tx(),
+	}
+	for i, input := range inputs {
+		day, err := parseDayOfWeek(input.DayOfWeek)
+		if err != nil {
+			return nil, validationError("slot %d: %s", i, err.Error())
+		}
+		start, err := parseClock(input.StartTime)
+		if err != nil {
+			return nil, validationError("slot %d: %s", i, err.Error())
+		}
+		end, err := parseClock(input.EndTime)
+		if err != nil {
+			return nil, validationError("slot %d: %s", i, err.Error())
+		}
+		if !start.Before(end) {
+			return nil, validationError("slot %d: start time must be before end time", i)
+		}
+
+		var reason *string
+		if r := strings.TrimSpace(input.Reason); r != "" {
+			reason = &r
+		}
+		txs = append(txs, s.db.TeacherUnavailability.CreateOne(
+			db.TeacherUnavailability.DayOfWeek.Set(day),
+			db.TeacherUnavailability.StartTime.Set(start),
+			db.TeacherUnavailability.EndTime.Set(end),
+			db.TeacherUnavailability.Teacher.Link(db.Teacher.ID.Equals(db.BigInt(teacherID))),
+			db.TeacherUnavailability.Reason.SetIfPresent(reason),
+		).Tx())
+	}
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to save teacher unavailability")
+	}
+	return s.GetUnavailability(teacherID)
+}
@@ -0,0 +1,469 @@
+// internal/service/teaching_journal_service.go
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type TeachingJournalService struct {
+	db *db.PrismaClient
+}
+
+func NewTeachingJournalService(db *db.PrismaClient) *TeachingJournalService {
+	return &TeachingJournalService{db: db}
+}
+
+// JournalInput adalah isi jurnal mengajar untuk satu jam pelajaran pada satu tanggal.
+// Attendances cukup berisi siswa yang statusnya berbeda dari isian otomatis.
+type JournalInput struct {
+	ScheduleID   int64
+	TeachingDate string // YYYY-MM-DD
+	Topic        string
+	Notes        string
+	Attendances  []StudentAttendanceInput
+}
+
+// TeachingJournalFilters adalah filter untuk daftar jurnal mengajar.
+type TeachingJournalFilters struct {
+	ScheduleID int64
+	ClassID    int64
+	TeacherID  int64
+	From       string
+	To         string
+	Page       int
+	Limit      int
+}
+
+// journalEditLockDays adalah batas hari setelah tanggal mengajar ketika jurnal
+// masih boleh diubah (JOURNAL_EDIT_LOCK_DAYS, default 7).
+func journalEditLockDays() int {
+	days := viper.GetInt("JOURNAL_EDIT_LOCK_DAYS")
+	if days <= 0 {
+		return 7
+	}
+	return days
+}
+
+// GetJournals mengambil daftar jurnal dengan paginasi. Guru hanya melihat jurnal
+// untuk jadwalnya sendiri atau jurnal yang ia tulis sebagai guru pengganti.
+func (s *TeachingJournalService) GetJournals(user *db.UserModel, filters TeachingJournalFilters) ([]db.TeachingJournalModel, int, error) {
+	ctx := context.Background()
+	var where []db.TeachingJournalWhereParam
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.TeachingJournal.Or(
+			db.TeachingJournal.AuthorTeacherID.Equals(teacher.ID),
+			db.TeachingJournal.Schedule.Where(db.Schedule.TeacherID.Equals(teacher.ID)),
+		))
+	}
+	if filters.ScheduleID > 0 {
+		where = append(where, db.TeachingJournal.ScheduleID.Equals(db.BigInt(filters.ScheduleID)))
+	}
+	if filters.ClassID > 0 {
+		where = append(where, db.TeachingJournal.Schedule.Where(db.Schedule.ClassID.Equals(db.BigInt(filters.ClassID))))
+	}
+	if filters.TeacherID > 0 {
+		teacherID := db.BigInt(filters.TeacherID)
+		where = append(where, db.TeachingJournal.Or(
+			db.TeachingJournal.AuthorTeacherID.Equals(teacherID),
+			db.TeachingJournal.Schedule.Where(db.Schedule.TeacherID.Equals(teacherID)),
+		))
+	}
+	if filters.From != "" {
+		from, err := parseDate(filters.From)
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.TeachingJournal.TeachingDate.Gte(from))
+	}
+	if filters.To != "" {
+		to, err := parseDate(filters.To)
+		if err != nil {
+			return nil, 0, err
+		}
+		where = append(where, db.TeachingJournal.TeachingDate.Lte(to))
+	}
+
+	all, err := s.db.TeachingJournal.FindMany(where...).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to count teaching journals")
+	}
+
+	journals, err := s.db.TeachingJournal.FindMany(where...).With(
+		db.TeachingJournal.Schedule.Fetch().With(
+			db.Schedule.Class.Fetch(),
+			db.Schedule.Subject.Fetch(),
+			db.Schedule.Teacher.Fetch(),
+		),
+		db.TeachingJournal.AuthorTeacher.Fetch(),
+	).OrderBy(
+		db.TeachingJournal.TeachingDate.Order(db.SortOrderDesc),
+	).Skip((filters.Page - 1) * filters.Limit).Take(filters.Limit).Exec(ctx)
+	if err != nil {
+		return nil, 0, errors.New("failed to retrieve teaching journals")
+	}
+	return journals, len(all), nil
+}
+
+// GetJournalByID mengambil satu jurnal. Guru hanya boleh melihat jurnal miliknya.
+func (s *TeachingJournalService) GetJournalByID(user *db.UserModel, id int) (*db.TeachingJournalModel, error) {
+	ctx := context.Background()
+	journal, err := s.findJournal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role == db.UserRoleTeacher {
+		teacher, err := teacherOfUser(ctx, s.db, int(user.ID))
+		if err != nil {
+			return nil, err
+		}
+		authorID, _ := journal.AuthorTeacherID()
+		if authorID != teacher.ID && journal.Schedule().TeacherID != teacher.ID {
+			return nil, forbiddenError("you can only view your own teaching journals")
+		}
+	}
+	return journal, nil
+}
+
+func (s *TeachingJournalService) findJournal(ctx context.Context, id int) (*db.TeachingJournalModel, error) {
+	journal, err := s.db.TeachingJournal.FindUnique(db.TeachingJournal.ID.Equals(db.BigInt(id))).With(
+		db.TeachingJournal.Schedule.Fetch().With(
+			db.Schedule.Class.Fetch(),
+			db.Schedule.Subject.Fetch(),
+			db.Schedule.Teacher.Fetch(),
+		),
+		db.TeachingJournal.AuthorTeacher.Fetch(),
+		db.TeachingJournal.Attendances.Fetch().With(
+			db.StudentLessonAttendance.Student.Fetch(),
+		),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("teaching journal not found")
+		}
+		return nil, err
+	}
+	return journal, nil
+}
+
+// CreateJournal membuat jurnal mengajar untuk salah satu jadwal milik guru yang
+// login (atau jadwal yang ia gantikan) pada tanggal yang harinya sesuai jadwal,
+// sekaligus mencatat kehadiran setiap siswa kelas tersebut.
+func (s *TeachingJournalService) CreateJournal(userID int, input JournalInput) (*db.TeachingJournalModel, error) {
+	ctx := context.Background()
+	teacher, err := teacherOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseDate(input.TeachingDate)
+	if err != nil {
+		return nil, err
+	}
+	if date.After(today()) {
+		return nil, validationError("cannot write a teaching journal for a future date")
+	}
+	schedule, err := s.db.Schedule.FindUnique(db.Schedule.ID.Equals(db.BigInt(input.ScheduleID))).With(
+		db.Schedule.Class.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("schedule not found")
+		}
+		return nil, err
+	}
+	if day := dayOfWeekOf(date); day != schedule.DayOfWeek {
+		return nil, validationError("%s is a %s but the schedule is on %s", formatDate(date), day, schedule.DayOfWeek)
+	}
+	year, err := resolveAcademicYear(ctx, s.db, date)
+	if err != nil {
+		return nil, err
+	}
+	if year != schedule.Class().AcademicYear {
+		return nil, validationError("%s is outside academic year %s of this schedule", formatDate(date), schedule.Class().AcademicYear)
+	}
+	if err := canTeach(ctx, s.db, schedule, teacher.ID, date); err != nil {
+		return nil, err
+	}
+
+	topic := strings.TrimSpace(input.Topic)
+	if topic == "" {
+		return nil, validationError("topic is required")
+	}
+	_, err = s.db.TeachingJournal.FindUnique(db.TeachingJournal.ScheduleDateUnique(
+		db.TeachingJournal.ScheduleID.Equals(schedule.ID),
+		db.TeachingJournal.TeachingDate.Equals(date),
+	)).Exec(ctx)
+	if err == nil {
+		return nil, conflictError("a teaching journal already exists for this lesson on %s", formatDate(date))
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	roster, err := buildRoster(ctx, s.db, schedule.ClassID, date, nil)
+	if err != nil {
+		return nil, err
+	}
+	roster, err = applyAttendanceInputs(roster, input.Attendances)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := []transaction.Param{
+		s.db.TeachingJournal.CreateOne(
+			db.TeachingJournal.TeachingDate.Set(date),
+			db.TeachingJournal.Topic.Set(topic),
+			db.TeachingJournal.Schedule.Link(db.Schedule.ID.Equals(schedule.ID)),
+			db.TeachingJournal.StudentAttendanceSummary.SetIfPresent(attendanceSummary(roster)),
+			db.TeachingJournal.Notes.SetIfPresent(optionalString(input.Notes)),
+			db.TeachingJournal.AuthorTeacher.Link(db.Teacher.ID.Equals(teacher.ID)),
+		).Tx(),
+	}
+	txs = append(txs, attendanceCreateTxs(s.db, schedule.ID, date, roster)...)
+	// Pengingat jurnal untuk jam pelajaran ini tidak diperlukan lagi.
+	txs = append(txs, s.db.JournalReminder.FindMany(
+		db.JournalReminder.ScheduleID.Equals(schedule.ID),
+		db.JournalReminder.TeachingDate.Equals(date),
+	).Delete().Tx())
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to create teaching journal")
+	}
+
+	created, err := s.db.TeachingJournal.FindUnique(db.TeachingJournal.ScheduleDateUnique(
+		db.TeachingJournal.ScheduleID.Equals(schedule.ID),
+		db.TeachingJournal.TeachingDate.Equals(date),
+	)).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.findJournal(ctx, int(created.ID))
+}
+
+// UpdateJournal mengubah isi jurnal dan kehadiran siswa. Hanya penulis jurnal
+// yang boleh mengubah, dan jurnal terkunci setelah melewati batas JOURNAL_EDIT_LOCK_DAYS.
+func (s *TeachingJournalService) UpdateJournal(userID, id int, input JournalInput) (*db.TeachingJournalModel, error) {
+	ctx := context.Background()
+	teacher, err := teacherOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	journal, err := s.findJournal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if authorID, ok := journal.AuthorTeacherID(); ok {
+		if authorID != teacher.ID {
+			return nil, forbiddenError("only the author can edit this teaching journal")
+		}
+	} else if err := canTeach(ctx, s.db, journal.Schedule(), teacher.ID, journal.TeachingDate); err != nil {
+		return nil, err
+	}
+	if JournalLocked(journal.TeachingDate) {
+		return nil, forbiddenError("teaching journal is locked; edits are allowed up to %d days after the teaching date", journalEditLockDays())
+	}
+
+	topic := strings.TrimSpace(input.Topic)
+	if topic == "" {
+		return nil, validationError("topic is required")
+	}
+	roster, err := buildRoster(ctx, s.db, journal.Schedule().ClassID, journal.TeachingDate, journal.Attendances())
+	if err != nil {
+		return nil, err
+	}
+	roster, err = applyAttendanceInputs(roster, input.Attendances)
+	if err != nil {
+		return nil, err
+	}
+
+	update := []db.TeachingJournalSetParam{
+		db.TeachingJournal.Topic.Set(topic),
+		db.TeachingJournal.Notes.SetOptional(optionalString(input.Notes)),
+		db.TeachingJournal.AuthorTeacher.Link(db.Teacher.ID.Equals(teacher.ID)),
+	}
+	// Jurnal lama tanpa daftar siswa mempertahankan ringkasan teksnya.
+	if summary := attendanceSummary(roster); summary != nil {
+		update = append(update, db.TeachingJournal.StudentAttendanceSummary.Set(*summary))
+	}
+	txs := []transaction.Param{
+		s.db.TeachingJournal.FindUnique(db.TeachingJournal.ID.Equals(journal.ID)).Update(update...).Tx(),
+	}
+	txs = append(txs, attendanceUpsertTxs(s.db, journal.ID, roster)...)
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to update teaching journal")
+	}
+	return s.findJournal(ctx, id)
+}
+
+// GetRoster mengambil daftar hadir sebuah jam pelajaran pada suatu tanggal untuk
+// diisi guru. Jika jurnalnya sudah ada, status yang tersimpan yang ditampilkan.
+func (s *TeachingJournalService) GetRoster(userID int, scheduleID int64, teachingDate string) ([]RosterEntry, error) {
+	ctx := context.Background()
+	teacher, err := teacherOfUser(ctx, s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	date, err := parseDate(teachingDate)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := s.db.Schedule.FindUnique(db.Schedule.ID.Equals(db.BigInt(scheduleID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("schedule not found")
+		}
+		return nil, err
+	}
+	if day := dayOfWeekOf(date); day != schedule.DayOfWeek {
+		return nil, validationError("%s is a %s but the schedule is on %s", formatDate(date), day, schedule.DayOfWeek)
+	}
+	if err := canTeach(ctx, s.db, schedule, teacher.ID, date); err != nil {
+		return nil, err
+	}
+
+	var saved []db.StudentLessonAttendanceModel
+	journal, err := s.db.TeachingJournal.FindUnique(db.TeachingJournal.ScheduleDateUnique(
+		db.TeachingJournal.ScheduleID.Equals(schedule.ID),
+		db.TeachingJournal.TeachingDate.Equals(date),
+	)).With(
+		db.TeachingJournal.Attendances.Fetch().With(
+			db.StudentLessonAttendance.Student.Fetch(),
+		),
+	).Exec(ctx)
+	if err == nil {
+		saved = journal.Attendances()
+	} else if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+	return buildRoster(ctx, s.db, schedule.ClassID, date, saved)
+}
+
+// GetLessonAttendances mengambil riwayat kehadiran siswa per jam pelajaran,
+// misalnya untuk melihat siapa yang alpa pada pelajaran tertentu.
+func (s *TeachingJournalService) GetLessonAttendances(filters LessonAttendanceFilters) ([]db.StudentLessonAttendanceModel, error) {
+	var where []db.StudentLessonAttendanceWhereParam
+	var journal []db.TeachingJournalWhereParam
+	if filters.From != "" {
+		from, err := parseDate(filters.From)
+		if err != nil {
+			return nil, err
+		}
+		journal = append(journal, db.TeachingJournal.TeachingDate.Gte(from))
+	}
+	if filters.To != "" {
+		to, err := parseDate(filters.To)
+		if err != nil {
+			return nil, err
+		}
+		journal = append(journal, db.TeachingJournal.TeachingDate.Lte(to))
+	}
+	if filters.ClassID > 0 {
+		journal = append(journal, db.TeachingJournal.Schedule.Where(db.Schedule.ClassID.Equals(db.BigInt(filters.ClassID))))
+	}
+	if len(journal) > 0 {
+		where = append(where, db.StudentLessonAttendance.Journal.Where(journal...))
+	}
+	if filters.StudentID > 0 {
+		where = append(where, db.StudentLessonAttendance.StudentID.Equals(db.BigInt(filters.StudentID)))
+	}
+	if filters.studentID > 0 {
+		where = append(where, db.StudentLessonAttendance.StudentID.Equals(filters.studentID))
+	}
+	if filters.Status != "" {
+		status, err := parseLessonAttendanceStatus(filters.Status)
+		if err != nil {
+			return nil, err
+		}
+		where = append(where, db.StudentLessonAttendance.Status.Equals(status))
+	}
+
+	attendances, err := s.db.StudentLessonAttendance.FindMany(where...).With(
+		db.StudentLessonAttendance.Student.Fetch(),
+		db.StudentLessonAttendance.Journal.Fetch().With(
+			db.TeachingJournal.Schedule.Fetch().With(
+				db.Schedule.Class.Fetch(),
+				db.Schedule.Subject.Fetch(),
+				db.Schedule.Teacher.Fetch(),
+			),
+		),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve lesson attendances")
+	}
+	sort.SliceStable(attendances, func(i, j int) bool {
+		return attendances[i].Journal().TeachingDate.After(attendances[j].Journal().TeachingDate)
+	})
+	return attendances, nil
+}
+
+// GetMyLessonAttendances mengambil riwayat kehadiran per jam pelajaran milik siswa yang login.
+func (s *TeachingJournalService) GetMyLessonAttendances(userID int, filters LessonAttendanceFilters) ([]db.StudentLessonAttendanceModel, error) {
+	student, err := studentOfUser(context.Background(), s.db, userID)
+	if err != nil {
+		return nil, err
+	}
+	filters.StudentID = 0
+	filters.ClassID = 0
+	filters.studentID = student.ID
+	return s.GetLessonAttendances(filters)
+}
+
+// JournalLocked menentukan apakah jurnal pada tanggal tersebut sudah tidak bisa diubah.
+func JournalLocked(teachingDate time.Time) bool {
+	return today().After(teachingDate.AddDate(0, 0, journalEditLockDays()))
+}
+
+// canTeach memastikan seorang guru berhak mengisi jurnal sebuah jam pelajaran
+// pada tanggal tertentu: guru pengganti yang tercatat, atau guru jadwal itu
+// sendiri jika pada tanggal tersebut tidak sedang digantikan.
+func canTeach(ctx context.Context, client *db.PrismaClient, schedule *db.ScheduleModel, teacherID db.BigInt, date time.Time) error {
+	substitution, err := client.Substitution.FindUnique(db.Substitution.ScheduleDateUnique(
+		db.Substitution.ScheduleID.Equals(schedule.ID),
+		db.Substitution.Date.Equals(date),
+	)).Exec(ctx)
+	if err != nil && !errors.Is(err, db.ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		if substitution.SubstituteTeacherID != teacherID {
+			return forbiddenError("this lesson is taught by a substitute teacher on %s", formatDate(date))
+		}
+		return nil
+	}
+	if schedule.TeacherID != teacherID {
+		return forbiddenError("you are not assigned to this lesson")
+	}
+	return nil
+}
+
+// teacherOfUser mengambil profil guru milik sebuah user.
+func teacherOfUser(ctx context.Context, client *db.PrismaClient, userID int) (*db.TeacherModel, error) {
+	teacher, err := client.Teacher.FindUnique(db.Teacher.UserID.Equals(db.BigInt(userID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, forbiddenError("only teachers can access this resource")
+		}
+		return nil, err
+	}
+	return teacher, nil
+}
+
+// optionalString mengubah string kosong menjadi nil untuk kolom opsional.
+func optionalString(value string) *string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,647 @@
+// internal/service/timetable_draft_service.go
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steebchen/prisma-client-go/runtime/transaction"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/timetable"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type TimetableDraftService struct {
+	db        *db.PrismaClient
+	schedules *ScheduleService
+}
+
+func NewTimetableDraftService(db *db.PrismaClient) *TimetableDraftService {
+	return &TimetableDraftService{db: db, schedules: NewScheduleService(db)}
+}
+
+// TimetablePeriod adalah satu jam pelajaran dalam sehari.
+type TimetablePeriod struct {
+	StartTime string `json:"start_time" example:"07:00"`
+	EndTime   string `json:"end_time" example:"07:45"`
+}
+
+// TimetableRoomBlock adalah rentang waktu ketika sebuah ruangan tidak bisa dipakai.
+type TimetableRoomBlock struct {
+	DayOfWeek string `json:"day_of_week" example:"Jumat"`
+	StartTime string `json:"start_time" example:"11:00"`
+	EndTime   string `json:"end_time" example:"13:00"`
+}
+
+// TimetableRoom adalah ruangan yang bisa dipakai penyusun jadwal.
+type TimetableRoom struct {
+	Name        string               `json:"name" example:"Lab RPL 1"`
+	Unavailable []TimetableRoomBlock `json:"unavailable,omitempty"`
+}
+
+// GenerateTimetableParams adalah input penyusun jadwal otomatis. Nilainya
+// disimpan pada draft agar hasil bisa ditelusuri dan diulang dengan seed yang sama.
+type GenerateTimetableParams struct {
+	AcademicYear     string            `json:"academic_year"`
+	ClassIDs         []int64           `json:"class_ids"`           // Kosong berarti semua kelas pada tahun ajaran
+	Days             []string          `json:"days"`                // Default Senin sampai Jumat
+	Periods          []TimetablePeriod `json:"periods"`             // Jam pelajaran harian, urut dan tidak beririsan
+	Rooms            []TimetableRoom   `json:"rooms,omitempty"`     // Kosong berarti ruangan tidak ikut dijadwalkan
+	MaxPeriodsPerDay int               `json:"max_periods_per_day"` // Maksimal jam satu mapel per hari, default 2
+	Attempts         int               `json:"attempts"`
+	Seed             int64             `json:"seed"`
+}
+
+// UnplacedLesson adalah jam pelajaran yang tidak berhasil dijadwalkan.
+type UnplacedLesson struct {
+	ClassID     int64  `json:"class_id" example:"3"`
+	ClassName   string `json:"class_name" example:"X RPL 1"`
+	SubjectID   int64  `json:"subject_id" example:"1"`
+	SubjectCode string `json:"subject_code" example:"MTK"`
+	SubjectName string `json:"subject_name" example:"Matematika"`
+	TeacherID   int64  `json:"teacher_id,omitempty" example:"2"`
+	TeacherName string `json:"teacher_name,omitempty" example:"Budi Santoso, S.Pd"`
+	Hours       int    `json:"hours" example:"2"`
+	Reason      string `json:"reason" example:"no teacher assigned"`
+}
+
+// TimetableDraftDetail adalah draft beserta pengaturan dan laporan jam yang tidak terjadwal.
+type TimetableDraftDetail struct {
+	Draft    *db.TimetableDraftModel
+	Settings GenerateTimetableParams
+	Unplaced []UnplacedLesson
+}
+
+// clockRange adalah satu jam pelajaran yang sudah diparse.
+type clockRange struct {
+	start time.Time
+	end   time.Time
+}
+
+// lessonRef menyimpan data kelas, mapel, dan guru untuk setiap timetable.Lesson.
+type lessonRef struct {
+	class   db.ClassModel
+	subject db.SubjectModel
+	teacher db.TeacherModel
+}
+
+// Generate menyusun jadwal untuk kelas-kelas pada satu tahun ajaran dan
+// menyimpannya sebagai draft. Jadwal kelas lain yang sudah tersimpan dihormati:
+// guru dan ruangan yang terpakai di sana tidak akan dijadwalkan bentrok.
+func (s *TimetableDraftService) Generate(params GenerateTimetableParams) (*TimetableDraftDetail, error) {
+	ctx := context.Background()
+	if _, _, err := parseAcademicYear(params.AcademicYear); err != nil {
+		return nil, err
+	}
+	days, err := parseTimetableDays(params.Days)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := parseTimetablePeriods(params.Periods)
+	if err != nil {
+		return nil, err
+	}
+	classes, err := s.draftClasses(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	params.Days = make([]string, 0, len(days))
+	for _, day := range days {
+		params.Days = append(params.Days, string(day))
+	}
+	params.ClassIDs = make([]int64, 0, len(classes))
+	for _, class := range classes {
+		params.ClassIDs = append(params.ClassIDs, int64(class.ID))
+	}
+	if params.Seed == 0 {
+		params.Seed = time.Now().UnixNano()
+	}
+
+	problem := timetable.Problem{
+		Days:               len(days),
+		PeriodsPerDay:      len(periods),
+		TeacherUnavailable: map[int64][]timetable.Slot{},
+		MaxPerDay:          params.MaxPeriodsPerDay,
+		Attempts:           params.Attempts,
+		Seed:               params.Seed,
+	}
+	var refs []lessonRef
+	unplaced := []UnplacedLesson{}
+	teacherIDs := map[db.BigInt]bool{}
+
+	for _, class := range classes {
+		class := class
+		curriculum, err := curriculumForClass(ctx, s.db, &class)
+		if err != nil {
+			return nil, err
+		}
+		if len(curriculum) == 0 {
+			return nil, validationError("class %s has no curriculum; fill the curriculum before generating", class.ClassName)
+		}
+		assignments, err := s.db.TeachingAssignment.FindMany(
+			db.TeachingAssignment.ClassID.Equals(class.ID),
+		).With(
+			db.TeachingAssignment.Teacher.Fetch(),
+		).Exec(ctx)
+		if err != nil {
+			return nil, errors.New("failed to retrieve teaching assignments")
+		}
+		teacherOf := make(map[db.BigInt]db.TeacherModel, len(assignments))
+		for _, assignment := range assignments {
+			teacherOf[assignment.SubjectID] = *assignment.Teacher()
+		}
+
+		subjectIDs := make([]db.BigInt, 0, len(curriculum))
+		for id := range curriculum {
+			subjectIDs = append(subjectIDs, id)
+		}
+		sort.Slice(subjectIDs, func(i, j int) bool { return subjectIDs[i] < subjectIDs[j] })
+
+		for _, subjectID := range subjectIDs {
+			item := curriculum[subjectID]
+			subject := item.Subject()
+			teacher, ok := teacherOf[subjectID]
+			if !ok {
+				unplaced = append(unplaced, UnplacedLesson{
+					ClassID:     int64(class.ID),
+					ClassName:   class.ClassName,
+					SubjectID:   int64(subject.ID),
+					SubjectCode: subject.SubjectCode,
+					SubjectName: subject.SubjectName,
+					Hours:       item.WeeklyHours,
+					Reason:      "no teacher assigned",
+				})
+				continue
+			}
+			problem.Lessons = append(problem.Lessons, timetable.Lesson{
+				ClassID:   int64(class.ID),
+				SubjectID: int64(subject.ID),
+				TeacherID: int64(teacher.ID),
+				Hours:     item.WeeklyHours,
+			})
+			refs = append(refs, lessonRef{class: class, subject: *subject, teacher: teacher})
+			teacherIDs[teacher.ID] = true
+		}
+	}
+
+	rooms, err := parseTimetableRooms(params.Rooms, days, periods)
+	if err != nil {
+		return nil, err
+	}
+	problem.Rooms = rooms
+	if err := s.blockBusySlots(ctx, &problem, params, teacherIDs, days, periods); err != nil {
+		return nil, err
+	}
+
+	result := timetable.Solve(problem)
+	for _, u := range result.Unplaced {
+		ref := refs[u.Lesson]
+		unplaced = append(unplaced, UnplacedLesson{
+			ClassID:     int64(ref.class.ID),
+			ClassName:   ref.class.ClassName,
+			SubjectID:   int64(ref.subject.ID),
+			SubjectCode: ref.subject.SubjectCode,
+			SubjectName: ref.subject.SubjectName,
+			TeacherID:   int64(ref.teacher.ID),
+			TeacherName: ref.teacher.FullName,
+			Hours:       u.Hours,
+			Reason:      u.Reason,
+		})
+	}
+
+	entries := mergePlacements(problem, result.Placements, days, periods)
+	return s.saveDraft(ctx, params, entries, unplaced)
+}
+
+// draftClasses mengambil kelas yang akan dijadwalkan dan memastikan semuanya
+// berada pada tahun ajaran yang diminta.
+func (s *TimetableDraftService) draftClasses(ctx context.Context, params GenerateTimetableParams) ([]db.ClassModel, error) {
+	where := []db.ClassWhereParam{db.Class.AcademicYear.Equals(params.AcademicYear)}
+	if len(params.ClassIDs) > 0 {
+		ids := make([]db.BigInt, 0, len(params.ClassIDs))
+		for _, id := range params.ClassIDs {
+			ids = append(ids, db.BigInt(id))
+		}
+		where = append(where, db.Class.ID.In(ids))
+	}
+	classes, err := s.db.Class.FindMany(where...).OrderBy(
+		db.Class.ClassName.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve classes")
+	}
+	if len(params.ClassIDs) > 0 && len(classes) != len(params.ClassIDs) {
+		return nil, validationError("some classes were not found in academic year %s", params.AcademicYear)
+	}
+	if len(classes) == 0 {
+		return nil, validationError("no classes found in academic year %s", params.AcademicYear)
+	}
+	return classes, nil
+}
+
+// blockBusySlots menandai slot yang tidak bisa dipakai guru: slot tidak tersedia
+// yang dicatat guru dan jadwal tersimpan di kelas lain pada tahun ajaran yang sama.
+// Ruangan yang dipakai jadwal kelas lain juga ditandai terpakai.
+func (s *TimetableDraftService) blockBusySlots(ctx context.Context, problem *timetable.Problem, params GenerateTimetableParams, teacherIDs map[db.BigInt]bool, days []db.DayOfWeek, periods []clockRange) error {
+	ids := make([]db.BigInt, 0, len(teacherIDs))
+	for id := range teacherIDs {
+		ids = append(ids, id)
+	}
+	if len(ids) > 0 {
+		unavailable, err := s.db.TeacherUnavailability.FindMany(
+			db.TeacherUnavailability.TeacherID.In(ids),
+		).Exec(ctx)
+		if err != nil {
+			return errors.New("failed to retrieve teacher unavailability")
+		}
+		for _, u := range unavailable {
+			for _, slot := range slotsOverlapping(u.DayOfWeek, u.StartTime, u.EndTime, days, periods) {
+				problem.TeacherUnavailable[int64(u.TeacherID)] = append(problem.TeacherUnavailable[int64(u.TeacherID)], slot)
+			}
+		}
+	}
+
+	classIDs := make([]db.BigInt, 0, len(params.ClassIDs))
+	for _, id := range params.ClassIDs {
+		classIDs = append(classIDs, db.BigInt(id))
+	}
+	others, err := s.db.Schedule.FindMany(
+		db.Schedule.Not(db.Schedule.ClassID.In(classIDs)),
+		db.Schedule.Class.Where(db.Class.AcademicYear.Equals(params.AcademicYear)),
+	).Exec(ctx)
+	if err != nil {
+		return errors.New("failed to retrieve existing schedules")
+	}
+	for _, schedule := range others {
+		slots := slotsOverlapping(schedule.DayOfWeek, schedule.StartTime, schedule.EndTime, days, periods)
+		if teacherIDs[schedule.TeacherID] {
+			problem.TeacherUnavailable[int64(schedule.TeacherID)] = append(problem.TeacherUnavailable[int64(schedule.TeacherID)], slots...)
+		}
+		room, _ := schedule.Room()
+		for i := range problem.Rooms {
+			if room != "" && strings.EqualFold(problem.Rooms[i].Name, room) {
+				problem.Rooms[i].Unavailable = append(problem.Rooms[i].Unavailable, slots...)
+			}
+		}
+	}
+	return nil
+}
+
+// saveDraft menyimpan draft dan seluruh slotnya. Slot dibuat setelah draft ada
+// karena butuh ID draft; jika gagal, draft yang setengah jadi dihapus lagi.
+func (s *TimetableDraftService) saveDraft(ctx context.Context, params GenerateTimetableParams, entries []plannedSchedule, unplaced []UnplacedLesson) (*TimetableDraftDetail, error) {
+	settings, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	report, err := json.Marshal(unplaced)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := s.db.TimetableDraft.CreateOne(
+		db.TimetableDraft.AcademicYear.Set(params.AcademicYear),
+		db.TimetableDraft.Settings.Set(string(settings)),
+		db.TimetableDraft.Unplaced.Set(string(report)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to create timetable draft")
+	}
+
+	txs := make([]transaction.Param, 0, len(entries))
+	for _, p := range entries {
+		txs = append(txs, s.db.TimetableDraftEntry.CreateOne(
+			db.TimetableDraftEntry.DayOfWeek.Set(p.day),
+			db.TimetableDraftEntry.StartTime.Set(p.start),
+			db.TimetableDraftEntry.EndTime.Set(p.end),
+			db.TimetableDraftEntry.Draft.Link(db.TimetableDraft.ID.Equals(draft.ID)),
+			db.TimetableDraftEntry.Class.Link(db.Class.ID.Equals(p.classID)),
+			db.TimetableDraftEntry.Subject.Link(db.Subject.ID.Equals(p.subjectID)),
+			db.TimetableDraftEntry.Teacher.Link(db.Teacher.ID.Equals(p.teacherID)),
+			db.TimetableDraftEntry.Room.SetIfPresent(p.roomPtr()),
+		).Tx())
+	}
+	if len(txs) > 0 {
+		if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+			_, _ = s.db.TimetableDraft.FindUnique(db.TimetableDraft.ID.Equals(draft.ID)).Delete().Exec(ctx)
+			return nil, errors.New("failed to save timetable draft entries")
+		}
+	}
+	return s.GetDraft(int(draft.ID))
+}
+
+// GetDrafts mengambil daftar draft jadwal, terbaru lebih dulu.
+func (s *TimetableDraftService) GetDrafts(academicYear string) ([]TimetableDraftDetail, error) {
+	var where []db.TimetableDraftWhereParam
+	if academicYear != "" {
+		where = append(where, db.TimetableDraft.AcademicYear.Equals(academicYear))
+	}
+	drafts, err := s.db.TimetableDraft.FindMany(where...).OrderBy(
+		db.TimetableDraft.CreatedAt.Order(db.SortOrderDesc),
+	).Exec(context.Background())
+	if err != nil {
+		return nil, errors.New("failed to retrieve timetable drafts")
+	}
+
+	details := make([]TimetableDraftDetail, 0, len(drafts))
+	for i := range drafts {
+		detail, err := toDraftDetail(&drafts[i])
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, *detail)
+	}
+	return details, nil
+}
+
+// GetDraft mengambil satu draft beserta seluruh slot jadwalnya.
+func (s *TimetableDraftService) GetDraft(id int) (*TimetableDraftDetail, error) {
+	draft, err := s.db.TimetableDraft.FindUnique(db.TimetableDraft.ID.Equals(db.BigInt(id))).With(
+		db.TimetableDraft.Entries.Fetch().With(
+			db.TimetableDraftEntry.Class.Fetch(),
+			db.TimetableDraftEntry.Subject.Fetch(),
+			db.TimetableDraftEntry.Teacher.Fetch(),
+		),
+	).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("timetable draft not found")
+		}
+		return nil, err
+	}
+
+	entries := draft.Entries()
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Class().ClassName != entries[j].Class().ClassName {
+			return entries[i].Class().ClassName < entries[j].Class().ClassName
+		}
+		di, dj := dayIndex(entries[i].DayOfWeek), dayIndex(entries[j].DayOfWeek)
+		if di != dj {
+			return di < dj
+		}
+		return minutesOfDay(entries[i].StartTime) < minutesOfDay(entries[j].StartTime)
+	})
+	return toDraftDetail(draft)
+}
+
+func toDraftDetail(draft *db.TimetableDraftModel) (*TimetableDraftDetail, error) {
+	detail := &TimetableDraftDetail{Draft: draft}
+	if err := json.Unmarshal([]byte(draft.Settings), &detail.Settings); err != nil {
+		return nil, errors.New("failed to read timetable draft settings")
+	}
+	if err := json.Unmarshal([]byte(draft.Unplaced), &detail.Unplaced); err != nil {
+		return nil, errors.New("failed to read timetable draft report")
+	}
+	return detail, nil
+}
+
+// CommitDraft mengganti jadwal kelas-kelas pada draft dengan hasil draft dalam
+// satu transaksi. Bentrok dicek ulang karena jadwal lain bisa berubah sejak
+// draft dibuat, dan jadwal lama yang sudah punya jurnal tidak boleh dihapus.
+func (s *TimetableDraftService) CommitDraft(id int) (*TimetableDraftDetail, error) {
+	ctx := context.Background()
+	detail, err := s.GetDraft(id)
+	if err != nil {
+		return nil, err
+	}
+	draft := detail.Draft
+	if draft.Status == db.TimetableDraftStatusCommitted {
+		return nil, conflictError("timetable draft has already been committed")
+	}
+
+	classIDs := make([]db.BigInt, 0, len(detail.Settings.ClassIDs))
+	for _, classID := range detail.Settings.ClassIDs {
+		classIDs = append(classIDs, db.BigInt(classID))
+	}
+	classes, err := s.db.Class.FindMany(db.Class.ID.In(classIDs)).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve classes")
+	}
+	if len(classes) != len(classIDs) {
+		return nil, conflictError("some classes in the draft no longer exist")
+	}
+
+	planned := make([]plannedSchedule, 0, len(draft.Entries()))
+	byClass := map[db.BigInt][]plannedSchedule{}
+	for _, entry := range draft.Entries() {
+		room, _ := entry.Room()
+		p := plannedSchedule{
+			classID:   entry.ClassID,
+			subjectID: entry.SubjectID,
+			teacherID: entry.TeacherID,
+			day:       entry.DayOfWeek,
+			start:     entry.StartTime,
+			end:       entry.EndTime,
+			room:      room,
+		}
+		planned = append(planned, p)
+		byClass[p.classID] = append(byClass[p.classID], p)
+	}
+
+	existing, err := s.db.Schedule.FindMany(db.Schedule.ClassID.In(classIDs)).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve class schedules")
+	}
+	exclude := make([]db.BigInt, 0, len(existing))
+	for _, schedule := range existing {
+		exclude = append(exclude, schedule.ID)
+	}
+
+	if err := s.schedules.checkConflicts(ctx, draft.AcademicYear, planned, exclude); err != nil {
+		return nil, err
+	}
+	for i := range classes {
+		if err := s.schedules.checkCurriculum(ctx, &classes[i], byClass[classes[i].ID]); err != nil {
+			return nil, err
+		}
+	}
+	if len(exclude) > 0 {
+		journals, err := s.db.TeachingJournal.FindMany(db.TeachingJournal.ScheduleID.In(exclude)).Exec(ctx)
+		if err != nil {
+			return nil, errors.New("failed to check teaching journals")
+		}
+		if len(journals) > 0 {
+			return nil, conflictError("schedule %d already has teaching journals and cannot be replaced", journals[0].ScheduleID)
+		}
+	}
+
+	txs := []transaction.Param{
+		s.db.Schedule.FindMany(db.Schedule.ClassID.In(classIDs)).Delete().Tx(),
+	}
+	for _, p := range planned {
+		txs = append(txs, s.schedules.createTx(p))
+	}
+	txs = append(txs, s.db.TimetableDraft.FindUnique(db.TimetableDraft.ID.Equals(draft.ID)).Update(
+		db.TimetableDraft.Status.Set(db.TimetableDraftStatusCommitted),
+		db.TimetableDraft.CommittedAt.Set(time.Now()),
+	).Tx())
+	if err := s.db.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		return nil, errors.New("failed to commit timetable draft")
+	}
+	return s.GetDraft(id)
+}
+
+// DeleteDraft menghapus draft beserta slotnya. Jadwal yang sudah di-commit tidak ikut terhapus.
+func (s *TimetableDraftService) DeleteDraft(id int) error {
+	_, err := s.db.TimetableDraft.FindUnique(db.TimetableDraft.ID.Equals(db.BigInt(id))).Delete().Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return notFoundError("timetable draft not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// parseTimetableDays memvalidasi hari sekolah; default Senin sampai Jumat.
+func parseTimetableDays(values []string) ([]db.DayOfWeek, error) {
+	if len(values) == 0 {
+		return daysOfWeek[:5], nil
+	}
+	seen := map[db.DayOfWeek]bool{}
+	days := make([]db.DayOfWeek, 0, len(values))
+	for _, value := range values {
+		day, err := parseDayOfWeek(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen[day] {
+			return nil, validationError("day %s is listed more than once", day)
+		}
+		seen[day] = true
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return dayIndex(days[i]) < dayIndex(days[j]) })
+	return days, nil
+}
+
+// parseTimetablePeriods memvalidasi jam pelajaran harian: harus urut dan tidak beririsan.
+func parseTimetablePeriods(values []TimetablePeriod) ([]clockRange, error) {
+	if len(values) == 0 {
+		return nil, validationError("at least one period is required")
+	}
+	periods := make([]clockRange, 0, len(values))
+	for i, value := range values {
+		start, err := parseClock(value.StartTime)
+		if err != nil {
+			return nil, validationError("period %d: %s", i+1, err.Error())
+		}
+		end, err := parseClock(value.EndTime)
+		if err != nil {
+			return nil, validationError("period %d: %s", i+1, err.Error())
+		}
+		if !start.Before(end) {
+			return nil, validationError("period %d: start time must be before end time", i+1)
+		}
+		if i > 0 && start.Before(periods[i-1].end) {
+			return nil, validationError("period %d must start after period %d ends", i+1, i)
+		}
+		periods = append(periods, clockRange{start: start, end: end})
+	}
+	return periods, nil
+}
+
+// parseTimetableRooms memvalidasi daftar ruangan dan mengubah jam tidak tersedia menjadi slot.
+func parseTimetableRooms(values []TimetableRoom, days []db.DayOfWeek, periods []clockRange) ([]timetable.Room, error) {
+	seen := map[string]bool{}
+	rooms := make([]timetable.Room, 0, len(values))
+	for _, value := range values {
+		name := strings.TrimSpace(value.Name)
+		if name == "" {
+			return nil, validationError("room name is required")
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, validationError("room %s is listed more than once", name)
+		}
+		seen[strings.ToLower(name)] = true
+
+		room := timetable.Room{Name: name}
+		for _, block := range value.Unavailable {
+			day, err := parseDayOfWeek(block.DayOfWeek)
+			if err != nil {
+				return nil, err
+			}
+			start, err := parseClock(block.StartTime)
+			if err != nil {
+				return nil, err
+			}
+			end, err := parseClock(block.EndTime)
+			if err != nil {
+				return nil, err
+			}
+			room.Unavailable = append(room.Unavailable, slotsOverlapping(day, start, end, days, periods)...)
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms, nil
+}
+
+// slotsOverlapping mengubah rentang waktu pada satu hari menjadi slot solver yang beririsan.
+func slotsOverlapping(day db.DayOfWeek, start, end time.Time, days []db.DayOfWeek, periods []clockRange) []timetable.Slot {
+	var slots []timetable.Slot
+	for d, schoolDay := range days {
+		if schoolDay != day {
+			continue
+		}
+		for p, period := range periods {
+			block := plannedSchedule{day: day, start: period.start, end: period.end}
+			if block.overlaps(day, start, end) {
+				slots = append(slots, timetable.Slot{Day: d, Period: p})
+			}
+		}
+	}
+	return slots
+}
+
+// mergePlacements mengubah hasil solver menjadi slot jadwal. Jam berurutan
+// dengan mapel, guru, dan ruangan yang sama digabung menjadi satu slot
+// (misalnya dua jam pelajaran 07:00-08:30) selama tidak dipisah jam istirahat.
+func mergePlacements(problem timetable.Problem, placements []timetable.Placement, days []db.DayOfWeek, periods []clockRange) []plannedSchedule {
+	sorted := make([]timetable.Placement, len(placements))
+	copy(sorted, placements)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := problem.Lessons[sorted[i].Lesson], problem.Lessons[sorted[j].Lesson]
+		if a.ClassID != b.ClassID {
+			return a.ClassID < b.ClassID
+		}
+		if sorted[i].Day != sorted[j].Day {
+			return sorted[i].Day < sorted[j].Day
+		}
+		return sorted[i].Period < sorted[j].Period
+	})
+
+	var entries []plannedSchedule
+	lastPeriod := -1
+	for _, p := range sorted {
+		lesson := problem.Lessons[p.Lesson]
+		if n := len(entries); n > 0 {
+			last := &entries[n-1]
+			if last.classID == db.BigInt(lesson.ClassID) &&
+				last.subjectID == db.BigInt(lesson.SubjectID) &&
+				last.teacherID == db.BigInt(lesson.TeacherID) &&
+				last.room == p.Room &&
+				last.day == days[p.Day] &&
+				lastPeriod == p.Period-1 &&
+				minutesOfDay(periods[lastPeriod].end) == minutesOfDay(periods[p.Period].start) {
+				last.end = periods[p.Period].end
+				lastPeriod = p.Period
+				continue
+			}
+		}
+		entries = append(entries, plannedSchedule{
+			classID:   db.BigInt(lesson.ClassID),
+			subjectID: db.BigInt(lesson.SubjectID),
+			teacherID: db.BigInt(lesson.TeacherID),
+			day:       days[p.Day],
+			start:     periods[p.Period].start,
+			end:       periods[p.Period].end,
+			room:      p.Room,
+		})
+		lastPeriod = p.Period
+	}
+	return entries
+}
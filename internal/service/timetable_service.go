@@ -0,0 +1,369 @@
+// internal/service/timetable_service.go
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/ical"
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/prisma/db"
+)
+
+type TimetableService struct {
+	db *db.PrismaClient
+}
+
+func NewTimetableService(db *db.PrismaClient) *TimetableService {
+	return &TimetableService{db: db}
+}
+
+// TimetableSlot adalah satu jam pelajaran pada tampilan jadwal mingguan.
+type TimetableSlot struct {
+	ScheduleID  int64  `json:"schedule_id" example:"12"`
+	StartTime   string `json:"start_time" example:"07:00"`
+	EndTime     string `json:"end_time" example:"08:30"`
+	SubjectID   int64  `json:"subject_id" example:"1"`
+	SubjectCode string `json:"subject_code" example:"MTK"`
+	SubjectName string `json:"subject_name" example:"Matematika"`
+	ClassID     int64  `json:"class_id" example:"3"`
+	ClassName   string `json:"class_name" example:"X RPL 1"`
+	TeacherID   int64  `json:"teacher_id" example:"2"`
+	TeacherName string `json:"teacher_name" example:"Budi Santoso, S.Pd"`
+	Room        string `json:"room,omitempty" example:"Lab RPL 1"`
+}
+
+// TimetableDay adalah daftar jam pelajaran pada satu hari.
+type TimetableDay struct {
+	DayOfWeek string          `json:"day_of_week" example:"Senin"`
+	Slots     []TimetableSlot `json:"slots"`
+}
+
+// Timetable adalah jadwal mingguan (grid hari x jam) milik kelas, guru, atau ruangan.
+type Timetable struct {
+	Type         string         `json:"type" example:"class"` // class, teacher, atau room
+	Title        string         `json:"title" example:"X RPL 1"`
+	AcademicYear string         `json:"academic_year" example:"2024/2025"`
+	Days         []TimetableDay `json:"days"`
+
+	schedules []db.ScheduleModel
+}
+
+// ClassTimetable menyusun jadwal mingguan sebuah kelas.
+func (s *TimetableService) ClassTimetable(classID int) (*Timetable, error) {
+	ctx := context.Background()
+	class, err := s.db.Class.FindUnique(db.Class.ID.Equals(db.BigInt(classID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("class not found")
+		}
+		return nil, err
+	}
+	return s.build(ctx, "class", class.ClassName, class.AcademicYear,
+		db.Schedule.ClassID.Equals(class.ID))
+}
+
+// TeacherTimetable menyusun jadwal mengajar seorang guru pada satu tahun ajaran.
+// Tahun ajaran kosong berarti tahun ajaran yang sedang berjalan.
+func (s *TimetableService) TeacherTimetable(teacherID int, academicYear string) (*Timetable, error) {
+	ctx := context.Background()
+	teacher, err := s.db.Teacher.FindUnique(db.Teacher.ID.Equals(db.BigInt(teacherID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("teacher not found")
+		}
+		return nil, err
+	}
+	if academicYear == "" {
+		if academicYear, err = resolveAcademicYear(ctx, s.db, today()); err != nil {
+			return nil, err
+		}
+	}
+	return s.build(ctx, "teacher", teacher.FullName, academicYear,
+		db.Schedule.TeacherID.Equals(teacher.ID),
+		db.Schedule.Class.Where(db.Class.AcademicYear.Equals(academicYear)),
+	)
+}
+
+// RoomTimetable menyusun jadwal pemakaian sebuah ruangan.
+func (s *TimetableService) RoomTimetable(room, academicYear string) (*Timetable, error) {
+	room = strings.TrimSpace(room)
+	if room == "" {
+		return nil, validationError("room is required")
+	}
+	ctx := context.Background()
+	if academicYear == "" {
+		var err error
+		if academicYear, err = resolveAcademicYear(ctx, s.db, today()); err != nil {
+			return nil, err
+		}
+	}
+	return s.build(ctx, "room", room, academicYear,
+		db.Schedule.Room.Equals(room),
+		db.Schedule.Class.Where(db.Class.AcademicYear.Equals(academicYear)),
+	)
+}
+
+// UserTimetable menyusun jadwal milik user yang login: jadwal mengajar untuk
+// guru, atau jadwal kelas saat ini untuk siswa.
+func (s *TimetableService) UserTimetable(userID int) (*Timetable, error) {
+	ctx := context.Background()
+	user, err := s.db.User.FindUnique(db.User.ID.Equals(db.BigInt(userID))).With(
+		db.User.Teacher.Fetch(),
+		db.User.Student.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("user not found")
+		}
+		return nil, err
+	}
+
+	if teacher, ok := user.Teacher(); ok {
+		return s.TeacherTimetable(int(teacher.ID), "")
+	}
+	if student, ok := user.Student(); ok {
+		classID, ok := student.CurrentClassID()
+		if !ok {
+			return nil, notFoundError("student is not assigned to a class")
+		}
+		return s.ClassTimetable(int(classID))
+	}
+	return nil, notFoundError("user has no teacher or student profile")
+}
+
+func (s *TimetableService) build(ctx context.Context, kind, title, academicYear string, where ...db.ScheduleWhereParam) (*Timetable, error) {
+	schedules, err := s.db.Schedule.FindMany(where...).With(
+		db.Schedule.Class.Fetch(),
+		db.Schedule.Subject.Fetch(),
+		db.Schedule.Teacher.Fetch(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, errors.New("failed to retrieve schedules")
+	}
+	sortSchedules(schedules)
+
+	timetable := &Timetable{Type: kind, Title: title, AcademicYear: academicYear, schedules: schedules}
+	for i, day := range daysOfWeek {
+		entry := TimetableDay{DayOfWeek: string(day), Slots: []TimetableSlot{}}
+		for _, schedule := range schedules {
+			if schedule.DayOfWeek != day {
+				continue
+			}
+			room, _ := schedule.Room()
+			entry.Slots = append(entry.Slots, TimetableSlot{
+				ScheduleID:  int64(schedule.ID),
+				StartTime:   formatClock(schedule.StartTime),
+				EndTime:     formatClock(schedule.EndTime),
+				SubjectID:   int64(schedule.SubjectID),
+				SubjectCode: schedule.Subject().SubjectCode,
+				SubjectName: schedule.Subject().SubjectName,
+				ClassID:     int64(schedule.ClassID),
+				ClassName:   schedule.Class().ClassName,
+				TeacherID:   int64(schedule.TeacherID),
+				TeacherName: schedule.Teacher().FullName,
+				Room:        room,
+			})
+		}
+		// Minggu hanya ditampilkan jika memang ada jadwal.
+		if i == len(daysOfWeek)-1 && len(entry.Slots) == 0 {
+			continue
+		}
+		timetable.Days = append(timetable.Days, entry)
+	}
+	return timetable, nil
+}
+
+// UserCalendar menghasilkan file .ics berisi jadwal mingguan user sebagai event
+// berulang setiap minggu selama tahun ajaran.
+func (s *TimetableService) UserCalendar(userID int) ([]byte, error) {
+	timetable, err := s.UserTimetable(userID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := s.academicYearRange(timetable.AcademicYear)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := appLocation()
+	until := time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, 0, loc)
+	calendar := ical.Calendar{
+		Name:     "Jadwal " + timetable.Title + " " + timetable.AcademicYear,
+		Location: loc,
+	}
+	for _, schedule := range timetable.schedules {
+		first := start
+		for dayOfWeekOf(first) != schedule.DayOfWeek {
+			first = first.AddDate(0, 0, 1)
+		}
+		if first.After(end) {
+			continue
+		}
+
+		summary := schedule.Subject().SubjectName
+		if timetable.Type == "teacher" {
+			summary += " - " + schedule.Class().ClassName
+		}
+		room, _ := schedule.Room()
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         fmt.Sprintf("schedule-%d@stmadb-portal", schedule.ID),
+			Summary:     summary,
+			Description: fmt.Sprintf("%s\n%s\n%s", schedule.Subject().SubjectName, schedule.Class().ClassName, schedule.Teacher().FullName),
+			Location:    room,
+			Start:       atClock(first, schedule.StartTime, loc),
+			End:         atClock(first, schedule.EndTime, loc),
+			RRule:       "FREQ=WEEKLY;UNTIL=" + ical.UntilUTC(until),
+		})
+	}
+	return calendar.Encode(), nil
+}
+
+// academicYearRange mengembalikan rentang tanggal sebuah tahun ajaran dari kalender sekolah.
+func (s *TimetableService) academicYearRange(year string) (time.Time, time.Time, error) {
+	return academicYearRange(context.Background(), s.db, year)
+}
+
+// atClock menggabungkan tanggal dengan jam dari kolom @db.Time pada zona waktu sekolah.
+func atClock(date, clock time.Time, loc *time.Location) time.Time {
+	clock = clock.UTC()
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+}
+
+// calendarFeedSecret adalah kunci HMAC token feed kalender (CALENDAR_FEED_SECRET),
+// sengaja terpisah dari JWT_SECRET agar rotasi salah satunya tidak memengaruhi yang lain.
+func calendarFeedSecret() ([]byte, error) {
+	secret := viper.GetString("CALENDAR_FEED_SECRET")
+	if secret == "" {
+		return nil, errors.New("CALENDAR_FEED_SECRET is not configured")
+	}
+	return []byte(secret), nil
+}
+
+// FeedToken mengembalikan token untuk URL langganan kalender. Aplikasi kalender
+// tidak bisa mengirim header Authorization, jadi URL feed ditandatangani dengan
+// HMAC dari kunci acak milik user. Kunci dibuat saat URL pertama kali diminta.
+func (s *TimetableService) FeedToken(userID int) (string, error) {
+	ctx := context.Background()
+	user, err := s.feedUser(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if key, ok := user.CalendarFeedKey(); ok {
+		return feedToken(userID, key)
+	}
+
+	key, err := newFeedKey()
+	if err != nil {
+		return "", err
+	}
+	// Hanya mengisi kunci yang masih kosong agar dua permintaan bersamaan
+	// tidak saling menimpa URL yang sudah dibagikan.
+	_, err = s.db.User.FindMany(
+		db.User.ID.Equals(db.BigInt(userID)),
+		db.User.CalendarFeedKey.IsNull(),
+	).Update(
+		db.User.CalendarFeedKey.Set(key),
+	).Exec(ctx)
+	if err != nil {
+		return "", errors.New("failed to create calendar feed")
+	}
+	if user, err = s.feedUser(ctx, userID); err != nil {
+		return "", err
+	}
+	key, ok := user.CalendarFeedKey()
+	if !ok {
+		return "", errors.New("failed to create calendar feed")
+	}
+	return feedToken(userID, key)
+}
+
+// RegenerateFeedToken mengganti kunci feed kalender user sehingga URL lama
+// tidak berlaku lagi, misalnya setelah URL tersebar.
+func (s *TimetableService) RegenerateFeedToken(userID int) (string, error) {
+	key, err := newFeedKey()
+	if err != nil {
+		return "", err
+	}
+	_, err = s.db.User.FindUnique(db.User.ID.Equals(db.BigInt(userID))).Update(
+		db.User.CalendarFeedKey.Set(key),
+	).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return "", notFoundError("user not found")
+		}
+		return "", errors.New("failed to regenerate calendar feed")
+	}
+	return feedToken(userID, key)
+}
+
+// UserIDFromFeedToken memvalidasi token feed dan mengembalikan ID user pemiliknya.
+func (s *TimetableService) UserIDFromFeedToken(token string) (int, error) {
+	token = strings.TrimSuffix(token, ".ics")
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return 0, notFoundError("calendar feed not found")
+	}
+	userID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, notFoundError("calendar feed not found")
+	}
+	user, err := s.db.User.FindUnique(db.User.ID.Equals(db.BigInt(userID))).Exec(context.Background())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return 0, notFoundError("calendar feed not found")
+		}
+		return 0, errors.New("failed to load calendar feed")
+	}
+	key, ok := user.CalendarFeedKey()
+	if !ok || !user.IsActive {
+		return 0, notFoundError("calendar feed not found")
+	}
+	expected, err := feedToken(userID, key)
+	if err != nil {
+		return 0, err
+	}
+	if !hmac.Equal([]byte(token), []byte(expected)) {
+		return 0, notFoundError("calendar feed not found")
+	}
+	return userID, nil
+}
+
+func (s *TimetableService) feedUser(ctx context.Context, userID int) (*db.UserModel, error) {
+	user, err := s.db.User.FindUnique(db.User.ID.Equals(db.BigInt(userID))).Exec(ctx)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, notFoundError("user not found")
+		}
+		return nil, errors.New("failed to load user")
+	}
+	return user, nil
+}
+
+func newFeedKey() (string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.New("failed to generate calendar feed key")
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// feedToken menyusun token "<userID>.<signature>" dari kunci feed user.
+func feedToken(userID int, key string) (string, error) {
+	secret, err := calendarFeedSecret()
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "timetable-feed:%d:%s", userID, key)
+	return fmt.Sprintf("%d.%s", userID, base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:32]), nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestFeedToken(t *testing.T) {
+	viper.Set("CALENDAR_FEED_SECRET", "feed-secret")
+	t.Cleanup(func() { viper.Set("CALENDAR_FEED_SECRET", nil) })
+
+	token, err := feedToken(5, "kunci-lama")
+	if err != nil {
+		t.Fatalf("feedToken() error = %v", err)
+	}
+	if !strings.HasPrefix(token, "5.") || len(token) != len("5.")+32 {
+		t.Errorf("token = %q, want 5.<32 character signature>", token)
+	}
+	if again, _ := feedToken(5, "kunci-lama"); again != token {
+		t.Errorf("token is not stable: %q != %q", again, token)
+	}
+
+	// Kunci baru mencabut URL lama, dan token user lain tidak bisa ditebak dari ID.
+	if rotated, _ := feedToken(5, "kunci-baru"); rotated == token {
+		t.Error("regenerated key produced the same token")
+	}
+	if other, _ := feedToken(6, "kunci-lama"); strings.TrimPrefix(other, "6.") == strings.TrimPrefix(token, "5.") {
+		t.Error("different users share a signature")
+	}
+
+	// JWT_SECRET tidak dipakai untuk feed kalender.
+	viper.Set("JWT_SECRET", "jwt-secret")
+	t.Cleanup(func() { viper.Set("JWT_SECRET", nil) })
+	viper.Set("CALENDAR_FEED_SECRET", "rotated-secret")
+	if rotated, _ := feedToken(5, "kunci-lama"); rotated == token {
+		t.Error("token does not depend on CALENDAR_FEED_SECRET")
+	}
+}
+
+func TestFeedTokenRequiresSecret(t *testing.T) {
+	viper.Set("CALENDAR_FEED_SECRET", "")
+	viper.Set("JWT_SECRET", "jwt-secret")
+	t.Cleanup(func() {
+		viper.Set("CALENDAR_FEED_SECRET", nil)
+		viper.Set("JWT_SECRET", nil)
+	})
+	if _, err := feedToken(5, "kunci"); err == nil {
+		t.Error("feedToken() without CALENDAR_FEED_SECRET succeeded")
+	}
+}
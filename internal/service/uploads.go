@@ -0,0 +1,205 @@
+// internal/service/uploads.go
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+
+	"github.com/akhmadzaqiriyadi/stmadb-portal-go/internal/storage"
+)
+
+var (
+	storageOnce    sync.Once
+	storageBackend storage.Storage
+	storageErr     error
+)
+
+// fileStorage mengembalikan penyimpanan file unggahan sesuai STORAGE_DRIVER:
+// "local" (default, di UPLOAD_DIR) atau "s3" (S3_ENDPOINT, S3_REGION,
+// S3_BUCKET, S3_ACCESS_KEY, S3_SECRET_KEY, S3_PREFIX).
+func fileStorage() (storage.Storage, error) {
+	storageOnce.Do(func() {
+		switch driver := strings.ToLower(viper.GetString("STORAGE_DRIVER")); driver {
+		case "", "local":
+			dir := viper.GetString("UPLOAD_DIR")
+			if dir == "" {
+				dir = "uploads"
+			}
+			storageBackend = storage.NewLocal(dir)
+		case "s3":
+			storageBackend, storageErr = storage.NewS3(storage.S3Config{
+				Endpoint:  viper.GetString("S3_ENDPOINT"),
+				Region:    viper.GetString("S3_REGION"),
+				Bucket:    viper.GetString("S3_BUCKET"),
+				AccessKey: viper.GetString("S3_ACCESS_KEY"),
+				SecretKey: viper.GetString("S3_SECRET_KEY"),
+				Prefix:    viper.GetString("S3_PREFIX"),
+			})
+		default:
+			storageErr = fmt.Errorf("unknown STORAGE_DRIVER %q", driver)
+		}
+		if storageErr != nil {
+			logrus.Errorf("File storage is not configured: %v", storageErr)
+		}
+	})
+	return storageBackend, storageErr
+}
+
+// MaxUploadSize adalah batas ukuran file unggahan dalam byte (UPLOAD_MAX_SIZE_MB, default 5).
+func MaxUploadSize() int64 {
+	if size := viper.GetInt64("UPLOAD_MAX_SIZE_MB"); size > 0 {
+		return size << 20
+	}
+	return 5 << 20
+}
+
+// uploadKind menentukan jenis file yang boleh diunggah.
+type uploadKind int
+
+const (
+	uploadImage      uploadKind = iota // JPEG atau PNG
+	uploadAttachment                   // JPEG, PNG atau PDF
+)
+
+// uploadExtensions memetakan tipe MIME yang diterima ke ekstensi file.
+var uploadExtensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+// prepareUpload memeriksa ukuran dan tipe file berdasarkan isinya (bukan nama
+// atau header dari klien), memperkecil gambar, lalu mengembalikan isi file
+// beserta ekstensinya.
+func prepareUpload(data []byte, kind uploadKind) ([]byte, string, error) {
+	if len(data) == 0 {
+		return nil, "", validationError("file is empty")
+	}
+	if int64(len(data)) > MaxUploadSize() {
+		return nil, "", validationError("file must not exceed %d MB", MaxUploadSize()>>20)
+	}
+	contentType := http.DetectContentType(data)
+	switch {
+	case contentType == "image/jpeg" || contentType == "image/png":
+		processed, err := processImage(data, contentType)
+		if err != nil {
+			return nil, "", err
+		}
+		return processed, uploadExtensions[contentType], nil
+	case contentType == "application/pdf" && kind == uploadAttachment:
+		return data, ".pdf", nil
+	case kind == uploadAttachment:
+		return nil, "", validationError("file must be a JPEG, PNG or PDF")
+	}
+	return nil, "", validationError("file must be a JPEG or PNG image")
+}
+
+// saveUpload menyimpan file unggahan ke penyimpanan dan mengembalikan key
+// relatifnya untuk disimpan di database.
+func saveUpload(relative string, data []byte) (string, error) {
+	store, err := fileStorage()
+	if err != nil {
+		return "", errors.New("file storage is not configured")
+	}
+	key := storage.CleanKey(relative)
+	if err := store.Put(context.Background(), key, data, http.DetectContentType(data)); err != nil {
+		logrus.Errorf("Failed to save uploaded file %s: %v", key, err)
+		return "", errors.New("failed to save uploaded file")
+	}
+	return key, nil
+}
+
+// readUpload membaca file unggahan dari penyimpanan.
+func readUpload(key string) ([]byte, error) {
+	store, err := fileStorage()
+	if err != nil {
+		return nil, errors.New("file storage is not configured")
+	}
+	return store.Get(context.Background(), key)
+}
+
+// deleteUpload menghapus file unggahan lama. Kegagalan hanya dicatat karena
+// data di database sudah menunjuk ke file baru.
+func deleteUpload(key string) {
+	if key == "" {
+		return
+	}
+	store, err := fileStorage()
+	if err != nil {
+		return
+	}
+	if err := store.Delete(context.Background(), key); err != nil {
+		logrus.Warnf("Failed to delete old uploaded file %s: %v", key, err)
+	}
+}
+
+// FileLink adalah tautan unduhan file unggahan yang berlaku sampai ExpiresAt.
+type FileLink struct {
+	Path      string    `json:"path" example:"attendances/12/2025-09-22-masuk.jpg"`
+	Token     string    `json:"-"`
+	ExpiresAt time.Time `json:"expires_at" example:"2025-09-22T08:15:00+07:00"`
+}
+
+// fileLinkTTL adalah masa berlaku tautan unduhan (FILE_URL_TTL_MINUTES, default 15 menit).
+func fileLinkTTL() time.Duration {
+	if minutes := viper.GetInt("FILE_URL_TTL_MINUTES"); minutes > 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return 15 * time.Minute
+}
+
+// signFileLink membuat tautan unduhan bertanda tangan HMAC untuk key tersebut.
+// Token berisi waktu kedaluwarsa dan key, sehingga tidak perlu disimpan.
+func signFileLink(key string) FileLink {
+	expires := time.Now().Add(fileLinkTTL()).Truncate(time.Second)
+	payload := strconv.FormatInt(expires.Unix(), 10) + "." + base64.RawURLEncoding.EncodeToString([]byte(key))
+	return FileLink{
+		Path:      key,
+		Token:     payload + "." + fileSignature(payload),
+		ExpiresAt: expires.In(appLocation()),
+	}
+}
+
+// verifyFileLink memvalidasi token unduhan dan mengembalikan key file beserta
+// waktu kedaluwarsanya.
+func verifyFileLink(token string) (string, time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", time.Time{}, notFoundError("file not found")
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(fileSignature(payload))) {
+		return "", time.Time{}, notFoundError("file not found")
+	}
+	unix, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return "", time.Time{}, notFoundError("file not found")
+	}
+	expires := time.Unix(unix, 0)
+	if time.Now().After(expires) {
+		return "", time.Time{}, forbiddenError("download link has expired")
+	}
+	key, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", time.Time{}, notFoundError("file not found")
+	}
+	return storage.CleanKey(string(key)), expires, nil
+}
+
+func fileSignature(payload string) string {
+	mac := hmac.New(sha256.New, []byte(viper.GetString("JWT_SECRET")))
+	fmt.Fprintf(mac, "file:%s", payload)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:32]
+}
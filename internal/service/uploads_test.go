@@ -0,0 +1,96 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func setFileLinkConfig(t *testing.T) {
+	t.Helper()
+	viper.Set("JWT_SECRET", "test-secret")
+	viper.Set("FILE_URL_TTL_MINUTES", 15)
+	t.Cleanup(func() {
+		viper.Set("JWT_SECRET", nil)
+		viper.Set("FILE_URL_TTL_MINUTES", nil)
+	})
+}
+
+// signedToken membuat token dengan waktu kedaluwarsa tertentu, seperti signFileLink.
+func signedToken(key string, expires time.Time) string {
+	payload := strconv.FormatInt(expires.Unix(), 10) + "." + base64.RawURLEncoding.EncodeToString([]byte(key))
+	return payload + "." + fileSignature(payload)
+}
+
+func TestSignedFileLink(t *testing.T) {
+	setFileLinkConfig(t)
+	const key = "attendances/12/2025-09-22-masuk.jpg"
+
+	link := signFileLink(key)
+	if link.Path != key {
+		t.Errorf("Path = %q, want %q", link.Path, key)
+	}
+	if ttl := time.Until(link.ExpiresAt); ttl < 14*time.Minute || ttl > 15*time.Minute {
+		t.Errorf("link expires in %v, want about 15 minutes", ttl)
+	}
+	got, expires, err := verifyFileLink(link.Token)
+	if err != nil {
+		t.Fatalf("verifyFileLink() error = %v", err)
+	}
+	if got != key {
+		t.Errorf("verifyFileLink() key = %q, want %q", got, key)
+	}
+	if !expires.Equal(link.ExpiresAt) {
+		t.Errorf("verifyFileLink() expires = %v, want %v", expires, link.ExpiresAt)
+	}
+}
+
+func TestExpiredFileLink(t *testing.T) {
+	setFileLinkConfig(t)
+	token := signedToken("signatures/4.png", time.Now().Add(-time.Minute))
+	if _, _, err := verifyFileLink(token); !errors.Is(err, ErrForbidden) {
+		t.Errorf("verifyFileLink() of an expired link error = %v, want forbidden", err)
+	}
+}
+
+func TestTamperedFileLink(t *testing.T) {
+	setFileLinkConfig(t)
+	token := signFileLink("leave-requests/7/lampiran.pdf").Token
+	parts := strings.Split(token, ".")
+
+	otherKey := base64.RawURLEncoding.EncodeToString([]byte("leave-requests/8/lampiran.pdf"))
+	laterExpiry := strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10)
+	signature := []byte(parts[2])
+	if signature[0] == 'A' {
+		signature[0] = 'B'
+	} else {
+		signature[0] = 'A'
+	}
+
+	tests := map[string]string{
+		"signature":       parts[0] + "." + parts[1] + "." + string(signature),
+		"path":            parts[0] + "." + otherKey + "." + parts[2],
+		"expiry":          laterExpiry + "." + parts[1] + "." + parts[2],
+		"missing part":    parts[0] + "." + parts[1],
+		"empty signature": parts[0] + "." + parts[1] + ".",
+	}
+	for name, tampered := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := verifyFileLink(tampered); !errors.Is(err, ErrNotFound) {
+				t.Errorf("verifyFileLink(%q) error = %v, want not found", tampered, err)
+			}
+		})
+	}
+
+	t.Run("other secret", func(t *testing.T) {
+		viper.Set("JWT_SECRET", "another-secret")
+		if _, _, err := verifyFileLink(token); !errors.Is(err, ErrNotFound) {
+			t.Errorf("verifyFileLink() with another secret error = %v, want not found", err)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+// internal/storage/local.go
+package storage
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Local menyimpan file di folder lokal.
+type Local struct {
+	dir string
+}
+
+// NewLocal membuat penyimpanan lokal di folder dir.
+func NewLocal(dir string) *Local {
+	return &Local{dir: dir}
+}
+
+func (l *Local) path(key string) (string, error) {
+	key = CleanKey(key)
+	if key == "" {
+		return "", errors.New("storage: empty key")
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
+
+// Put menyimpan file ke folder lokal.
+func (l *Local) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	full, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return err
+	}
+	// Tulis ke file sementara dulu agar pembaca tidak melihat file setengah jadi.
+	temp, err := os.CreateTemp(filepath.Dir(full), ".upload-*")
+	if err != nil {
+		return err
+	}
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := os.Chmod(temp.Name(), 0o644); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	return os.Rename(temp.Name(), full)
+}
+
+// Get membaca file dari folder lokal.
+func (l *Local) Get(ctx context.Context, key string) ([]byte, error) {
+	full, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(full)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Delete menghapus file dari folder lokal.
+func (l *Local) Delete(ctx context.Context, key string) error {
+	full, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
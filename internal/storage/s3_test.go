@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "minioadmin"
+	testSecretKey = "minio-secret"
+	testRegion    = "ap-southeast-1"
+)
+
+// fakeS3 adalah pengganti S3 di memori yang memverifikasi tanda tangan SigV4
+// setiap permintaan secara independen dari implementasi S3.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+	auth    []string
+}
+
+func newFakeS3(t *testing.T) *httptest.Server {
+	fake := &fakeS3{objects: map[string][]byte{}, types: map[string]string{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if err := verifySigV4(r, body); err != nil {
+		http.Error(w, "SignatureDoesNotMatch: "+err.Error(), http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = append(f.auth, r.Header.Get("Authorization"))
+	path := r.URL.EscapedPath()
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[path] = body
+		f.types[path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		data, ok := f.objects[path]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// verifySigV4 menghitung ulang tanda tangan dari permintaan yang diterima server.
+func verifySigV4(r *http.Request, body []byte) error {
+	auth := r.Header.Get("Authorization")
+	const prefix = "AWS4-HMAC-SHA256 "
+	if !strings.HasPrefix(auth, prefix) {
+		return errors.New("missing AWS4-HMAC-SHA256 authorization")
+	}
+	fields := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(auth, prefix), ", ") {
+		name, value, _ := strings.Cut(part, "=")
+		fields[name] = value
+	}
+	stamp := r.Header.Get("X-Amz-Date")
+	if len(stamp) != len("20060102T150405Z") {
+		return errors.New("missing X-Amz-Date")
+	}
+	scope := stamp[:8] + "/" + testRegion + "/s3/aws4_request"
+	if fields["Credential"] != testAccessKey+"/"+scope {
+		return errors.New("unexpected credential " + fields["Credential"])
+	}
+	sum := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(sum[:])
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return errors.New("payload hash does not match body")
+	}
+
+	var headers strings.Builder
+	for _, name := range strings.Split(fields["SignedHeaders"], ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		headers.WriteString(name + ":" + value + "\n")
+	}
+	canonical := strings.Join([]string{r.Method, r.URL.EscapedPath(), r.URL.RawQuery, headers.String(), fields["SignedHeaders"], payloadHash}, "\n")
+	canonicalHash := sha256.Sum256([]byte(canonical))
+	stringToSign := "AWS4-HMAC-SHA256\n" + stamp + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range []string{stamp[:8], testRegion, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	if fields["Signature"] != hex.EncodeToString(key) {
+		return errors.New("signature does not match")
+	}
+	return nil
+}
+
+func newTestS3(t *testing.T, endpoint, prefix string) *S3 {
+	t.Helper()
+	store, err := NewS3(S3Config{
+		Endpoint:  endpoint,
+		Region:    testRegion,
+		Bucket:    "portal",
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+		Prefix:    prefix,
+	})
+	if err != nil {
+		t.Fatalf("NewS3() error = %v", err)
+	}
+	return store
+}
+
+func TestS3PutGetDelete(t *testing.T) {
+	server := newFakeS3(t)
+	store := newTestS3(t, server.URL+"/", "uploads")
+	ctx := context.Background()
+	key := "attendances/12/2025-09-22 masuk+foto.jpg"
+	data := []byte("\xff\xd8\xff jpeg data")
+
+	if err := store.Put(ctx, key, data, "image/jpeg"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	fake := server.Config.Handler.(*fakeS3)
+	const stored = "/portal/uploads/attendances/12/2025-09-22%20masuk%2Bfoto.jpg"
+	if got := fake.types[stored]; got != "image/jpeg" {
+		t.Errorf("stored content type = %q, want image/jpeg (objects: %v)", got, fake.objects)
+	}
+
+	got, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(got) != string(data) {
+		t.Errorf("Get() = %q, want %q", got, data)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of a missing key error = %v", err)
+	}
+}
+
+func TestS3AuthorizationHeader(t *testing.T) {
+	server := newFakeS3(t)
+	store := newTestS3(t, server.URL, "")
+	store.now = func() time.Time { return time.Date(2025, 9, 22, 7, 30, 0, 0, time.FixedZone("WIB", 7*3600)) }
+
+	if err := store.Put(context.Background(), "signatures/4.png", []byte("png"), "image/png"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	fake := server.Config.Handler.(*fakeS3)
+	if len(fake.auth) != 1 {
+		t.Fatalf("requests = %d, want 1", len(fake.auth))
+	}
+	const want = "AWS4-HMAC-SHA256 Credential=minioadmin/20250922/ap-southeast-1/s3/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="
+	if auth := fake.auth[0]; !strings.HasPrefix(auth, want) || len(auth) != len(want)+64 {
+		t.Errorf("Authorization = %q, want prefix %q and a 64-character signature", auth, want)
+	}
+}
+
+func TestS3RejectedSignature(t *testing.T) {
+	server := newFakeS3(t)
+	store := newTestS3(t, server.URL, "")
+	store.config.SecretKey = "wrong-secret"
+
+	err := store.Put(context.Background(), "a.txt", []byte("a"), "text/plain")
+	if err == nil || !strings.Contains(err.Error(), "SignatureDoesNotMatch") {
+		t.Errorf("Put() with a wrong secret error = %v, want 403", err)
+	}
+}
+
+func TestNewS3RequiresConfig(t *testing.T) {
+	if _, err := NewS3(S3Config{Endpoint: "http://localhost:9000", Bucket: "portal"}); err == nil {
+		t.Error("NewS3() without credentials succeeded")
+	}
+}
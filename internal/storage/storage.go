@@ -0,0 +1,32 @@
+// internal/storage/storage.go
+// Package storage menyimpan file unggahan (foto presensi, lampiran izin, tanda
+// tangan) di folder lokal atau di object storage yang kompatibel dengan S3
+// (AWS S3, MinIO, Cloudflare R2, dan sejenisnya). Key berupa path relatif
+// dengan pemisah "/", misalnya "attendances/12/2025-09-22-masuk.jpg".
+package storage
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+)
+
+// ErrNotFound dikembalikan jika file dengan key tersebut tidak ada.
+var ErrNotFound = errors.New("storage: file not found")
+
+// Storage adalah tempat penyimpanan file unggahan.
+type Storage interface {
+	// Put menyimpan data dengan key tersebut, menimpa file lama jika ada.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get membaca isi file. Mengembalikan ErrNotFound jika tidak ada.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete menghapus file. File yang tidak ada tidak dianggap error.
+	Delete(ctx context.Context, key string) error
+}
+
+// CleanKey merapikan key agar tidak bisa keluar dari folder penyimpanan,
+// misalnya "../a//b.jpg" menjadi "a/b.jpg".
+func CleanKey(key string) string {
+	return strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(key, `\`, "/")), "/")
+}
@@ -0,0 +1,385 @@
+// internal/timetable/solver.go
+// Package timetable berisi solver heuristik untuk menyusun jadwal pelajaran
+// mingguan tanpa bentrok guru, kelas, dan ruangan.
+package timetable
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Slot adalah satu jam pelajaran pada hari tertentu (indeks dimulai dari 0).
+type Slot struct {
+	Day    int
+	Period int
+}
+
+// Lesson adalah kebutuhan jam pelajaran satu mapel di satu kelas.
+type Lesson struct {
+	ClassID   int64
+	SubjectID int64
+	TeacherID int64
+	Hours     int
+}
+
+// Room adalah ruangan yang bisa dipakai beserta slot yang tidak tersedia.
+type Room struct {
+	Name        string
+	Unavailable []Slot
+}
+
+// Problem adalah seluruh input solver.
+type Problem struct {
+	Days               int
+	PeriodsPerDay      int
+	Lessons            []Lesson
+	Rooms              []Room           // Kosong berarti ruangan tidak ikut dijadwalkan
+	TeacherUnavailable map[int64][]Slot // Slot yang tidak bisa dipakai guru
+	MaxPerDay          int              // Maksimal jam satu mapel per hari di satu kelas, default 2
+	Attempts           int              // Jumlah percobaan acak, default 20
+	Seed               int64
+}
+
+// Placement adalah satu jam pelajaran yang berhasil ditempatkan.
+type Placement struct {
+	Lesson int // Indeks pada Problem.Lessons
+	Slot
+	Room string
+}
+
+// Unplaced adalah jam pelajaran yang tidak mendapat slot.
+type Unplaced struct {
+	Lesson int
+	Hours  int
+	Reason string
+}
+
+// Result adalah hasil solver.
+type Result struct {
+	Placements []Placement
+	Unplaced   []Unplaced
+}
+
+// unit adalah satu jam pelajaran dari sebuah Lesson.
+type unit struct {
+	lesson int
+}
+
+type state struct {
+	p            *Problem
+	classBusy    map[int64]map[Slot]bool
+	teacherBusy  map[int64]map[Slot]bool
+	roomBusy     map[string]map[Slot]bool
+	subjectDay   map[[3]int64]int // (kelas, mapel, hari) -> jumlah jam
+	classDayLoad map[[2]int64]int // (kelas, hari) -> jumlah jam
+	placements   []Placement
+}
+
+// Solve menjalankan beberapa percobaan greedy dengan urutan acak dan
+// mengembalikan hasil dengan jam tak terjadwal paling sedikit.
+func Solve(p Problem) Result {
+	if p.MaxPerDay <= 0 {
+		p.MaxPerDay = 2
+	}
+	if p.Attempts <= 0 {
+		p.Attempts = 20
+	}
+	rng := rand.New(rand.NewSource(p.Seed))
+
+	units := expand(p)
+	var best *Result
+	for attempt := 0; attempt < p.Attempts; attempt++ {
+		order := make([]unit, len(units))
+		copy(order, units)
+		if attempt > 0 {
+			shuffleWithinDifficulty(order, p, rng)
+		}
+		result := solveOnce(&p, order)
+		if best == nil || unplacedHours(result) < unplacedHours(*best) {
+			r := result
+			best = &r
+		}
+		if len(best.Unplaced) == 0 {
+			break
+		}
+	}
+	if best == nil {
+		return Result{}
+	}
+	return *best
+}
+
+// expand memecah setiap Lesson menjadi unit satu jam dan mengurutkannya dari
+// yang paling sulit ditempatkan (guru dengan beban terbanyak dan slot tersedia paling sedikit).
+func expand(p Problem) []unit {
+	load := map[int64]int{}
+	for _, l := range p.Lessons {
+		load[l.TeacherID] += l.Hours
+	}
+	var units []unit
+	for i, l := range p.Lessons {
+		for h := 0; h < l.Hours; h++ {
+			units = append(units, unit{lesson: i})
+		}
+	}
+	sort.SliceStable(units, func(i, j int) bool {
+		return difficulty(p, load, units[i]) > difficulty(p, load, units[j])
+	})
+	return units
+}
+
+func difficulty(p Problem, load map[int64]int, u unit) int {
+	l := p.Lessons[u.lesson]
+	return load[l.TeacherID] + len(p.TeacherUnavailable[l.TeacherID])
+}
+
+// shuffleWithinDifficulty mengacak urutan unit dengan tingkat kesulitan yang sama
+// agar setiap percobaan menghasilkan susunan berbeda tanpa merusak prioritas.
+func shuffleWithinDifficulty(units []unit, p Problem, rng *rand.Rand) {
+	load := map[int64]int{}
+	for _, l := range p.Lessons {
+		load[l.TeacherID] += l.Hours
+	}
+	start := 0
+	for start < len(units) {
+		end := start + 1
+		d := difficulty(p, load, units[start])
+		for end < len(units) && difficulty(p, load, units[end]) == d {
+			end++
+		}
+		group := units[start:end]
+		// Unit dari lesson yang sama dipindah bersama agar jam ganda tetap berdekatan.
+		lessons := map[int][]unit{}
+		var keys []int
+		for _, u := range group {
+			if _, ok := lessons[u.lesson]; !ok {
+				keys = append(keys, u.lesson)
+			}
+			lessons[u.lesson] = append(lessons[u.lesson], u)
+		}
+		rng.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+		i := start
+		for _, k := range keys {
+			for _, u := range lessons[k] {
+				units[i] = u
+				i++
+			}
+		}
+		start = end
+	}
+}
+
+func solveOnce(p *Problem, units []unit) Result {
+	s := &state{
+		p:            p,
+		classBusy:    map[int64]map[Slot]bool{},
+		teacherBusy:  map[int64]map[Slot]bool{},
+		roomBusy:     map[string]map[Slot]bool{},
+		subjectDay:   map[[3]int64]int{},
+		classDayLoad: map[[2]int64]int{},
+	}
+	for teacherID, slots := range p.TeacherUnavailable {
+		for _, slot := range slots {
+			mark(s.teacherBusy, teacherID, slot)
+		}
+	}
+	for _, room := range p.Rooms {
+		for _, slot := range room.Unavailable {
+			markRoom(s.roomBusy, room.Name, slot)
+		}
+	}
+
+	failed := map[int]int{}
+	for _, u := range units {
+		if !s.place(u) && !s.placeWithEviction(u) {
+			failed[u.lesson]++
+		}
+	}
+
+	result := Result{Placements: s.placements}
+	for lesson, hours := range failed {
+		result.Unplaced = append(result.Unplaced, Unplaced{Lesson: lesson, Hours: hours, Reason: s.reason(lesson)})
+	}
+	sort.Slice(result.Unplaced, func(i, j int) bool { return result.Unplaced[i].Lesson < result.Unplaced[j].Lesson })
+	return result
+}
+
+// place menempatkan unit pada slot dengan skor terbaik.
+func (s *state) place(u unit) bool {
+	l := s.p.Lessons[u.lesson]
+	bestScore := -1 << 30
+	var bestSlot Slot
+	bestRoom := ""
+	found := false
+	for day := 0; day < s.p.Days; day++ {
+		if s.subjectDay[[3]int64{l.ClassID, l.SubjectID, int64(day)}] >= s.p.MaxPerDay {
+			continue
+		}
+		for period := 0; period < s.p.PeriodsPerDay; period++ {
+			slot := Slot{Day: day, Period: period}
+			if !s.free(l, slot) {
+				continue
+			}
+			room, ok := s.freeRoom(slot)
+			if !ok {
+				continue
+			}
+			score := s.score(l, slot)
+			if !found || score > bestScore {
+				bestScore, bestSlot, bestRoom, found = score, slot, room, true
+			}
+		}
+	}
+	if !found {
+		return false
+	}
+	s.assign(u.lesson, bestSlot, bestRoom)
+	return true
+}
+
+// placeWithEviction mencoba memindahkan satu jam pelajaran lain dari kelas yang
+// sama ke slot kosong lain agar unit ini mendapat tempat (perbaikan satu langkah).
+func (s *state) placeWithEviction(u unit) bool {
+	l := s.p.Lessons[u.lesson]
+	for i := range s.placements {
+		victim := s.placements[i]
+		vl := s.p.Lessons[victim.Lesson]
+		if vl.ClassID != l.ClassID || victim.Lesson == u.lesson {
+			continue
+		}
+		s.unassign(i)
+		if s.subjectDay[[3]int64{l.ClassID, l.SubjectID, int64(victim.Day)}] < s.p.MaxPerDay && s.free(l, victim.Slot) {
+			if room, ok := s.freeRoom(victim.Slot); ok {
+				s.assign(u.lesson, victim.Slot, room)
+				if s.place(unit{lesson: victim.Lesson}) {
+					return true
+				}
+				s.unassign(len(s.placements) - 1)
+			}
+		}
+		s.insertAt(i, victim)
+	}
+	return false
+}
+
+func (s *state) free(l Lesson, slot Slot) bool {
+	return !s.classBusy[l.ClassID][slot] && !s.teacherBusy[l.TeacherID][slot]
+}
+
+func (s *state) freeRoom(slot Slot) (string, bool) {
+	if len(s.p.Rooms) == 0 {
+		return "", true
+	}
+	for _, room := range s.p.Rooms {
+		if !s.roomBusy[room.Name][slot] {
+			return room.Name, true
+		}
+	}
+	return "", false
+}
+
+// score menilai slot: jam ganda berurutan lebih disukai, beban harian kelas
+// diratakan, dan jam awal sedikit diutamakan agar tidak banyak jam kosong.
+func (s *state) score(l Lesson, slot Slot) int {
+	score := 0
+	for _, p := range s.placements {
+		pl := s.p.Lessons[p.Lesson]
+		if pl.ClassID == l.ClassID && pl.SubjectID == l.SubjectID && p.Day == slot.Day {
+			if p.Period == slot.Period-1 || p.Period == slot.Period+1 {
+				score += 50
+			} else {
+				score -= 30
+			}
+		}
+	}
+	score -= 10 * s.classDayLoad[[2]int64{l.ClassID, int64(slot.Day)}]
+	score -= slot.Period
+	return score
+}
+
+func (s *state) assign(lesson int, slot Slot, room string) {
+	l := s.p.Lessons[lesson]
+	mark(s.classBusy, l.ClassID, slot)
+	mark(s.teacherBusy, l.TeacherID, slot)
+	if room != "" {
+		markRoom(s.roomBusy, room, slot)
+	}
+	s.subjectDay[[3]int64{l.ClassID, l.SubjectID, int64(slot.Day)}]++
+	s.classDayLoad[[2]int64{l.ClassID, int64(slot.Day)}]++
+	s.placements = append(s.placements, Placement{Lesson: lesson, Slot: slot, Room: room})
+}
+
+func (s *state) unassign(index int) {
+	p := s.placements[index]
+	l := s.p.Lessons[p.Lesson]
+	delete(s.classBusy[l.ClassID], p.Slot)
+	delete(s.teacherBusy[l.TeacherID], p.Slot)
+	if p.Room != "" {
+		delete(s.roomBusy[p.Room], p.Slot)
+	}
+	s.subjectDay[[3]int64{l.ClassID, l.SubjectID, int64(p.Day)}]--
+	s.classDayLoad[[2]int64{l.ClassID, int64(p.Day)}]--
+	s.placements = append(s.placements[:index], s.placements[index+1:]...)
+}
+
+// insertAt mengembalikan placement yang sebelumnya dilepas ke posisi semula.
+func (s *state) insertAt(index int, p Placement) {
+	l := s.p.Lessons[p.Lesson]
+	mark(s.classBusy, l.ClassID, p.Slot)
+	mark(s.teacherBusy, l.TeacherID, p.Slot)
+	if p.Room != "" {
+		markRoom(s.roomBusy, p.Room, p.Slot)
+	}
+	s.subjectDay[[3]int64{l.ClassID, l.SubjectID, int64(p.Day)}]++
+	s.classDayLoad[[2]int64{l.ClassID, int64(p.Day)}]++
+	s.placements = append(s.placements, Placement{})
+	copy(s.placements[index+1:], s.placements[index:])
+	s.placements[index] = p
+}
+
+// reason menjelaskan kemungkinan penyebab sebuah lesson tidak bisa ditempatkan.
+func (s *state) reason(lesson int) string {
+	l := s.p.Lessons[lesson]
+	teacherFree, classFree := false, false
+	for day := 0; day < s.p.Days; day++ {
+		for period := 0; period < s.p.PeriodsPerDay; period++ {
+			slot := Slot{Day: day, Period: period}
+			if !s.teacherBusy[l.TeacherID][slot] {
+				teacherFree = true
+			}
+			if !s.classBusy[l.ClassID][slot] {
+				classFree = true
+			}
+		}
+	}
+	switch {
+	case !classFree:
+		return "class has no free periods left"
+	case !teacherFree:
+		return "teacher has no free periods left"
+	default:
+		return "no period where class, teacher and a room are free at the same time"
+	}
+}
+
+func mark(busy map[int64]map[Slot]bool, id int64, slot Slot) {
+	if busy[id] == nil {
+		busy[id] = map[Slot]bool{}
+	}
+	busy[id][slot] = true
+}
+
+func markRoom(busy map[string]map[Slot]bool, name string, slot Slot) {
+	if busy[name] == nil {
+		busy[name] = map[Slot]bool{}
+	}
+	busy[name][slot] = true
+}
+
+func unplacedHours(r Result) int {
+	total := 0
+	for _, u := range r.Unplaced {
+		total += u.Hours
+	}
+	return total
+}
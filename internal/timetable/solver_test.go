@@ -0,0 +1,203 @@
+package timetable
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// checkResult memastikan hasil solver konsisten: tidak ada guru, kelas atau
+// ruangan yang dipakai dua kali pada slot yang sama, slot tidak tersedia tidak
+// dipakai, dan setiap jam pelajaran tercatat sebagai placement atau unplaced.
+func checkResult(t *testing.T, p Problem, r Result) {
+	t.Helper()
+	maxPerDay := p.MaxPerDay
+	if maxPerDay <= 0 {
+		maxPerDay = 2
+	}
+	unavailable := map[int64]map[Slot]bool{}
+	for teacherID, slots := range p.TeacherUnavailable {
+		unavailable[teacherID] = map[Slot]bool{}
+		for _, slot := range slots {
+			unavailable[teacherID][slot] = true
+		}
+	}
+	roomClosed := map[string]map[Slot]bool{}
+	for _, room := range p.Rooms {
+		roomClosed[room.Name] = map[Slot]bool{}
+		for _, slot := range room.Unavailable {
+			roomClosed[room.Name][slot] = true
+		}
+	}
+
+	teacherAt := map[int64]map[Slot]bool{}
+	classAt := map[int64]map[Slot]bool{}
+	roomAt := map[string]map[Slot]bool{}
+	subjectDay := map[[3]int64]int{}
+	placed := map[int]int{}
+	use := func(busy map[int64]map[Slot]bool, id int64, slot Slot, what string) {
+		if busy[id] == nil {
+			busy[id] = map[Slot]bool{}
+		}
+		if busy[id][slot] {
+			t.Errorf("%s %d is double-booked at day %d period %d", what, id, slot.Day, slot.Period)
+		}
+		busy[id][slot] = true
+	}
+	for _, placement := range r.Placements {
+		l := p.Lessons[placement.Lesson]
+		slot := placement.Slot
+		if slot.Day < 0 || slot.Day >= p.Days || slot.Period < 0 || slot.Period >= p.PeriodsPerDay {
+			t.Errorf("placement outside the week: %+v", placement)
+		}
+		use(teacherAt, l.TeacherID, slot, "teacher")
+		use(classAt, l.ClassID, slot, "class")
+		if unavailable[l.TeacherID][slot] {
+			t.Errorf("teacher %d placed at unavailable day %d period %d", l.TeacherID, slot.Day, slot.Period)
+		}
+		if len(p.Rooms) > 0 {
+			if roomAt[placement.Room] == nil {
+				roomAt[placement.Room] = map[Slot]bool{}
+			}
+			if roomAt[placement.Room][slot] {
+				t.Errorf("room %q is double-booked at day %d period %d", placement.Room, slot.Day, slot.Period)
+			}
+			roomAt[placement.Room][slot] = true
+			if closed, ok := roomClosed[placement.Room]; !ok || closed[slot] {
+				t.Errorf("room %q used at unavailable day %d period %d", placement.Room, slot.Day, slot.Period)
+			}
+		}
+		key := [3]int64{l.ClassID, l.SubjectID, int64(slot.Day)}
+		if subjectDay[key]++; subjectDay[key] > maxPerDay {
+			t.Errorf("class %d has more than %d hours of subject %d on day %d", l.ClassID, maxPerDay, l.SubjectID, slot.Day)
+		}
+		placed[placement.Lesson]++
+	}
+	for _, unplaced := range r.Unplaced {
+		placed[unplaced.Lesson] += unplaced.Hours
+	}
+	for i, l := range p.Lessons {
+		if placed[i] != l.Hours {
+			t.Errorf("lesson %d: %d hours placed or reported, want %d", i, placed[i], l.Hours)
+		}
+	}
+}
+
+// weekProblem adalah tiga kelas dengan guru yang mengajar di beberapa kelas
+// dan dua ruangan untuk tiga kelas.
+func weekProblem() Problem {
+	var lessons []Lesson
+	for class := int64(1); class <= 3; class++ {
+		lessons = append(lessons,
+			Lesson{ClassID: class, SubjectID: 1, TeacherID: 10, Hours: 4},
+			Lesson{ClassID: class, SubjectID: 2, TeacherID: 11, Hours: 4},
+			Lesson{ClassID: class, SubjectID: 3, TeacherID: 12 + class%2, Hours: 3},
+			Lesson{ClassID: class, SubjectID: 4, TeacherID: 14, Hours: 2},
+		)
+	}
+	return Problem{
+		Days:          5,
+		PeriodsPerDay: 6,
+		Lessons:       lessons,
+		Rooms: []Room{
+			{Name: "R1"},
+			{Name: "R2", Unavailable: []Slot{{Day: 0, Period: 0}, {Day: 0, Period: 1}}},
+		},
+		Seed: 7,
+	}
+}
+
+func TestSolveAvoidsDoubleBooking(t *testing.T) {
+	p := weekProblem()
+	r := Solve(p)
+	checkResult(t, p, r)
+	if len(r.Unplaced) != 0 {
+		t.Errorf("Unplaced = %+v, want every lesson placed", r.Unplaced)
+	}
+}
+
+func TestSolveIsDeterministic(t *testing.T) {
+	p := weekProblem()
+	first, second := Solve(p), Solve(p)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Solve() with the same seed returned different results")
+	}
+}
+
+func TestSolveRespectsTeacherUnavailability(t *testing.T) {
+	p := weekProblem()
+	p.TeacherUnavailable = map[int64][]Slot{}
+	for period := 0; period < p.PeriodsPerDay; period++ {
+		// Guru 10 tidak bisa hadir hari pertama dan kedua, guru 11 tidak bisa jam pertama.
+		p.TeacherUnavailable[10] = append(p.TeacherUnavailable[10], Slot{Day: 0, Period: period}, Slot{Day: 1, Period: period})
+	}
+	for day := 0; day < p.Days; day++ {
+		p.TeacherUnavailable[11] = append(p.TeacherUnavailable[11], Slot{Day: day, Period: 0})
+	}
+
+	r := Solve(p)
+	checkResult(t, p, r)
+	if len(r.Unplaced) != 0 {
+		t.Errorf("Unplaced = %+v, want every lesson placed", r.Unplaced)
+	}
+}
+
+func TestSolveReportsUnplacedLessons(t *testing.T) {
+	tests := []struct {
+		name       string
+		problem    Problem
+		wantHours  int
+		wantReason string
+	}{
+		{
+			name: "teacher overloaded",
+			problem: Problem{
+				Days: 1, PeriodsPerDay: 3, MaxPerDay: 3,
+				Lessons: []Lesson{
+					{ClassID: 1, SubjectID: 1, TeacherID: 10, Hours: 2},
+					{ClassID: 2, SubjectID: 1, TeacherID: 10, Hours: 2},
+				},
+			},
+			wantHours:  1,
+			wantReason: "teacher has no free periods left",
+		},
+		{
+			name: "class overloaded",
+			problem: Problem{
+				Days: 1, PeriodsPerDay: 3, MaxPerDay: 3,
+				Lessons: []Lesson{
+					{ClassID: 1, SubjectID: 1, TeacherID: 10, Hours: 2},
+					{ClassID: 1, SubjectID: 2, TeacherID: 11, Hours: 3},
+				},
+			},
+			wantHours:  2,
+			wantReason: "class has no free periods left",
+		},
+		{
+			name: "no room available",
+			problem: Problem{
+				Days: 1, PeriodsPerDay: 2,
+				Lessons: []Lesson{{ClassID: 1, SubjectID: 1, TeacherID: 10, Hours: 2}},
+				Rooms:   []Room{{Name: "Lab", Unavailable: []Slot{{Day: 0, Period: 0}, {Day: 0, Period: 1}}}},
+			},
+			wantHours:  2,
+			wantReason: "room",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Solve(tt.problem)
+			checkResult(t, tt.problem, r)
+			hours := 0
+			for _, unplaced := range r.Unplaced {
+				hours += unplaced.Hours
+				if !strings.Contains(unplaced.Reason, tt.wantReason) {
+					t.Errorf("lesson %d reason = %q, want it to mention %q", unplaced.Lesson, unplaced.Reason, tt.wantReason)
+				}
+			}
+			if hours != tt.wantHours {
+				t.Errorf("unplaced hours = %d, want %d (%+v)", hours, tt.wantHours, r.Unplaced)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+// internal/xlsx/xlsx.go
+// Package xlsx menulis file Excel (.xlsx) sederhana berisi teks dan angka
+// dengan beberapa gaya sel bawaan, tanpa dependensi eksternal. Cukup untuk
+// rekap yang diunduh lalu diolah atau dicetak dari Excel/LibreOffice.
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Style adalah gaya sel yang tersedia.
+type Style int
+
+const (
+	Normal   Style = iota
+	Bold           // Teks tebal tanpa garis
+	Title          // Teks tebal besar untuk judul/kop
+	Header         // Teks tebal rata tengah dengan garis dan latar abu-abu
+	Bordered       // Teks biasa dengan garis
+	Centered       // Teks biasa rata tengah dengan garis
+)
+
+// Cell adalah isi satu sel. Value berupa string atau angka (int, float64).
+type Cell struct {
+	Value any
+	Style Style
+}
+
+// Text membuat sel teks.
+func Text(value string, style Style) Cell {
+	return Cell{Value: value, Style: style}
+}
+
+// Number membuat sel angka.
+func Number(value float64, style Style) Cell {
+	return Cell{Value: value, Style: style}
+}
+
+// Sheet adalah satu lembar kerja.
+type Sheet struct {
+	name   string
+	rows   [][]Cell
+	widths map[int]float64
+	merges []string
+}
+
+// Workbook adalah file Excel yang sedang disusun.
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// New membuat workbook kosong.
+func New() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet menambahkan lembar kerja. Nama dipotong menjadi 31 karakter dan
+// karakter yang tidak diizinkan Excel diganti spasi.
+func (w *Workbook) AddSheet(name string) *Sheet {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return ' '
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		name = "Sheet" + strconv.Itoa(len(w.sheets)+1)
+	}
+	sheet := &Sheet{name: name, widths: make(map[int]float64)}
+	w.sheets = append(w.sheets, sheet)
+	return sheet
+}
+
+// AddRow menambahkan satu baris di bawah baris terakhir dan mengembalikan
+// nomor barisnya (mulai dari 0).
+func (s *Sheet) AddRow(cells ...Cell) int {
+	s.rows = append(s.rows, cells)
+	return len(s.rows) - 1
+}
+
+// SetColumnWidth mengatur lebar kolom (mulai dari 0) dalam satuan karakter.
+func (s *Sheet) SetColumnWidth(column int, width float64) {
+	s.widths[column] = width
+}
+
+// Merge menggabungkan sel dari (fromColumn, fromRow) sampai (toColumn, toRow),
+// semua mulai dari 0.
+func (s *Sheet) Merge(fromColumn, fromRow, toColumn, toRow int) {
+	s.merges = append(s.merges, CellName(fromColumn, fromRow)+":"+CellName(toColumn, toRow))
+}
+
+// ColumnName mengubah nomor kolom (mulai dari 0) menjadi huruf kolom Excel, misalnya 27 menjadi "AB".
+func ColumnName(column int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+	return name
+}
+
+// CellName mengubah posisi sel (mulai dari 0) menjadi alamat Excel, misalnya "C5".
+func CellName(column, row int) string {
+	return ColumnName(column) + strconv.Itoa(row+1)
+}
+
+// Bytes menghasilkan isi file .xlsx.
+func (w *Workbook) Bytes() ([]byte, error) {
+	if len(w.sheets) == 0 {
+		w.AddSheet("Sheet1")
+	}
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", w.contentTypes()},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", w.workbook()},
+		{"xl/_rels/workbook.xml.rels", w.workbookRels()},
+		{"xl/styles.xml", styles},
+	}
+	for i, sheet := range w.sheets {
+		files = append(files, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.Write([]byte(xml.Header + file.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+const rootRels = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles berisi gaya sel sesuai urutan konstanta Style.
+const styles = `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="3">` +
+	`<font><sz val="10"/><name val="Arial"/></font>` +
+	`<font><b/><sz val="10"/><name val="Arial"/></font>` +
+	`<font><b/><sz val="13"/><name val="Arial"/></font>` +
+	`</fonts>` +
+	`<fills count="3">` +
+	`<fill><patternFill patternType="none"/></fill>` +
+	`<fill><patternFill patternType="gray125"/></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FFE6E6E6"/><bgColor indexed="64"/></patternFill></fill>` +
+	`</fills>` +
+	`<borders count="2">` +
+	`<border><left/><right/><top/><bottom/><diagonal/></border>` +
+	`<border><left style="thin"/><right style="thin"/><top style="thin"/><bottom style="thin"/><diagonal/></border>` +
+	`</borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="6">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="0" fontId="2" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="2" borderId="1" xfId="0" applyFont="1" applyFill="1" applyBorder="1" applyAlignment="1"><alignment horizontal="center" vertical="center" wrapText="1"/></xf>` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="1" xfId="0" applyBorder="1"/>` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="1" xfId="0" applyBorder="1" applyAlignment="1"><alignment horizontal="center"/></xf>` +
+	`</cellXfs>` +
+	`</styleSheet>`
+
+func (w *Workbook) contentTypes() string {
+	var b strings.Builder
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range w.sheets {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func (w *Workbook) workbook() string {
+	var b strings.Builder
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range w.sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func (w *Workbook) workbookRels() string {
+	var b strings.Builder
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range w.sheets {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func (s *Sheet) xml() string {
+	var b strings.Builder
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if len(s.widths) > 0 {
+		last := 0
+		for column := range s.widths {
+			last = max(last, column)
+		}
+		b.WriteString(`<cols>`)
+		for column := 0; column <= last; column++ {
+			if width, ok := s.widths[column]; ok {
+				fmt.Fprintf(&b, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, column+1, column+1, strconv.FormatFloat(width, 'f', -1, 64))
+			}
+		}
+		b.WriteString(`</cols>`)
+	}
+	b.WriteString(`<sheetData>`)
+	for r, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := CellName(c, r)
+			switch value := cell.Value.(type) {
+			case nil:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"/>`, ref, cell.Style)
+			case string:
+				if value == "" {
+					fmt.Fprintf(&b, `<c r="%s" s="%d"/>`, ref, cell.Style)
+					continue
+				}
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.Style, escape(value))
+			case int:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%d</v></c>`, ref, cell.Style, value)
+			case float64:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.Style, strconv.FormatFloat(value, 'f', -1, 64))
+			default:
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t>%s</t></is></c>`, ref, cell.Style, escape(fmt.Sprint(value)))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+	if len(s.merges) > 0 {
+		fmt.Fprintf(&b, `<mergeCells count="%d">`, len(s.merges))
+		for _, merge := range s.merges {
+			fmt.Fprintf(&b, `<mergeCell ref="%s"/>`, merge)
+		}
+		b.WriteString(`</mergeCells>`)
+	}
+	b.WriteString(`</worksheet>`)
+	return b.String()
+}
+
+// escape menyiapkan teks untuk isi atau atribut XML dan membuang karakter
+// kontrol yang tidak sah di XML.
+func escape(value string) string {
+	var b bytes.Buffer
+	clean := strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, value)
+	_ = xml.EscapeText(&b, []byte(clean))
+	return b.String()
+}
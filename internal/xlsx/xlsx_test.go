@@ -0,0 +1,190 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+// openWorkbook membuka hasil Bytes() dan mengembalikan isi setiap part.
+func openWorkbook(t *testing.T, w *Workbook) map[string]string {
+	t.Helper()
+	data, err := w.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("result is not a zip archive: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		parts[file.Name] = string(content)
+	}
+	return parts
+}
+
+// wellFormed memastikan content adalah XML yang dapat dibaca sampai habis.
+func wellFormed(t *testing.T, name, content string) {
+	t.Helper()
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Errorf("%s is not well-formed XML: %v", name, err)
+			return
+		}
+	}
+}
+
+type worksheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string `xml:"r,attr"`
+			S      int    `xml:"s,attr"`
+			T      string `xml:"t,attr"`
+			V      string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+	Merges []struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"mergeCells>mergeCell"`
+}
+
+func TestWorkbookParts(t *testing.T) {
+	w := New()
+	sheet := w.AddSheet("Rekap Guru")
+	sheet.SetColumnWidth(0, 30)
+	sheet.AddRow(Text("Rekap Presensi <September> & \"Oktober\"", Title))
+	sheet.AddRow(Text("Nama", Header), Text("Hadir", Header), Text("", Bordered))
+	sheet.AddRow(Text("Siti\x01 Aminah", Bordered), Number(20.5, Centered), Cell{Value: 3})
+	sheet.Merge(0, 0, 2, 0)
+	w.AddSheet("Rekap Siswa")
+
+	parts := openWorkbook(t, w)
+	for _, name := range []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/workbook.xml",
+		"xl/_rels/workbook.xml.rels",
+		"xl/styles.xml",
+		"xl/worksheets/sheet1.xml",
+		"xl/worksheets/sheet2.xml",
+	} {
+		content, ok := parts[name]
+		if !ok {
+			t.Errorf("part %s is missing", name)
+			continue
+		}
+		wellFormed(t, name, content)
+	}
+	if len(parts) != 7 {
+		t.Errorf("parts = %d, want 7", len(parts))
+	}
+
+	var sheet1 worksheet
+	if err := xml.Unmarshal([]byte(parts["xl/worksheets/sheet1.xml"]), &sheet1); err != nil {
+		t.Fatalf("unmarshal sheet1: %v", err)
+	}
+	if len(sheet1.Rows) != 3 {
+		t.Fatalf("rows = %d, want 3", len(sheet1.Rows))
+	}
+	title := sheet1.Rows[0].Cells[0]
+	if title.R != "A1" || title.S != int(Title) || title.Inline != "Rekap Presensi <September> & \"Oktober\"" {
+		t.Errorf("title cell = %+v", title)
+	}
+	if empty := sheet1.Rows[1].Cells[2]; empty.R != "C2" || empty.T != "" || empty.Inline != "" {
+		t.Errorf("empty cell = %+v, want a styled cell without value", empty)
+	}
+	row := sheet1.Rows[2].Cells
+	if row[0].Inline != "Siti Aminah" {
+		t.Errorf("control character kept: %q", row[0].Inline)
+	}
+	if row[1].V != "20.5" || row[1].T != "" || row[2].V != "3" {
+		t.Errorf("number cells = %+v, %+v", row[1], row[2])
+	}
+	if len(sheet1.Merges) != 1 || sheet1.Merges[0].Ref != "A1:C1" {
+		t.Errorf("merges = %+v, want A1:C1", sheet1.Merges)
+	}
+}
+
+func TestEmptyWorkbookHasSheet(t *testing.T) {
+	parts := openWorkbook(t, New())
+	if _, ok := parts["xl/worksheets/sheet1.xml"]; !ok {
+		t.Error("empty workbook has no worksheet")
+	}
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Sheet1"`) {
+		t.Errorf("workbook.xml = %s, want a Sheet1 sheet", parts["xl/workbook.xml"])
+	}
+}
+
+func TestSheetNames(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Kelas X RPL 1", want: "Kelas X RPL 1"},
+		{name: "Rekap [09/2025]: *guru?*", want: "Rekap  09 2025    guru  "},
+		{name: `a\b`, want: "a b"},
+		{name: strings.Repeat("Rekap", 10), want: strings.Repeat("Rekap", 6) + "R"},
+		{name: strings.Repeat("é", 40), want: strings.Repeat("é", 31)},
+		{name: "", want: "Sheet1"},
+		{name: "A & <B>", want: "A & <B>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := New()
+			if got := w.AddSheet(tt.name).name; got != tt.want {
+				t.Fatalf("AddSheet(%q) name = %q, want %q", tt.name, got, tt.want)
+			}
+			parts := openWorkbook(t, w)
+			var workbook struct {
+				Sheets []struct {
+					Name string `xml:"name,attr"`
+				} `xml:"sheets>sheet"`
+			}
+			if err := xml.Unmarshal([]byte(parts["xl/workbook.xml"]), &workbook); err != nil {
+				t.Fatalf("unmarshal workbook.xml: %v", err)
+			}
+			if len(workbook.Sheets) != 1 || workbook.Sheets[0].Name != tt.want {
+				t.Errorf("workbook sheets = %+v, want %q", workbook.Sheets, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellName(t *testing.T) {
+	tests := []struct {
+		column, row int
+		want        string
+	}{
+		{0, 0, "A1"},
+		{25, 9, "Z10"},
+		{26, 0, "AA1"},
+		{27, 4, "AB5"},
+		{701, 0, "ZZ1"},
+		{702, 0, "AAA1"},
+	}
+	for _, tt := range tests {
+		if got := CellName(tt.column, tt.row); got != tt.want {
+			t.Errorf("CellName(%d, %d) = %q, want %q", tt.column, tt.row, got, tt.want)
+		}
+	}
+}
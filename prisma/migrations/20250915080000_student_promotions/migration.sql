@@ -0,0 +1,25 @@
+-- CreateTable
+CREATE TABLE `student_promotions` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `student_id` BIGINT NOT NULL,
+    `from_class_id` BIGINT NULL,
+    `to_class_id` BIGINT NULL,
+    `from_academic_year` VARCHAR(10) NOT NULL,
+    `to_academic_year` VARCHAR(10) NOT NULL,
+    `result` ENUM('NAIK', 'TINGGAL', 'LULUS') NOT NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    INDEX `student_promotions_from_class_id_idx`(`from_class_id`),
+    INDEX `student_promotions_to_class_id_idx`(`to_class_id`),
+    UNIQUE INDEX `student_promotions_student_id_from_academic_year_key`(`student_id`, `from_academic_year`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `student_promotions` ADD CONSTRAINT `student_promotions_student_id_fkey` FOREIGN KEY (`student_id`) REFERENCES `students`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `student_promotions` ADD CONSTRAINT `student_promotions_from_class_id_fkey` FOREIGN KEY (`from_class_id`) REFERENCES `classes`(`id`) ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `student_promotions` ADD CONSTRAINT `student_promotions_to_class_id_fkey` FOREIGN KEY (`to_class_id`) REFERENCES `classes`(`id`) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,26 @@
+-- CreateTable
+CREATE TABLE `student_class_histories` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `student_id` BIGINT NOT NULL,
+    `class_id` BIGINT NOT NULL,
+    `academic_year` VARCHAR(10) NOT NULL,
+    `start_date` DATE NOT NULL,
+    `end_date` DATE NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    INDEX `student_class_histories_student_id_idx`(`student_id`),
+    INDEX `student_class_histories_class_id_idx`(`class_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `student_class_histories` ADD CONSTRAINT `student_class_histories_student_id_fkey` FOREIGN KEY (`student_id`) REFERENCES `students`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `student_class_histories` ADD CONSTRAINT `student_class_histories_class_id_fkey` FOREIGN KEY (`class_id`) REFERENCES `classes`(`id`) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- Backfill: kelas saat ini menjadi riwayat yang masih terbuka
+INSERT INTO `student_class_histories` (`student_id`, `class_id`, `academic_year`, `start_date`)
+SELECT `s`.`id`, `s`.`current_class_id`, `c`.`academic_year`, CURRENT_DATE
+FROM `students` `s`
+JOIN `classes` `c` ON `c`.`id` = `s`.`current_class_id`;
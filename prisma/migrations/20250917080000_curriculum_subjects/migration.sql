@@ -0,0 +1,15 @@
+-- CreateTable
+CREATE TABLE `curriculum_subjects` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `subject_id` BIGINT NOT NULL,
+    `grade_level` VARCHAR(10) NOT NULL,
+    `major` VARCHAR(100) NOT NULL DEFAULT '',
+    `weekly_hours` INTEGER NOT NULL,
+
+    INDEX `curriculum_subjects_grade_level_major_idx`(`grade_level`, `major`),
+    UNIQUE INDEX `curriculum_subjects_subject_id_grade_level_major_key`(`subject_id`, `grade_level`, `major`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `curriculum_subjects` ADD CONSTRAINT `curriculum_subjects_subject_id_fkey` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
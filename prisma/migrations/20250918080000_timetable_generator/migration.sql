@@ -0,0 +1,82 @@
+-- CreateTable
+CREATE TABLE `teaching_assignments` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `class_id` BIGINT NOT NULL,
+    `subject_id` BIGINT NOT NULL,
+    `teacher_id` BIGINT NOT NULL,
+
+    INDEX `teaching_assignments_subject_id_idx`(`subject_id`),
+    INDEX `teaching_assignments_teacher_id_idx`(`teacher_id`),
+    UNIQUE INDEX `teaching_assignments_class_id_subject_id_key`(`class_id`, `subject_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateTable
+CREATE TABLE `teacher_unavailabilities` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `teacher_id` BIGINT NOT NULL,
+    `day_of_week` ENUM('Senin', 'Selasa', 'Rabu', 'Kamis', 'Jumat', 'Sabtu', 'Minggu') NOT NULL,
+    `start_time` TIME NOT NULL,
+    `end_time` TIME NOT NULL,
+    `reason` VARCHAR(255) NULL,
+
+    INDEX `teacher_unavailabilities_teacher_id_idx`(`teacher_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateTable
+CREATE TABLE `timetable_drafts` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `academic_year` VARCHAR(10) NOT NULL,
+    `status` ENUM('Draft', 'Committed') NOT NULL DEFAULT 'Draft',
+    `settings` TEXT NOT NULL,
+    `unplaced` TEXT NOT NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    `committed_at` DATETIME(3) NULL,
+
+    INDEX `timetable_drafts_academic_year_idx`(`academic_year`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateTable
+CREATE TABLE `timetable_draft_entries` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `draft_id` BIGINT NOT NULL,
+    `class_id` BIGINT NOT NULL,
+    `subject_id` BIGINT NOT NULL,
+    `teacher_id` BIGINT NOT NULL,
+    `day_of_week` ENUM('Senin', 'Selasa', 'Rabu', 'Kamis', 'Jumat', 'Sabtu', 'Minggu') NOT NULL,
+    `start_time` TIME NOT NULL,
+    `end_time` TIME NOT NULL,
+    `room` VARCHAR(50) NULL,
+
+    INDEX `timetable_draft_entries_draft_id_idx`(`draft_id`),
+    INDEX `timetable_draft_entries_class_id_idx`(`class_id`),
+    INDEX `timetable_draft_entries_subject_id_idx`(`subject_id`),
+    INDEX `timetable_draft_entries_teacher_id_idx`(`teacher_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `teaching_assignments` ADD CONSTRAINT `teaching_assignments_class_id_fkey` FOREIGN KEY (`class_id`) REFERENCES `classes`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `teaching_assignments` ADD CONSTRAINT `teaching_assignments_subject_id_fkey` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `teaching_assignments` ADD CONSTRAINT `teaching_assignments_teacher_id_fkey` FOREIGN KEY (`teacher_id`) REFERENCES `teachers`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `teacher_unavailabilities` ADD CONSTRAINT `teacher_unavailabilities_teacher_id_fkey` FOREIGN KEY (`teacher_id`) REFERENCES `teachers`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `timetable_draft_entries` ADD CONSTRAINT `timetable_draft_entries_draft_id_fkey` FOREIGN KEY (`draft_id`) REFERENCES `timetable_drafts`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `timetable_draft_entries` ADD CONSTRAINT `timetable_draft_entries_class_id_fkey` FOREIGN KEY (`class_id`) REFERENCES `classes`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `timetable_draft_entries` ADD CONSTRAINT `timetable_draft_entries_subject_id_fkey` FOREIGN KEY (`subject_id`) REFERENCES `subjects`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `timetable_draft_entries` ADD CONSTRAINT `timetable_draft_entries_teacher_id_fkey` FOREIGN KEY (`teacher_id`) REFERENCES `teachers`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,33 @@
+-- AlterTable
+ALTER TABLE `teaching_journals` ADD COLUMN `author_teacher_id` BIGINT NULL;
+
+-- CreateTable
+CREATE TABLE `substitutions` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `schedule_id` BIGINT NOT NULL,
+    `date` DATE NOT NULL,
+    `substitute_teacher_id` BIGINT NOT NULL,
+    `leave_request_id` BIGINT NULL,
+    `notes` TEXT NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    INDEX `substitutions_substitute_teacher_id_idx`(`substitute_teacher_id`),
+    INDEX `substitutions_leave_request_id_idx`(`leave_request_id`),
+    UNIQUE INDEX `substitutions_schedule_id_date_key`(`schedule_id`, `date`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateIndex
+CREATE INDEX `teaching_journals_author_teacher_id_idx` ON `teaching_journals`(`author_teacher_id`);
+
+-- AddForeignKey
+ALTER TABLE `teaching_journals` ADD CONSTRAINT `teaching_journals_author_teacher_id_fkey` FOREIGN KEY (`author_teacher_id`) REFERENCES `teachers`(`id`) ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `substitutions` ADD CONSTRAINT `substitutions_schedule_id_fkey` FOREIGN KEY (`schedule_id`) REFERENCES `schedules`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `substitutions` ADD CONSTRAINT `substitutions_substitute_teacher_id_fkey` FOREIGN KEY (`substitute_teacher_id`) REFERENCES `teachers`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `substitutions` ADD CONSTRAINT `substitutions_leave_request_id_fkey` FOREIGN KEY (`leave_request_id`) REFERENCES `leave_requests`(`id`) ON DELETE SET NULL ON UPDATE CASCADE;
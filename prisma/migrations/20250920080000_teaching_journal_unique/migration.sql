@@ -0,0 +1,8 @@
+-- AlterTable
+ALTER TABLE `teaching_journals` ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);
+
+-- CreateIndex
+CREATE UNIQUE INDEX `teaching_journals_schedule_id_teaching_date_key` ON `teaching_journals`(`schedule_id`, `teaching_date`);
+
+-- DropIndex
+DROP INDEX `teaching_journals_schedule_id_idx` ON `teaching_journals`;
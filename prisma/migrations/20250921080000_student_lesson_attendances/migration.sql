@@ -0,0 +1,25 @@
+-- CreateTable
+CREATE TABLE `student_lesson_attendances` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `journal_id` BIGINT NOT NULL,
+    `student_id` BIGINT NOT NULL,
+    `status` ENUM('Hadir', 'Sakit', 'Izin', 'Alpa') NOT NULL DEFAULT 'Hadir',
+    `notes` VARCHAR(255) NULL,
+    `leave_request_id` BIGINT NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    INDEX `student_lesson_attendances_student_id_idx`(`student_id`),
+    INDEX `student_lesson_attendances_leave_request_id_idx`(`leave_request_id`),
+    UNIQUE INDEX `student_lesson_attendances_journal_id_student_id_key`(`journal_id`, `student_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `student_lesson_attendances` ADD CONSTRAINT `student_lesson_attendances_journal_id_fkey` FOREIGN KEY (`journal_id`) REFERENCES `teaching_journals`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `student_lesson_attendances` ADD CONSTRAINT `student_lesson_attendances_student_id_fkey` FOREIGN KEY (`student_id`) REFERENCES `students`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `student_lesson_attendances` ADD CONSTRAINT `student_lesson_attendances_leave_request_id_fkey` FOREIGN KEY (`leave_request_id`) REFERENCES `leave_requests`(`id`) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,18 @@
+-- CreateTable
+CREATE TABLE `journal_reminders` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `teacher_id` BIGINT NOT NULL,
+    `schedule_id` BIGINT NOT NULL,
+    `teaching_date` DATE NOT NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    INDEX `journal_reminders_teacher_id_idx`(`teacher_id`),
+    UNIQUE INDEX `journal_reminders_schedule_id_teaching_date_teacher_id_key`(`schedule_id`, `teaching_date`, `teacher_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `journal_reminders` ADD CONSTRAINT `journal_reminders_teacher_id_fkey` FOREIGN KEY (`teacher_id`) REFERENCES `teachers`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `journal_reminders` ADD CONSTRAINT `journal_reminders_schedule_id_fkey` FOREIGN KEY (`schedule_id`) REFERENCES `schedules`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
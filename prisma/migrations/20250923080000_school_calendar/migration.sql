@@ -0,0 +1,49 @@
+-- CreateTable
+CREATE TABLE `academic_years` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `name` VARCHAR(10) NOT NULL,
+    `start_date` DATE NOT NULL,
+    `end_date` DATE NOT NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    UNIQUE INDEX `academic_years_name_key`(`name`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateTable
+CREATE TABLE `semesters` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `academic_year_id` BIGINT NOT NULL,
+    `term` ENUM('Ganjil', 'Genap') NOT NULL,
+    `start_date` DATE NOT NULL,
+    `end_date` DATE NOT NULL,
+
+    UNIQUE INDEX `semesters_academic_year_id_term_key`(`academic_year_id`, `term`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateTable
+CREATE TABLE `calendar_events` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `academic_year_id` BIGINT NULL,
+    `title` VARCHAR(255) NOT NULL,
+    `description` TEXT NULL,
+    `event_type` ENUM('LiburNasional', 'LiburSekolah', 'KegiatanSekolah', 'Ujian') NOT NULL,
+    `start_date` DATE NOT NULL,
+    `end_date` DATE NOT NULL,
+    `non_teaching` BOOLEAN NOT NULL DEFAULT true,
+    `grade_level` VARCHAR(10) NULL,
+    `ical_uid` VARCHAR(255) NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    UNIQUE INDEX `calendar_events_ical_uid_key`(`ical_uid`),
+    INDEX `calendar_events_academic_year_id_idx`(`academic_year_id`),
+    INDEX `calendar_events_start_date_end_date_idx`(`start_date`, `end_date`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `semesters` ADD CONSTRAINT `semesters_academic_year_id_fkey` FOREIGN KEY (`academic_year_id`) REFERENCES `academic_years`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `calendar_events` ADD CONSTRAINT `calendar_events_academic_year_id_fkey` FOREIGN KEY (`academic_year_id`) REFERENCES `academic_years`(`id`) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,7 @@
+-- AlterTable
+ALTER TABLE `companies` ADD COLUMN `contact_person` VARCHAR(255) NULL,
+    ADD COLUMN `contact_phone` VARCHAR(20) NULL,
+    ADD COLUMN `contact_email` VARCHAR(255) NULL,
+    ADD COLUMN `capacity` INTEGER NULL,
+    ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);
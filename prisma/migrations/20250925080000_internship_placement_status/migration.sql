@@ -0,0 +1,8 @@
+-- AlterTable
+ALTER TABLE `internship_placements` ADD COLUMN `status_reason` TEXT NULL,
+    ADD COLUMN `status_changed_at` DATETIME(3) NULL,
+    ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);
+
+-- CreateIndex
+CREATE INDEX `internship_placements_student_id_status_idx` ON `internship_placements`(`student_id`, `status`);
@@ -0,0 +1,9 @@
+-- AlterTable
+ALTER TABLE `internship_journals` ADD COLUMN `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    ADD COLUMN `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);
+
+-- CreateIndex
+CREATE UNIQUE INDEX `internship_journals_placement_id_activity_date_key` ON `internship_journals`(`placement_id`, `activity_date`);
+
+-- DropIndex
+DROP INDEX `internship_journals_placement_id_idx` ON `internship_journals`;
@@ -0,0 +1,20 @@
+-- CreateTable
+CREATE TABLE `internship_attendances` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `placement_id` BIGINT NOT NULL,
+    `attendance_date` DATE NOT NULL,
+    `status` ENUM('Masuk', 'Pulang') NOT NULL,
+    `timestamp` DATETIME(3) NOT NULL,
+    `location_coordinates` VARCHAR(100) NOT NULL,
+    `photo_path` VARCHAR(255) NOT NULL,
+    `distance_meters` INTEGER NULL,
+    `out_of_range` BOOLEAN NOT NULL DEFAULT false,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    INDEX `internship_attendances_out_of_range_idx`(`out_of_range`),
+    UNIQUE INDEX `internship_attendances_placement_id_attendance_date_status_key`(`placement_id`, `attendance_date`, `status`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `internship_attendances` ADD CONSTRAINT `internship_attendances_placement_id_fkey` FOREIGN KEY (`placement_id`) REFERENCES `internship_placements`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
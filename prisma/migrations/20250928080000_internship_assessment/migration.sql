@@ -0,0 +1,66 @@
+-- CreateTable
+CREATE TABLE `internship_rubrics` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `major` VARCHAR(100) NOT NULL,
+    `name` VARCHAR(255) NOT NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    UNIQUE INDEX `internship_rubrics_major_key`(`major`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateTable
+CREATE TABLE `internship_rubric_items` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `rubric_id` BIGINT NOT NULL,
+    `competency` VARCHAR(255) NOT NULL,
+    `assessor` ENUM('Pembimbing', 'Mentor') NOT NULL,
+    `weight` INTEGER NOT NULL,
+    `sort_order` INTEGER NOT NULL DEFAULT 0,
+
+    INDEX `internship_rubric_items_rubric_id_idx`(`rubric_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateTable
+CREATE TABLE `internship_scores` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `placement_id` BIGINT NOT NULL,
+    `rubric_item_id` BIGINT NOT NULL,
+    `score` INTEGER NOT NULL,
+    `notes` TEXT NULL,
+    `assessed_by` VARCHAR(255) NOT NULL,
+    `assessed_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    INDEX `internship_scores_rubric_item_id_idx`(`rubric_item_id`),
+    UNIQUE INDEX `internship_scores_placement_id_rubric_item_id_key`(`placement_id`, `rubric_item_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- CreateTable
+CREATE TABLE `internship_mentor_tokens` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `placement_id` BIGINT NOT NULL,
+    `token_hash` VARCHAR(64) NOT NULL,
+    `mentor_name` VARCHAR(255) NOT NULL,
+    `expires_at` DATETIME(3) NOT NULL,
+    `used_at` DATETIME(3) NULL,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    UNIQUE INDEX `internship_mentor_tokens_token_hash_key`(`token_hash`),
+    INDEX `internship_mentor_tokens_placement_id_idx`(`placement_id`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+-- AddForeignKey
+ALTER TABLE `internship_rubric_items` ADD CONSTRAINT `internship_rubric_items_rubric_id_fkey` FOREIGN KEY (`rubric_id`) REFERENCES `internship_rubrics`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `internship_scores` ADD CONSTRAINT `internship_scores_placement_id_fkey` FOREIGN KEY (`placement_id`) REFERENCES `internship_placements`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `internship_scores` ADD CONSTRAINT `internship_scores_rubric_item_id_fkey` FOREIGN KEY (`rubric_item_id`) REFERENCES `internship_rubric_items`(`id`) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+-- AddForeignKey
+ALTER TABLE `internship_mentor_tokens` ADD CONSTRAINT `internship_mentor_tokens_placement_id_fkey` FOREIGN KEY (`placement_id`) REFERENCES `internship_placements`(`id`) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,14 @@
+-- CreateTable
+CREATE TABLE `attendance_rules` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `role` ENUM('admin', 'teacher', 'student', 'staff') NOT NULL,
+    `day_of_week` ENUM('Senin', 'Selasa', 'Rabu', 'Kamis', 'Jumat', 'Sabtu', 'Minggu') NOT NULL,
+    `start_time` TIME NOT NULL,
+    `end_time` TIME NOT NULL,
+    `grace_minutes` INTEGER NOT NULL DEFAULT 0,
+    `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    `updated_at` DATETIME(3) NOT NULL,
+
+    UNIQUE INDEX `attendance_rules_role_day_of_week_key`(`role`, `day_of_week`),
+    PRIMARY KEY (`id`)
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci;
@@ -0,0 +1,7 @@
+-- AlterTable
+ALTER TABLE `attendances` ADD COLUMN `device_name` VARCHAR(50) NULL,
+    ADD COLUMN `client_event_id` VARCHAR(64) NULL,
+    ADD COLUMN `synced_at` DATETIME(3) NULL;
+
+-- CreateIndex
+CREATE UNIQUE INDEX `attendances_device_name_client_event_id_key` ON `attendances`(`device_name`, `client_event_id`);
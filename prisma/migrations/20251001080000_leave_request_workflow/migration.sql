@@ -0,0 +1,5 @@
+-- AlterTable
+ALTER TABLE `leave_requests` MODIFY `status` ENUM('Pending', 'Approved', 'Rejected', 'Cancelled') NOT NULL DEFAULT 'Pending';
+
+-- CreateIndex
+CREATE INDEX `leave_requests_user_id_start_date_end_date_idx` ON `leave_requests`(`user_id`, `start_date`, `end_date`);
@@ -0,0 +1,18 @@
+-- AlterTable
+ALTER TABLE `attendances` ADD COLUMN `attendance_date` DATE NULL;
+
+-- Backfill: tanggal presensi lama menurut zona waktu sekolah (WIB, UTC+7)
+UPDATE `attendances` SET `attendance_date` = DATE(CONVERT_TZ(`timestamp`, '+00:00', '+07:00'));
+
+-- Hapus presensi ganda dari request bersamaan, simpan yang tercatat lebih dulu
+DELETE `a` FROM `attendances` `a`
+    INNER JOIN `attendances` `b`
+        ON `a`.`user_id` = `b`.`user_id`
+        AND `a`.`attendance_date` = `b`.`attendance_date`
+        AND `a`.`status` = `b`.`status`
+        AND `a`.`id` > `b`.`id`;
+
+ALTER TABLE `attendances` MODIFY `attendance_date` DATE NOT NULL;
+
+-- CreateIndex
+CREATE UNIQUE INDEX `attendances_user_id_attendance_date_status_key` ON `attendances`(`user_id`, `attendance_date`, `status`);
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE `users` ADD COLUMN `calendar_feed_key` VARCHAR(64) NULL;